@@ -0,0 +1,17 @@
+// api/auth.go
+package api
+
+// Credentials is the body of POST /auth/login and POST /auth/register.
+type Credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Tokens is the token pair returned by login, register and refresh. Token is
+// the access token, sent as "Authorization: Bearer <token>"; ExpiresIn is
+// its lifetime in seconds.
+type Tokens struct {
+	Token        string `json:"token"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
@@ -0,0 +1,48 @@
+// api/errors.go
+
+// Package api holds the request and response bodies of the LinkedInify API,
+// shared by the server's handlers and the Go client so the two cannot drift
+// apart.
+package api
+
+// ErrorCode classifies an error response so clients can act on it without
+// parsing the message.
+type ErrorCode string
+
+const (
+	CodeValidation     ErrorCode = "validation"
+	CodeUnauthorized   ErrorCode = "unauthorized"
+	CodeForbidden      ErrorCode = "forbidden"
+	CodeNotFound       ErrorCode = "not_found"
+	CodeConflict       ErrorCode = "conflict"
+	CodeQuotaExceeded  ErrorCode = "quota_exceeded"
+	CodeContentFlagged ErrorCode = "content_flagged"
+	CodeRateLimited    ErrorCode = "rate_limited"
+	CodeNotImplemented ErrorCode = "not_implemented"
+	// CodeUpstreamAI is an AI provider failing, timing out or answering
+	// with nothing usable.
+	CodeUpstreamAI ErrorCode = "upstream_ai"
+	// CodeContextLengthExceeded is an input too long for the model to
+	// take.
+	CodeContextLengthExceeded ErrorCode = "context_length_exceeded"
+	// CodeLinkedInNotConnected asks the user to connect their LinkedIn
+	// account, for the first time or again after LinkedIn revoked it.
+	CodeLinkedInNotConnected ErrorCode = "linkedin_not_connected"
+	// CodeTimeout is a request cut off by the server's timeout.
+	CodeTimeout  ErrorCode = "timeout"
+	CodeInternal ErrorCode = "internal"
+)
+
+// ErrorDetail describes what went wrong. RequestID is the ID the server
+// assigned to the request, for users to quote when reporting a problem.
+type ErrorDetail struct {
+	Code      ErrorCode `json:"code"`
+	Message   string    `json:"message"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// ErrorEnvelope is the body of every error response. Responses with more to
+// say embed it next to their own fields.
+type ErrorEnvelope struct {
+	Error ErrorDetail `json:"error"`
+}
@@ -0,0 +1,104 @@
+// api/posts.go
+package api
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sampling holds the sampling parameters a generation may choose; unset
+// ones use the deployment's defaults.
+type Sampling struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+}
+
+// GenerateRequest is the body of POST /posts: the text to turn into a post
+// and how to write it.
+type GenerateRequest struct {
+	Text     string `json:"text"`
+	Model    string `json:"model,omitempty"`
+	Template string `json:"template,omitempty"`
+	Tone     string `json:"tone,omitempty"`
+	Length   string `json:"length,omitempty"`
+	Language string `json:"language,omitempty"`
+	// IncludeHashtags appends suggested hashtags to the generated post.
+	IncludeHashtags bool `json:"include_hashtags,omitempty"`
+	// UseProfile personalizes the post with the author's profile; it
+	// defaults to true.
+	UseProfile *bool `json:"use_profile,omitempty"`
+	// Force saves the post even when it is nearly identical to one
+	// generated recently.
+	Force bool `json:"force,omitempty"`
+	Sampling
+}
+
+// Usage is what a generation cost.
+type Usage struct {
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	TotalTokens      int     `json:"total_tokens"`
+	Model            string  `json:"model,omitempty"`
+	EstimatedCostUSD float64 `json:"estimated_cost_usd"`
+}
+
+// GeneratedPost is the response to generating or regenerating a post.
+type GeneratedPost struct {
+	ID    uuid.UUID `json:"id"`
+	Post  string    `json:"post"`
+	Usage Usage     `json:"usage"`
+	// Truncated reports whether the post was trimmed to the maximum post
+	// length.
+	Truncated bool `json:"truncated"`
+}
+
+// Post is the JSON shape of a stored post.
+type Post struct {
+	ID       uuid.UUID `json:"id"`
+	Input    string    `json:"input"`
+	Post     string    `json:"post"`
+	Status   string    `json:"status"`
+	Tone     string    `json:"tone,omitempty"`
+	Length   string    `json:"length,omitempty"`
+	Language string    `json:"language,omitempty"`
+	ImageURL string    `json:"image_url,omitempty"`
+	// ImageExpiresAt is when ImageURL, hosted by the AI provider, stops
+	// working; generate the image again after it.
+	ImageExpiresAt *time.Time `json:"image_expires_at,omitempty"`
+	// LinkedInURN and LinkedInURL identify and link to the post on LinkedIn
+	// once it has been published.
+	LinkedInURN string `json:"linkedin_urn,omitempty"`
+	LinkedInURL string `json:"linkedin_url,omitempty"`
+	// Favorited is always sent, so clients can render the toggle.
+	Favorited bool `json:"favorited"`
+	// Temperature and TopP are the sampling parameters the post was
+	// generated with.
+	Temperature float64 `json:"temperature"`
+	TopP        float64 `json:"top_p"`
+	// Schedule is set once the post has been scheduled for publishing.
+	Schedule *Schedule `json:"schedule,omitempty"`
+}
+
+// Schedule is the scheduled publication of a post.
+type Schedule struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+	Status      string    `json:"status"`
+	Attempts    int       `json:"attempts"`
+	// Error is why the last attempt failed.
+	Error string `json:"error,omitempty"`
+}
+
+// PageMeta describes a page of a listing paginated with limit and offset.
+type PageMeta struct {
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// PostPage is a page of posts, as returned by GET /posts and
+// GET /posts/search.
+type PostPage struct {
+	Data []Post   `json:"data"`
+	Meta PageMeta `json:"meta"`
+}
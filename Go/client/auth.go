@@ -0,0 +1,21 @@
+// client/auth.go
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/you/linkedinify/api"
+)
+
+// Login exchanges an email and password for tokens. Pass the access token
+// to WithToken to call the routes API keys cannot. Too many failed logins
+// from this host are rejected with ErrRateLimited until the lockout ends;
+// they are not retried, since the lockout outlasts DefaultMaxRetryWait.
+func (c *Client) Login(ctx context.Context, creds api.Credentials) (*api.Tokens, error) {
+	var out api.Tokens
+	if err := c.do(ctx, http.MethodPost, "/auth/login", creds, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,186 @@
+// client/client.go
+
+// Package client is a Go client for the LinkedInify API. Requests and
+// responses are the structs of package api, which the server uses too.
+//
+//	c := client.New("https://linkedinify.example.com", os.Getenv("LINKEDINIFY_API_KEY"))
+//	post, err := c.Generate(ctx, api.GenerateRequest{Text: "We shipped v2 today"})
+//	if errors.Is(err, client.ErrQuotaExceeded) {
+//		// Wait for next month.
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is how many times a request rate limited with a 429
+	// is retried unless WithMaxRetries says otherwise.
+	DefaultMaxRetries = 3
+	// DefaultRetryBaseDelay is the first backoff interval when the server
+	// sends no Retry-After; it doubles on each subsequent attempt.
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	// DefaultMaxRetryWait is the longest a request waits to be retried; a
+	// Retry-After further away returns the 429 instead.
+	DefaultMaxRetryWait = 30 * time.Second
+
+	apiKeyHeader = "X-API-Key"
+	userAgent    = "linkedinify-go-client"
+)
+
+// Client calls the LinkedInify API. It is safe for concurrent use.
+type Client struct {
+	baseURL      string
+	apiKey       string
+	token        string
+	http         *http.Client
+	maxRetries   int
+	baseDelay    time.Duration
+	maxRetryWait time.Duration
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sends requests with hc instead of http.DefaultClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.http = hc }
+}
+
+// WithToken authenticates with an access token, as returned by Login,
+// instead of the API key. API keys only work for the /posts routes.
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithMaxRetries sets how many times a rate limited request is retried;
+// zero never retries.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) { c.maxRetries = max(n, 0) }
+}
+
+// WithRetryDelays sets the first backoff interval and the longest wait for a
+// retry; see DefaultRetryBaseDelay and DefaultMaxRetryWait.
+func WithRetryDelays(base, maxWait time.Duration) Option {
+	return func(c *Client) {
+		c.baseDelay = base
+		c.maxRetryWait = maxWait
+	}
+}
+
+// New returns a client for the server at baseURL, such as
+// "https://linkedinify.example.com", calling its /api/v1 routes with apiKey
+// in the X-API-Key header. apiKey may be empty for a client that only logs
+// in, or authenticates WithToken.
+func New(baseURL, apiKey string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:      strings.TrimSuffix(baseURL, "/") + "/api/v1",
+		apiKey:       apiKey,
+		http:         http.DefaultClient,
+		maxRetries:   DefaultMaxRetries,
+		baseDelay:    DefaultRetryBaseDelay,
+		maxRetryWait: DefaultMaxRetryWait,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// do sends a request to path, relative to /api/v1, with in as its JSON body
+// unless nil, and decodes a successful response into out unless nil.
+// Responses rate limited with a 429 are retried with backoff; other errors
+// are returned as an *Error.
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("client: encoding the request: %w", err)
+		}
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, path, body)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode < http.StatusBadRequest {
+			defer resp.Body.Close()
+			if out == nil {
+				return nil
+			}
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return fmt.Errorf("client: decoding the response of %s %s: %w", method, path, err)
+			}
+			return nil
+		}
+
+		apiErr := readError(resp)
+		if apiErr.StatusCode != http.StatusTooManyRequests || attempt >= c.maxRetries {
+			return apiErr
+		}
+		delay := c.backoff(attempt, apiErr.RetryAfter)
+		if delay > c.maxRetryWait {
+			return apiErr
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return apiErr
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(apiErr, ctx.Err())
+		}
+	}
+}
+
+// send makes one attempt at a request.
+func (c *Client) send(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, r)
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	switch {
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	case c.apiKey != "":
+		req.Header.Set(apiKeyHeader, c.apiKey)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("client: %s %s: %w", method, path, err)
+	}
+	return resp, nil
+}
+
+// backoff returns how long to wait before the next attempt. The server's
+// Retry-After takes precedence over the computed delay.
+func (c *Client) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	d := c.baseDelay << attempt
+	// Jitter in [d/2, d) so concurrent callers don't retry in lockstep.
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
@@ -0,0 +1,130 @@
+// client/client_test.go
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/api"
+	"github.com/you/linkedinify/client"
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+func TestClient_Generate(t *testing.T) {
+	id := uuid.New()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/posts", r.URL.Path)
+		assert.Equal(t, "key", r.Header.Get(middleware.APIKeyHeader))
+		var in api.GenerateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		assert.Equal(t, "We shipped", in.Text)
+		assert.Equal(t, "casual", in.Tone)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(api.GeneratedPost{ID: id, Post: "Post", Usage: api.Usage{TotalTokens: 42}})
+	}))
+	defer srv.Close()
+
+	out, err := client.New(srv.URL+"/", "key").Generate(context.Background(), api.GenerateRequest{Text: "We shipped", Tone: "casual"})
+	require.NoError(t, err)
+	assert.Equal(t, id, out.ID)
+	assert.Equal(t, "Post", out.Post)
+	assert.Equal(t, 42, out.Usage.TotalTokens)
+}
+
+func TestClient_ListPosts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/posts", r.URL.Path)
+		assert.Equal(t, "limit=5&offset=10&status=draft", r.URL.RawQuery)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Empty(t, r.Header.Get(middleware.APIKeyHeader), "A token takes the place of the API key")
+		json.NewEncoder(w).Encode(api.PostPage{Data: []api.Post{{Post: "Post"}}, Meta: api.PageMeta{Total: 11, Limit: 5, Offset: 10}})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL, "key", client.WithToken("token"))
+	page, err := c.ListPosts(context.Background(), client.ListPostsOptions{Limit: 5, Offset: 10, Status: "draft"})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "Post", page.Data[0].Post)
+	assert.Equal(t, 11, page.Meta.Total)
+}
+
+func TestClient_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(middleware.RequestIDHeader, "req-42")
+		middleware.WriteError(w, http.StatusNotFound, middleware.CodeNotFound, "Post not found")
+	}))
+	defer srv.Close()
+
+	_, err := client.New(srv.URL, "key").GetPost(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, client.ErrNotFound)
+	assert.NotErrorIs(t, err, client.ErrUnauthorized)
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "Post not found", apiErr.Message)
+	assert.Equal(t, "req-42", apiErr.RequestID)
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<html>bad gateway</html>", http.StatusBadGateway)
+	}))
+	defer proxy.Close()
+	_, err = client.New(proxy.URL, "key").GetPost(context.Background(), uuid.New())
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Empty(t, apiErr.Code, "Responses without an envelope have no code")
+}
+
+func TestClient_RetriesRateLimited(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var in api.Credentials
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in), "The body is sent again on every attempt")
+		assert.Equal(t, "a@b.c", in.Email)
+		if calls < 3 {
+			middleware.WriteError(w, http.StatusTooManyRequests, middleware.CodeRateLimited, "Slow down")
+			return
+		}
+		json.NewEncoder(w).Encode(api.Tokens{Token: "token", ExpiresIn: 900})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL, "", client.WithRetryDelays(time.Millisecond, time.Second))
+	tokens, err := c.Login(context.Background(), api.Credentials{Email: "a@b.c", Password: "pw"})
+	require.NoError(t, err)
+	assert.Equal(t, "token", tokens.Token)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	_, err = client.New(srv.URL, "", client.WithMaxRetries(1), client.WithRetryDelays(time.Millisecond, time.Second)).
+		Login(context.Background(), api.Credentials{Email: "a@b.c", Password: "pw"})
+	assert.ErrorIs(t, err, client.ErrRateLimited)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_DoesNotWaitPastMaxRetryWait(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		middleware.TooManyRequests(w, 15*time.Minute)
+	}))
+	defer srv.Close()
+
+	_, err := client.New(srv.URL, "").Login(context.Background(), api.Credentials{Email: "a@b.c", Password: "pw"})
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 15*time.Minute, apiErr.RetryAfter)
+	assert.Equal(t, 1, calls)
+	assert.ErrorIs(t, err, client.ErrRateLimited)
+}
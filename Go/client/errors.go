@@ -0,0 +1,89 @@
+// client/errors.go
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/you/linkedinify/api"
+)
+
+// Error is an error response of the API. Match its code with errors.Is and
+// the sentinels below, or errors.As it for the details:
+//
+//	var apiErr *client.Error
+//	if errors.As(err, &apiErr) {
+//		log.Printf("request %s failed: %s", apiErr.RequestID, apiErr.Message)
+//	}
+type Error struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// ErrorDetail is the error envelope of the response. Responses that
+	// were not sent by the API, such as those of a proxy, have no Code and
+	// the status text as their Message.
+	api.ErrorDetail
+	// RetryAfter is how long the server asked to wait before retrying,
+	// from the Retry-After header of 429 and 503 responses.
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("linkedinify: %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("linkedinify: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Is matches the sentinel errors of this package, and any *Error without a
+// StatusCode, by their codes.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.StatusCode == 0 && t.Code != "" && t.Code == e.Code
+}
+
+// Sentinels matching an *Error by its code with errors.Is.
+var (
+	ErrValidation            = codeError(api.CodeValidation)
+	ErrUnauthorized          = codeError(api.CodeUnauthorized)
+	ErrForbidden             = codeError(api.CodeForbidden)
+	ErrNotFound              = codeError(api.CodeNotFound)
+	ErrConflict              = codeError(api.CodeConflict)
+	ErrQuotaExceeded         = codeError(api.CodeQuotaExceeded)
+	ErrContentFlagged        = codeError(api.CodeContentFlagged)
+	ErrRateLimited           = codeError(api.CodeRateLimited)
+	ErrNotImplemented        = codeError(api.CodeNotImplemented)
+	ErrUpstreamAI            = codeError(api.CodeUpstreamAI)
+	ErrContextLengthExceeded = codeError(api.CodeContextLengthExceeded)
+	ErrLinkedInNotConnected  = codeError(api.CodeLinkedInNotConnected)
+	ErrTimeout               = codeError(api.CodeTimeout)
+	ErrInternal              = codeError(api.CodeInternal)
+)
+
+func codeError(code api.ErrorCode) *Error {
+	return &Error{ErrorDetail: api.ErrorDetail{Code: code, Message: string(code)}}
+}
+
+// maxErrorBody is the most of an error response read; envelopes are far
+// smaller.
+const maxErrorBody = 64 << 10
+
+// readError reads and closes the body of an error response.
+func readError(resp *http.Response) *Error {
+	defer resp.Body.Close()
+	e := &Error{StatusCode: resp.StatusCode}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		e.RetryAfter = time.Duration(secs) * time.Second
+	}
+	var env api.ErrorEnvelope
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if json.Unmarshal(b, &env) == nil && env.Error.Code != "" {
+		e.ErrorDetail = env.Error
+	} else {
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+	return e
+}
@@ -0,0 +1,78 @@
+// client/posts.go
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/api"
+)
+
+// Generate turns req.Text into a LinkedIn post, which is saved as a draft.
+func (c *Client) Generate(ctx context.Context, req api.GenerateRequest) (*api.GeneratedPost, error) {
+	var out api.GeneratedPost
+	if err := c.do(ctx, http.MethodPost, "/posts", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPost returns one of the user's posts.
+func (c *Client) GetPost(ctx context.Context, id uuid.UUID) (*api.Post, error) {
+	var out api.Post
+	if err := c.do(ctx, http.MethodGet, "/posts/"+id.String(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListPostsOptions filters and pages ListPosts. Zero values leave the
+// server's defaults: the 20 newest posts, whatever their status.
+type ListPostsOptions struct {
+	Limit  int
+	Offset int
+	// Status is "draft" or "final".
+	Status string
+	// Favorited keeps only favorited posts when true, and only the others
+	// when false.
+	Favorited *bool
+	// Sort is "created_desc", "created_asc" or "updated_desc".
+	Sort string
+}
+
+func (o ListPostsOptions) query() url.Values {
+	q := url.Values{}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		q.Set("offset", strconv.Itoa(o.Offset))
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.Favorited != nil {
+		q.Set("favorited", strconv.FormatBool(*o.Favorited))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+	return q
+}
+
+// ListPosts returns a page of the user's posts.
+func (c *Client) ListPosts(ctx context.Context, opts ListPostsOptions) (*api.PostPage, error) {
+	path := "/posts"
+	if q := opts.query(); len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var out api.PostPage
+	if err := c.do(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -2,12 +2,13 @@
 package main
 
 import (
-	"log"
-	"net/http"
+	"log/slog"
+	"os"
 
 	"github.com/joho/godotenv"
 	"github.com/you/linkedinify/internal/config"
-	"github.com/you/linkedinify/internal/router"
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/server"
 )
 
 func main() {
@@ -19,30 +20,45 @@ func main() {
 	}
 
 	// Try loading from each path until successful
-	var loaded bool
+	var loadedFrom string
 	for _, path := range envPaths {
 		if err := godotenv.Load(path); err == nil {
-			log.Printf("✓ Successfully loaded .env file from %s", path)
-			loaded = true
+			loadedFrom = path
 			break
 		}
 	}
 
-	if !loaded {
-		log.Println("⚠ Warning: Could not load .env file - using default configuration")
-	}
 	cfg := config.Load()
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			os.Exit(runMigrate(cfg, os.Args[2:]))
+		case "seed":
+			os.Exit(runSeed(cfg, os.Args[2:]))
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		slog.Error("invalid configuration:\n" + err.Error())
+		os.Exit(1)
+	}
+	// The level was checked by Validate.
+	level, _ := logging.ParseLevel(cfg.LogLevel)
+	slog.SetDefault(logging.New(os.Stderr, cfg.Env, level))
 
-	// Create the router, which now includes all middleware
-	appRouter := router.New(cfg)
-
-	server := &http.Server{
-		Addr:    cfg.HTTPAddr,
-		Handler: appRouter, // Use the router from the router package directly
+	// The .env file decides the log format, so report it only now.
+	if loadedFrom != "" {
+		slog.Info("loaded .env file", "path", loadedFrom)
+	} else {
+		slog.Warn("could not load .env file, using default configuration")
+	}
+	if !cfg.Strict {
+		slog.Warn("STRICT_CONFIG=false: optional credentials are not enforced")
 	}
 
-	log.Printf("⇢ Server starting on %s", cfg.HTTPAddr)
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Fatalf("Server failed to start: %v", err)
+	// Run blocks until the server fails or has shut down after a signal
+	if err := server.Run(cfg); err != nil {
+		slog.Error("server error", "error", err)
+		os.Exit(1)
 	}
+	slog.Info("server stopped")
 }
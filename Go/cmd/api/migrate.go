@@ -0,0 +1,113 @@
+// cmd/api/migrate.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/you/linkedinify/internal/config"
+	"github.com/you/linkedinify/internal/db"
+	"github.com/you/linkedinify/internal/migrate"
+	"github.com/you/linkedinify/migrations"
+)
+
+const migrateUsage = `usage: linkedinify migrate [-dry-run] <command>
+
+commands:
+  up                apply every pending migration
+  down [N]          revert the last N applied migrations (default 1)
+  status            list the migrations and when they were applied
+  baseline VERSION  record migrations up to VERSION as applied without
+                    running them, for a database that was set up by hand
+
+flags:`
+
+// runMigrate implements the migrate subcommand and returns the exit code.
+// Only DATABASE_DSN and the pool settings of cfg are used, so the rest of
+// the configuration need not be valid.
+func runMigrate(cfg config.Config, args []string) int {
+	fset := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dryRun := fset.Bool("dry-run", false, "show what would change without changing the database")
+	fset.Usage = func() {
+		fmt.Fprintln(fset.Output(), migrateUsage)
+		fset.PrintDefaults()
+	}
+	if err := fset.Parse(args); err != nil {
+		return 2
+	}
+	cmd, rest := fset.Arg(0), fset.Args()[min(1, fset.NArg()):]
+
+	var arg int
+	switch {
+	case cmd == "up" && len(rest) == 0, cmd == "status" && len(rest) == 0:
+	case cmd == "down" && len(rest) == 0:
+		arg = 1
+	case (cmd == "down" || cmd == "baseline") && len(rest) == 1:
+		n, err := strconv.Atoi(rest[0])
+		if err != nil || n < 1 {
+			fmt.Fprintf(fset.Output(), "%s needs a positive number, got %q\n", cmd, rest[0])
+			return 2
+		}
+		arg = n
+	default:
+		fset.Usage()
+		return 2
+	}
+
+	all, err := migrate.Load(migrations.FS)
+	if err != nil {
+		slog.Error("loading migrations failed", "error", err)
+		return 1
+	}
+	database := db.New(cfg)
+	defer database.Close()
+	m := migrate.New(database.DB, all, migrate.WithDryRun(*dryRun))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	verb := map[string]string{"up": "applied", "down": "reverted", "baseline": "recorded"}[cmd]
+	if *dryRun {
+		verb = map[string]string{"up": "would apply", "down": "would revert", "baseline": "would record"}[cmd]
+	}
+	var done []migrate.Migration
+	switch cmd {
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			slog.Error("reading migration status failed", "error", err)
+			return 1
+		}
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Printf("%-28s %s\n", s.Name, applied)
+		}
+		return 0
+	case "up":
+		done, err = m.Up(ctx)
+	case "down":
+		done, err = m.Down(ctx, arg)
+	case "baseline":
+		done, err = m.Baseline(ctx, arg)
+	}
+	for _, mig := range done {
+		fmt.Println(verb, mig.Name)
+	}
+	if err != nil {
+		slog.Error("migration failed", "error", err)
+		return 1
+	}
+	if len(done) == 0 {
+		fmt.Println("nothing to do")
+	}
+	return 0
+}
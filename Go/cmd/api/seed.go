@@ -0,0 +1,38 @@
+// cmd/api/seed.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/you/linkedinify/internal/config"
+	"github.com/you/linkedinify/internal/db"
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/seed"
+)
+
+// runSeed implements the seed subcommand and returns the exit code. It
+// expects the schema to be migrated already.
+func runSeed(cfg config.Config, args []string) int {
+	if len(args) > 0 {
+		fmt.Println("usage: linkedinify seed")
+		return 2
+	}
+	if cfg.Env == logging.EnvProduction {
+		slog.Error("refusing to seed a production database (APP_ENV=production)")
+		return 1
+	}
+
+	database := db.New(cfg)
+	defer database.Close()
+	res, err := seed.Run(context.Background(), repository.NewUserRepo(database), repository.NewPostRepo(database))
+	if err != nil {
+		slog.Error("seeding failed", "error", err)
+		return 1
+	}
+	fmt.Printf("created %d users and %d posts\n", res.Users, res.Posts)
+	fmt.Printf("log in as %s (user) or %s (admin) with password %s\n", seed.UserEmail, seed.AdminEmail, seed.Password)
+	return 0
+}
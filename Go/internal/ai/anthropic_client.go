@@ -0,0 +1,271 @@
+// internal/ai/anthropic_client.go
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	anthropicAPIURL  = "https://api.anthropic.com/v1/messages"
+	anthropicVersion = "2023-06-01"
+)
+
+type anthropicClient struct {
+	http         *http.Client
+	url          string
+	token        string
+	model        string
+	systemPrompt string
+}
+
+// AnthropicConfig configures an Anthropic client.
+type AnthropicConfig struct {
+	Token string
+	// Model defaults to DefaultAnthropicModel.
+	Model string
+	// SystemPrompt is the system message of every generation; empty means
+	// DefaultSystemPrompt.
+	SystemPrompt string
+}
+
+// NewAnthropic creates a Client backed by the Anthropic Messages API. The
+// model falls back to DefaultAnthropicModel when empty.
+func NewAnthropic(token, model string) Client {
+	return NewAnthropicWithConfig(AnthropicConfig{Token: token, Model: model})
+}
+
+// NewAnthropicWithConfig creates an Anthropic client from a full
+// AnthropicConfig.
+func NewAnthropicWithConfig(cfg AnthropicConfig) Client {
+	return &anthropicClient{
+		http:         &http.Client{Transport: newRetryTransport(http.DefaultTransport, 0, 0)},
+		url:          anthropicAPIURL,
+		token:        cfg.Token,
+		model:        cmp.Or(cfg.Model, DefaultAnthropicModel),
+		systemPrompt: cmp.Or(cfg.SystemPrompt, DefaultSystemPrompt),
+	}
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// maxAnthropicTemperature is the highest temperature Anthropic accepts;
+// higher ones, which OpenAI allows, are sent as it.
+const maxAnthropicTemperature = 1.0
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	MaxTokens   int                `json:"max_tokens"`
+	System      string             `json:"system,omitempty"`
+	Messages    []anthropicMessage `json:"messages"`
+	Stream      bool               `json:"stream,omitempty"`
+	Temperature *float64           `json:"temperature,omitempty"`
+	TopP        *float64           `json:"top_p,omitempty"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+type anthropicErrorBody struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// anthropicEvent is the union of the streaming event payloads we care about.
+// message_start carries the input tokens and message_delta the output
+// tokens so far.
+type anthropicEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Message struct {
+		Usage anthropicUsage `json:"usage"`
+	} `json:"message"`
+	Usage anthropicUsage      `json:"usage"`
+	Error *anthropicErrorBody `json:"error"`
+}
+
+type anthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+func (c *anthropicClient) Transform(ctx context.Context, prompt string, opts Options) (Result, error) {
+	model := c.modelFor(opts)
+	resp, err := c.do(ctx, prompt, model, opts, false)
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+
+	var out anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return Result{}, fmt.Errorf("anthropic: decode response: %w", err)
+	}
+	var sb strings.Builder
+	for _, block := range out.Content {
+		if block.Type == "text" {
+			sb.WriteString(block.Text)
+		}
+	}
+	return Result{
+		Text:  sb.String(),
+		Model: model,
+		Usage: Usage{
+			PromptTokens:     out.Usage.InputTokens,
+			CompletionTokens: out.Usage.OutputTokens,
+			TotalTokens:      out.Usage.InputTokens + out.Usage.OutputTokens,
+		},
+	}, nil
+}
+
+// Stream yields text deltas from the Messages API streaming endpoint,
+// followed by the usage once the message is complete. As with the OpenAI
+// client, cancelling ctx aborts the request and closes the channel.
+func (c *anthropicClient) Stream(ctx context.Context, prompt string, opts Options) (<-chan Chunk, error) {
+	model := c.modelFor(opts)
+	resp, err := c.do(ctx, prompt, model, opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan Chunk)
+	go func() {
+		defer close(out)
+		defer resp.Body.Close()
+
+		send := func(ch Chunk) bool {
+			select {
+			case out <- ch:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var usage anthropicUsage
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			var ev anthropicEvent
+			if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &ev); err != nil {
+				send(Chunk{Err: fmt.Errorf("anthropic: decode event: %w", err)})
+				return
+			}
+			switch ev.Type {
+			case "message_start":
+				usage.InputTokens = ev.Message.Usage.InputTokens
+			case "content_block_delta":
+				if ev.Delta.Type == "text_delta" && ev.Delta.Text != "" && !send(Chunk{Text: ev.Delta.Text, Model: model}) {
+					return
+				}
+			case "message_delta":
+				usage.OutputTokens = ev.Usage.OutputTokens
+			case "message_stop":
+				send(Chunk{Model: model, Usage: Usage{
+					PromptTokens:     usage.InputTokens,
+					CompletionTokens: usage.OutputTokens,
+					TotalTokens:      usage.InputTokens + usage.OutputTokens,
+				}})
+				return
+			case "error":
+				body := anthropicErrorBody{Type: "api_error", Message: "unknown error"}
+				if ev.Error != nil {
+					body = *ev.Error
+				}
+				send(Chunk{Err: anthropicError(0, body)})
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil && ctx.Err() == nil {
+			send(Chunk{Err: err})
+		}
+	}()
+	return out, nil
+}
+
+func (c *anthropicClient) modelFor(opts Options) string {
+	if opts.Model != "" {
+		return opts.Model
+	}
+	return c.model
+}
+
+// do sends a Messages API request and returns the response once a successful
+// status has been received. Error responses are decoded into an error.
+func (c *anthropicClient) do(ctx context.Context, prompt, model string, opts Options, stream bool) (*http.Response, error) {
+	temperature := opts.Temperature
+	if temperature != nil && *temperature > maxAnthropicTemperature {
+		t := maxAnthropicTemperature
+		temperature = &t
+	}
+	body, err := json.Marshal(anthropicRequest{
+		Model:       model,
+		MaxTokens:   opts.maxTokens(),
+		System:      c.systemPrompt,
+		Messages:    []anthropicMessage{{Role: "user", Content: prompt}},
+		Stream:      stream,
+		Temperature: temperature,
+		TopP:        opts.TopP,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-Key", c.token)
+	req.Header.Set("Anthropic-Version", anthropicVersion)
+	if stream {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, readAnthropicError(resp)
+	}
+	return resp, nil
+}
+
+// readAnthropicError converts a failed response into an *Error.
+func readAnthropicError(resp *http.Response) *Error {
+	var e struct {
+		Error anthropicErrorBody `json:"error"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if json.Unmarshal(raw, &e) != nil {
+		e.Error = anthropicErrorBody{}
+	}
+	return anthropicError(resp.StatusCode, e.Error)
+}
@@ -0,0 +1,140 @@
+// internal/ai/anthropic_client_test.go
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAnthropic(t *testing.T, h http.HandlerFunc) *anthropicClient {
+	t.Helper()
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+	c := NewAnthropic("test-key", "").(*anthropicClient)
+	c.url = server.URL
+	return c
+}
+
+func TestAnthropic_Transform(t *testing.T) {
+	c := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-key", r.Header.Get("X-Api-Key"))
+		assert.Equal(t, anthropicVersion, r.Header.Get("Anthropic-Version"))
+		var req anthropicRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, DefaultAnthropicModel, req.Model)
+		assert.Equal(t, DefaultSystemPrompt, req.System)
+		assert.False(t, req.Stream)
+		fmt.Fprint(w, `{"content":[{"type":"text","text":"Hello from Claude"}],"usage":{"input_tokens":12,"output_tokens":30}}`)
+	})
+
+	out, err := c.Transform(context.Background(), "hi", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello from Claude", out.Text)
+	assert.Equal(t, DefaultAnthropicModel, out.Model)
+	assert.Equal(t, Usage{PromptTokens: 12, CompletionTokens: 30, TotalTokens: 42}, out.Usage)
+}
+
+func TestAnthropic_SystemPrompt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req anthropicRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "You write for Acme's engineering blog.", req.System)
+		require.Len(t, req.Messages, 1)
+		assert.Equal(t, "hi", req.Messages[0].Content, "The prompt still follows as the user message")
+		fmt.Fprint(w, `{"content":[{"type":"text","text":"ok"}]}`)
+	}))
+	t.Cleanup(server.Close)
+	c := NewAnthropicWithConfig(AnthropicConfig{Token: "test-key", SystemPrompt: "You write for Acme's engineering blog."}).(*anthropicClient)
+	c.url = server.URL
+
+	_, err := c.Transform(context.Background(), "hi", Options{})
+	require.NoError(t, err)
+}
+
+func TestAnthropic_Sampling(t *testing.T) {
+	var got []anthropicRequest
+	c := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+		var req anthropicRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		got = append(got, req)
+		fmt.Fprint(w, `{"content":[{"type":"text","text":"ok"}]}`)
+	})
+	hot, zero, topP := 1.7, 0.0, 0.9
+
+	for _, opts := range []Options{{}, {Temperature: &hot, TopP: &topP}, {Temperature: &zero}} {
+		_, err := c.Transform(context.Background(), "hi", opts)
+		require.NoError(t, err)
+	}
+	require.Len(t, got, 3)
+	assert.Nil(t, got[0].Temperature, "Unset parameters are left to Anthropic")
+	assert.Nil(t, got[0].TopP)
+	assert.Equal(t, 1.0, *got[1].Temperature, "Temperatures above Anthropic's maximum are capped")
+	assert.Equal(t, 0.9, *got[1].TopP)
+	require.NotNil(t, got[2].Temperature, "A zero temperature is sent")
+	assert.Zero(t, *got[2].Temperature)
+}
+
+func TestAnthropic_Transform_APIError(t *testing.T) {
+	c := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"type":"error","error":{"type":"invalid_request_error","message":"bad model"}}`)
+	})
+
+	_, err := c.Transform(context.Background(), "hi", Options{Model: "nope"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad model")
+	var aiErr *Error
+	require.ErrorAs(t, err, &aiErr)
+	assert.Equal(t, http.StatusBadRequest, aiErr.StatusCode)
+	assert.Equal(t, "bad model", aiErr.Message)
+}
+
+func TestAnthropic_Stream(t *testing.T) {
+	c := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"usage\":{\"input_tokens\":12,\"output_tokens\":1}}}\n\n")
+		fmt.Fprint(w, "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"Hel\"}}\n\n")
+		fmt.Fprint(w, "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"lo\"}}\n\n")
+		fmt.Fprint(w, "event: message_delta\ndata: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"end_turn\"},\"usage\":{\"output_tokens\":2}}\n\n")
+		fmt.Fprint(w, "event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n")
+	})
+
+	chunks, err := c.Stream(context.Background(), "hi", Options{Model: "claude-opus-4-0"})
+	require.NoError(t, err)
+	var got string
+	var last Chunk
+	for ch := range chunks {
+		require.NoError(t, ch.Err)
+		assert.Equal(t, "claude-opus-4-0", ch.Model)
+		got += ch.Text
+		last = ch
+	}
+	assert.Equal(t, "Hello", got)
+	assert.Equal(t, Usage{PromptTokens: 12, CompletionTokens: 2, TotalTokens: 14}, last.Usage, "The stream ends with its usage")
+}
+
+func TestAnthropic_Stream_MidStreamError(t *testing.T) {
+	c := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"Hel\"}}\n\n")
+		fmt.Fprint(w, "event: error\ndata: {\"type\":\"error\",\"error\":{\"type\":\"overloaded_error\",\"message\":\"Overloaded\"}}\n\n")
+	})
+
+	chunks, err := c.Stream(context.Background(), "hi", Options{})
+	require.NoError(t, err)
+	var last Chunk
+	for ch := range chunks {
+		last = ch
+	}
+	require.Error(t, last.Err)
+	assert.Contains(t, last.Err.Error(), "Overloaded")
+	var aiErr *Error
+	require.ErrorAs(t, last.Err, &aiErr)
+	assert.Equal(t, ErrorServer, aiErr.Kind)
+}
@@ -0,0 +1,109 @@
+// internal/ai/batch.go
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrAbandoned is wrapped by the error of every batch item that had not
+// completed when the batch's context was cancelled.
+var ErrAbandoned = errors.New("batch cancelled before the item completed")
+
+// BatchRunner runs the items of a batch on a bounded pool of workers, such
+// as generations against a Client, reporting each item as it completes.
+type BatchRunner[T any] struct {
+	concurrency int
+	itemTimeout time.Duration
+}
+
+// NewBatchRunner returns a runner working on at most concurrency items at
+// once, at least one. Each item gets itemTimeout to complete; zero leaves
+// items bounded only by the batch's context.
+func NewBatchRunner[T any](concurrency int, itemTimeout time.Duration) *BatchRunner[T] {
+	return &BatchRunner[T]{concurrency: max(concurrency, 1), itemTimeout: itemTimeout}
+}
+
+// BatchResult is the outcome of the item at Index: Value, or Err when it
+// failed.
+type BatchResult[T any] struct {
+	Index int
+	Value T
+	Err   error
+}
+
+// Run calls fn for every index from 0 to n-1 and sends each result on the
+// returned channel as soon as it is known, in the order items complete. A
+// failed item does not stop the others. The channel receives exactly n
+// results and is closed once every call to fn has returned, so no worker
+// outlives it.
+//
+// When ctx is cancelled no further items are started. The items not started
+// and those whose fn fails after the cancellation are reported with an error
+// wrapping ErrAbandoned; fn is passed a context derived from ctx and must
+// honour it for in-flight items to be abandoned promptly.
+func (b *BatchRunner[T]) Run(ctx context.Context, n int, fn func(ctx context.Context, i int) (T, error)) <-chan BatchResult[T] {
+	// Buffered for every result, so workers never wait on a slow reader.
+	results := make(chan BatchResult[T], n)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(b.concurrency, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results <- b.run(ctx, i, fn)
+			}
+		}()
+	}
+
+	go func() {
+		i := 0
+	feed:
+		for ; i < n; i++ {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		close(jobs)
+		for ; i < n; i++ {
+			results <- abandoned[T](ctx, i, nil)
+		}
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// run calls fn for item i, unless the batch was cancelled before the item's
+// turn came.
+func (b *BatchRunner[T]) run(ctx context.Context, i int, fn func(ctx context.Context, i int) (T, error)) BatchResult[T] {
+	if ctx.Err() != nil {
+		return abandoned[T](ctx, i, nil)
+	}
+	itemCtx := ctx
+	if b.itemTimeout > 0 {
+		var cancel context.CancelFunc
+		itemCtx, cancel = context.WithTimeout(ctx, b.itemTimeout)
+		defer cancel()
+	}
+	v, err := fn(itemCtx, i)
+	if err != nil && ctx.Err() != nil {
+		return abandoned[T](ctx, i, err)
+	}
+	return BatchResult[T]{Index: i, Value: v, Err: err}
+}
+
+// abandoned is the result of item i of a batch cancelled before it
+// completed; err is what the item failed with, if it ran.
+func abandoned[T any](ctx context.Context, i int, err error) BatchResult[T] {
+	if err == nil {
+		err = context.Cause(ctx)
+	}
+	return BatchResult[T]{Index: i, Err: fmt.Errorf("%w: %w", ErrAbandoned, err)}
+}
@@ -0,0 +1,114 @@
+// internal/ai/batch_test.go
+package ai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+// slowClient answers "slow" prompts after a while, fails "fail" prompts and
+// answers the rest at once, tracking how many calls run at the same time.
+func slowClient(running, peak *int32) *ai.ClientMock {
+	return &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			n := atomic.AddInt32(running, 1)
+			defer atomic.AddInt32(running, -1)
+			for {
+				p := atomic.LoadInt32(peak)
+				if n <= p || atomic.CompareAndSwapInt32(peak, p, n) {
+					break
+				}
+			}
+			switch {
+			case strings.HasPrefix(prompt, "slow"):
+				select {
+				case <-time.After(time.Second):
+				case <-ctx.Done():
+					return ai.Result{}, ctx.Err()
+				}
+			case strings.HasPrefix(prompt, "fail"):
+				time.Sleep(5 * time.Millisecond)
+				return ai.Result{}, errors.New("openai unavailable")
+			default:
+				time.Sleep(5 * time.Millisecond)
+			}
+			return ai.Result{Text: "post about " + prompt}, nil
+		},
+	}
+}
+
+// transformAll runs prompts through client with runner, collecting the
+// results by index in the order they completed.
+func transformAll(ctx context.Context, runner *ai.BatchRunner[ai.Result], client ai.Client, prompts []string) (map[int]ai.BatchResult[ai.Result], []int) {
+	results := map[int]ai.BatchResult[ai.Result]{}
+	var order []int
+	for res := range runner.Run(ctx, len(prompts), func(ctx context.Context, i int) (ai.Result, error) {
+		return client.Transform(ctx, prompts[i], ai.Options{})
+	}) {
+		results[res.Index] = res
+		order = append(order, res.Index)
+	}
+	return results, order
+}
+
+func TestBatchRunner_Run(t *testing.T) {
+	var running, peak int32
+	client := slowClient(&running, &peak)
+	prompts := make([]string, 8)
+	for i := range prompts {
+		prompts[i] = fmt.Sprintf("topic %d", i)
+	}
+	prompts[0] = "slow topic"
+	prompts[3] = "fail topic"
+
+	results, order := transformAll(context.Background(), ai.NewBatchRunner[ai.Result](3, 50*time.Millisecond), client, prompts)
+	require.Len(t, results, len(prompts), "Every item is reported once")
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3), "No more than the configured number of items run at once")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&running), "Every call has returned once the channel is closed")
+	assert.NotEqual(t, 0, order[0], "Results are sent as they complete, not in order")
+
+	assert.ErrorIs(t, results[0].Err, context.DeadlineExceeded, "A slow item hits the item timeout")
+	assert.NotErrorIs(t, results[0].Err, ai.ErrAbandoned)
+	assert.EqualError(t, results[3].Err, "openai unavailable")
+	for i, p := range prompts {
+		if i == 0 || i == 3 {
+			continue
+		}
+		require.NoError(t, results[i].Err, p)
+		assert.Equal(t, "post about "+p, results[i].Value.Text)
+	}
+}
+
+func TestBatchRunner_Run_Cancelled(t *testing.T) {
+	var running, peak int32
+	client := slowClient(&running, &peak)
+	prompts := []string{"topic 0", "topic 1", "slow topic 2", "slow topic 3", "topic 4", "topic 5"}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	results, _ := transformAll(ctx, ai.NewBatchRunner[ai.Result](2, 0), client, prompts)
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "In-flight items are abandoned instead of waited for")
+	require.Len(t, results, len(prompts), "Abandoned items are reported too")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&running))
+
+	for _, i := range []int{0, 1} {
+		require.NoError(t, results[i].Err, "Items done before the cancellation keep their results")
+		assert.Equal(t, "post about "+prompts[i], results[i].Value.Text)
+	}
+	for _, i := range []int{2, 3, 4, 5} {
+		assert.ErrorIs(t, results[i].Err, ai.ErrAbandoned, prompts[i])
+		assert.ErrorIs(t, results[i].Err, context.DeadlineExceeded, prompts[i])
+	}
+	assert.Len(t, client.TransformCalls(), 4, "Items not started by the cancellation never start")
+}
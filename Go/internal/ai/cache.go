@@ -0,0 +1,104 @@
+// internal/ai/cache.go
+package ai
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// Cache stores generation results so identical requests do not reach the
+// provider again. Implementations backed by a remote store should treat
+// their own failures as misses.
+type Cache interface {
+	Get(ctx context.Context, key string) (Result, bool)
+	Set(ctx context.Context, key string, r Result)
+}
+
+// CacheKey identifies a generation by everything that influences its output.
+// more holds any further settings, such as sampling parameters.
+func CacheKey(model, prompt, tone, length string, more ...string) string {
+	h := sha256.New()
+	for _, part := range append([]string{model, prompt, tone, length}, more...) {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// LRUCache is an in-memory Cache holding at most a fixed number of entries.
+// The least recently used entry is evicted first, and entries older than the
+// TTL are treated as missing.
+type LRUCache struct {
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	order *list.List // front is most recently used
+	items map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	result  Result
+	expires time.Time
+}
+
+// NewLRUCache returns a cache of the given capacity. A ttl of zero keeps
+// entries until they are evicted.
+func NewLRUCache(capacity int, ttl time.Duration) *LRUCache {
+	return &LRUCache{
+		capacity: max(capacity, 1),
+		ttl:      ttl,
+		now:      time.Now,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *LRUCache) Get(_ context.Context, key string) (Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return Result{}, false
+	}
+	e := el.Value.(*lruEntry)
+	if c.ttl > 0 && c.now().After(e.expires) {
+		c.order.Remove(el)
+		delete(c.items, key)
+		return Result{}, false
+	}
+	c.order.MoveToFront(el)
+	return e.result, true
+}
+
+func (c *LRUCache) Set(_ context.Context, key string, r Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expires := c.now().Add(c.ttl)
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*lruEntry)
+		e.result, e.expires = r, expires
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, result: r, expires: expires})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len reports the number of cached entries, including expired ones that have
+// not been looked up since.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
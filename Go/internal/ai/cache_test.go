@@ -0,0 +1,52 @@
+// internal/ai/cache_test.go
+package ai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRUCache(2, 0)
+	c.Set(ctx, "a", Result{Text: "A"})
+	c.Set(ctx, "b", Result{Text: "B"})
+	_, _ = c.Get(ctx, "a") // a is now more recent than b
+	c.Set(ctx, "c", Result{Text: "C"})
+
+	_, ok := c.Get(ctx, "b")
+	assert.False(t, ok, "b should have been evicted")
+	got, ok := c.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, "A", got.Text)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestLRUCache_ExpiresAfterTTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	c := NewLRUCache(10, time.Minute)
+	c.now = func() time.Time { return now }
+	c.Set(ctx, "k", Result{Text: "v"})
+
+	now = now.Add(59 * time.Second)
+	_, ok := c.Get(ctx, "k")
+	assert.True(t, ok)
+
+	now = now.Add(2 * time.Second)
+	_, ok = c.Get(ctx, "k")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len(), "Expired entries are dropped on lookup")
+}
+
+func TestCacheKey(t *testing.T) {
+	base := CacheKey("gpt-4o", "prompt", "casual", "short")
+	assert.Equal(t, base, CacheKey("gpt-4o", "prompt", "casual", "short"))
+	assert.NotEqual(t, base, CacheKey("gpt-4o", "prompt", "casual", "long"))
+	assert.NotEqual(t, base, CacheKey("gpt-4o", "prompt", "", "short"))
+	// Field boundaries matter: shifting text between fields is a new key.
+	assert.NotEqual(t, CacheKey("ab", "c", "", ""), CacheKey("a", "bc", "", ""))
+}
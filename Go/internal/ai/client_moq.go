@@ -18,7 +18,10 @@ var _ Client = &ClientMock{}
 //
 //		// make and configure a mocked Client
 //		mockedClient := &ClientMock{
-//			TransformFunc: func(ctx context.Context, text string) (string, error) {
+//			StreamFunc: func(ctx context.Context, prompt string, opts Options) (<-chan Chunk, error) {
+//				panic("mock out the Stream method")
+//			},
+//			TransformFunc: func(ctx context.Context, prompt string, opts Options) (Result, error) {
 //				panic("mock out the Transform method")
 //			},
 //		}
@@ -28,38 +31,95 @@ var _ Client = &ClientMock{}
 //
 //	}
 type ClientMock struct {
+	// StreamFunc mocks the Stream method.
+	StreamFunc func(ctx context.Context, prompt string, opts Options) (<-chan Chunk, error)
+
 	// TransformFunc mocks the Transform method.
-	TransformFunc func(ctx context.Context, text string) (string, error)
+	TransformFunc func(ctx context.Context, prompt string, opts Options) (Result, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Stream holds details about calls to the Stream method.
+		Stream []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Prompt is the prompt argument value.
+			Prompt string
+			// Opts is the opts argument value.
+			Opts Options
+		}
 		// Transform holds details about calls to the Transform method.
 		Transform []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Text is the text argument value.
-			Text string
+			// Prompt is the prompt argument value.
+			Prompt string
+			// Opts is the opts argument value.
+			Opts Options
 		}
 	}
+	lockStream    sync.RWMutex
 	lockTransform sync.RWMutex
 }
 
+// Stream calls StreamFunc.
+func (mock *ClientMock) Stream(ctx context.Context, prompt string, opts Options) (<-chan Chunk, error) {
+	if mock.StreamFunc == nil {
+		panic("ClientMock.StreamFunc: method is nil but Client.Stream was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Prompt string
+		Opts   Options
+	}{
+		Ctx:    ctx,
+		Prompt: prompt,
+		Opts:   opts,
+	}
+	mock.lockStream.Lock()
+	mock.calls.Stream = append(mock.calls.Stream, callInfo)
+	mock.lockStream.Unlock()
+	return mock.StreamFunc(ctx, prompt, opts)
+}
+
+// StreamCalls gets all the calls that were made to Stream.
+// Check the length with:
+//
+//	len(mockedClient.StreamCalls())
+func (mock *ClientMock) StreamCalls() []struct {
+	Ctx    context.Context
+	Prompt string
+	Opts   Options
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Prompt string
+		Opts   Options
+	}
+	mock.lockStream.RLock()
+	calls = mock.calls.Stream
+	mock.lockStream.RUnlock()
+	return calls
+}
+
 // Transform calls TransformFunc.
-func (mock *ClientMock) Transform(ctx context.Context, text string) (string, error) {
+func (mock *ClientMock) Transform(ctx context.Context, prompt string, opts Options) (Result, error) {
 	if mock.TransformFunc == nil {
 		panic("ClientMock.TransformFunc: method is nil but Client.Transform was just called")
 	}
 	callInfo := struct {
-		Ctx  context.Context
-		Text string
+		Ctx    context.Context
+		Prompt string
+		Opts   Options
 	}{
-		Ctx:  ctx,
-		Text: text,
+		Ctx:    ctx,
+		Prompt: prompt,
+		Opts:   opts,
 	}
 	mock.lockTransform.Lock()
 	mock.calls.Transform = append(mock.calls.Transform, callInfo)
 	mock.lockTransform.Unlock()
-	return mock.TransformFunc(ctx, text)
+	return mock.TransformFunc(ctx, prompt, opts)
 }
 
 // TransformCalls gets all the calls that were made to Transform.
@@ -67,12 +127,14 @@ func (mock *ClientMock) Transform(ctx context.Context, text string) (string, err
 //
 //	len(mockedClient.TransformCalls())
 func (mock *ClientMock) TransformCalls() []struct {
-	Ctx  context.Context
-	Text string
+	Ctx    context.Context
+	Prompt string
+	Opts   Options
 } {
 	var calls []struct {
-		Ctx  context.Context
-		Text string
+		Ctx    context.Context
+		Prompt string
+		Opts   Options
 	}
 	mock.lockTransform.RLock()
 	calls = mock.calls.Transform
@@ -0,0 +1,175 @@
+// internal/ai/errors.go
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// ErrorKind classifies why an AI provider failed a request.
+type ErrorKind string
+
+const (
+	// ErrorAuth is the provider rejecting our API key; only the operator
+	// can fix it.
+	ErrorAuth ErrorKind = "auth"
+	// ErrorRateLimit is the provider throttling us, or our account being
+	// out of credit.
+	ErrorRateLimit ErrorKind = "rate_limit"
+	// ErrorContextLength is a prompt too long for the model.
+	ErrorContextLength ErrorKind = "context_length_exceeded"
+	// ErrorContentFilter is the provider refusing the prompt or cutting
+	// off the completion under its content policy.
+	ErrorContentFilter ErrorKind = "content_filter"
+	// ErrorServer is any other failure on the provider's side, and anything
+	// we cannot classify.
+	ErrorServer ErrorKind = "server_error"
+)
+
+// Error is a failure reported by an AI provider. Both clients return one for
+// every error response, wrapping the provider's own error, so callers can
+// tell with errors.As what went wrong without knowing the provider.
+type Error struct {
+	Kind     ErrorKind
+	Provider string
+	// StatusCode is the HTTP status of the provider's response; zero for
+	// errors reported inside a successful response, such as a filtered
+	// completion.
+	StatusCode int
+	// Message is the provider's explanation with anything resembling an
+	// API key redacted, safe to show to clients.
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.Provider, e.Kind, e.Message)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// maxErrorMessageLength bounds Error.Message; provider messages are short,
+// but it ends up in API responses.
+const maxErrorMessageLength = 300
+
+// apiKeyPattern matches OpenAI and Anthropic API keys, which providers echo
+// back, partly masked, when rejecting them.
+var apiKeyPattern = regexp.MustCompile(`\bsk-[A-Za-z0-9_*.\-]{4,}`)
+
+// sanitizeMessage redacts API keys from a provider's error message and
+// bounds its length.
+func sanitizeMessage(msg string) string {
+	msg = strings.TrimSpace(apiKeyPattern.ReplaceAllString(msg, "sk-[redacted]"))
+	if r := []rune(msg); len(r) > maxErrorMessageLength {
+		msg = string(r[:maxErrorMessageLength]) + "…"
+	}
+	return msg
+}
+
+// kindOf classifies a provider error from its HTTP status and whatever
+// machine-readable code the provider sent.
+func kindOf(status int, code string) ErrorKind {
+	switch code {
+	case "context_length_exceeded", "string_above_max_length":
+		return ErrorContextLength
+	case "content_filter", "content_policy_violation":
+		return ErrorContentFilter
+	case "invalid_api_key", "authentication_error", "permission_error":
+		return ErrorAuth
+	case "rate_limit_exceeded", "insufficient_quota", "rate_limit_error":
+		return ErrorRateLimit
+	}
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrorAuth
+	case status == http.StatusTooManyRequests:
+		return ErrorRateLimit
+	case status == http.StatusRequestEntityTooLarge:
+		return ErrorContextLength
+	}
+	return ErrorServer
+}
+
+// openAIError turns the errors of the OpenAI client into an *Error. Errors
+// that never reached OpenAI, such as a cancelled context, are returned as
+// they are.
+func openAIError(err error) error {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		code, _ := apiErr.Code.(string)
+		if code == "" {
+			code = apiErr.Type
+		}
+		return &Error{
+			Kind:       kindOf(apiErr.HTTPStatusCode, code),
+			Provider:   ProviderOpenAI,
+			StatusCode: apiErr.HTTPStatusCode,
+			Message:    sanitizeMessage(apiErr.Message),
+			Err:        err,
+		}
+	}
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return &Error{
+			Kind:       kindOf(reqErr.HTTPStatusCode, ""),
+			Provider:   ProviderOpenAI,
+			StatusCode: reqErr.HTTPStatusCode,
+			Message:    http.StatusText(reqErr.HTTPStatusCode),
+			Err:        err,
+		}
+	}
+	return err
+}
+
+// errContentFiltered is the error of a completion the provider stopped under
+// its content policy.
+func errContentFiltered(provider string) *Error {
+	return &Error{
+		Kind:     ErrorContentFilter,
+		Provider: provider,
+		Message:  "The completion was stopped by the provider's content filter",
+		Err:      errors.New("completion stopped by content filter"),
+	}
+}
+
+// errNoCompletion is the error of a successful response holding no
+// completion at all, which callers can only treat as a provider failure.
+func errNoCompletion(provider string) *Error {
+	return &Error{
+		Kind:     ErrorServer,
+		Provider: provider,
+		Message:  "The provider returned no completion",
+		Err:      errors.New("response contained no choices"),
+	}
+}
+
+// anthropicError is the *Error of an Anthropic error response, or of an
+// error event in a stream, whose status is zero. Anthropic reports a prompt
+// over the context window as an invalid request, so it is told from other
+// invalid requests by its message.
+func anthropicError(status int, body anthropicErrorBody) *Error {
+	code := body.Type
+	if code == "invalid_request_error" && strings.Contains(strings.ToLower(body.Message), "prompt is too long") {
+		code = "context_length_exceeded"
+	}
+	e := &Error{
+		Kind:       kindOf(status, code),
+		Provider:   ProviderAnthropic,
+		StatusCode: status,
+		Message:    sanitizeMessage(body.Message),
+		Err:        fmt.Errorf("anthropic: %s: %s", body.Type, body.Message),
+	}
+	switch {
+	case body.Message == "":
+		e.Message = http.StatusText(status)
+		e.Err = fmt.Errorf("anthropic: status code %d", status)
+	case status != 0:
+		e.Err = fmt.Errorf("anthropic: status code %d, %s: %s", status, body.Type, body.Message)
+	}
+	return e
+}
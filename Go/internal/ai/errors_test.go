@@ -0,0 +1,66 @@
+// internal/ai/errors_test.go
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  *openai.APIError
+		want ErrorKind
+	}{
+		{"bad key", &openai.APIError{HTTPStatusCode: 401, Code: "invalid_api_key", Message: "Incorrect API key provided: sk-proj-ab**********wxyz."}, ErrorAuth},
+		{"rate limited", &openai.APIError{HTTPStatusCode: 429, Code: "rate_limit_exceeded", Message: "Rate limit reached"}, ErrorRateLimit},
+		{"out of credit", &openai.APIError{HTTPStatusCode: 429, Code: "insufficient_quota", Message: "You exceeded your current quota"}, ErrorRateLimit},
+		{"prompt too long", &openai.APIError{HTTPStatusCode: 400, Code: "context_length_exceeded", Message: "This model's maximum context length is 128000 tokens"}, ErrorContextLength},
+		{"filtered", &openai.APIError{HTTPStatusCode: 400, Code: "content_filter", Message: "The response was filtered"}, ErrorContentFilter},
+		{"server", &openai.APIError{HTTPStatusCode: 500, Type: "server_error", Message: "The server had an error"}, ErrorServer},
+		{"unknown bad request", &openai.APIError{HTTPStatusCode: 400, Message: "Invalid value"}, ErrorServer},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := openAIError(fmt.Errorf("wrapped: %w", tc.err))
+			var aiErr *Error
+			require.ErrorAs(t, err, &aiErr)
+			assert.Equal(t, tc.want, aiErr.Kind)
+			assert.Equal(t, ProviderOpenAI, aiErr.Provider)
+			assert.Equal(t, tc.err.HTTPStatusCode, aiErr.StatusCode)
+			var raw *openai.APIError
+			assert.ErrorAs(t, err, &raw, "The provider's error is wrapped")
+		})
+	}
+
+	err := openAIError(&openai.APIError{HTTPStatusCode: 401, Code: "invalid_api_key", Message: "Incorrect API key provided: sk-proj-ab**********wxyz. You can find your API key at https://platform.openai.com/account/api-keys."})
+	var aiErr *Error
+	require.ErrorAs(t, err, &aiErr)
+	assert.NotContains(t, aiErr.Message, "sk-proj-ab", "API keys are redacted")
+	assert.Contains(t, aiErr.Message, "Incorrect API key provided: sk-[redacted]")
+
+	err = openAIError(&openai.RequestError{HTTPStatusCode: http.StatusBadGateway, Err: errors.New("bad gateway")})
+	require.ErrorAs(t, err, &aiErr)
+	assert.Equal(t, ErrorServer, aiErr.Kind)
+
+	assert.Equal(t, context.Canceled, openAIError(context.Canceled), "Errors that never reached OpenAI are left alone")
+}
+
+func TestAnthropicError(t *testing.T) {
+	assert.Equal(t, ErrorAuth, anthropicError(401, anthropicErrorBody{Type: "authentication_error", Message: "invalid x-api-key"}).Kind)
+	assert.Equal(t, ErrorRateLimit, anthropicError(429, anthropicErrorBody{Type: "rate_limit_error", Message: "slow down"}).Kind)
+	assert.Equal(t, ErrorServer, anthropicError(529, anthropicErrorBody{Type: "overloaded_error", Message: "Overloaded"}).Kind)
+	assert.Equal(t, ErrorContextLength, anthropicError(400, anthropicErrorBody{Type: "invalid_request_error", Message: "prompt is too long: 210000 tokens > 200000 maximum"}).Kind)
+	assert.Equal(t, ErrorServer, anthropicError(400, anthropicErrorBody{Type: "invalid_request_error", Message: "bad model"}).Kind)
+
+	e := anthropicError(502, anthropicErrorBody{})
+	assert.Equal(t, "Bad Gateway", e.Message)
+	assert.EqualError(t, e.Err, "anthropic: status code 502")
+}
@@ -0,0 +1,75 @@
+// internal/ai/image.go
+package ai
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// ImageModel is the OpenAI model images are generated with.
+const ImageModel = openai.CreateImageModelDallE3
+
+// ImageSizes are the sizes ImageModel supports.
+var ImageSizes = []string{
+	openai.CreateImageSize1024x1024,
+	openai.CreateImageSize1792x1024,
+	openai.CreateImageSize1024x1792,
+}
+
+// DefaultImageSize is used when no size is requested.
+const DefaultImageSize = openai.CreateImageSize1024x1024
+
+// ImageURLLifetime is how long OpenAI serves a generated image from its URL.
+const ImageURLLifetime = time.Hour
+
+// IsImageSize reports whether size is one of ImageSizes.
+func IsImageSize(size string) bool {
+	return slices.Contains(ImageSizes, size)
+}
+
+// ImageGenerator creates an image from a text prompt.
+type ImageGenerator interface {
+	GenerateImage(ctx context.Context, prompt, size string) (Image, error)
+}
+
+// Image is a generated image. URL is hosted by the provider and stops
+// working at ExpiresAt, so clients should download it promptly.
+// RevisedPrompt is the prompt the model actually used, when it rewrote ours.
+type Image struct {
+	URL           string
+	ExpiresAt     time.Time
+	RevisedPrompt string
+}
+
+// NewOpenAIImageGenerator returns an ImageGenerator backed by OpenAI's image
+// endpoint. Only the token and retry settings of cfg are used.
+func NewOpenAIImageGenerator(cfg OpenAIConfig) ImageGenerator {
+	return newOpenAIClient(cfg)
+}
+
+// GenerateImage asks for a URL rather than base64 data, which keeps a
+// multi-megabyte payload out of our responses and database.
+func (c *openaiClient) GenerateImage(ctx context.Context, prompt, size string) (Image, error) {
+	resp, err := c.cl.CreateImage(ctx, openai.ImageRequest{
+		Prompt:         prompt,
+		Model:          ImageModel,
+		N:              1,
+		Size:           size,
+		ResponseFormat: openai.CreateImageResponseFormatURL,
+	})
+	if err != nil {
+		return Image{}, openAIError(err)
+	}
+	if len(resp.Data) == 0 || resp.Data[0].URL == "" {
+		return Image{}, errors.New("openai: image response contained no URL")
+	}
+	return Image{
+		URL:           resp.Data[0].URL,
+		ExpiresAt:     time.Unix(resp.Created, 0).Add(ImageURLLifetime),
+		RevisedPrompt: resp.Data[0].RevisedPrompt,
+	}, nil
+}
@@ -0,0 +1,87 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package ai
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that ImageGeneratorMock does implement ImageGenerator.
+// If this is not the case, regenerate this file with moq.
+var _ ImageGenerator = &ImageGeneratorMock{}
+
+// ImageGeneratorMock is a mock implementation of ImageGenerator.
+//
+//	func TestSomethingThatUsesImageGenerator(t *testing.T) {
+//
+//		// make and configure a mocked ImageGenerator
+//		mockedImageGenerator := &ImageGeneratorMock{
+//			GenerateImageFunc: func(ctx context.Context, prompt string, size string) (Image, error) {
+//				panic("mock out the GenerateImage method")
+//			},
+//		}
+//
+//		// use mockedImageGenerator in code that requires ImageGenerator
+//		// and then make assertions.
+//
+//	}
+type ImageGeneratorMock struct {
+	// GenerateImageFunc mocks the GenerateImage method.
+	GenerateImageFunc func(ctx context.Context, prompt string, size string) (Image, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GenerateImage holds details about calls to the GenerateImage method.
+		GenerateImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Prompt is the prompt argument value.
+			Prompt string
+			// Size is the size argument value.
+			Size string
+		}
+	}
+	lockGenerateImage sync.RWMutex
+}
+
+// GenerateImage calls GenerateImageFunc.
+func (mock *ImageGeneratorMock) GenerateImage(ctx context.Context, prompt string, size string) (Image, error) {
+	if mock.GenerateImageFunc == nil {
+		panic("ImageGeneratorMock.GenerateImageFunc: method is nil but ImageGenerator.GenerateImage was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Prompt string
+		Size   string
+	}{
+		Ctx:    ctx,
+		Prompt: prompt,
+		Size:   size,
+	}
+	mock.lockGenerateImage.Lock()
+	mock.calls.GenerateImage = append(mock.calls.GenerateImage, callInfo)
+	mock.lockGenerateImage.Unlock()
+	return mock.GenerateImageFunc(ctx, prompt, size)
+}
+
+// GenerateImageCalls gets all the calls that were made to GenerateImage.
+// Check the length with:
+//
+//	len(mockedImageGenerator.GenerateImageCalls())
+func (mock *ImageGeneratorMock) GenerateImageCalls() []struct {
+	Ctx    context.Context
+	Prompt string
+	Size   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Prompt string
+		Size   string
+	}
+	mock.lockGenerateImage.RLock()
+	calls = mock.calls.GenerateImage
+	mock.lockGenerateImage.RUnlock()
+	return calls
+}
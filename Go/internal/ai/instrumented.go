@@ -0,0 +1,79 @@
+// internal/ai/instrumented.go
+package ai
+
+import (
+	"cmp"
+	"context"
+	"time"
+)
+
+// Operations reported to a MetricsRecorder.
+const (
+	OperationTransform = "transform"
+	OperationStream    = "stream"
+)
+
+// MetricsRecorder receives one observation per generation. Usage is zero
+// for streams whose provider does not report it.
+type MetricsRecorder interface {
+	ObserveGeneration(provider, model, operation string, d time.Duration, usage Usage, err error)
+}
+
+type instrumentedClient struct {
+	next     Client
+	provider string
+	model    string
+	rec      MetricsRecorder
+}
+
+// NewInstrumentedClient reports every generation of c to rec. provider and
+// model label the observations; model is used when a request does not
+// override it.
+func NewInstrumentedClient(c Client, provider, model string, rec MetricsRecorder) Client {
+	return &instrumentedClient{next: c, provider: provider, model: model, rec: rec}
+}
+
+func (c *instrumentedClient) Transform(ctx context.Context, prompt string, opts Options) (Result, error) {
+	start := time.Now()
+	res, err := c.next.Transform(ctx, prompt, opts)
+	c.rec.ObserveGeneration(c.provider, cmp.Or(res.Model, opts.Model, c.model), OperationTransform, time.Since(start), res.Usage, err)
+	return res, err
+}
+
+// Stream is observed when the stream ends, so the duration covers the whole
+// generation and a failure part way through counts as an error.
+func (c *instrumentedClient) Stream(ctx context.Context, prompt string, opts Options) (<-chan Chunk, error) {
+	start := time.Now()
+	model := cmp.Or(opts.Model, c.model)
+	upstream, err := c.next.Stream(ctx, prompt, opts)
+	if err != nil {
+		c.rec.ObserveGeneration(c.provider, model, OperationStream, time.Since(start), Usage{}, err)
+		return nil, err
+	}
+	out := make(chan Chunk)
+	go func() {
+		defer close(out)
+		var streamErr error
+		var usage Usage
+		defer func() {
+			c.rec.ObserveGeneration(c.provider, model, OperationStream, time.Since(start), usage, cmp.Or(streamErr, ctx.Err()))
+		}()
+		for chunk := range upstream {
+			if chunk.Err != nil {
+				streamErr = chunk.Err
+			}
+			if chunk.Model != "" {
+				model = chunk.Model
+			}
+			if chunk.Usage != (Usage{}) {
+				usage = chunk.Usage
+			}
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,61 @@
+// internal/ai/instrumented_test.go
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type generation struct {
+	model, operation string
+	usage            Usage
+	err              error
+}
+
+type fakeRecorder struct{ seen []generation }
+
+func (f *fakeRecorder) ObserveGeneration(provider, model, operation string, d time.Duration, usage Usage, err error) {
+	f.seen = append(f.seen, generation{model, operation, usage, err})
+}
+
+func TestInstrumentedClient(t *testing.T) {
+	streamErr := errors.New("connection reset")
+	inner := &ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts Options) (Result, error) {
+			if prompt == "fail" {
+				return Result{}, errors.New("unavailable")
+			}
+			return Result{Text: "post", Model: "gpt-4o", Usage: Usage{PromptTokens: 10, CompletionTokens: 20, TotalTokens: 30}}, nil
+		},
+		StreamFunc: func(ctx context.Context, prompt string, opts Options) (<-chan Chunk, error) {
+			ch := make(chan Chunk, 2)
+			ch <- Chunk{Text: "po"}
+			ch <- Chunk{Err: streamErr}
+			close(ch)
+			return ch, nil
+		},
+	}
+	rec := &fakeRecorder{}
+	c := NewInstrumentedClient(inner, ProviderOpenAI, "gpt-4o-mini", rec)
+
+	_, err := c.Transform(context.Background(), "text", Options{Model: "gpt-4o"})
+	require.NoError(t, err)
+	_, err = c.Transform(context.Background(), "fail", Options{})
+	require.Error(t, err)
+	chunks, err := c.Stream(context.Background(), "text", Options{})
+	require.NoError(t, err)
+	for range chunks {
+	}
+
+	require.Len(t, rec.seen, 3)
+	assert.Equal(t, generation{"gpt-4o", OperationTransform, Usage{PromptTokens: 10, CompletionTokens: 20, TotalTokens: 30}, nil}, rec.seen[0])
+	assert.Equal(t, "gpt-4o-mini", rec.seen[1].model, "The default model labels requests that do not override it")
+	assert.Error(t, rec.seen[1].err)
+	assert.Equal(t, OperationStream, rec.seen[2].operation)
+	assert.ErrorIs(t, rec.seen[2].err, streamErr, "A stream failing part way through is an error")
+}
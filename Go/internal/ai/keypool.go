@@ -0,0 +1,145 @@
+// internal/ai/keypool.go
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultKeyCooldown is how long an API key is skipped after a 429 that did
+// not say when to retry.
+const DefaultKeyCooldown = time.Minute
+
+// apiKey is one credential in a keyPool.
+type apiKey struct {
+	secret    string
+	served    int
+	coolUntil time.Time
+}
+
+// label identifies a key in logs without revealing it.
+func (k *apiKey) label() string {
+	if len(k.secret) <= 4 {
+		return "…"
+	}
+	return "…" + k.secret[len(k.secret)-4:]
+}
+
+// keyPool hands out API keys round-robin, skipping keys that were recently
+// rate limited.
+type keyPool struct {
+	mu       sync.Mutex
+	keys     []*apiKey
+	next     int
+	cooldown time.Duration
+	now      func() time.Time
+}
+
+func newKeyPool(secrets []string, cooldown time.Duration) *keyPool {
+	if cooldown <= 0 {
+		cooldown = DefaultKeyCooldown
+	}
+	p := &keyPool{cooldown: cooldown, now: time.Now}
+	for _, s := range secrets {
+		p.keys = append(p.keys, &apiKey{secret: s})
+	}
+	return p
+}
+
+// pick returns the next key that is not cooling down. When every key is,
+// the one that becomes usable first is returned.
+func (p *keyPool) pick() *apiKey {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	var soonest *apiKey
+	for i := range p.keys {
+		k := p.keys[(p.next+i)%len(p.keys)]
+		if !now.Before(k.coolUntil) {
+			p.next = (p.next + i + 1) % len(p.keys)
+			k.served++
+			return k
+		}
+		if soonest == nil || k.coolUntil.Before(soonest.coolUntil) {
+			soonest = k
+		}
+	}
+	soonest.served++
+	return soonest
+}
+
+// rateLimited puts k on cooldown for d, or the pool's default when d is zero,
+// and reports whether another key is ready to take over.
+func (p *keyPool) rateLimited(k *apiKey, d time.Duration) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if d <= 0 {
+		d = p.cooldown
+	}
+	now := p.now()
+	k.coolUntil = now.Add(d)
+	for _, other := range p.keys {
+		if !now.Before(other.coolUntil) {
+			return true
+		}
+	}
+	return false
+}
+
+// usage summarises how many requests each key has served, for the logs.
+func (p *keyPool) usage() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	parts := make([]string, len(p.keys))
+	for i, k := range p.keys {
+		parts[i] = fmt.Sprintf("%s=%d", k.label(), k.served)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// keyTransport authenticates each request with a key from the pool. A 429 is
+// retried straight away with the next key when one is available; otherwise
+// it is returned so retryTransport can back off.
+type keyTransport struct {
+	base http.RoundTripper
+	pool *keyPool
+}
+
+func (t *keyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				return nil, errors.New("ai: cannot retry request without GetBody")
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		k := t.pool.pick()
+		req = req.Clone(ctx)
+		req.Header.Set("Authorization", "Bearer "+k.secret)
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+
+		d, _ := retryAfter(resp.Header.Get("Retry-After"))
+		another := t.pool.rateLimited(k, d)
+		slog.WarnContext(ctx, "ai: key was rate limited", "key", k.label(), "requests_per_key", t.pool.usage())
+		if !another || attempt+1 >= len(t.pool.keys) {
+			return resp, nil
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
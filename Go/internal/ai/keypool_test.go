@@ -0,0 +1,73 @@
+// internal/ai/keypool_test.go
+package ai
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyPool_RoundRobinSkipsCoolingKeys(t *testing.T) {
+	now := time.Now()
+	p := newKeyPool([]string{"key-a", "key-b", "key-c"}, time.Minute)
+	p.now = func() time.Time { return now }
+
+	var got []string
+	for i := 0; i < 3; i++ {
+		got = append(got, p.pick().secret)
+	}
+	assert.Equal(t, []string{"key-a", "key-b", "key-c"}, got)
+
+	assert.True(t, p.rateLimited(p.keys[1], 0), "Other keys are still available")
+	got = got[:0]
+	for i := 0; i < 4; i++ {
+		got = append(got, p.pick().secret)
+	}
+	assert.Equal(t, []string{"key-a", "key-c", "key-a", "key-c"}, got)
+
+	now = now.Add(61 * time.Second)
+	assert.Contains(t, []string{p.pick().secret, p.pick().secret, p.pick().secret}, "key-b", "Key is used again after the cooldown")
+}
+
+func TestKeyPool_AllCoolingPicksSoonestAvailable(t *testing.T) {
+	p := newKeyPool([]string{"key-a", "key-b"}, time.Minute)
+	p.rateLimited(p.keys[0], 10*time.Minute)
+	assert.False(t, p.rateLimited(p.keys[1], time.Minute))
+	assert.Equal(t, "key-b", p.pick().secret)
+}
+
+func TestKeyTransport_FailsOverOn429(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Get("Authorization"))
+		mu.Unlock()
+		if r.Header.Get("Authorization") == "Bearer key-a" {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	pool := newKeyPool([]string{"key-a", "key-b"}, time.Minute)
+	cl := &http.Client{Transport: &keyTransport{base: http.DefaultTransport, pool: pool}}
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{}`))
+		resp, err := cl.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, []string{"Bearer key-a", "Bearer key-b", "Bearer key-b"}, seen,
+		"A rate-limited key is retried with the next one and then skipped")
+	assert.Equal(t, "…ey-a=1, …ey-b=2", pool.usage())
+}
@@ -0,0 +1,184 @@
+// internal/ai/mock_client.go
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MockModel is the model reported by the mock client's results.
+const MockModel = "mock"
+
+// DefaultMockLatency is how long a mock generation takes unless configured
+// otherwise, roughly what a short post takes from a real model.
+const DefaultMockLatency = 500 * time.Millisecond
+
+// mockPosts are the canned posts of the mock client; %[1]s is the topic and
+// %[2]s a hashtag made from it.
+var mockPosts = []string{
+	"🚀 Big news: %[1]s.\n\nI've been thinking about this for a while, and here is what I learned: the small steps compound. Grateful for everyone who made it possible.\n\nWhat would you have done differently? 👇\n\n#%[2]s #growth #leadership",
+	"Most people will scroll past this. But the few who stop will get it.\n\n%[1]s.\n\nThat's it. That's the post. Consistency beats talent when talent isn't consistent. 💡\n\n#%[2]s #mindset #careers",
+	"3 lessons from %[1]s:\n\n1️⃣ Start before you're ready.\n2️⃣ Ask for help early.\n3️⃣ Celebrate the small wins.\n\nWhich one resonates with you the most?\n\n#%[2]s #lessonslearned #teamwork",
+	"I almost didn't share this. 🙏\n\n%[1]s — and it changed how I think about work. Turns out the journey is the destination.\n\nAgree? Let me know in the comments.\n\n#%[2]s #inspiration #future",
+}
+
+type mockClient struct {
+	latency      time.Duration
+	errorPercent int
+}
+
+// MockOption configures the mock client.
+type MockOption func(*mockClient)
+
+// WithLatency makes every mock generation take d; a stream spreads d over
+// its chunks. Zero answers at once.
+func WithLatency(d time.Duration) MockOption {
+	return func(c *mockClient) { c.latency = d }
+}
+
+// WithErrorPercent fails about percent of the mock's calls, at random, with
+// an *Error of kind ErrorServer, to exercise error handling.
+func WithErrorPercent(percent int) MockOption {
+	return func(c *mockClient) { c.errorPercent = percent }
+}
+
+// NewMock returns a Client that needs no provider and costs nothing, for CI
+// and demos. It answers each prompt with one of a few canned posts about the
+// prompt's topic, chosen by the topic, so the same prompt always gets the
+// same post. Generations take DefaultMockLatency unless configured
+// otherwise.
+func NewMock(opts ...MockOption) Client {
+	c := &mockClient{latency: DefaultMockLatency}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *mockClient) Transform(ctx context.Context, prompt string, opts Options) (Result, error) {
+	if err := c.fail(); err != nil {
+		return Result{}, err
+	}
+	if err := sleep(ctx, c.latency); err != nil {
+		return Result{}, err
+	}
+	return mockResult(prompt, opts), nil
+}
+
+// Stream sends the post of Transform word by word, and then its usage.
+func (c *mockClient) Stream(ctx context.Context, prompt string, opts Options) (<-chan Chunk, error) {
+	if err := c.fail(); err != nil {
+		return nil, err
+	}
+	res := mockResult(prompt, opts)
+	words := strings.SplitAfter(res.Text, " ")
+	delay := c.latency / time.Duration(len(words))
+
+	out := make(chan Chunk)
+	go func() {
+		defer close(out)
+		for _, w := range words {
+			if sleep(ctx, delay) != nil {
+				return
+			}
+			select {
+			case out <- Chunk{Text: w, Model: res.Model}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		select {
+		case out <- Chunk{Model: res.Model, Usage: res.Usage}:
+		case <-ctx.Done():
+		}
+	}()
+	return out, nil
+}
+
+// fail returns the injected error of a call, if it is one of those chosen
+// to fail.
+func (c *mockClient) fail() error {
+	if c.errorPercent <= 0 || rand.IntN(100) >= c.errorPercent {
+		return nil
+	}
+	return &Error{
+		Kind:       ErrorServer,
+		Provider:   ProviderMock,
+		StatusCode: http.StatusServiceUnavailable,
+		Message:    "Injected mock failure",
+		Err:        errors.New("mock: injected failure"),
+	}
+}
+
+// mockResult is the canned post for prompt, cut to opts' token limit, with
+// usage estimated as a provider would have counted it.
+func mockResult(prompt string, opts Options) Result {
+	topic := mockTopic(prompt)
+	h := fnv.New32a()
+	h.Write([]byte(topic))
+	text := fmt.Sprintf(mockPosts[h.Sum32()%uint32(len(mockPosts))], topic, hashtagOf(topic))
+
+	if limit := opts.maxTokens(); EstimateTokens(text, MockModel) > limit {
+		text = string([]rune(text)[:limit*4])
+	}
+	usage := Usage{PromptTokens: EstimateTokens(DefaultSystemPrompt+prompt, MockModel), CompletionTokens: EstimateTokens(text, MockModel)}
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	return Result{Text: text, Model: MockModel, Usage: usage}
+}
+
+// mockTopicLength bounds the topic quoted in a mock post.
+const mockTopicLength = 80
+
+// mockTopic finds what prompt asks to write about: prompts end with the
+// user's text, after the instructions and a blank line, usually quoted.
+func mockTopic(prompt string) string {
+	paragraphs := strings.Split(strings.TrimSpace(prompt), "\n\n")
+	topic := strings.Join(strings.Fields(paragraphs[len(paragraphs)-1]), " ")
+	topic = strings.TrimRight(strings.Trim(topic, `"`), ".!? ")
+	if r := []rune(topic); len(r) > mockTopicLength {
+		topic = strings.TrimSpace(string(r[:mockTopicLength])) + "…"
+	}
+	if topic == "" {
+		return "something I learned this week"
+	}
+	return topic
+}
+
+// hashtagOf makes a hashtag of the first word of topic with a letter in it,
+// keeping only its letters and digits.
+func hashtagOf(topic string) string {
+	for _, w := range strings.Fields(topic) {
+		tag := strings.ToLower(strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, w))
+		if strings.IndexFunc(tag, unicode.IsLetter) >= 0 {
+			return tag
+		}
+	}
+	return "linkedin"
+}
+
+// sleep waits for d or until ctx is done, whichever is first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
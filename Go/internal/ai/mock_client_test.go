@@ -0,0 +1,92 @@
+// internal/ai/mock_client_test.go
+package ai_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+const mockPrompt = "Rewrite the following statement as an over-the-top inspirational LinkedIn post.\n\n\"We shipped v2 of our billing API.\""
+
+func TestMock_Transform(t *testing.T) {
+	client := ai.NewMock(ai.WithLatency(0))
+
+	first, err := client.Transform(context.Background(), mockPrompt, ai.Options{})
+	require.NoError(t, err)
+	assert.Contains(t, first.Text, "We shipped v2 of our billing API", "Posts are about the prompt's topic")
+	assert.Contains(t, first.Text, "#we ")
+	assert.Equal(t, ai.MockModel, first.Model)
+	assert.Positive(t, first.Usage.PromptTokens)
+	assert.Equal(t, first.Usage.PromptTokens+first.Usage.CompletionTokens, first.Usage.TotalTokens)
+
+	again, err := client.Transform(context.Background(), mockPrompt, ai.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, first, again, "The same prompt always gets the same post")
+
+	other, err := client.Transform(context.Background(), "Write a post.\n\n\"Hiring two backend engineers\"", ai.Options{})
+	require.NoError(t, err)
+	assert.Contains(t, other.Text, "Hiring two backend engineers")
+
+	short, err := client.Transform(context.Background(), mockPrompt, ai.Options{MaxTokens: 10})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, ai.EstimateTokens(short.Text, ai.MockModel), 10, "The token limit is honoured")
+}
+
+func TestMock_Latency(t *testing.T) {
+	client := ai.NewMock(ai.WithLatency(50 * time.Millisecond))
+	start := time.Now()
+	_, err := client.Transform(context.Background(), mockPrompt, ai.Options{})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = ai.NewMock(ai.WithLatency(time.Minute)).Transform(ctx, mockPrompt, ai.Options{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestMock_Stream(t *testing.T) {
+	client := ai.NewMock(ai.WithLatency(10 * time.Millisecond))
+	want, err := client.Transform(context.Background(), mockPrompt, ai.Options{})
+	require.NoError(t, err)
+
+	chunks, err := client.Stream(context.Background(), mockPrompt, ai.Options{})
+	require.NoError(t, err)
+	var got strings.Builder
+	var last ai.Chunk
+	n := 0
+	for c := range chunks {
+		require.NoError(t, c.Err)
+		got.WriteString(c.Text)
+		last = c
+		n++
+	}
+	assert.Equal(t, want.Text, got.String(), "A stream sends the post Transform returns")
+	assert.Greater(t, n, 1, "The post is sent in pieces")
+	assert.Equal(t, want.Usage, last.Usage, "The stream ends with the usage of Transform")
+	assert.Equal(t, want.Model, last.Model)
+}
+
+func TestMock_ErrorPercent(t *testing.T) {
+	failing := ai.NewMock(ai.WithLatency(0), ai.WithErrorPercent(100))
+	_, err := failing.Transform(context.Background(), mockPrompt, ai.Options{})
+	var aiErr *ai.Error
+	require.ErrorAs(t, err, &aiErr)
+	assert.Equal(t, ai.ErrorServer, aiErr.Kind)
+	assert.Equal(t, ai.ProviderMock, aiErr.Provider)
+	_, err = failing.Stream(context.Background(), mockPrompt, ai.Options{})
+	assert.ErrorAs(t, err, &aiErr)
+
+	healthy := ai.NewMock(ai.WithLatency(0), ai.WithErrorPercent(0))
+	for range 20 {
+		_, err := healthy.Transform(context.Background(), mockPrompt, ai.Options{})
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,59 @@
+// internal/ai/models.go
+package ai
+
+// Supported AI providers.
+const (
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+	// ProviderMock is NewMock, which needs no API key.
+	ProviderMock = "mock"
+)
+
+// DefaultModel is the OpenAI chat model used when none is configured.
+const DefaultModel = "gpt-4o-mini"
+
+// DefaultAnthropicModel is the Claude model used when none is configured.
+const DefaultAnthropicModel = "claude-3-5-haiku-latest"
+
+// knownModels maps the chat models this service has been tested against to
+// the provider that serves them.
+var knownModels = map[string]string{
+	"gpt-4o-mini":   ProviderOpenAI,
+	"gpt-4o":        ProviderOpenAI,
+	"gpt-4-turbo":   ProviderOpenAI,
+	"gpt-4":         ProviderOpenAI,
+	"gpt-3.5-turbo": ProviderOpenAI,
+
+	"claude-3-5-haiku-latest":  ProviderAnthropic,
+	"claude-3-5-sonnet-latest": ProviderAnthropic,
+	"claude-3-7-sonnet-latest": ProviderAnthropic,
+	"claude-sonnet-4-0":        ProviderAnthropic,
+	"claude-opus-4-0":          ProviderAnthropic,
+}
+
+// contextWindows holds the context window, in tokens, of each model. Keep
+// it in step with knownModels.
+var contextWindows = map[string]int{
+	"gpt-4o-mini":   128_000,
+	"gpt-4o":        128_000,
+	"gpt-4-turbo":   128_000,
+	"gpt-4":         8_192,
+	"gpt-3.5-turbo": 16_385,
+
+	"claude-3-5-haiku-latest":  200_000,
+	"claude-3-5-sonnet-latest": 200_000,
+	"claude-3-7-sonnet-latest": 200_000,
+	"claude-sonnet-4-0":        200_000,
+	"claude-opus-4-0":          200_000,
+}
+
+// IsKnownModel reports whether model is one of the supported chat models.
+func IsKnownModel(model string) bool {
+	_, ok := knownModels[model]
+	return ok
+}
+
+// ModelProvider returns the provider serving model, or "" if it is unknown.
+func ModelProvider(model string) string {
+	return knownModels[model]
+}
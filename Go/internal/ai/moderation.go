@@ -0,0 +1,60 @@
+// internal/ai/moderation.go
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// Moderator checks text against a content policy.
+type Moderator interface {
+	Moderate(ctx context.Context, text string) (Moderation, error)
+}
+
+// Moderation is the verdict on a piece of text. Categories lists the policy
+// categories the text was flagged for, such as "hate" or "violence".
+type Moderation struct {
+	Flagged    bool
+	Categories []string
+}
+
+// NewOpenAIModerator returns a Moderator backed by OpenAI's moderation
+// endpoint. Only the token and retry settings of cfg are used. It works
+// whichever provider generates the posts.
+func NewOpenAIModerator(cfg OpenAIConfig) Moderator {
+	return newOpenAIClient(cfg)
+}
+
+func (c *openaiClient) Moderate(ctx context.Context, text string) (Moderation, error) {
+	resp, err := c.cl.Moderations(ctx, openai.ModerationRequest{Input: text})
+	if err != nil {
+		return Moderation{}, err
+	}
+	var m Moderation
+	for _, r := range resp.Results {
+		if !r.Flagged {
+			continue
+		}
+		m.Flagged = true
+		m.Categories = append(m.Categories, flaggedCategories(r.Categories)...)
+	}
+	sort.Strings(m.Categories)
+	return m, nil
+}
+
+// flaggedCategories returns the API names of the categories set in c.
+func flaggedCategories(c openai.ResultCategories) []string {
+	raw, _ := json.Marshal(c)
+	var all map[string]bool
+	_ = json.Unmarshal(raw, &all)
+	var names []string
+	for name, flagged := range all {
+		if flagged {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,81 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package ai
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that ModeratorMock does implement Moderator.
+// If this is not the case, regenerate this file with moq.
+var _ Moderator = &ModeratorMock{}
+
+// ModeratorMock is a mock implementation of Moderator.
+//
+//	func TestSomethingThatUsesModerator(t *testing.T) {
+//
+//		// make and configure a mocked Moderator
+//		mockedModerator := &ModeratorMock{
+//			ModerateFunc: func(ctx context.Context, text string) (Moderation, error) {
+//				panic("mock out the Moderate method")
+//			},
+//		}
+//
+//		// use mockedModerator in code that requires Moderator
+//		// and then make assertions.
+//
+//	}
+type ModeratorMock struct {
+	// ModerateFunc mocks the Moderate method.
+	ModerateFunc func(ctx context.Context, text string) (Moderation, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Moderate holds details about calls to the Moderate method.
+		Moderate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Text is the text argument value.
+			Text string
+		}
+	}
+	lockModerate sync.RWMutex
+}
+
+// Moderate calls ModerateFunc.
+func (mock *ModeratorMock) Moderate(ctx context.Context, text string) (Moderation, error) {
+	if mock.ModerateFunc == nil {
+		panic("ModeratorMock.ModerateFunc: method is nil but Moderator.Moderate was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Text string
+	}{
+		Ctx:  ctx,
+		Text: text,
+	}
+	mock.lockModerate.Lock()
+	mock.calls.Moderate = append(mock.calls.Moderate, callInfo)
+	mock.lockModerate.Unlock()
+	return mock.ModerateFunc(ctx, text)
+}
+
+// ModerateCalls gets all the calls that were made to Moderate.
+// Check the length with:
+//
+//	len(mockedModerator.ModerateCalls())
+func (mock *ModeratorMock) ModerateCalls() []struct {
+	Ctx  context.Context
+	Text string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Text string
+	}
+	mock.lockModerate.RLock()
+	calls = mock.calls.Moderate
+	mock.lockModerate.RUnlock()
+	return calls
+}
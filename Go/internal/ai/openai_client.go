@@ -2,38 +2,227 @@
 package ai
 
 import (
+	"cmp"
 	"context"
-	"fmt"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"time"
 
 	openai "github.com/sashabaranov/go-openai"
 )
 
+// Client generates a post from a prompt. The prompt is sent as the user
+// message after the client's system prompt; callers are responsible for
+// wrapping the user's text in instructions.
 type Client interface {
-	Transform(ctx context.Context, text string) (string, error)
+	Transform(ctx context.Context, prompt string, opts Options) (Result, error)
+	Stream(ctx context.Context, prompt string, opts Options) (<-chan Chunk, error)
+}
+
+// Options tunes a single generation. Zero values fall back to the client's
+// defaults.
+type Options struct {
+	// Model overrides the model the client was created with.
+	Model string
+	// MaxTokens caps the length of the completion; zero uses the default.
+	MaxTokens int
+	// Temperature (0 to 2) and TopP (0 to 1) tune how freely the model
+	// picks words; nil leaves them to the provider. Tuning one of them is
+	// usually enough.
+	Temperature *float64
+	TopP        *float64
+}
+
+func (o Options) maxTokens() int {
+	if o.MaxTokens > 0 {
+		return o.MaxTokens
+	}
+	return maxTokens
+}
+
+// Chunk is a piece of a streamed completion. A stream that fails after it
+// has started ends with a final Chunk whose Err is set. Every text chunk
+// names the Model the request was sent to. Providers that report token usage
+// for streams end a completed stream with a Chunk holding only its Usage.
+type Chunk struct {
+	Text  string
+	Err   error
+	Model string
+	Usage Usage
 }
 
 type openaiClient struct {
-	cl *openai.Client
+	cl           *openai.Client
+	model        string
+	systemPrompt string
 }
 
-func NewOpenAI(token string) Client {
-	return &openaiClient{cl: openai.NewClient(token)}
+// OpenAIConfig configures an OpenAI client.
+type OpenAIConfig struct {
+	Token string
+	// Tokens are further API keys. With more than one key in total,
+	// requests rotate between them and a key is skipped for KeyCooldown
+	// after it is rate limited.
+	Tokens []string
+	// KeyCooldown defaults to DefaultKeyCooldown; a Retry-After header on
+	// the 429 takes precedence.
+	KeyCooldown time.Duration
+	// Model defaults to DefaultModel.
+	Model string
+	// MaxRetries is how many times a transient failure (429, 500, 502, 503
+	// or a network timeout) is retried. Zero means DefaultMaxRetries and a
+	// negative value disables retries.
+	MaxRetries int
+	// RetryBaseDelay is the initial backoff; zero means DefaultRetryBaseDelay.
+	RetryBaseDelay time.Duration
+	// SystemPrompt is the system message of every generation; empty means
+	// DefaultSystemPrompt.
+	SystemPrompt string
 }
 
-func (c *openaiClient) Transform(ctx context.Context, text string) (string, error) {
-	msg := fmt.Sprintf(`Rewrite the following statement as an over-the-top inspirational LinkedIn post with emojis, buzzwords, and hashtags. Keep it under 240 characters.
+// keys returns Token and Tokens without blanks or duplicates.
+func (c OpenAIConfig) keys() []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, k := range append([]string{c.Token}, c.Tokens...) {
+		if k != "" && !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
 
-"%s"`, text)
-	resp, err := c.cl.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model: "gpt-4o-mini",
-		Messages: []openai.ChatCompletionMessage{
-			{Role: "system", Content: "You are a viral LinkedIn influencer."},
-			{Role: "user", Content: msg},
+// NewOpenAI creates a client that generates posts with the given model,
+// falling back to DefaultModel when model is empty.
+func NewOpenAI(token, model string) Client {
+	return NewOpenAIWithConfig(OpenAIConfig{Token: token, Model: model})
+}
+
+// NewOpenAIWithConfig creates a client from a full OpenAIConfig.
+func NewOpenAIWithConfig(cfg OpenAIConfig) Client {
+	return newOpenAIClient(cfg)
+}
+
+func newOpenAIClient(cfg OpenAIConfig) *openaiClient {
+	if cfg.Model == "" {
+		cfg.Model = DefaultModel
+	}
+	keys := cfg.keys()
+	var base http.RoundTripper = http.DefaultTransport
+	if len(keys) > 1 {
+		base = &keyTransport{base: base, pool: newKeyPool(keys, cfg.KeyCooldown)}
+	}
+	token := cfg.Token
+	if len(keys) > 0 {
+		token = keys[0]
+	}
+	oc := openai.DefaultConfig(token)
+	oc.HTTPClient = &http.Client{
+		Transport: newRetryTransport(base, cfg.MaxRetries, cfg.RetryBaseDelay),
+	}
+	return &openaiClient{
+		cl:           openai.NewClientWithConfig(oc),
+		model:        cfg.Model,
+		systemPrompt: cmp.Or(cfg.SystemPrompt, DefaultSystemPrompt),
+	}
+}
+
+func (c *openaiClient) Transform(ctx context.Context, prompt string, opts Options) (Result, error) {
+	req := c.request(prompt, opts)
+	resp, err := c.cl.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return Result{}, openAIError(err)
+	}
+	if len(resp.Choices) == 0 {
+		return Result{}, errNoCompletion(ProviderOpenAI)
+	}
+	if resp.Choices[0].FinishReason == openai.FinishReasonContentFilter {
+		return Result{}, errContentFiltered(ProviderOpenAI)
+	}
+	return Result{
+		Text:  resp.Choices[0].Message.Content,
+		Model: req.Model,
+		Usage: Usage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
 		},
-		MaxTokens: 120,
-	})
+	}, nil
+}
+
+// Stream sends the same request as Transform but yields the completion as it
+// is generated. The returned channel is closed when the stream ends or ctx is
+// cancelled; cancelling ctx also aborts the upstream HTTP request. The
+// version of the OpenAI SDK in use cannot ask for usage on streams, so none
+// is reported.
+func (c *openaiClient) Stream(ctx context.Context, prompt string, opts Options) (<-chan Chunk, error) {
+	req := c.request(prompt, opts)
+	stream, err := c.cl.CreateChatCompletionStream(ctx, req)
 	if err != nil {
-		return "", err
+		return nil, openAIError(err)
+	}
+
+	out := make(chan Chunk)
+	go func() {
+		defer close(out)
+		defer stream.Close()
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err == nil && len(resp.Choices) > 0 && resp.Choices[0].FinishReason == openai.FinishReasonContentFilter {
+				err = errContentFiltered(ProviderOpenAI)
+			}
+			if err != nil {
+				select {
+				case out <- Chunk{Err: openAIError(err)}:
+				case <-ctx.Done():
+				}
+				return
+			}
+			if len(resp.Choices) == 0 || resp.Choices[0].Delta.Content == "" {
+				continue
+			}
+			select {
+			case out <- Chunk{Text: resp.Choices[0].Delta.Content, Model: req.Model}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (c *openaiClient) request(prompt string, opts Options) openai.ChatCompletionRequest {
+	model := c.model
+	if opts.Model != "" {
+		model = opts.Model
+	}
+	return openai.ChatCompletionRequest{
+		Model: model,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: "system", Content: c.systemPrompt},
+			{Role: "user", Content: prompt},
+		},
+		MaxTokens:   opts.maxTokens(),
+		Temperature: openAISampling(opts.Temperature),
+		TopP:        openAISampling(opts.TopP),
+	}
+}
+
+// openAISampling converts a sampling parameter for the OpenAI client, which
+// leaves zero values out of the request. An explicit zero is sent as the
+// smallest float32 above it, which has the same effect.
+func openAISampling(v *float64) float32 {
+	switch {
+	case v == nil:
+		return 0
+	case *v == 0:
+		return math.SmallestNonzeroFloat32
 	}
-	return resp.Choices[0].Message.Content, nil
+	return float32(*v)
 }
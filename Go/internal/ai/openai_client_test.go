@@ -0,0 +1,31 @@
+// internal/ai/openai_client_test.go
+package ai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIClient_Transform_NoChoices(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"chatcmpl-1","object":"chat.completion","choices":[],"usage":{"total_tokens":0}}`))
+	}))
+	defer srv.Close()
+	oc := openai.DefaultConfig("test-key")
+	oc.BaseURL = srv.URL
+	c := &openaiClient{cl: openai.NewClientWithConfig(oc), model: DefaultModel, systemPrompt: DefaultSystemPrompt}
+
+	_, err := c.Transform(context.Background(), "prompt", Options{})
+
+	var aiErr *Error
+	require.ErrorAs(t, err, &aiErr)
+	assert.Equal(t, ErrorServer, aiErr.Kind)
+	assert.Equal(t, ProviderOpenAI, aiErr.Provider)
+}
@@ -0,0 +1,61 @@
+// internal/ai/ping.go
+package ai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Pinger is implemented by clients that can check the provider is reachable
+// and accepts their credentials without generating anything.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Ping checks that c can reach its provider. Clients that do not implement
+// Pinger, such as the mock, are always reachable.
+func Ping(ctx context.Context, c Client) error {
+	if p, ok := c.(Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// Ping lists the models of the account, which is free and fails the same way
+// a generation would on a bad key.
+func (c *openaiClient) Ping(ctx context.Context) error {
+	if _, err := c.cl.ListModels(ctx); err != nil {
+		return openAIError(err)
+	}
+	return nil
+}
+
+// Ping lists the models of the account, which is free and fails the same way
+// a generation would on a bad key.
+func (c *anthropicClient) Ping(ctx context.Context) error {
+	url := strings.TrimSuffix(c.url, "/messages") + "/models"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Api-Key", c.token)
+	req.Header.Set("Anthropic-Version", anthropicVersion)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return readAnthropicError(resp)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// Ping forwards to the wrapped client; pings are not generations, so they
+// are not observed.
+func (c *instrumentedClient) Ping(ctx context.Context) error {
+	return Ping(ctx, c.next)
+}
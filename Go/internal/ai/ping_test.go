@@ -0,0 +1,40 @@
+// internal/ai/ping_test.go
+package ai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnthropic_Ping(t *testing.T) {
+	c := newTestAnthropic(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v1/models", r.URL.Path)
+		assert.Equal(t, anthropicVersion, r.Header.Get("Anthropic-Version"))
+		if r.Header.Get("X-Api-Key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":{"type":"authentication_error","message":"invalid x-api-key"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+	c.url += "/v1/messages"
+
+	require.NoError(t, Ping(context.Background(), c))
+
+	c.token = "wrong"
+	err := Ping(context.Background(), c)
+	var aiErr *Error
+	require.ErrorAs(t, err, &aiErr)
+	assert.Equal(t, http.StatusUnauthorized, aiErr.StatusCode)
+}
+
+func TestPing_Unsupported(t *testing.T) {
+	assert.NoError(t, Ping(context.Background(), NewMock()), "Clients without Ping are always reachable")
+	assert.NoError(t, Ping(context.Background(), NewInstrumentedClient(NewMock(), ProviderMock, "mock", nil)))
+}
@@ -0,0 +1,64 @@
+// internal/ai/prompt.go
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// DefaultSystemPrompt is sent as the system message by every provider unless
+// the client is configured with another. The caller's prompt, rendered from a
+// template, follows it as the user message.
+const DefaultSystemPrompt = "You are a viral LinkedIn influencer."
+
+// MaxSystemPromptTokens caps the estimated length of a configured system
+// prompt, which is paid for on every generation.
+const MaxSystemPromptTokens = 1000
+
+// maxTokens caps the length of a generated post unless Options.MaxTokens
+// says otherwise.
+const maxTokens = 120
+
+// EstimateTokens roughly estimates how many tokens s is for model, at the
+// characters per token of typical English text: four for OpenAI's
+// tokenizers and unknown models, three and a half for Claude's. It needs no
+// tokenizer, so it is only good for limits with some headroom.
+func EstimateTokens(s, model string) int {
+	perToken := 4.0
+	if ModelProvider(model) == ProviderAnthropic {
+		perToken = 3.5
+	}
+	return int(math.Ceil(float64(utf8.RuneCountInString(s)) / perToken))
+}
+
+// ContextWindow returns how many tokens model takes in one request, prompt
+// and completion together, and false for models it does not know.
+func ContextWindow(model string) (int, bool) {
+	n, ok := contextWindows[model]
+	return n, ok
+}
+
+// CheckContextLength returns an *Error of kind ErrorContextLength when the
+// system prompt and prompt, with room for the completion opts allows, are
+// estimated not to fit the context window of model. It returns nil for
+// models with an unknown window, leaving the check to the provider.
+func CheckContextLength(model, systemPrompt, prompt string, opts Options) error {
+	window, ok := ContextWindow(model)
+	if !ok {
+		return nil
+	}
+	completion := opts.maxTokens()
+	n := EstimateTokens(systemPrompt, model) + EstimateTokens(prompt, model)
+	if n+completion <= window {
+		return nil
+	}
+	msg := fmt.Sprintf("the prompt is about %d tokens, but %s fits %d including the %d tokens of the post; shorten the text", n, model, window, completion)
+	return &Error{
+		Kind:     ErrorContextLength,
+		Provider: ModelProvider(model),
+		Message:  msg,
+		Err:      errors.New(msg),
+	}
+}
@@ -0,0 +1,37 @@
+// internal/ai/prompt_test.go
+package ai_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	text := strings.Repeat("a", 700)
+	assert.Equal(t, 175, ai.EstimateTokens(text, "gpt-4o-mini"))
+	assert.Equal(t, 200, ai.EstimateTokens(text, "claude-3-5-haiku-latest"), "Claude tokenizes more densely")
+	assert.Equal(t, 175, ai.EstimateTokens(text, "unknown-model"))
+}
+
+func TestCheckContextLength(t *testing.T) {
+	fits := strings.Repeat("word ", 6000) // about 7500 tokens
+	assert.NoError(t, ai.CheckContextLength("gpt-4", ai.DefaultSystemPrompt, fits, ai.Options{}))
+	assert.NoError(t, ai.CheckContextLength("gpt-4o-mini", ai.DefaultSystemPrompt, fits+fits, ai.Options{}))
+	assert.NoError(t, ai.CheckContextLength("fine-tuned-model", ai.DefaultSystemPrompt, fits+fits, ai.Options{}), "Unknown windows are left to the provider")
+
+	err := ai.CheckContextLength("gpt-4", ai.DefaultSystemPrompt, fits, ai.Options{MaxTokens: 1000})
+	var aiErr *ai.Error
+	require.ErrorAs(t, err, &aiErr, "The post must fit too")
+	assert.Equal(t, ai.ErrorContextLength, aiErr.Kind)
+	assert.Equal(t, ai.ProviderOpenAI, aiErr.Provider)
+	assert.Contains(t, aiErr.Message, "gpt-4 fits 8192")
+
+	window, ok := ai.ContextWindow("claude-sonnet-4-0")
+	assert.True(t, ok)
+	assert.Equal(t, 200_000, window)
+}
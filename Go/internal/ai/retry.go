@@ -0,0 +1,155 @@
+// internal/ai/retry.go
+package ai
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/you/linkedinify/internal/requestid"
+)
+
+const (
+	// DefaultMaxRetries is how many times a failed call is retried when the
+	// client config leaves MaxRetries at zero.
+	DefaultMaxRetries = 3
+	// DefaultRetryBaseDelay is the first backoff interval; it doubles on each
+	// subsequent attempt.
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+
+	// clientRequestIDHeader is accepted by OpenAI for correlating requests
+	// in its logs; other providers ignore it.
+	clientRequestIDHeader = "X-Client-Request-Id"
+)
+
+// retryTransport retries requests that failed with a transient error using
+// exponential backoff with jitter. It never sleeps past the deadline of the
+// request's context.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+}
+
+func newRetryTransport(base http.RoundTripper, maxRetries int, baseDelay time.Duration) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	if baseDelay <= 0 {
+		baseDelay = DefaultRetryBaseDelay
+	}
+	return &retryTransport{base: base, maxRetries: maxRetries, baseDelay: baseDelay}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	// Forward our request ID so provider-side logs can be correlated too.
+	reqID := requestid.FromContext(ctx)
+	if reqID != "" {
+		req = req.Clone(ctx)
+		req.Header.Set(clientRequestIDHeader, reqID)
+	}
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				return nil, errors.New("ai: cannot retry request without GetBody")
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.maxRetries || ctx.Err() != nil || !retryable(resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err
+		}
+		slog.WarnContext(ctx, "ai: request failed, retrying",
+			"method", req.Method, "host", req.URL.Host, "failure", describeFailure(resp, err),
+			"delay", delay.Round(time.Millisecond), "attempt", attempt+1, "max_retries", t.maxRetries)
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After
+// header from the server takes precedence over the computed delay.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+	d := t.baseDelay << attempt
+	// Jitter in [d/2, d) so concurrent callers don't retry in lockstep.
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// describeFailure summarises a retryable failure for the logs.
+func describeFailure(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.Status
+}
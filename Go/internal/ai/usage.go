@@ -0,0 +1,48 @@
+// internal/ai/usage.go
+package ai
+
+// Usage is the token consumption reported by the provider for one generation.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// Result is the outcome of a non-streaming generation.
+type Result struct {
+	Text string
+	// Model is the model the request was sent to.
+	Model string
+	Usage Usage
+}
+
+// price is the USD cost per million tokens.
+type price struct {
+	prompt     float64
+	completion float64
+}
+
+// prices holds list prices per model. Keep it in step with knownModels.
+var prices = map[string]price{
+	"gpt-4o-mini":   {prompt: 0.15, completion: 0.60},
+	"gpt-4o":        {prompt: 2.50, completion: 10.00},
+	"gpt-4-turbo":   {prompt: 10.00, completion: 30.00},
+	"gpt-4":         {prompt: 30.00, completion: 60.00},
+	"gpt-3.5-turbo": {prompt: 0.50, completion: 1.50},
+
+	"claude-3-5-haiku-latest":  {prompt: 0.80, completion: 4.00},
+	"claude-3-5-sonnet-latest": {prompt: 3.00, completion: 15.00},
+	"claude-3-7-sonnet-latest": {prompt: 3.00, completion: 15.00},
+	"claude-sonnet-4-0":        {prompt: 3.00, completion: 15.00},
+	"claude-opus-4-0":          {prompt: 15.00, completion: 75.00},
+}
+
+// EstimateCost returns the approximate USD cost of usage on model. Models
+// without a known price cost zero.
+func EstimateCost(usage Usage, model string) float64 {
+	p, ok := prices[model]
+	if !ok {
+		return 0
+	}
+	return (float64(usage.PromptTokens)*p.prompt + float64(usage.CompletionTokens)*p.completion) / 1_000_000
+}
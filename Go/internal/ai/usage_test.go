@@ -0,0 +1,18 @@
+// internal/ai/usage_test.go
+package ai_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+func TestEstimateCost(t *testing.T) {
+	usage := ai.Usage{PromptTokens: 1_000_000, CompletionTokens: 1_000_000, TotalTokens: 2_000_000}
+	assert.InDelta(t, 0.75, ai.EstimateCost(usage, "gpt-4o-mini"), 1e-9)
+	assert.InDelta(t, 12.50, ai.EstimateCost(usage, "gpt-4o"), 1e-9)
+	assert.Zero(t, ai.EstimateCost(usage, "unknown-model"))
+	assert.Zero(t, ai.EstimateCost(ai.Usage{}, "gpt-4o"))
+}
@@ -0,0 +1,149 @@
+// internal/analytics/writer.go
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+// Defaults used unless overridden with an Option.
+const (
+	DefaultFlushInterval = 5 * time.Second
+	DefaultBatchSize     = 100
+	DefaultBufferSize    = 1000
+	flushTimeout         = 10 * time.Second
+)
+
+// Writer stores analytics events in the background. Events wait in a
+// bounded buffer and are inserted in batches, whenever a batch is full and
+// on every flush interval. When the buffer is full new events are dropped:
+// analytics never hold up generation.
+type Writer struct {
+	repo      repository.AnalyticsRepository
+	interval  time.Duration
+	batchSize int
+
+	mu     sync.RWMutex
+	closed bool
+	buffer chan model.AnalyticsEvent
+
+	ctx    context.Context // cancelled when Close gives up waiting
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Option configures optional Writer behaviour.
+type Option func(*Writer)
+
+// WithFlushInterval sets how long an event waits at most before it is
+// stored.
+func WithFlushInterval(d time.Duration) Option {
+	return func(w *Writer) { w.interval = d }
+}
+
+// WithBatchSize sets how many events are inserted at once.
+func WithBatchSize(n int) Option {
+	return func(w *Writer) { w.batchSize = n }
+}
+
+// WithBufferSize sets how many events may wait to be stored.
+func WithBufferSize(n int) Option {
+	return func(w *Writer) { w.buffer = make(chan model.AnalyticsEvent, n) }
+}
+
+// New starts a Writer storing events in repo. Call Close to flush the
+// buffer on shutdown.
+func New(repo repository.AnalyticsRepository, opts ...Option) *Writer {
+	w := &Writer{
+		repo:      repo,
+		interval:  DefaultFlushInterval,
+		batchSize: DefaultBatchSize,
+		buffer:    make(chan model.AnalyticsEvent, DefaultBufferSize),
+		done:      make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	go w.run()
+	return w
+}
+
+// Record buffers e and returns immediately.
+func (w *Writer) Record(e model.AnalyticsEvent) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		slog.Warn("analytics event dropped: shutting down", "post_id", e.PostID)
+		return
+	}
+	select {
+	case w.buffer <- e:
+	default:
+		slog.Warn("analytics event dropped: buffer is full", "post_id", e.PostID, "buffer_size", cap(w.buffer))
+	}
+}
+
+// Close stops accepting events and waits until the buffered ones are stored
+// or ctx is done, in which case they are abandoned.
+func (w *Writer) Close(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.buffer)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		pending := len(w.buffer)
+		w.cancel()
+		<-w.done
+		return fmt.Errorf("analytics: %d buffered events abandoned: %w", pending, ctx.Err())
+	}
+}
+
+func (w *Writer) run() {
+	defer close(w.done)
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+	batch := make([]model.AnalyticsEvent, 0, w.batchSize)
+	for {
+		select {
+		case e, ok := <-w.buffer:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+			batch = append(batch, e)
+			if len(batch) >= w.batchSize {
+				w.flush(batch)
+				batch = batch[:0]
+			}
+		case <-t.C:
+			w.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// flush inserts batch. Failed batches are logged and dropped rather than
+// retried, so a database outage cannot grow the buffer without bound.
+func (w *Writer) flush(batch []model.AnalyticsEvent) {
+	if len(batch) == 0 || w.ctx.Err() != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(w.ctx, flushTimeout)
+	defer cancel()
+	if err := w.repo.Insert(ctx, batch); err != nil {
+		slog.Warn("storing analytics events failed", "events", len(batch), "error", err)
+	}
+}
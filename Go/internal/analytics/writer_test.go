@@ -0,0 +1,109 @@
+// internal/analytics/writer_test.go
+package analytics_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/analytics"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+// store is an analytics repository recording the batches inserted.
+type store struct {
+	repository.AnalyticsRepositoryMock
+	mu       sync.Mutex
+	batches  [][]model.AnalyticsEvent
+	inserted chan struct{}
+}
+
+func newStore(err error) *store {
+	s := &store{inserted: make(chan struct{}, 10)}
+	s.InsertFunc = func(_ context.Context, events []model.AnalyticsEvent) error {
+		s.mu.Lock()
+		s.batches = append(s.batches, append([]model.AnalyticsEvent(nil), events...))
+		s.mu.Unlock()
+		s.inserted <- struct{}{}
+		return err
+	}
+	return s
+}
+
+func event() model.AnalyticsEvent {
+	return model.AnalyticsEvent{UserID: uuid.New(), PostID: uuid.New(), Tone: "casual"}
+}
+
+func TestWriter_FlushesOnClose(t *testing.T) {
+	repo := newStore(nil)
+	w := analytics.New(repo, analytics.WithFlushInterval(time.Hour))
+	first, second := event(), event()
+	w.Record(first)
+	w.Record(second)
+	require.NoError(t, w.Close(context.Background()))
+
+	require.Len(t, repo.batches, 1)
+	assert.Equal(t, []model.AnalyticsEvent{first, second}, repo.batches[0])
+
+	w.Record(event())
+	assert.Len(t, repo.batches, 1, "Events recorded after Close are dropped")
+}
+
+func TestWriter_FlushesOnInterval(t *testing.T) {
+	repo := newStore(nil)
+	w := analytics.New(repo, analytics.WithFlushInterval(10*time.Millisecond))
+	defer w.Close(context.Background())
+	w.Record(event())
+
+	select {
+	case <-repo.inserted:
+	case <-time.After(time.Second):
+		t.Fatal("The event was not stored before Close")
+	}
+}
+
+func TestWriter_FlushesFullBatches(t *testing.T) {
+	repo := newStore(nil)
+	w := analytics.New(repo, analytics.WithFlushInterval(time.Hour), analytics.WithBatchSize(2))
+	for range 5 {
+		w.Record(event())
+	}
+	require.NoError(t, w.Close(context.Background()))
+
+	require.Len(t, repo.batches, 3)
+	assert.Len(t, repo.batches[0], 2)
+	assert.Len(t, repo.batches[1], 2)
+	assert.Len(t, repo.batches[2], 1)
+}
+
+func TestWriter_DropsFailedBatches(t *testing.T) {
+	repo := newStore(errors.New("connection refused"))
+	w := analytics.New(repo, analytics.WithFlushInterval(time.Hour), analytics.WithBatchSize(1))
+	w.Record(event())
+	w.Record(event())
+	require.NoError(t, w.Close(context.Background()))
+
+	assert.Len(t, repo.InsertCalls(), 2, "A failed batch is not retried")
+}
+
+func TestWriter_CloseGivesUp(t *testing.T) {
+	repo := &repository.AnalyticsRepositoryMock{
+		InsertFunc: func(ctx context.Context, events []model.AnalyticsEvent) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+	w := analytics.New(repo, analytics.WithFlushInterval(time.Hour))
+	w.Record(event())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, w.Close(ctx), context.DeadlineExceeded)
+}
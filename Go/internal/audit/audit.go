@@ -0,0 +1,106 @@
+// internal/audit/audit.go
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+)
+
+// Action names a kind of audited action.
+type Action string
+
+const (
+	// ActionLogin and ActionLoginFailed are successful logins and wrong
+	// passwords for an existing account; the subject is the user.
+	ActionLogin       Action = "auth.login"
+	ActionLoginFailed Action = "auth.login_failed"
+	// ActionPasswordReset is a password changed with a reset token; the
+	// subject is the user.
+	ActionPasswordReset Action = "auth.password_reset"
+	// ActionPostDelete is a user soft-deleting a post and ActionPostPurge an
+	// admin deleting one for good; the subject is the post.
+	ActionPostDelete Action = "post.delete"
+	ActionPostPurge  Action = "post.purge"
+	// ActionAPIKeyCreate and ActionAPIKeyRevoke change a user's API keys;
+	// the subject is the key.
+	ActionAPIKeyCreate Action = "api_key.create"
+	ActionAPIKeyRevoke Action = "api_key.revoke"
+	// ActionAccountDelete is a user erasing their account; the subject is
+	// the user.
+	ActionAccountDelete Action = "account.delete"
+)
+
+// Actions lists every Action.
+var Actions = []Action{
+	ActionLogin, ActionLoginFailed, ActionPasswordReset, ActionPostDelete, ActionPostPurge,
+	ActionAPIKeyCreate, ActionAPIKeyRevoke, ActionAccountDelete,
+}
+
+// Valid reports whether a is a known action.
+func (a Action) Valid() bool {
+	for _, known := range Actions {
+		if a == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Store saves audit entries.
+type Store interface {
+	Create(ctx context.Context, e *model.AuditLog) error
+}
+
+type recorderKey struct{}
+
+// recorder is what Middleware puts into a request's context for Record.
+type recorder struct {
+	store Store
+	ip    string
+}
+
+// Middleware makes Record save the entries of every request through it in
+// store, with the IP the request came from.
+func Middleware(store Store) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &recorder{store: store, ip: middleware.ClientIP(r)}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), recorderKey{}, rec)))
+		})
+	}
+}
+
+// Record saves that action was taken on subjectID, with metadata describing
+// it. The actor is the user authenticated on ctx, if any. Recording never
+// fails the action it describes: errors are logged and dropped, and nothing
+// is recorded outside a request served through Middleware.
+func Record(ctx context.Context, action Action, subjectID uuid.UUID, metadata map[string]any) {
+	rec, ok := ctx.Value(recorderKey{}).(*recorder)
+	if !ok {
+		return
+	}
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	e := &model.AuditLog{
+		ID:        uuid.New(),
+		ActorID:   middleware.UserID(ctx),
+		SubjectID: subjectID,
+		Action:    string(action),
+		IP:        rec.ip,
+		Metadata:  metadata,
+		CreatedAt: time.Now(),
+	}
+	// The action has happened by now, so a client hanging up must not
+	// lose its record.
+	if err := rec.store.Create(context.WithoutCancel(ctx), e); err != nil {
+		slog.ErrorContext(ctx, "recording audit event failed", "action", action, "subject_id", subjectID, "error", err)
+	}
+}
@@ -0,0 +1,102 @@
+// internal/audit/audit_test.go
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/audit"
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+)
+
+// storeFunc adapts a function to audit.Store.
+type storeFunc func(ctx context.Context, e *model.AuditLog) error
+
+func (f storeFunc) Create(ctx context.Context, e *model.AuditLog) error { return f(ctx, e) }
+
+func TestRecord(t *testing.T) {
+	secret := []byte("test-secret-for-audit")
+	userID, postID := uuid.New(), uuid.New()
+	var saved []*model.AuditLog
+	store := storeFunc(func(ctx context.Context, e *model.AuditLog) error {
+		assert.NoError(t, ctx.Err(), "Entries are saved even if the client has gone")
+		saved = append(saved, e)
+		return nil
+	})
+
+	h := audit.Middleware(store)(middleware.Auth(secret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithCancel(r.Context())
+		cancel()
+		audit.Record(ctx, audit.ActionPostDelete, postID, map[string]any{"reason": "test"})
+	})))
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": userID.String(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString(secret)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodDelete, "/posts/"+postID.String(), nil)
+	req.RemoteAddr = "192.0.2.7:51234"
+	req.Header.Set("Authorization", "Bearer "+token)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Len(t, saved, 1)
+	e := saved[0]
+	assert.Equal(t, userID, e.ActorID, "The actor is the authenticated user")
+	assert.Equal(t, postID, e.SubjectID)
+	assert.Equal(t, string(audit.ActionPostDelete), e.Action)
+	assert.Equal(t, "192.0.2.7", e.IP)
+	assert.Equal(t, map[string]any{"reason": "test"}, e.Metadata)
+	assert.WithinDuration(t, time.Now(), e.CreatedAt, time.Minute)
+}
+
+func TestRecord_Anonymous(t *testing.T) {
+	var saved *model.AuditLog
+	h := audit.Middleware(storeFunc(func(ctx context.Context, e *model.AuditLog) error {
+		saved = e
+		return nil
+	}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		audit.Record(r.Context(), audit.ActionLogin, uuid.New(), nil)
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/auth/login", nil))
+
+	require.NotNil(t, saved)
+	assert.Equal(t, uuid.Nil, saved.ActorID, "Logins happen before authenticating")
+	assert.NotNil(t, saved.Metadata, "Missing metadata is stored as an empty object")
+}
+
+func TestRecord_NeverFails(t *testing.T) {
+	called := false
+	h := audit.Middleware(storeFunc(func(ctx context.Context, e *model.AuditLog) error {
+		return errors.New("database is down")
+	}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		audit.Record(r.Context(), audit.ActionAccountDelete, uuid.New(), nil)
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/users/me", nil))
+	assert.True(t, called)
+	assert.Equal(t, http.StatusNoContent, rr.Code, "A failed recording does not fail the request")
+
+	assert.NotPanics(t, func() {
+		audit.Record(context.Background(), audit.ActionLogin, uuid.New(), nil)
+	}, "Outside a request nothing is recorded")
+}
+
+func TestAction_Valid(t *testing.T) {
+	for _, a := range audit.Actions {
+		assert.True(t, a.Valid(), a)
+	}
+	assert.False(t, audit.Action("post.create").Valid())
+	assert.False(t, audit.Action("").Valid())
+}
@@ -2,48 +2,675 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"net/netip"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/analytics"
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/scheduler"
+	"github.com/you/linkedinify/internal/service"
 )
 
+// headerPrefixPattern matches the header name prefixes accepted for
+// RATE_LIMIT_HEADER_PREFIX.
+var headerPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// minJWTSecretLength is the shortest JWT_SECRET accepted: 32 bytes matches
+// the output size of HS256.
+const minJWTSecretLength = 32
+
 type Config struct {
 	HTTPAddr      string
 	DSN           string
 	JWTSecret     []byte
 	OpenAIToken   string
+	OpenAIModel   string
 	TreblleToken  string
 	TreblleAPIKey string
+
+	// OpenAIMaxRetries and OpenAIRetryBaseDelay tune the backoff applied to
+	// transient OpenAI failures; zero retries disables retrying.
+	OpenAIMaxRetries     int
+	OpenAIRetryBaseDelay time.Duration
+
+	// AIProvider selects the post generator: "openai" (default),
+	// "anthropic" or "mock", which needs no credentials and answers with
+	// canned posts, for CI and demos.
+	AIProvider     string
+	AnthropicToken string
+	AnthropicModel string
+	// MockAILatency and MockAIErrorPercent make the mock provider take
+	// that long per generation and fail that percentage of calls.
+	MockAILatency      time.Duration
+	MockAIErrorPercent int
+
+	// PasswordResetURL is the frontend page linked from reset emails.
+	PasswordResetURL string
+	// InvitationURL is the frontend page linked from organization
+	// invitations.
+	InvitationURL string
+	// SMTP settings for outgoing email. When SMTPHost is empty emails are
+	// written to the log instead.
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	// RateLimitPerMinute caps post generations per user; 0 disables the limit.
+	RateLimitPerMinute int
+	// RateLimitHeaderPrefix names the headers reporting the state of the
+	// limit: it is followed by Limit, Remaining and Reset.
+	RateLimitHeaderPrefix string
+
+	// ShutdownTimeout is how long in-flight requests get to finish after
+	// SIGINT or SIGTERM.
+	ShutdownTimeout time.Duration
+
+	// Strict makes Validate require every production credential. Disable it
+	// with STRICT_CONFIG=false for local development without Treblle.
+	Strict bool
+
+	// AllowedOrigins lists the browser origins allowed to call the API
+	// cross-origin. Empty denies all cross-origin requests.
+	AllowedOrigins []string
+
+	// TrustedProxies are the load balancers and proxies whose
+	// X-Forwarded-For and X-Real-IP headers are believed when working out
+	// the client IP. Empty uses the address of the peer.
+	TrustedProxies []netip.Prefix
+
+	// PromptTemplatesDir replaces the built-in prompt templates with the
+	// *.tmpl files in this directory when set.
+	PromptTemplatesDir string
+
+	// SystemPrompt is the system message of every generation, ahead of the
+	// prompt rendered from the post's template; empty means
+	// ai.DefaultSystemPrompt. It is read from SYSTEM_PROMPT_FILE, kept in
+	// SystemPromptFile, when that is set instead of SYSTEM_PROMPT.
+	SystemPrompt     string
+	SystemPromptFile string
+
+	// AITemperature and AITopP are the sampling parameters of generations
+	// that do not choose their own.
+	AITemperature float64
+	AITopP        float64
+
+	// PostVersionLimit is how many earlier versions are kept per post.
+	PostVersionLimit int
+
+	// AICacheEnabled turns caching of identical generations on; AICacheSize
+	// and AICacheTTL bound the in-memory cache.
+	AICacheEnabled bool
+	AICacheSize    int
+	AICacheTTL     time.Duration
+
+	// TreblleMaskedFields are request/response fields and headers whose
+	// values are masked before they are sent to Treblle.
+	TreblleMaskedFields []string
+	// Debug makes the Treblle SDK print every payload it sends.
+	Debug bool
+
+	// OpenAITokens are extra OpenAI keys rotated together with OpenAIToken
+	// to spread load across rate limits; OpenAIKeyCooldown is how long a
+	// rate-limited key is skipped.
+	OpenAITokens      []string
+	OpenAIKeyCooldown time.Duration
+
+	// EnableModeration runs every generated post through OpenAI's
+	// moderation endpoint. It needs an OpenAI key even when AIProvider is
+	// anthropic.
+	EnableModeration bool
+
+	// WarmupAI pings the AI provider at startup, before serving, so a bad
+	// key or an unreachable provider shows up in the logs and in /readyz
+	// rather than on the first generation. WarmupAIStrict makes a failed
+	// ping stop startup instead of starting degraded; WarmupAITimeout
+	// bounds the ping.
+	WarmupAI        bool
+	WarmupAIStrict  bool
+	WarmupAITimeout time.Duration
+
+	// EnableInputFilter screens the text of posts before anything is
+	// generated, rejecting text matching a blocklist of prompt injection
+	// patterns: the built-in one, or the one in InputBlocklistFile, which
+	// is reloaded when it changes. InputFilterModeration also runs the
+	// text through OpenAI's moderation endpoint.
+	EnableInputFilter     bool
+	InputBlocklistFile    string
+	InputFilterModeration bool
+
+	// WebhookURL receives a signed POST whenever a post is created or
+	// finalized; empty disables webhooks. WebhookSecret keys the HMAC in
+	// the X-Signature header. Deliveries wait in a queue of
+	// WebhookQueueSize and are attempted up to WebhookMaxAttempts times.
+	WebhookURL         string
+	WebhookSecret      string
+	WebhookQueueSize   int
+	WebhookMaxAttempts int
+
+	// InboundWebhookSecret verifies the signatures of the delivery status
+	// callbacks sent to POST /api/v1/webhooks/linkedin; empty disables the
+	// endpoint. Signatures older than WebhookTolerance are rejected so a
+	// captured payload cannot be replayed.
+	InboundWebhookSecret string
+	WebhookTolerance     time.Duration
+
+	// ImageSize is the default size of generated post images, one of
+	// ai.ImageSizes; ImageTimeout bounds a single image generation. Images
+	// need an OpenAI key whichever AIProvider is used.
+	ImageSize    string
+	ImageTimeout time.Duration
+
+	// MaxPostLength is the longest generated post, in characters; longer
+	// posts are trimmed at a sentence boundary. It defaults to LinkedIn's
+	// limit and can be changed for platforms with a different one.
+	MaxPostLength int
+
+	// PostProcessors names the cleanups applied, in order, to every
+	// generated post before it is moderated and trimmed; see
+	// service.PostProcessorNames.
+	PostProcessors []string
+
+	// BatchConcurrency is how many posts of a POST /posts/batch request
+	// are generated at once, and BatchItemTimeout how long each may take;
+	// zero leaves items bounded only by LongRequestTimeout.
+	BatchConcurrency int
+	BatchItemTimeout time.Duration
+
+	// EnableMetrics serves Prometheus metrics at /metrics. The endpoint
+	// has no auth of its own and must be kept off the public internet.
+	EnableMetrics bool
+
+	// Env is "development" or "production". Production logs JSON for log
+	// aggregators; development logs human-readable text.
+	Env string
+	// LogLevel is the lowest level logged: debug, info, warn or error.
+	LogLevel string
+
+	// RequestTimeout cancels a /posts request with a 504 when it runs
+	// longer, so a hung AI connection cannot hold a goroutine forever.
+	// Streams, batches and exports get LongRequestTimeout instead. Zero
+	// disables the timeout.
+	RequestTimeout     time.Duration
+	LongRequestTimeout time.Duration
+
+	// MaxBodyBytes bounds the body of every API request; longer ones get a
+	// 413. Zero disables the limit.
+	MaxBodyBytes int64
+
+	// CompressionLevel is the gzip level, 1 to 9, of JSON and text
+	// responses of at least CompressionMinSize bytes; 0 disables
+	// compression.
+	CompressionLevel   int
+	CompressionMinSize int
+
+	// IdempotencyTTL is how long an Idempotency-Key on POST /posts is
+	// remembered. Zero disables idempotency keys.
+	IdempotencyTTL time.Duration
+
+	// JWTExpiry is the lifetime of access tokens: 15 minutes in
+	// production and an hour in development unless set.
+	JWTExpiry time.Duration
+	// JWTIssuer is the iss claim minted into, and required of, access
+	// tokens.
+	JWTIssuer string
+
+	// MinPasswordLength is the fewest characters accepted for a new
+	// password at signup or reset.
+	MinPasswordLength int
+
+	// MaxLoginAttempts failed logins in a row for an email from an IP lock
+	// further logins from there for LoginLockoutDuration, and
+	// MaxLoginAttemptsPerEmail from any IPs lock the email everywhere; zero
+	// disables a lockout.
+	MaxLoginAttempts         int
+	MaxLoginAttemptsPerEmail int
+	LoginLockoutDuration     time.Duration
+
+	// Database connection pool. DBMaxOpenConns caps connections to
+	// Postgres, zero meaning unlimited; DBMaxIdleConns, which is clamped to
+	// it, are kept open between requests; DBConnMaxLifetime recycles
+	// connections, zero keeping them forever.
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
+
+	// DBReplicaDSN is an optional read replica that post history, search
+	// and lookups are served from.
+	DBReplicaDSN string
+
+	// MigrateOnStart applies pending migrations before the server starts
+	// listening.
+	MigrateOnStart bool
+
+	// MonthlyQuotas maps plan names to how many posts a user on that plan
+	// may generate per calendar month, or QuotaUnlimited. Users on a plan
+	// not listed get the limit of the "free" plan; without one they have no
+	// limit.
+	MonthlyQuotas map[string]int
+
+	// LinkedInClientID and LinkedInClientSecret are the credentials of the
+	// LinkedIn app posts are published with; publishing is disabled without
+	// a client ID. LinkedInRedirectURL is the callback registered with the
+	// app, which this API serves at /api/v1/linkedin/callback.
+	LinkedInClientID     string
+	LinkedInClientSecret string
+	LinkedInRedirectURL  string
+
+	// SchedulerInterval is how often each instance looks for scheduled
+	// posts that are due; a post is tried SchedulerMaxAttempts times before
+	// it is marked failed. Both only matter when publishing is enabled.
+	SchedulerInterval    time.Duration
+	SchedulerMaxAttempts int
+
+	// AnalyticsEnabled records an event for every generation, which
+	// GET /api/v1/users/me/stats summarizes. Events are buffered and
+	// written every AnalyticsFlushInterval.
+	AnalyticsEnabled       bool
+	AnalyticsFlushInterval time.Duration
+
+	// DuplicateThreshold rejects generated posts with at least this
+	// similarity, from 0 to 1, to one the same user generated in the last
+	// DuplicateWindow, unless the request forces them; 0, the default,
+	// disables the check.
+	DuplicateThreshold float64
+	DuplicateWindow    time.Duration
+
+	// V1DeprecatedAt marks /api/v1 deprecated from that time: its
+	// responses then carry a Deprecation header, and a Sunset header with
+	// V1SunsetAt when set. V1DeprecationLink points clients to the
+	// migration guide. v1 keeps working either way.
+	V1DeprecatedAt    time.Time
+	V1SunsetAt        time.Time
+	V1DeprecationLink string
+}
+
+// maxSchedulerAttempts bounds SCHEDULER_MAX_ATTEMPTS; the backoff doubles
+// on every attempt, so the last ones would wait for months.
+const maxSchedulerAttempts = 20
+
+// QuotaUnlimited is the MonthlyQuotas limit of a plan without a cap, written
+// "unlimited" in MONTHLY_QUOTAS.
+const QuotaUnlimited = -1
+
+// defaultTreblleMaskedFields covers the credentials this API accepts or
+// returns; the Treblle SDK's own defaults are masked as well.
+var defaultTreblleMaskedFields = []string{
+	"password", "token", "refresh_token", "authorization", "api_key", "secret",
 }
 
+// Load reads the configuration from the environment. It only fails on values
+// that cannot be parsed; call Validate to check the result is usable.
 func Load() Config {
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("FATAL: JWT_SECRET environment variable is required")
+	env := envDefault("APP_ENV", "development")
+	return Config{
+		HTTPAddr:      envDefault("HTTP_ADDR", ":8080"),
+		DSN:           envDefault("DATABASE_DSN", "postgres:///linkedinify?sslmode=disable"),
+		JWTSecret:     []byte(os.Getenv("JWT_SECRET")),
+		OpenAIToken:   os.Getenv("OPENAI_TOKEN"),
+		OpenAIModel:   envDefault("OPENAI_MODEL", "gpt-4o-mini"),
+		TreblleToken:  os.Getenv("TREBLLE_SDK_TOKEN"),
+		TreblleAPIKey: os.Getenv("TREBLLE_API_KEY"),
+
+		OpenAIMaxRetries:     envInt("OPENAI_MAX_RETRIES", ai.DefaultMaxRetries),
+		OpenAIRetryBaseDelay: envDuration("OPENAI_RETRY_BASE_DELAY", 500*time.Millisecond),
+
+		AIProvider:     envDefault("AI_PROVIDER", "openai"),
+		AnthropicToken: os.Getenv("ANTHROPIC_TOKEN"),
+		AnthropicModel: envDefault("ANTHROPIC_MODEL", "claude-3-5-haiku-latest"),
+
+		MockAILatency:      envDuration("MOCK_AI_LATENCY", ai.DefaultMockLatency),
+		MockAIErrorPercent: envInt("MOCK_AI_ERROR_PERCENT", 0),
+
+		PasswordResetURL: envDefault("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+		InvitationURL:    envDefault("INVITATION_URL", "http://localhost:3000/accept-invitation"),
+		SMTPHost:         os.Getenv("SMTP_HOST"),
+		SMTPPort:         envDefault("SMTP_PORT", "587"),
+		SMTPUsername:     os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:     os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:         envDefault("SMTP_FROM", "no-reply@linkedinify.local"),
+
+		RateLimitPerMinute:    envInt("RATE_LIMIT_PER_MINUTE", 10),
+		RateLimitHeaderPrefix: envDefault("RATE_LIMIT_HEADER_PREFIX", middleware.DefaultRateLimitHeaderPrefix),
+
+		ShutdownTimeout: envDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+
+		Strict: envBool("STRICT_CONFIG", true),
+
+		AllowedOrigins: envList("ALLOWED_ORIGINS"),
+		TrustedProxies: envPrefixes("TRUSTED_PROXIES"),
+
+		PromptTemplatesDir: os.Getenv("PROMPT_TEMPLATES_DIR"),
+
+		SystemPrompt:     envFile("SYSTEM_PROMPT", "SYSTEM_PROMPT_FILE"),
+		SystemPromptFile: os.Getenv("SYSTEM_PROMPT_FILE"),
+
+		AITemperature: envFloat("AI_TEMPERATURE", service.DefaultTemperature),
+		AITopP:        envFloat("AI_TOP_P", service.DefaultTopP),
+
+		PostVersionLimit: envInt("POST_VERSION_LIMIT", 20),
+
+		AICacheEnabled: envBool("AI_CACHE_ENABLED", true),
+		AICacheSize:    envInt("AI_CACHE_SIZE", 1000),
+		AICacheTTL:     envDuration("AI_CACHE_TTL", time.Hour),
+
+		TreblleMaskedFields: envListDefault("TREBLLE_MASKED_FIELDS", defaultTreblleMaskedFields),
+		Debug:               envBool("DEBUG", false),
+
+		OpenAITokens:      envList("OPENAI_TOKENS"),
+		OpenAIKeyCooldown: envDuration("OPENAI_KEY_COOLDOWN", time.Minute),
+
+		EnableModeration: envBool("ENABLE_MODERATION", false),
+
+		WarmupAI:        envBool("WARMUP_AI", false),
+		WarmupAIStrict:  envBool("WARMUP_AI_STRICT", false),
+		WarmupAITimeout: envDuration("WARMUP_AI_TIMEOUT", 10*time.Second),
+
+		EnableInputFilter:     envBool("ENABLE_INPUT_FILTER", false),
+		InputBlocklistFile:    os.Getenv("INPUT_BLOCKLIST_FILE"),
+		InputFilterModeration: envBool("INPUT_FILTER_MODERATION", true),
+
+		WebhookURL:         os.Getenv("WEBHOOK_URL"),
+		WebhookSecret:      os.Getenv("WEBHOOK_SECRET"),
+		WebhookQueueSize:   envInt("WEBHOOK_QUEUE_SIZE", 100),
+		WebhookMaxAttempts: envInt("WEBHOOK_MAX_ATTEMPTS", 5),
+
+		InboundWebhookSecret: os.Getenv("INBOUND_WEBHOOK_SECRET"),
+		WebhookTolerance:     envDuration("WEBHOOK_TOLERANCE", 5*time.Minute),
+
+		ImageSize:    envDefault("IMAGE_SIZE", ai.DefaultImageSize),
+		ImageTimeout: envDuration("IMAGE_TIMEOUT", 90*time.Second),
+
+		MaxPostLength: envInt("MAX_POST_LENGTH", 3000),
+
+		PostProcessors: envList("POST_PROCESSORS"),
+
+		BatchConcurrency: envInt("BATCH_CONCURRENCY", 4),
+		BatchItemTimeout: envDuration("BATCH_ITEM_TIMEOUT", time.Minute),
+
+		EnableMetrics: envBool("ENABLE_METRICS", false),
+
+		Env:      env,
+		LogLevel: envDefault("LOG_LEVEL", "info"),
+
+		RequestTimeout:     envDuration("REQUEST_TIMEOUT", 30*time.Second),
+		LongRequestTimeout: envDuration("LONG_REQUEST_TIMEOUT", 5*time.Minute),
+
+		MaxBodyBytes: int64(envInt("MAX_BODY_BYTES", middleware.DefaultMaxBodyBytes)),
+
+		CompressionLevel:   envInt("COMPRESSION_LEVEL", 5),
+		CompressionMinSize: envInt("COMPRESSION_MIN_SIZE", middleware.DefaultCompressionMinSize),
+
+		IdempotencyTTL: envDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+
+		JWTExpiry: envDuration("JWT_EXPIRY", defaultJWTExpiry(env)),
+		JWTIssuer: envDefault("JWT_ISSUER", "linkedinify"),
+
+		MinPasswordLength: envInt("MIN_PASSWORD_LENGTH", 8),
+
+		MaxLoginAttempts:         envInt("MAX_LOGIN_ATTEMPTS", 5),
+		MaxLoginAttemptsPerEmail: envInt("MAX_LOGIN_ATTEMPTS_PER_EMAIL", 50),
+		LoginLockoutDuration:     envDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+
+		DBMaxOpenConns:    envInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:    envInt("DB_MAX_IDLE_CONNS", 10),
+		DBConnMaxLifetime: envDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+
+		DBReplicaDSN: os.Getenv("DATABASE_REPLICA_DSN"),
+
+		MigrateOnStart: envBool("MIGRATE_ON_START", false),
+
+		MonthlyQuotas: envQuotas("MONTHLY_QUOTAS"),
+
+		LinkedInClientID:     os.Getenv("LINKEDIN_CLIENT_ID"),
+		LinkedInClientSecret: os.Getenv("LINKEDIN_CLIENT_SECRET"),
+		LinkedInRedirectURL:  envDefault("LINKEDIN_REDIRECT_URL", "http://localhost:8080/api/v1/linkedin/callback"),
+
+		SchedulerInterval:    envDuration("SCHEDULER_INTERVAL", scheduler.DefaultInterval),
+		SchedulerMaxAttempts: envInt("SCHEDULER_MAX_ATTEMPTS", scheduler.DefaultMaxAttempts),
+
+		AnalyticsEnabled:       envBool("ANALYTICS_ENABLED", true),
+		AnalyticsFlushInterval: envDuration("ANALYTICS_FLUSH_INTERVAL", analytics.DefaultFlushInterval),
+
+		DuplicateThreshold: envFloat("DUPLICATE_THRESHOLD", 0),
+		DuplicateWindow:    envDuration("DUPLICATE_WINDOW", service.DefaultDuplicateWindow),
+
+		V1DeprecatedAt:    envTime("API_V1_DEPRECATED_AT"),
+		V1SunsetAt:        envTime("API_V1_SUNSET_AT"),
+		V1DeprecationLink: os.Getenv("API_V1_DEPRECATION_LINK"),
 	}
+}
 
-	openAIToken := os.Getenv("OPENAI_TOKEN")
-	if openAIToken == "" {
-		log.Fatal("FATAL: OPENAI_TOKEN environment variable is required")
+// defaultJWTExpiry keeps access tokens short-lived in production and saves
+// developers from refreshing them constantly.
+func defaultJWTExpiry(env string) time.Duration {
+	if env == "production" {
+		return 15 * time.Minute
 	}
+	return time.Hour
+}
 
-	treblleToken := os.Getenv("TREBLLE_SDK_TOKEN")
-	if treblleToken == "" {
-		log.Fatal("FATAL: TREBLLE_SDK_TOKEN environment variable is required")
+// Validate reports every problem with the configuration at once, so a
+// misconfigured deployment can be fixed in one go.
+func (c Config) Validate() error {
+	var errs []error
+	if len(c.JWTSecret) == 0 {
+		errs = append(errs, errors.New("JWT_SECRET is required"))
+	} else if len(c.JWTSecret) < minJWTSecretLength {
+		errs = append(errs, fmt.Errorf("JWT_SECRET must be at least %d characters, got %d", minJWTSecretLength, len(c.JWTSecret)))
 	}
 
-	treblleAPIKey := os.Getenv("TREBLLE_API_KEY")
-	if treblleAPIKey == "" {
-		log.Fatal("FATAL: TREBLLE_API_KEY environment variable is required")
+	if c.JWTExpiry <= 0 {
+		errs = append(errs, errors.New("JWT_EXPIRY must be positive"))
 	}
 
-	return Config{
-		HTTPAddr:      envDefault("HTTP_ADDR", ":8080"),
-		DSN:           envDefault("DATABASE_DSN", "postgres:///linkedinify?sslmode=disable"),
-		JWTSecret:     []byte(jwtSecret),
-		OpenAIToken:   openAIToken,
-		TreblleToken:  treblleToken,
-		TreblleAPIKey: treblleAPIKey,
+	if c.OpenAIMaxRetries < 0 {
+		errs = append(errs, errors.New("OPENAI_MAX_RETRIES must not be negative"))
 	}
+
+	if u, err := url.Parse(c.DSN); c.DSN == "" || err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		errs = append(errs, errors.New("DATABASE_DSN must be a postgres:// URL"))
+	}
+	if c.DBReplicaDSN != "" {
+		if u, err := url.Parse(c.DBReplicaDSN); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+			errs = append(errs, errors.New("DATABASE_REPLICA_DSN must be a postgres:// URL"))
+		}
+	}
+
+	switch c.AIProvider {
+	case "openai":
+		if c.OpenAIToken == "" && len(c.OpenAITokens) == 0 {
+			errs = append(errs, errors.New("OPENAI_TOKEN is required (or a comma-separated list in OPENAI_TOKENS)"))
+		}
+	case "anthropic":
+		if c.AnthropicToken == "" {
+			errs = append(errs, errors.New("ANTHROPIC_TOKEN is required when AI_PROVIDER=anthropic"))
+		}
+	case "mock":
+		if c.MockAILatency < 0 {
+			errs = append(errs, errors.New("MOCK_AI_LATENCY must not be negative"))
+		}
+		if c.MockAIErrorPercent < 0 || c.MockAIErrorPercent > 100 {
+			errs = append(errs, errors.New("MOCK_AI_ERROR_PERCENT must be between 0 and 100"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("AI_PROVIDER must be \"openai\", \"anthropic\" or \"mock\", got %q", c.AIProvider))
+	}
+
+	if c.Strict {
+		if c.TreblleToken == "" {
+			errs = append(errs, errors.New("TREBLLE_SDK_TOKEN is required (set STRICT_CONFIG=false to run without Treblle)"))
+		}
+		if c.TreblleAPIKey == "" {
+			errs = append(errs, errors.New("TREBLLE_API_KEY is required (set STRICT_CONFIG=false to run without Treblle)"))
+		}
+	}
+
+	if c.RateLimitPerMinute < 0 {
+		errs = append(errs, errors.New("RATE_LIMIT_PER_MINUTE must not be negative"))
+	}
+	if c.RateLimitPerMinute > 0 && !headerPrefixPattern.MatchString(c.RateLimitHeaderPrefix) {
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_HEADER_PREFIX must be letters, digits and hyphens, got %q", c.RateLimitHeaderPrefix))
+	}
+	if c.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("SHUTDOWN_TIMEOUT must be positive"))
+	}
+	if c.PostVersionLimit < 1 {
+		errs = append(errs, errors.New("POST_VERSION_LIMIT must be at least 1"))
+	}
+	if n := ai.EstimateTokens(c.SystemPrompt, c.AIModel()); n > ai.MaxSystemPromptTokens {
+		errs = append(errs, fmt.Errorf("%s must be at most about %d tokens, got about %d", c.systemPromptSource(), ai.MaxSystemPromptTokens, n))
+	}
+	if !service.ValidTemperature(c.AITemperature) {
+		errs = append(errs, fmt.Errorf("AI_TEMPERATURE must be between 0 and %g", service.MaxTemperature))
+	}
+	if !service.ValidTopP(c.AITopP) {
+		errs = append(errs, fmt.Errorf("AI_TOP_P must be between 0 and %g", service.MaxTopP))
+	}
+	if c.AICacheEnabled && c.AICacheSize < 1 {
+		errs = append(errs, errors.New("AI_CACHE_SIZE must be at least 1 when AI_CACHE_ENABLED=true"))
+	}
+	if !ai.IsImageSize(c.ImageSize) {
+		errs = append(errs, fmt.Errorf("IMAGE_SIZE must be one of %s, got %q", strings.Join(ai.ImageSizes, ", "), c.ImageSize))
+	}
+	if c.ImageTimeout <= 0 {
+		errs = append(errs, errors.New("IMAGE_TIMEOUT must be positive"))
+	}
+	if c.MaxPostLength < 1 {
+		errs = append(errs, errors.New("MAX_POST_LENGTH must be at least 1"))
+	}
+	if _, err := service.PostProcessorsByName(c.PostProcessors); err != nil {
+		errs = append(errs, fmt.Errorf("POST_PROCESSORS: %w, expected one of %s", err, strings.Join(service.PostProcessorNames(), ", ")))
+	}
+	if c.BatchConcurrency < 1 {
+		errs = append(errs, errors.New("BATCH_CONCURRENCY must be at least 1"))
+	}
+	if c.BatchItemTimeout < 0 {
+		errs = append(errs, errors.New("BATCH_ITEM_TIMEOUT must not be negative"))
+	}
+	if c.Env != "development" && c.Env != "production" {
+		errs = append(errs, fmt.Errorf("APP_ENV must be \"development\" or \"production\", got %q", c.Env))
+	}
+	if err := new(slog.Level).UnmarshalText([]byte(c.LogLevel)); err != nil {
+		errs = append(errs, fmt.Errorf("LOG_LEVEL must be debug, info, warn or error, got %q", c.LogLevel))
+	}
+	if c.RequestTimeout < 0 {
+		errs = append(errs, errors.New("REQUEST_TIMEOUT must not be negative"))
+	}
+	if c.LongRequestTimeout < 0 {
+		errs = append(errs, errors.New("LONG_REQUEST_TIMEOUT must not be negative"))
+	}
+	if c.MaxBodyBytes < 0 {
+		errs = append(errs, errors.New("MAX_BODY_BYTES must not be negative"))
+	}
+	if c.CompressionLevel < 0 || c.CompressionLevel > 9 {
+		errs = append(errs, errors.New("COMPRESSION_LEVEL must be between 0 and 9"))
+	}
+	if c.CompressionMinSize < 0 {
+		errs = append(errs, errors.New("COMPRESSION_MIN_SIZE must not be negative"))
+	}
+	if c.IdempotencyTTL < 0 {
+		errs = append(errs, errors.New("IDEMPOTENCY_TTL must not be negative"))
+	}
+	// bcrypt ignores everything past 72 bytes, so a longer minimum could
+	// never be honoured.
+	if c.MinPasswordLength < 1 || c.MinPasswordLength > 72 {
+		errs = append(errs, fmt.Errorf("MIN_PASSWORD_LENGTH must be between 1 and 72, got %d", c.MinPasswordLength))
+	}
+	if c.MaxLoginAttempts < 0 {
+		errs = append(errs, errors.New("MAX_LOGIN_ATTEMPTS must not be negative"))
+	}
+	if c.MaxLoginAttemptsPerEmail < 0 {
+		errs = append(errs, errors.New("MAX_LOGIN_ATTEMPTS_PER_EMAIL must not be negative"))
+	}
+	switch {
+	case c.LoginLockoutDuration > 0:
+	case c.MaxLoginAttempts > 0:
+		errs = append(errs, errors.New("LOGIN_LOCKOUT_DURATION must be positive when MAX_LOGIN_ATTEMPTS is set"))
+	case c.MaxLoginAttemptsPerEmail > 0:
+		errs = append(errs, errors.New("LOGIN_LOCKOUT_DURATION must be positive when MAX_LOGIN_ATTEMPTS_PER_EMAIL is set"))
+	}
+	if c.DBMaxOpenConns < 0 {
+		errs = append(errs, errors.New("DB_MAX_OPEN_CONNS must not be negative"))
+	}
+	if c.DBMaxIdleConns < 0 {
+		errs = append(errs, errors.New("DB_MAX_IDLE_CONNS must not be negative"))
+	}
+	if c.DBConnMaxLifetime < 0 {
+		errs = append(errs, errors.New("DB_CONN_MAX_LIFETIME must not be negative"))
+	}
+	for plan, n := range c.MonthlyQuotas {
+		if n < QuotaUnlimited {
+			errs = append(errs, fmt.Errorf("MONTHLY_QUOTAS limit of plan %q must not be negative, got %d", plan, n))
+		}
+	}
+	if c.WebhookURL != "" {
+		if u, err := url.Parse(c.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, errors.New("WEBHOOK_URL must be an http:// or https:// URL"))
+		}
+		if c.WebhookSecret == "" {
+			errs = append(errs, errors.New("WEBHOOK_SECRET is required when WEBHOOK_URL is set"))
+		}
+		if c.WebhookQueueSize < 1 {
+			errs = append(errs, errors.New("WEBHOOK_QUEUE_SIZE must be at least 1"))
+		}
+		if c.WebhookMaxAttempts < 1 {
+			errs = append(errs, errors.New("WEBHOOK_MAX_ATTEMPTS must be at least 1"))
+		}
+	}
+	if c.InboundWebhookSecret != "" && c.WebhookTolerance <= 0 {
+		errs = append(errs, errors.New("WEBHOOK_TOLERANCE must be positive"))
+	}
+	if c.LinkedInClientID != "" {
+		if c.LinkedInClientSecret == "" {
+			errs = append(errs, errors.New("LINKEDIN_CLIENT_SECRET is required when LINKEDIN_CLIENT_ID is set"))
+		}
+		if u, err := url.Parse(c.LinkedInRedirectURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, errors.New("LINKEDIN_REDIRECT_URL must be an http:// or https:// URL"))
+		}
+		if c.SchedulerInterval <= 0 {
+			errs = append(errs, errors.New("SCHEDULER_INTERVAL must be positive"))
+		}
+		if c.SchedulerMaxAttempts < 1 || c.SchedulerMaxAttempts > maxSchedulerAttempts {
+			errs = append(errs, fmt.Errorf("SCHEDULER_MAX_ATTEMPTS must be between 1 and %d", maxSchedulerAttempts))
+		}
+	}
+	if c.WarmupAI && c.WarmupAITimeout <= 0 {
+		errs = append(errs, errors.New("WARMUP_AI_TIMEOUT must be positive when WARMUP_AI=true"))
+	}
+	if c.AnalyticsEnabled && c.AnalyticsFlushInterval <= 0 {
+		errs = append(errs, errors.New("ANALYTICS_FLUSH_INTERVAL must be positive when ANALYTICS_ENABLED=true"))
+	}
+	if c.DuplicateThreshold < 0 || c.DuplicateThreshold > 1 {
+		errs = append(errs, errors.New("DUPLICATE_THRESHOLD must be between 0 and 1"))
+	}
+	if c.DuplicateThreshold > 0 && c.DuplicateWindow <= 0 {
+		errs = append(errs, errors.New("DUPLICATE_WINDOW must be positive when DUPLICATE_THRESHOLD is set"))
+	}
+	if c.V1DeprecatedAt.IsZero() {
+		if !c.V1SunsetAt.IsZero() || c.V1DeprecationLink != "" {
+			errs = append(errs, errors.New("API_V1_SUNSET_AT and API_V1_DEPRECATION_LINK need API_V1_DEPRECATED_AT"))
+		}
+	} else {
+		if !c.V1SunsetAt.IsZero() && !c.V1SunsetAt.After(c.V1DeprecatedAt) {
+			errs = append(errs, errors.New("API_V1_SUNSET_AT must be after API_V1_DEPRECATED_AT"))
+		}
+		if u, err := url.Parse(c.V1DeprecationLink); c.V1DeprecationLink != "" && (err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "") {
+			errs = append(errs, errors.New("API_V1_DEPRECATION_LINK must be an http:// or https:// URL"))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 func envDefault(key, def string) string {
@@ -53,6 +680,186 @@ func envDefault(key, def string) string {
 	return def
 }
 
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Fatalf("FATAL: %s must be an integer, got %q", key, v)
+	}
+	return n
+}
+
+func envFloat(key string, def float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		log.Fatalf("FATAL: %s must be a number, got %q", key, v)
+	}
+	return f
+}
+
+func envBool(key string, def bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Fatalf("FATAL: %s must be true or false, got %q", key, v)
+	}
+	return b
+}
+
+// envList splits a comma-separated variable, dropping empty items.
+func envList(key string) []string {
+	var out []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// envPrefixes parses a comma-separated list of CIDR prefixes such as
+// 10.0.0.0/8; a bare address stands for itself alone.
+func envPrefixes(key string) []netip.Prefix {
+	var out []netip.Prefix
+	for _, v := range envList(key) {
+		p, err := netip.ParsePrefix(v)
+		if err != nil {
+			addr, aerr := netip.ParseAddr(v)
+			if aerr != nil {
+				log.Fatalf("FATAL: %s must list CIDR prefixes or IP addresses, such as 10.0.0.0/8, got %q", key, v)
+			}
+			p = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		out = append(out, p.Masked())
+	}
+	return out
+}
+
+// envListDefault is envList returning def when the variable is unset or
+// empty.
+func envListDefault(key string, def []string) []string {
+	if v := envList(key); len(v) > 0 {
+		return v
+	}
+	return def
+}
+
+// envQuotas parses a comma-separated list of plan=limit pairs, such as
+// "free=50,pro=unlimited".
+func envQuotas(key string) map[string]int {
+	items := envList(key)
+	if len(items) == 0 {
+		return nil
+	}
+	quotas := make(map[string]int, len(items))
+	for _, item := range items {
+		plan, limit, ok := strings.Cut(item, "=")
+		plan, limit = strings.TrimSpace(plan), strings.TrimSpace(limit)
+		if !ok || plan == "" {
+			log.Fatalf("FATAL: %s must list plan=limit pairs, got %q", key, item)
+		}
+		if limit == "unlimited" {
+			quotas[plan] = QuotaUnlimited
+			continue
+		}
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			log.Fatalf("FATAL: %s limit of plan %q must be an integer or unlimited, got %q", key, plan, limit)
+		}
+		quotas[plan] = n
+	}
+	return quotas
+}
+
+// envFile returns the value of key, or the contents of the file named by
+// fileKey, trimmed of surrounding whitespace. Setting both is ambiguous.
+func envFile(key, fileKey string) string {
+	path := os.Getenv(fileKey)
+	if path == "" {
+		return os.Getenv(key)
+	}
+	if os.Getenv(key) != "" {
+		log.Fatalf("FATAL: set %s or %s, not both", key, fileKey)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("FATAL: reading %s: %v", fileKey, err)
+	}
+	return strings.TrimSpace(string(b))
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("FATAL: %s must be a duration such as 500ms or 2s, got %q", key, v)
+	}
+	return d
+}
+
+// envTime parses an RFC 3339 time such as 2026-06-01T00:00:00Z, returning
+// the zero time when the variable is unset.
+func envTime(key string) time.Time {
+	v := os.Getenv(key)
+	if v == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		log.Fatalf("FATAL: %s must be an RFC 3339 time such as 2026-06-01T00:00:00Z, got %q", key, v)
+	}
+	return t
+}
+
+// AIModel is the default model of the configured provider.
+func (c Config) AIModel() string {
+	switch c.AIProvider {
+	case ai.ProviderAnthropic:
+		return c.AnthropicModel
+	case ai.ProviderMock:
+		return ai.MockModel
+	}
+	return c.OpenAIModel
+}
+
+// systemPromptSource names where SystemPrompt came from, for messages about
+// it.
+func (c Config) systemPromptSource() string {
+	if c.SystemPromptFile != "" {
+		return "SYSTEM_PROMPT_FILE " + c.SystemPromptFile
+	}
+	return "SYSTEM_PROMPT"
+}
+
 func (c Config) GetJWTSecret() []byte {
 	return c.JWTSecret
 }
+
+func (c Config) GetJWTExpiry() time.Duration {
+	return c.JWTExpiry
+}
+
+func (c Config) GetJWTIssuer() string {
+	return c.JWTIssuer
+}
+
+func (c Config) GetPasswordResetURL() string {
+	return c.PasswordResetURL
+}
+
+func (c Config) GetMinPasswordLength() int {
+	return c.MinPasswordLength
+}
@@ -2,7 +2,11 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
@@ -11,7 +15,102 @@ import (
 	"github.com/you/linkedinify/internal/config"
 )
 
-func New(cfg config.Config) *bun.DB {
-	sqldb := sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(cfg.DSN)))
-	return bun.NewDB(sqldb, pgdialect.New())
+// DB is the application's database handle. It can be passed anywhere a
+// bun.IDB is expected, as can the bun.Tx handed out by WithTx, so repositories
+// work the same inside and outside a transaction.
+type DB struct {
+	*bun.DB
+	replica *bun.DB // nil without cfg.DBReplicaDSN
+}
+
+// New opens the connection pool described by cfg, and a second one to the
+// read replica if cfg.DBReplicaDSN is set. Connections are made lazily, so
+// New does not fail if the database is down; see Ping.
+func New(cfg config.Config) *DB {
+	maxOpen, maxIdle := cfg.DBMaxOpenConns, cfg.DBMaxIdleConns
+	if maxOpen > 0 && maxIdle > maxOpen {
+		slog.Warn("DB_MAX_IDLE_CONNS exceeds DB_MAX_OPEN_CONNS, clamping it", "max_idle_conns", maxIdle, "max_open_conns", maxOpen)
+		maxIdle = maxOpen
+	}
+	open := func(dsn string) *bun.DB {
+		sqldb := sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(dsn)))
+		sqldb.SetMaxOpenConns(maxOpen)
+		sqldb.SetMaxIdleConns(maxIdle)
+		sqldb.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+		return bun.NewDB(sqldb, pgdialect.New())
+	}
+
+	d := &DB{DB: open(cfg.DSN)}
+	if cfg.DBReplicaDSN != "" {
+		d.replica = open(cfg.DBReplicaDSN)
+	}
+	slog.Info("database pool configured",
+		"max_open_conns", maxOpen,
+		"max_idle_conns", maxIdle,
+		"conn_max_lifetime", cfg.DBConnMaxLifetime,
+		"replica", d.replica != nil)
+	return d
+}
+
+// Reader is the handle for queries that can tolerate replication lag: the
+// read replica if one is configured, the primary otherwise. Everything
+// else, and anything in a transaction, must use the primary.
+func (d *DB) Reader() bun.IDB {
+	if d.replica != nil {
+		return d.replica
+	}
+	return d.DB
+}
+
+// WithTx runs fn in a transaction. The transaction is committed if fn returns
+// nil and rolled back if it returns an error or panics; a panic is re-raised
+// after the rollback.
+//
+//	err := database.WithTx(ctx, func(tx bun.Tx) error {
+//		posts := repository.NewPostRepo(tx)
+//		...
+//	})
+func (d *DB) WithTx(ctx context.Context, fn func(tx bun.Tx) error) error {
+	tx, err := d.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// Ping checks that the database, and the read replica if there is one, are
+// reachable. The caller's ctx should carry a deadline, since a hung server
+// otherwise blocks until the OS gives up.
+func (d *DB) Ping(ctx context.Context) error {
+	if err := d.PingContext(ctx); err != nil {
+		return err
+	}
+	if d.replica != nil {
+		if err := d.replica.PingContext(ctx); err != nil {
+			return fmt.Errorf("replica: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close closes the connection pools.
+func (d *DB) Close() error {
+	err := d.DB.Close()
+	if d.replica != nil {
+		err = errors.Join(err, d.replica.Close())
+	}
+	return err
 }
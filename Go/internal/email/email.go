@@ -0,0 +1,48 @@
+// internal/email/email.go
+package email
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/smtp"
+	"strings"
+)
+
+// LogSender writes emails to the application log instead of delivering them.
+// It is meant for local development.
+type LogSender struct{}
+
+func NewLogSender() *LogSender { return &LogSender{} }
+
+func (LogSender) Send(ctx context.Context, to, subject, body string) error {
+	slog.InfoContext(ctx, "email not sent, SMTP is not configured", "to", to, "subject", subject, "body", body)
+	return nil
+}
+
+// SMTPSender delivers email through an SMTP server using PLAIN auth.
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPSender(host, port, username, password, from string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPSender{addr: host + ":" + port, auth: auth, from: from}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return fmt.Errorf("email: header values must not contain line breaks")
+	}
+	msg := "From: " + s.from + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + body
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{to}, []byte(msg))
+}
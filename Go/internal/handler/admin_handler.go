@@ -0,0 +1,207 @@
+// internal/handler/admin_handler.go
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/audit"
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+type AdminHandler struct {
+	svc service.AdminServiceInteractor
+}
+
+func NewAdmin(svc service.AdminServiceInteractor) *AdminHandler {
+	return &AdminHandler{svc: svc}
+}
+
+// Routes returns the admin API. Every route requires a token with the admin
+// role.
+func (h *AdminHandler) Routes(secret []byte, opts ...middleware.AuthOption) chi.Router {
+	r := chi.NewRouter()
+	r.Use(middleware.Auth(secret, opts...))
+	r.Use(RequireRole(model.RoleAdmin))
+	r.Get("/users", h.listUsers)
+	r.Delete("/posts/{id}", h.purgePost)
+	r.Get("/feedback/stats", h.feedbackStats)
+	r.Get("/audit", h.auditLog)
+	return r
+}
+
+// RequireRole rejects requests with 403 unless the token checked by
+// middleware.Auth carries role, so it must be used after Auth.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return middleware.RequireRole(role)
+}
+
+type userResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (h *AdminHandler) listUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.svc.ListUsers(r.Context())
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to list users")
+		return
+	}
+	res := make([]userResponse, 0, len(users))
+	for _, u := range users {
+		res = append(res, userResponse{ID: u.ID, Email: u.Email, Role: u.Role, CreatedAt: u.CreatedAt})
+	}
+	respondJSON(w, http.StatusOK, res)
+}
+
+// purgePost permanently deletes a post, whether or not its owner soft-deleted
+// it first.
+func (h *AdminHandler) purgePost(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+	err = h.svc.PurgePost(r.Context(), postID)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to delete post")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type feedbackStatResponse struct {
+	Template   string `json:"template"`
+	Model      string `json:"model"`
+	Total      int    `json:"total"`
+	ThumbsUp   int    `json:"thumbs_up"`
+	ThumbsDown int    `json:"thumbs_down"`
+	// ApprovalRate is the share of ratings, from 0 to 1, that were thumbs
+	// up.
+	ApprovalRate float64 `json:"approval_rate"`
+}
+
+type feedbackStatsResponse struct {
+	Data []feedbackStatResponse `json:"data"`
+}
+
+// feedbackStats reports the approval rate of posts by the template and model
+// they were generated with.
+func (h *AdminHandler) feedbackStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.svc.FeedbackStats(r.Context())
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to load feedback stats")
+		return
+	}
+	res := feedbackStatsResponse{Data: make([]feedbackStatResponse, 0, len(stats))}
+	for _, s := range stats {
+		res.Data = append(res.Data, feedbackStatResponse{
+			Template:     s.Template,
+			Model:        s.Model,
+			Total:        s.Total,
+			ThumbsUp:     s.ThumbsUp,
+			ThumbsDown:   s.Total - s.ThumbsUp,
+			ApprovalRate: s.ApprovalRate,
+		})
+	}
+	respondJSON(w, http.StatusOK, res)
+}
+
+type auditEntryResponse struct {
+	ID uuid.UUID `json:"id"`
+	// ActorID is null for actions taken before authenticating, such as
+	// logging in.
+	ActorID   *uuid.UUID     `json:"actor_id"`
+	SubjectID *uuid.UUID     `json:"subject_id"`
+	Action    string         `json:"action"`
+	IP        string         `json:"ip"`
+	Metadata  map[string]any `json:"metadata"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type auditPage struct {
+	Data []auditEntryResponse `json:"data"`
+	Meta pageMeta             `json:"meta"`
+}
+
+func toAuditEntryResponse(e model.AuditLog) auditEntryResponse {
+	out := auditEntryResponse{ID: e.ID, Action: e.Action, IP: e.IP, Metadata: e.Metadata, CreatedAt: e.CreatedAt}
+	if e.ActorID != uuid.Nil {
+		out.ActorID = &e.ActorID
+	}
+	if e.SubjectID != uuid.Nil {
+		out.SubjectID = &e.SubjectID
+	}
+	return out
+}
+
+// auditLog lists audit entries, newest first, optionally filtered by the
+// user_id, action, from and to query parameters.
+func (h *AdminHandler) auditLog(w http.ResponseWriter, r *http.Request) {
+	limit, offset, msg := parsePagination(r)
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	f, msg := parseAuditFilter(r)
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	entries, total, err := h.svc.AuditLog(r.Context(), f, limit, offset)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to load the audit log")
+		return
+	}
+	res := auditPage{Data: make([]auditEntryResponse, 0, len(entries)), Meta: pageMeta{Total: total, Limit: limit, Offset: offset}}
+	for _, e := range entries {
+		res.Data = append(res.Data, toAuditEntryResponse(e))
+	}
+	respondJSON(w, http.StatusOK, res)
+}
+
+// parseAuditFilter reads the filters of auditLog and returns a
+// client-facing message when one is invalid.
+func parseAuditFilter(r *http.Request) (service.AuditFilter, string) {
+	var f service.AuditFilter
+	q := r.URL.Query()
+	if v := q.Get("user_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return f, "The 'user_id' parameter must be a UUID"
+		}
+		f.UserID = id
+	}
+	if v := q.Get("action"); v != "" {
+		if !audit.Action(v).Valid() {
+			return f, "Unknown 'action'"
+		}
+		f.Action = v
+	}
+	for _, p := range []struct {
+		name string
+		t    *time.Time
+	}{{"from", &f.From}, {"to", &f.To}} {
+		v := q.Get(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return f, "The '" + p.name + "' parameter must be an RFC 3339 time, such as 2024-05-01T00:00:00Z"
+		}
+		*p.t = t
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return f, "The 'from' parameter must be before 'to'"
+	}
+	return f, ""
+}
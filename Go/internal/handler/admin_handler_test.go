@@ -0,0 +1,184 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// generateRoleToken is generateTestToken with a role claim.
+func generateRoleToken(t *testing.T, role string, secret []byte) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  uuid.NewString(),
+		"role": role,
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	})
+	signed, err := token.SignedString(secret)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestAdminHandler_listUsers(t *testing.T) {
+	users := []model.User{
+		{ID: uuid.New(), Email: "admin@example.com", Role: model.RoleAdmin, PasswordHash: "secret-hash"},
+		{ID: uuid.New(), Email: "user@example.com", Role: model.RoleUser, PasswordHash: "secret-hash"},
+	}
+	mockService := &service.AdminServiceInteractorMock{
+		ListUsersFunc: func(ctx context.Context) ([]model.User, error) { return users, nil },
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewAdmin(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/users", nil)
+	req.Header.Set("Authorization", "Bearer "+generateRoleToken(t, model.RoleAdmin, testSecret))
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body, 2)
+	assert.Equal(t, "admin@example.com", body[0]["email"])
+	assert.Equal(t, "user", body[1]["role"])
+	assert.NotContains(t, body[0], "password_hash")
+}
+
+func TestAdminHandler_listUsers_ForbiddenForUsers(t *testing.T) {
+	mockService := &service.AdminServiceInteractorMock{}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewAdmin(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/users", nil)
+	req.Header.Set("Authorization", "Bearer "+generateRoleToken(t, model.RoleUser, testSecret))
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Len(t, mockService.ListUsersCalls(), 0)
+}
+
+func TestAdminHandler_purgePost(t *testing.T) {
+	postID := uuid.New()
+	mockService := &service.AdminServiceInteractorMock{
+		PurgePostFunc: func(ctx context.Context, id uuid.UUID) error {
+			if id == postID {
+				return nil
+			}
+			return service.ErrPostNotFound
+		},
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewAdmin(mockService).Routes(testSecret))
+	defer server.Close()
+
+	for id, wantStatus := range map[string]int{postID.String(): http.StatusNoContent, uuid.NewString(): http.StatusNotFound} {
+		req, _ := http.NewRequest(http.MethodDelete, server.URL+"/posts/"+id, nil)
+		req.Header.Set("Authorization", "Bearer "+generateRoleToken(t, model.RoleAdmin, testSecret))
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, wantStatus, resp.StatusCode, id)
+	}
+}
+
+func TestAdminHandler_feedbackStats(t *testing.T) {
+	mockService := &service.AdminServiceInteractorMock{
+		FeedbackStatsFunc: func(ctx context.Context) ([]service.FeedbackStat, error) {
+			return []service.FeedbackStat{{Template: "announcement", Model: "gpt-4o-mini", Total: 4, ThumbsUp: 3, ApprovalRate: 0.75}}, nil
+		},
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewAdmin(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/feedback/stats", nil)
+	req.Header.Set("Authorization", "Bearer "+generateRoleToken(t, model.RoleAdmin, testSecret))
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Data, 1)
+	assert.Equal(t, "announcement", body.Data[0]["template"])
+	assert.Equal(t, "gpt-4o-mini", body.Data[0]["model"])
+	assert.EqualValues(t, 3, body.Data[0]["thumbs_up"])
+	assert.EqualValues(t, 1, body.Data[0]["thumbs_down"])
+	assert.EqualValues(t, 0.75, body.Data[0]["approval_rate"])
+}
+
+func TestAdminHandler_auditLog(t *testing.T) {
+	userID := uuid.New()
+	entry := model.AuditLog{ID: uuid.New(), SubjectID: userID, Action: "auth.login", IP: "192.0.2.7", Metadata: map[string]any{}, CreatedAt: time.Now()}
+	mockService := &service.AdminServiceInteractorMock{
+		AuditLogFunc: func(ctx context.Context, f service.AuditFilter, limit, offset int) ([]model.AuditLog, int, error) {
+			return []model.AuditLog{entry}, 1, nil
+		},
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewAdmin(mockService).Routes(testSecret))
+	defer server.Close()
+	token := generateRoleToken(t, model.RoleAdmin, testSecret)
+
+	get := func(query string) (int, map[string]interface{}) {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/audit"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+
+	status, body := get("?user_id=" + userID.String() + "&action=auth.login&from=2024-05-01T00:00:00Z&to=2024-06-01T00:00:00Z&limit=10")
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, body["data"], 1)
+	item := body["data"].([]interface{})[0].(map[string]interface{})
+	assert.Nil(t, item["actor_id"], "Logins have no actor")
+	assert.Equal(t, userID.String(), item["subject_id"])
+	assert.Equal(t, "192.0.2.7", item["ip"])
+	assert.Equal(t, float64(1), body["meta"].(map[string]interface{})["total"])
+
+	require.Len(t, mockService.AuditLogCalls(), 1)
+	call := mockService.AuditLogCalls()[0]
+	assert.Equal(t, service.AuditFilter{
+		UserID: userID,
+		Action: "auth.login",
+		From:   time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}, call.F)
+	assert.Equal(t, 10, call.Limit)
+
+	for _, query := range []string{"?user_id=nope", "?action=post.create", "?from=yesterday", "?from=2024-06-01T00:00:00Z&to=2024-05-01T00:00:00Z", "?limit=-1", "?limit=0"} {
+		status, _ = get(query)
+		assert.Equal(t, http.StatusBadRequest, status, query)
+	}
+	assert.Len(t, mockService.AuditLogCalls(), 1)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/audit", nil)
+	req.Header.Set("Authorization", "Bearer "+generateRoleToken(t, model.RoleUser, testSecret))
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "The audit log is for admins only")
+}
@@ -2,12 +2,18 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/you/linkedinify/api"
+	"github.com/you/linkedinify/internal/middleware"
 	"github.com/you/linkedinify/internal/service"
 )
 
@@ -23,42 +29,168 @@ func (h *AuthHandler) Routes() chi.Router {
 	r := chi.NewRouter()
 	r.Post("/login", h.login)
 	r.Post("/register", h.register)
+	r.Post("/refresh", h.refresh)
+	r.Post("/forgot-password", h.forgotPassword)
+	r.Post("/reset-password", h.resetPassword)
+	r.Post("/logout", h.logout)
 	return r
 }
 
-type creds struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+type creds = api.Credentials
+
+// ClientIP returns the address of the client making the request, as worked
+// out by middleware.RealIP from the forwarding headers of trusted proxies,
+// or "" outside a request it handled.
+func ClientIP(ctx context.Context) string {
+	return middleware.ClientIPFromContext(ctx)
 }
 
 func (h *AuthHandler) login(w http.ResponseWriter, r *http.Request) {
 	var c creds
-	if err := json.NewDecoder(r.Body).Decode(&c); err != nil || c.Email == "" || c.Password == "" {
-		http.Error(w, "bad request: missing email or password", http.StatusBadRequest)
+	if err := decodeJSON(r, &c); err != nil && !errors.Is(err, io.EOF) {
+		respondDecodeError(w, err)
+		return
+	}
+	if c.Email == "" || c.Password == "" {
+		respondError(w, http.StatusBadRequest, "The 'email' and 'password' fields are required")
+		return
+	}
+	tokens, err := h.svc.Login(r.Context(), c.Email, c.Password, middleware.ClientIP(r))
+	var locked *service.LoginLockedError
+	if errors.As(err, &locked) {
+		// The same 429 whether or not the account exists.
+		middleware.TooManyRequests(w, locked.RetryAfter)
 		return
 	}
-	token, err := h.svc.Login(r.Context(), c.Email, c.Password)
 	if err != nil {
-		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		respondError(w, http.StatusUnauthorized, "Invalid credentials")
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"token": token})
+	writeTokens(w, http.StatusOK, tokens)
 }
 
 func (h *AuthHandler) register(w http.ResponseWriter, r *http.Request) {
 	var c creds
-	if err := json.NewDecoder(r.Body).Decode(&c); err != nil || c.Email == "" || c.Password == "" {
-		http.Error(w, "bad request: missing email or password", http.StatusBadRequest)
+	if err := decodeJSON(r, &c); err != nil && !errors.Is(err, io.EOF) {
+		respondDecodeError(w, err)
+		return
+	}
+	if c.Email == "" || c.Password == "" {
+		respondError(w, http.StatusBadRequest, "The 'email' and 'password' fields are required")
+		return
+	}
+	tokens, err := h.svc.Register(r.Context(), c.Email, c.Password)
+	if err != nil {
+		respondServiceError(w, r, err, "Registration failed")
+		return
+	}
+	writeTokens(w, http.StatusCreated, tokens)
+}
+
+type refreshReq struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (h *AuthHandler) refresh(w http.ResponseWriter, r *http.Request) {
+	var in refreshReq
+	if err := decodeJSON(r, &in); err != nil && !errors.Is(err, io.EOF) {
+		respondDecodeError(w, err)
 		return
 	}
-	token, err := h.svc.Register(r.Context(), c.Email, c.Password)
+	if in.RefreshToken == "" {
+		respondError(w, http.StatusBadRequest, "The 'refresh_token' field is required")
+		return
+	}
+	tokens, err := h.svc.Refresh(r.Context(), in.RefreshToken)
 	if err != nil {
-		log.Printf("Registration error: %v", err)
-		http.Error(w, "registration failed", http.StatusInternalServerError)
+		if errors.Is(err, service.ErrRefreshTokenReused) {
+			slog.WarnContext(r.Context(), "refresh token reuse detected, token family revoked")
+		}
+		respondServiceError(w, r, err, "Refresh failed")
+		return
+	}
+	writeTokens(w, http.StatusOK, tokens)
+}
+
+type forgotPasswordReq struct {
+	Email string `json:"email"`
+}
+
+// forgotPasswordMessage is returned whether or not the email is registered.
+const forgotPasswordMessage = "If an account exists for that email, a password reset link has been sent."
+
+func (h *AuthHandler) forgotPassword(w http.ResponseWriter, r *http.Request) {
+	var in forgotPasswordReq
+	if err := decodeJSON(r, &in); err != nil && !errors.Is(err, io.EOF) {
+		respondDecodeError(w, err)
 		return
 	}
+	if in.Email == "" {
+		respondError(w, http.StatusBadRequest, "The 'email' field is required")
+		return
+	}
+	// Failures are only logged: a different response would reveal whether
+	// the account exists.
+	if err := h.svc.ForgotPassword(r.Context(), in.Email); err != nil {
+		slog.ErrorContext(r.Context(), "forgot password failed", "error", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(messageResponse{Message: forgotPasswordMessage})
+}
+
+type resetPasswordReq struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+func (h *AuthHandler) resetPassword(w http.ResponseWriter, r *http.Request) {
+	var in resetPasswordReq
+	if err := decodeJSON(r, &in); err != nil && !errors.Is(err, io.EOF) {
+		respondDecodeError(w, err)
+		return
+	}
+	if in.Token == "" || in.Password == "" {
+		respondError(w, http.StatusBadRequest, "The 'token' and 'password' fields are required")
+		return
+	}
+	if err := h.svc.ResetPassword(r.Context(), in.Token, in.Password); err != nil {
+		respondServiceError(w, r, err, "Password reset failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AuthHandler) logout(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	if err := h.svc.Logout(r.Context(), strings.TrimPrefix(auth, "Bearer ")); err != nil {
+		respondServiceError(w, r, err, "Logout failed")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(messageResponse{Message: "Logged out"})
+}
+
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
+type tokenResponse = api.Tokens
+
+// writeTokens sends a token pair. "token" carries the access token so
+// existing clients keep working.
+func writeTokens(w http.ResponseWriter, status int, t *service.Tokens) {
+	body := tokenResponse{
+		Token:        t.AccessToken,
+		ExpiresIn:    int(t.ExpiresIn.Seconds()),
+		RefreshToken: t.RefreshToken,
+	}
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(map[string]string{"token": token})
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
 }
@@ -5,23 +5,27 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/middleware"
 	"github.com/you/linkedinify/internal/service"
 )
 
 func TestAuthHandler_Login_Success(t *testing.T) {
 	mockAuthService := &service.AuthServiceInteractorMock{
-		LoginFunc: func(ctx context.Context, email, password string) (string, error) {
+		LoginFunc: func(ctx context.Context, email, password, ip string) (*service.Tokens, error) {
 			assert.Equal(t, "test@example.com", email)
 			assert.Equal(t, "password123", password)
-			return "test-jwt-token", nil
+			return &service.Tokens{AccessToken: "test-jwt-token", RefreshToken: "test-refresh-token", ExpiresIn: 15 * time.Minute}, nil
 		},
 	}
 	authHandler := handler.NewAuth(mockAuthService)
@@ -43,17 +47,19 @@ func TestAuthHandler_Login_Success(t *testing.T) {
 	defer resp.Body.Close()
 
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
-	var respBody map[string]string
+	var respBody map[string]interface{}
 	err = json.NewDecoder(resp.Body).Decode(&respBody)
 	require.NoError(t, err)
 	assert.Equal(t, "test-jwt-token", respBody["token"])
+	assert.Equal(t, "test-refresh-token", respBody["refresh_token"])
+	assert.Equal(t, float64(900), respBody["expires_in"])
 	require.Len(t, mockAuthService.LoginCalls(), 1)
 }
 
 func TestAuthHandler_Login_InvalidCredentials(t *testing.T) {
 	mockAuthService := &service.AuthServiceInteractorMock{
-		LoginFunc: func(ctx context.Context, email, password string) (string, error) {
-			return "", errors.New("invalid credentials")
+		LoginFunc: func(ctx context.Context, email, password, ip string) (*service.Tokens, error) {
+			return nil, errors.New("invalid credentials")
 		},
 	}
 	authHandler := handler.NewAuth(mockAuthService)
@@ -74,6 +80,23 @@ func TestAuthHandler_Login_InvalidCredentials(t *testing.T) {
 	require.Len(t, mockAuthService.LoginCalls(), 1)
 }
 
+func TestAuthHandler_Login_Locked(t *testing.T) {
+	mockAuthService := &service.AuthServiceInteractorMock{
+		LoginFunc: func(ctx context.Context, email, password, ip string) (*service.Tokens, error) {
+			return nil, &service.LoginLockedError{RetryAfter: 90 * time.Second}
+		},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"email":"test@example.com","password":"guess"}`))
+	req.RemoteAddr = "203.0.113.7:51234"
+	rr := httptest.NewRecorder()
+	handler.NewAuth(mockAuthService).Routes().ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "90", rr.Header().Get("Retry-After"))
+	assert.Contains(t, rr.Body.String(), `"code":"rate_limited"`)
+	assert.Equal(t, "203.0.113.7", mockAuthService.LoginCalls()[0].IP)
+}
+
 func TestAuthHandler_Login_BadRequest_MissingFields(t *testing.T) {
 	mockAuthService := &service.AuthServiceInteractorMock{}
 	authHandler := handler.NewAuth(mockAuthService)
@@ -106,12 +129,40 @@ func TestAuthHandler_Login_BadRequest_MissingFields(t *testing.T) {
 	assert.Len(t, mockAuthService.LoginCalls(), 0)
 }
 
+func TestAuthHandler_Login_StrictJSON(t *testing.T) {
+	mockAuthService := &service.AuthServiceInteractorMock{}
+	server := httptest.NewServer(middleware.MaxBodyBytes(128)(handler.NewAuth(mockAuthService).Routes()))
+	defer server.Close()
+
+	login := func(payload string) (int, middleware.ErrorDetail) {
+		resp, err := server.Client().Post(server.URL+"/login", "application/json", bytes.NewBufferString(payload))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var e middleware.ErrorEnvelope
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&e))
+		return resp.StatusCode, e.Error
+	}
+
+	status, e := login(`{"email": "test@example.com", "pasword": "password123"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "Unknown field: pasword", e.Message, "A typo is named rather than reported as a missing field")
+
+	status, e = login(`{"email": "test@example.com", "password": "` + strings.Repeat("x", 200) + `"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+	assert.Equal(t, middleware.CodeValidation, e.Code)
+
+	status, e = login(``)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "The 'email' and 'password' fields are required", e.Message)
+	assert.Empty(t, mockAuthService.LoginCalls())
+}
+
 func TestAuthHandler_Register_Success(t *testing.T) {
 	mockAuthService := &service.AuthServiceInteractorMock{
-		RegisterFunc: func(ctx context.Context, email, password string) (string, error) {
+		RegisterFunc: func(ctx context.Context, email, password string) (*service.Tokens, error) {
 			assert.Equal(t, "newuser@example.com", email)
 			assert.Equal(t, "securepassword", password)
-			return "new-test-jwt-token", nil
+			return &service.Tokens{AccessToken: "new-test-jwt-token"}, nil
 		},
 	}
 	authHandler := handler.NewAuth(mockAuthService)
@@ -129,7 +180,7 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 	defer resp.Body.Close()
 
 	assert.Equal(t, http.StatusCreated, resp.StatusCode)
-	var respBody map[string]string
+	var respBody map[string]interface{}
 	err = json.NewDecoder(resp.Body).Decode(&respBody)
 	require.NoError(t, err)
 	assert.Equal(t, "new-test-jwt-token", respBody["token"])
@@ -139,8 +190,8 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 func TestAuthHandler_Register_Conflict(t *testing.T) {
 	userExistsError := errors.New("user already exists")
 	mockAuthService := &service.AuthServiceInteractorMock{
-		RegisterFunc: func(ctx context.Context, email, password string) (string, error) {
-			return "", userExistsError
+		RegisterFunc: func(ctx context.Context, email, password string) (*service.Tokens, error) {
+			return nil, userExistsError
 		},
 	}
 	authHandler := handler.NewAuth(mockAuthService)
@@ -160,3 +211,140 @@ func TestAuthHandler_Register_Conflict(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 	require.Len(t, mockAuthService.RegisterCalls(), 1)
 }
+
+func TestAuthHandler_Register_WeakPassword(t *testing.T) {
+	mockAuthService := &service.AuthServiceInteractorMock{
+		RegisterFunc: func(ctx context.Context, email, password string) (*service.Tokens, error) {
+			return nil, service.ValidatePassword(password, 8)
+		},
+	}
+	server := httptest.NewServer(handler.NewAuth(mockAuthService).Routes())
+	defer server.Close()
+
+	jsonBody, _ := json.Marshal(map[string]string{"email": "newuser@example.com", "password": "123"})
+	resp, err := server.Client().Post(server.URL+"/register", "application/json", bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "password must be at least 8 characters")
+}
+
+func TestAuthHandler_Refresh_Success(t *testing.T) {
+	mockAuthService := &service.AuthServiceInteractorMock{
+		RefreshFunc: func(ctx context.Context, refreshToken string) (*service.Tokens, error) {
+			assert.Equal(t, "old-refresh-token", refreshToken)
+			return &service.Tokens{AccessToken: "fresh-jwt", RefreshToken: "new-refresh-token", ExpiresIn: 15 * time.Minute}, nil
+		},
+	}
+	server := httptest.NewServer(handler.NewAuth(mockAuthService).Routes())
+	defer server.Close()
+
+	jsonBody, _ := json.Marshal(map[string]string{"refresh_token": "old-refresh-token"})
+	resp, err := server.Client().Post(server.URL+"/refresh", "application/json", bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var respBody map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&respBody))
+	assert.Equal(t, "fresh-jwt", respBody["token"])
+	assert.Equal(t, "new-refresh-token", respBody["refresh_token"])
+}
+
+func TestAuthHandler_Refresh_Rejected(t *testing.T) {
+	for name, svcErr := range map[string]error{
+		"invalid": service.ErrInvalidRefreshToken,
+		"reused":  service.ErrRefreshTokenReused,
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockAuthService := &service.AuthServiceInteractorMock{
+				RefreshFunc: func(ctx context.Context, refreshToken string) (*service.Tokens, error) {
+					return nil, svcErr
+				},
+			}
+			server := httptest.NewServer(handler.NewAuth(mockAuthService).Routes())
+			defer server.Close()
+
+			jsonBody, _ := json.Marshal(map[string]string{"refresh_token": "some-token"})
+			resp, err := server.Client().Post(server.URL+"/refresh", "application/json", bytes.NewBuffer(jsonBody))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		})
+	}
+}
+
+func TestAuthHandler_ForgotPassword_SameResponseForUnknownEmail(t *testing.T) {
+	mockAuthService := &service.AuthServiceInteractorMock{
+		ForgotPasswordFunc: func(ctx context.Context, email string) error {
+			if email == "broken@example.com" {
+				return errors.New("smtp down")
+			}
+			return nil
+		},
+	}
+	server := httptest.NewServer(handler.NewAuth(mockAuthService).Routes())
+	defer server.Close()
+
+	var bodies []string
+	for _, email := range []string{"known@example.com", "broken@example.com"} {
+		jsonBody, _ := json.Marshal(map[string]string{"email": email})
+		resp, err := server.Client().Post(server.URL+"/forgot-password", "application/json", bytes.NewBuffer(jsonBody))
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		bodies = append(bodies, string(body))
+	}
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestAuthHandler_ResetPassword(t *testing.T) {
+	mockAuthService := &service.AuthServiceInteractorMock{
+		ResetPasswordFunc: func(ctx context.Context, token, newPassword string) error {
+			if token == "good-token" {
+				return nil
+			}
+			return service.ErrInvalidResetToken
+		},
+	}
+	server := httptest.NewServer(handler.NewAuth(mockAuthService).Routes())
+	defer server.Close()
+
+	for token, wantStatus := range map[string]int{"good-token": http.StatusNoContent, "bad-token": http.StatusBadRequest} {
+		jsonBody, _ := json.Marshal(map[string]string{"token": token, "password": "new-password"})
+		resp, err := server.Client().Post(server.URL+"/reset-password", "application/json", bytes.NewBuffer(jsonBody))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, wantStatus, resp.StatusCode, token)
+	}
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	mockAuthService := &service.AuthServiceInteractorMock{
+		LogoutFunc: func(ctx context.Context, accessToken string) error {
+			if accessToken == "good-token" {
+				return nil
+			}
+			return service.ErrInvalidAccessToken
+		},
+	}
+	server := httptest.NewServer(handler.NewAuth(mockAuthService).Routes())
+	defer server.Close()
+
+	for token, wantStatus := range map[string]int{"good-token": http.StatusOK, "bad-token": http.StatusUnauthorized} {
+		req, _ := http.NewRequest(http.MethodPost, server.URL+"/logout", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, wantStatus, resp.StatusCode, token)
+	}
+
+	resp, err := server.Client().Post(server.URL+"/logout", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "missing Authorization header")
+}
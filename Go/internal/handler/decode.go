@@ -0,0 +1,75 @@
+// internal/handler/decode.go
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+// errTrailingData is returned by decodeJSON for a body with more after its
+// JSON value.
+var errTrailingData = errors.New("request body has data after the JSON value")
+
+// decodeJSON decodes the request body, a single JSON value, into v. Fields v
+// has no place for are errors, so a misspelled field is reported rather than
+// silently ignored. An empty body is io.EOF, which endpoints whose body is
+// optional accept. Errors are for respondDecodeError.
+func decodeJSON(r *http.Request, v any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return err
+		}
+		return errTrailingData
+	}
+	return nil
+}
+
+// respondDecodeError reports an error of decodeJSON: a 413 for a body over
+// the limit of middleware.MaxBodyBytes, and a 400 naming the field for an
+// unknown or mistyped one.
+func respondDecodeError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &tooLarge):
+		respondError(w, http.StatusRequestEntityTooLarge, middleware.TooLargeMessage(tooLarge.Limit))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no error type for unknown fields.
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		respondError(w, http.StatusBadRequest, "Unknown field: "+field)
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("The '%s' field must be %s", typeErr.Field, jsonType(typeErr.Type)))
+	default:
+		respondError(w, http.StatusBadRequest, "Invalid request payload")
+	}
+}
+
+// jsonType names a Go type the way the JSON of a request would.
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "a list"
+	case reflect.Struct, reflect.Map:
+		return "an object"
+	}
+	return "a string"
+}
@@ -0,0 +1,310 @@
+// internal/handler/errors.go
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// ErrorCode classifies an error response; see middleware.ErrorCode.
+type ErrorCode = middleware.ErrorCode
+
+const (
+	CodeValidation            = middleware.CodeValidation
+	CodeUnauthorized          = middleware.CodeUnauthorized
+	CodeForbidden             = middleware.CodeForbidden
+	CodeNotFound              = middleware.CodeNotFound
+	CodeConflict              = middleware.CodeConflict
+	CodeQuotaExceeded         = middleware.CodeQuotaExceeded
+	CodeContentFlagged        = middleware.CodeContentFlagged
+	CodeRateLimited           = middleware.CodeRateLimited
+	CodeNotImplemented        = middleware.CodeNotImplemented
+	CodeUpstreamAI            = middleware.CodeUpstreamAI
+	CodeContextLengthExceeded = middleware.CodeContextLengthExceeded
+	CodeLinkedInNotConnected  = middleware.CodeLinkedInNotConnected
+	CodeTimeout               = middleware.CodeTimeout
+	CodeInternal              = middleware.CodeInternal
+)
+
+// errorCodes lists every ErrorCode, for the OpenAPI spec.
+var errorCodes = []ErrorCode{
+	CodeValidation, CodeUnauthorized, CodeForbidden, CodeNotFound, CodeConflict, CodeQuotaExceeded,
+	CodeContentFlagged, CodeRateLimited, CodeNotImplemented, CodeUpstreamAI, CodeContextLengthExceeded,
+	CodeLinkedInNotConnected, CodeTimeout, CodeInternal,
+}
+
+// WriteError sends the JSON error envelope every endpoint and middleware
+// responds with:
+//
+//	{"error": {"code": "not_found", "message": "Post not found", "request_id": "..."}}
+//
+// The request ID set by middleware.RequestID is included so users can quote
+// it when reporting a problem.
+func WriteError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	middleware.WriteError(w, status, code, message)
+}
+
+// RequestIDFromContext returns the ID middleware.RequestID assigned to the
+// request, or "" outside a request it handled.
+func RequestIDFromContext(ctx context.Context) string {
+	return middleware.RequestIDFromContext(ctx)
+}
+
+// errorResponse is the envelope of WriteError, for responses that add
+// fields of their own next to it.
+type errorResponse = middleware.ErrorEnvelope
+
+type flaggedResponse struct {
+	errorResponse
+	Categories []string `json:"categories"`
+	// Usage is what the flagged generations cost, if any were made.
+	Usage *usageResponse `json:"usage,omitempty"`
+}
+
+type quotaExceededResponse struct {
+	errorResponse
+	Quota quotaResponse `json:"quota"`
+}
+
+type duplicateResponse struct {
+	errorResponse
+	DuplicateOf uuid.UUID `json:"duplicate_of"`
+	Similarity  float64   `json:"similarity"`
+}
+
+// codeOf is the code of an error response with status, for responses that
+// do not choose one.
+func codeOf(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		return CodeValidation
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return CodeConflict
+	case http.StatusPaymentRequired:
+		return CodeQuotaExceeded
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusNotImplemented:
+		return CodeNotImplemented
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return CodeUpstreamAI
+	}
+	return CodeInternal
+}
+
+// respondError sends an error response with the code matching status.
+func respondError(w http.ResponseWriter, status int, message string) {
+	WriteError(w, status, codeOf(status), message)
+}
+
+// apiError is how an error returned by a service is shown to clients.
+type apiError struct {
+	status  int
+	code    ErrorCode
+	message string
+}
+
+// serviceErrors maps the errors services return to responses. Handlers
+// only special-case an error here when they can say more about it.
+var serviceErrors = []struct {
+	err error
+	apiError
+}{
+	{service.ErrPostNotFound, apiError{http.StatusNotFound, CodeNotFound, "Post not found"}},
+	{service.ErrVersionNotFound, apiError{http.StatusNotFound, CodeNotFound, "Version not found"}},
+	{service.ErrUserNotFound, apiError{http.StatusNotFound, CodeNotFound, "User not found"}},
+	{service.ErrAPIKeyNotFound, apiError{http.StatusNotFound, CodeNotFound, "API key not found"}},
+	{service.ErrUnknownTemplate, apiError{http.StatusBadRequest, CodeValidation, "Unknown template"}},
+	{service.ErrInvalidCursor, apiError{http.StatusBadRequest, CodeValidation, "Invalid cursor"}},
+	{service.ErrInvalidRating, apiError{http.StatusBadRequest, CodeValidation, "The 'rating' field must be 'up' or 'down'"}},
+	{service.ErrInvalidStatus, apiError{http.StatusBadRequest, CodeValidation, "The 'status' field must be 'draft' or 'final'"}},
+	{service.ErrPreconditionFailed, apiError{http.StatusPreconditionFailed, CodeConflict, "The post was changed since it was read; fetch it again and retry"}},
+	{service.ErrInvalidImageSize, apiError{http.StatusBadRequest, CodeValidation, "Unsupported image size"}},
+	{service.ErrBatchTooLarge, apiError{http.StatusBadRequest, CodeValidation, fmt.Sprintf("A batch can have at most %d items", service.MaxBatchSize)}},
+	{service.ErrInvalidRefreshToken, apiError{http.StatusUnauthorized, CodeUnauthorized, "Invalid refresh token"}},
+	{service.ErrRefreshTokenReused, apiError{http.StatusUnauthorized, CodeUnauthorized, "Invalid refresh token"}},
+	{service.ErrInvalidAccessToken, apiError{http.StatusUnauthorized, CodeUnauthorized, "Unauthorized"}},
+	{service.ErrInvalidResetToken, apiError{http.StatusBadRequest, CodeValidation, "Invalid or expired reset token"}},
+	{service.ErrWrongPassword, apiError{http.StatusForbidden, CodeForbidden, "Incorrect password"}},
+	{service.ErrTooManyAPIKeys, apiError{http.StatusConflict, CodeConflict, fmt.Sprintf("You already have %d API keys; revoke one first", service.MaxAPIKeys)}},
+	{service.ErrNotOrgOwner, apiError{http.StatusForbidden, CodeForbidden, "Only owners of the organization can do this"}},
+	{service.ErrPersonalOrg, apiError{http.StatusConflict, CodeConflict, "Personal organizations cannot have members; create an organization first"}},
+	{service.ErrAlreadyMember, apiError{http.StatusConflict, CodeConflict, "Already a member of this organization"}},
+	{service.ErrSoleOwner, apiError{http.StatusConflict, CodeConflict, "You are the only owner of your organization and cannot leave it while it has other members"}},
+	{service.ErrInvalidInvitation, apiError{http.StatusBadRequest, CodeValidation, "Invalid or expired invitation"}},
+	{service.ErrInvalidOAuthState, apiError{http.StatusBadRequest, CodeValidation, "Invalid or expired LinkedIn authorization; connect again with GET /api/v1/linkedin/connect"}},
+	{service.ErrLinkedInNotConnected, apiError{http.StatusConflict, CodeLinkedInNotConnected, "Connect your LinkedIn account first with GET /api/v1/linkedin/connect"}},
+	{service.ErrLinkedInReconnect, apiError{http.StatusConflict, CodeLinkedInNotConnected, "Your LinkedIn connection has expired or was revoked; connect it again with GET /api/v1/linkedin/connect"}},
+	{service.ErrAlreadyPublished, apiError{http.StatusConflict, CodeConflict, "This post has already been published to LinkedIn"}},
+	{service.ErrPublishInProgress, apiError{http.StatusConflict, CodeConflict, "This post is being published to LinkedIn right now"}},
+	{service.ErrInvalidScheduleTime, apiError{http.StatusBadRequest, CodeValidation, "The 'scheduled_at' field must be in the future and at most a year away"}},
+	{service.ErrIdempotencyKeyReused, apiError{http.StatusUnprocessableEntity, CodeConflict, "This Idempotency-Key was already used for a different request"}},
+	{service.ErrNoHashtags, apiError{http.StatusBadGateway, CodeUpstreamAI, "The AI did not suggest any hashtags, please try again"}},
+	{context.DeadlineExceeded, apiError{http.StatusGatewayTimeout, CodeUpstreamAI, "The AI took too long to respond, please try again"}},
+	{service.ErrImagesDisabled, apiError{http.StatusNotImplemented, CodeNotImplemented, "Image generation is not enabled on this server"}},
+	{service.ErrPublishingDisabled, apiError{http.StatusNotImplemented, CodeNotImplemented, "Publishing to LinkedIn is not enabled on this server"}},
+	{service.ErrAPIKeysUnsupported, apiError{http.StatusNotImplemented, CodeNotImplemented, "API keys are not enabled"}},
+	{service.ErrUsageUnsupported, apiError{http.StatusNotImplemented, CodeNotImplemented, "Usage tracking is not enabled"}},
+	{service.ErrStatsUnsupported, apiError{http.StatusNotImplemented, CodeNotImplemented, "Generation stats are not enabled"}},
+	{service.ErrRefreshUnsupported, apiError{http.StatusNotImplemented, CodeNotImplemented, "Refresh tokens are not enabled"}},
+	{service.ErrPasswordResetUnsupported, apiError{http.StatusNotImplemented, CodeNotImplemented, "Password reset is not enabled"}},
+	{service.ErrLogoutUnsupported, apiError{http.StatusNotImplemented, CodeNotImplemented, "Logout is not enabled"}},
+	{service.ErrAuditLogUnsupported, apiError{http.StatusNotImplemented, CodeNotImplemented, "The audit log is not enabled"}},
+	{service.ErrInvitationsUnsupported, apiError{http.StatusNotImplemented, CodeNotImplemented, "Invitations are not enabled"}},
+}
+
+// toAPIError looks err up in serviceErrors. A weak password is explained by
+// the error itself, and an AI provider's failure by its *ai.Error.
+func toAPIError(err error) (apiError, bool) {
+	if errors.Is(err, service.ErrWeakPassword) {
+		return apiError{http.StatusBadRequest, CodeValidation, err.Error()}, true
+	}
+	var aiErr *ai.Error
+	if errors.As(err, &aiErr) {
+		return aiAPIError(aiErr), true
+	}
+	for _, e := range serviceErrors {
+		if errors.Is(err, e.err) {
+			return e.apiError, true
+		}
+	}
+	return apiError{}, false
+}
+
+// aiAPIError is the response to a failure of the AI provider. The provider's
+// message, with API keys redacted, is passed on to help debugging; failures
+// that are ours to fix rather than the client's are answered with a 502.
+func aiAPIError(e *ai.Error) apiError {
+	switch e.Kind {
+	case ai.ErrorRateLimit:
+		return apiError{http.StatusTooManyRequests, CodeRateLimited, "The AI provider is rate limiting this server, please try again shortly: " + e.Message}
+	case ai.ErrorContextLength:
+		return apiError{http.StatusRequestEntityTooLarge, CodeContextLengthExceeded, "The input is too long for the model: " + e.Message}
+	case ai.ErrorContentFilter:
+		return apiError{http.StatusUnprocessableEntity, CodeContentFlagged, "The AI provider's content filter blocked this post: " + e.Message}
+	case ai.ErrorAuth:
+		slog.Error("the AI provider rejected our credentials", "provider", e.Provider, "status", e.StatusCode, "error", e.Message)
+		return apiError{http.StatusBadGateway, CodeUpstreamAI, "The AI provider rejected this server's credentials: " + e.Message}
+	}
+	return apiError{http.StatusBadGateway, CodeUpstreamAI, "The AI provider failed, please try again: " + e.Message}
+}
+
+// respondServiceError sends the response for err, returned by a service.
+// Errors unknown to serviceErrors are logged and answered with a 500 saying
+// failure, without revealing what went wrong.
+func respondServiceError(w http.ResponseWriter, r *http.Request, err error, failure string) {
+	if respondFlagged(w, err) || respondQuotaExceeded(w, err) || respondDuplicate(w, err) {
+		return
+	}
+	if e, ok := toAPIError(err); ok {
+		WriteError(w, e.status, e.code, e.message)
+		return
+	}
+	slog.ErrorContext(r.Context(), "request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+	WriteError(w, http.StatusInternalServerError, CodeInternal, failure)
+}
+
+const (
+	flaggedMessage       = "The generated post was flagged by content moderation"
+	inputRejectedMessage = "The text was rejected by the content filter; rephrase it and try again"
+)
+
+// respondFlagged writes a 422 listing the moderation categories if err is a
+// *service.ModerationError or a *service.InputRejectedError, and reports
+// whether it did.
+func respondFlagged(w http.ResponseWriter, err error) bool {
+	message, categories, ok := flaggedDetails(err)
+	if !ok {
+		return false
+	}
+	respondJSON(w, http.StatusUnprocessableEntity, flaggedResponse{
+		errorResponse: middleware.NewErrorEnvelope(w, CodeContentFlagged, message),
+		Categories:    categories,
+		Usage:         flaggedUsage(err),
+	})
+	return true
+}
+
+// flaggedUsage returns the usage of the generations behind a
+// *service.ModerationError, or nil when err is not one or none were made.
+func flaggedUsage(err error) *usageResponse {
+	var flagged *service.ModerationError
+	if !errors.As(err, &flagged) || flagged.Usage == (ai.Usage{}) {
+		return nil
+	}
+	u := toUsageResponse(flagged.Usage, flagged.Model)
+	return &u
+}
+
+// flaggedDetails returns the message and categories of a rejected post or
+// text, and whether err is one.
+func flaggedDetails(err error) (message string, categories []string, ok bool) {
+	var flagged *service.ModerationError
+	if errors.As(err, &flagged) {
+		return flaggedMessage, flagged.Categories, true
+	}
+	var rejected *service.InputRejectedError
+	if errors.As(err, &rejected) {
+		return inputRejectedMessage, rejected.Categories, true
+	}
+	return "", nil, false
+}
+
+const quotaExceededMessage = "You have used this month's generations; the quota resets at the start of next month (UTC)"
+
+// respondQuotaExceeded writes a 402 with the user's quota if err is a
+// *service.QuotaError, and reports whether it did.
+func respondQuotaExceeded(w http.ResponseWriter, err error) bool {
+	var exceeded *service.QuotaError
+	if !errors.As(err, &exceeded) {
+		return false
+	}
+	respondJSON(w, http.StatusPaymentRequired, quotaExceededResponse{
+		errorResponse: middleware.NewErrorEnvelope(w, CodeQuotaExceeded, quotaExceededMessage),
+		Quota:         toQuotaResponse(exceeded.Usage),
+	})
+	return true
+}
+
+const duplicateMessage = "This post is nearly identical to one you generated recently; send \"force\": true to save it anyway"
+
+// respondDuplicate writes a 409 naming the recent post if err is a
+// *service.DuplicateError, and reports whether it did.
+func respondDuplicate(w http.ResponseWriter, err error) bool {
+	var dup *service.DuplicateError
+	if !errors.As(err, &dup) {
+		return false
+	}
+	respondJSON(w, http.StatusConflict, duplicateResponse{
+		errorResponse: middleware.NewErrorEnvelope(w, CodeConflict, duplicateMessage),
+		DuplicateOf:   dup.PostID,
+		Similarity:    dup.Similarity,
+	})
+	return true
+}
+
+// NotFound answers requests to routes that do not exist.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, http.StatusNotFound, CodeNotFound, "Not found")
+}
+
+// MethodNotAllowed answers requests to a route with a method it does not
+// serve.
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, http.StatusMethodNotAllowed, CodeValidation, "Method not allowed")
+}
@@ -0,0 +1,79 @@
+// internal/handler/errors_test.go
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestServiceErrors(t *testing.T) {
+	testSecret := []byte("your-test-jwt-secret")
+	var serviceErr error
+	mockService := &service.LinkedInServiceInteractorMock{
+		VersionsFunc: func(ctx context.Context, userID, postID uuid.UUID) ([]model.PostVersion, error) {
+			return nil, serviceErr
+		},
+	}
+	server := httptest.NewServer(middleware.RequestID(handler.NewLinkedIn(mockService).Routes(testSecret)))
+	defer server.Close()
+	authToken := generateTestToken(t, uuid.New(), testSecret)
+
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    handler.ErrorCode
+		wantMessage string
+	}{
+		{"not found", service.ErrPostNotFound, http.StatusNotFound, handler.CodeNotFound, "Post not found"},
+		{"wrapped", fmt.Errorf("loading post: %w", service.ErrPostNotFound), http.StatusNotFound, handler.CodeNotFound, "Post not found"},
+		{"upstream ai", service.ErrNoHashtags, http.StatusBadGateway, handler.CodeUpstreamAI, "The AI did not suggest any hashtags, please try again"},
+		{"ai timeout", context.DeadlineExceeded, http.StatusGatewayTimeout, handler.CodeUpstreamAI, "The AI took too long to respond, please try again"},
+		{"ai server error", &ai.Error{Kind: ai.ErrorServer, Message: "The server had an error"}, http.StatusBadGateway, handler.CodeUpstreamAI, "The AI provider failed, please try again: The server had an error"},
+		{"ai rate limit", fmt.Errorf("generating: %w", &ai.Error{Kind: ai.ErrorRateLimit, Message: "Rate limit reached"}), http.StatusTooManyRequests, handler.CodeRateLimited, "The AI provider is rate limiting this server, please try again shortly: Rate limit reached"},
+		{"ai context length", &ai.Error{Kind: ai.ErrorContextLength, Message: "Too many tokens"}, http.StatusRequestEntityTooLarge, handler.CodeContextLengthExceeded, "The input is too long for the model: Too many tokens"},
+		{"ai content filter", &ai.Error{Kind: ai.ErrorContentFilter, Message: "Filtered"}, http.StatusUnprocessableEntity, handler.CodeContentFlagged, "The AI provider's content filter blocked this post: Filtered"},
+		{"ai auth", &ai.Error{Kind: ai.ErrorAuth, Message: "Incorrect API key provided: sk-[redacted]"}, http.StatusBadGateway, handler.CodeUpstreamAI, "The AI provider rejected this server's credentials: Incorrect API key provided: sk-[redacted]"},
+		{"unknown", errors.New("connection refused"), http.StatusInternalServerError, handler.CodeInternal, "Failed to retrieve versions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceErr = tt.err
+			req, _ := http.NewRequest(http.MethodGet, server.URL+"/"+uuid.NewString()+"/versions", nil)
+			req.Header.Set("Authorization", "Bearer "+authToken)
+			req.Header.Set(middleware.RequestIDHeader, "req-42")
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			var body middleware.ErrorEnvelope
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, middleware.ErrorDetail{Code: tt.wantCode, Message: tt.wantMessage, RequestID: "req-42"}, body.Error)
+		})
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	rr := httptest.NewRecorder()
+	handler.NotFound(rr, httptest.NewRequest(http.MethodGet, "/nope", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	var body middleware.ErrorEnvelope
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	assert.Equal(t, handler.CodeNotFound, body.Error.Code)
+}
@@ -0,0 +1,211 @@
+// internal/handler/export.go
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+)
+
+// exportItem is a post as exported, with the metadata the listing endpoints
+// leave out.
+type exportItem struct {
+	ID        uuid.UUID  `json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+	Status    string     `json:"status"`
+	Source    string     `json:"source"`
+	Input     string     `json:"input"`
+	Post      string     `json:"post"`
+	Template  string     `json:"template"`
+	Tone      string     `json:"tone"`
+	Length    string     `json:"length"`
+	Language  string     `json:"language"`
+	Model     string     `json:"model"`
+	ImageURL  string     `json:"image_url"`
+	Favorited bool       `json:"favorited"`
+}
+
+func toExportItem(p model.LinkedInPost) exportItem {
+	item := exportItem{
+		ID:        p.ID,
+		CreatedAt: p.CreatedAt,
+		Status:    p.Status,
+		Source:    p.Source,
+		Input:     p.InputText,
+		Post:      p.OutputText,
+		Template:  p.Template,
+		Tone:      p.Tone,
+		Length:    p.Length,
+		Language:  p.Language,
+		Model:     p.Model,
+		ImageURL:  p.ImageURL,
+		Favorited: p.Favorited,
+	}
+	if !p.UpdatedAt.IsZero() {
+		item.UpdatedAt = &p.UpdatedAt
+	}
+	return item
+}
+
+// exportColumns is the CSV header; each row follows exportItem's fields.
+var exportColumns = []string{
+	"id", "created_at", "updated_at", "status", "source", "input", "post",
+	"template", "tone", "length", "language", "model", "image_url", "favorited",
+}
+
+func (e exportItem) csvRecord() []string {
+	updated := ""
+	if e.UpdatedAt != nil {
+		updated = e.UpdatedAt.Format(time.RFC3339)
+	}
+	record := []string{
+		e.ID.String(), e.CreatedAt.Format(time.RFC3339), updated, e.Status, e.Source, e.Input, e.Post,
+		e.Template, e.Tone, e.Length, e.Language, e.Model, e.ImageURL, strconv.FormatBool(e.Favorited),
+	}
+	for i, cell := range record {
+		record[i] = csvSafe(cell)
+	}
+	return record
+}
+
+// csvSafe defuses a cell that a spreadsheet would run as a formula, one
+// starting with =, +, -, @, a tab or a carriage return, by prefixing it with
+// a single quote. Posts and their input are written by users, and a post
+// beginning with "=HYPERLINK(...)" must open as text.
+func csvSafe(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// postExporter writes posts one at a time in an export format.
+type postExporter interface {
+	Write(p model.LinkedInPost) error
+	// Close completes the export; it must be called even if no post was
+	// written.
+	Close() error
+}
+
+type csvExporter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (e *csvExporter) Write(p model.LinkedInPost) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	return e.w.Write(toExportItem(p).csvRecord())
+}
+
+func (e *csvExporter) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// writeHeader writes the header row unless it has been written already.
+func (e *csvExporter) writeHeader() error {
+	if e.header {
+		return nil
+	}
+	e.header = true
+	return e.w.Write(exportColumns)
+}
+
+// jsonExporter writes a JSON array, one post per line.
+type jsonExporter struct {
+	w io.Writer
+	n int
+}
+
+func (e *jsonExporter) Write(p model.LinkedInPost) error {
+	data, err := json.Marshal(toExportItem(p))
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if e.n == 0 {
+		sep = "[\n"
+	}
+	e.n++
+	if _, err := io.WriteString(e.w, sep); err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonExporter) Close() error {
+	end := "\n]\n"
+	if e.n == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+// export streams all of the user's posts, except deleted ones, as a CSV or
+// JSON download. Posts are written as they are read from the database, so
+// once the first one is sent a failure can only cut the download short; it
+// is logged, and a JSON export is left without its closing bracket.
+func (h *LinkedInHandler) export(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	var exp postExporter
+	var contentType string
+	switch format {
+	case "csv":
+		exp, contentType = &csvExporter{w: csv.NewWriter(w)}, "text/csv; charset=utf-8"
+	case "json":
+		exp, contentType = &jsonExporter{w: w}, "application/json"
+	default:
+		respondError(w, http.StatusBadRequest, "The 'format' parameter must be 'csv' or 'json'")
+		return
+	}
+
+	uid := middleware.UserID(r.Context())
+	started := false
+	err := h.svc.Export(r.Context(), uid, func(p model.LinkedInPost) error {
+		if !started {
+			started = true
+			setExportHeaders(w, contentType, format)
+		}
+		return exp.Write(p)
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "export failed", "error", err)
+		if !started {
+			respondError(w, http.StatusInternalServerError, "Failed to export posts")
+		}
+		return
+	}
+	if !started {
+		setExportHeaders(w, contentType, format)
+	}
+	if err := exp.Close(); err != nil {
+		slog.ErrorContext(r.Context(), "export failed", "error", err)
+	}
+}
+
+func setExportHeaders(w http.ResponseWriter, contentType, format string) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="linkedinify-posts-%s.%s"`, time.Now().UTC().Format("2006-01-02"), format))
+}
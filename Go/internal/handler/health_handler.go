@@ -0,0 +1,66 @@
+// internal/handler/health_handler.go
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// probeTimeout bounds each readiness check so a hung dependency fails the
+// probe instead of stalling it.
+const probeTimeout = 2 * time.Second
+
+// HealthCheck is a named readiness dependency. Check returns nil when the
+// dependency is usable.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	checks []HealthCheck
+}
+
+func NewHealth(checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+// Healthz reports that the process is alive. It never touches dependencies,
+// so a database outage does not get the process restarted.
+func (h *HealthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+type readyResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+	Failed []string          `json:"failed,omitempty"`
+}
+
+// Readyz runs every check and responds 503 naming the failed ones if any
+// fails. Why a check failed is logged rather than sent.
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	res := readyResponse{Status: "ok", Checks: make(map[string]string, len(h.checks))}
+	for _, c := range h.checks {
+		ctx, cancel := context.WithTimeout(r.Context(), probeTimeout)
+		err := c.Check(ctx)
+		cancel()
+		if err != nil {
+			// The probe is unauthenticated, so what failed is only logged.
+			slog.ErrorContext(r.Context(), "readiness check failed", "check", c.Name, "error", err)
+			res.Checks[c.Name] = "unavailable"
+			res.Failed = append(res.Failed, c.Name)
+			continue
+		}
+		res.Checks[c.Name] = "ok"
+	}
+
+	status := http.StatusOK
+	if len(res.Failed) > 0 {
+		res.Status = "unavailable"
+		status = http.StatusServiceUnavailable
+	}
+	respondJSON(w, status, res)
+}
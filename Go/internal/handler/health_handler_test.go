@@ -0,0 +1,41 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+)
+
+func TestHealthHandler_Readyz(t *testing.T) {
+	ok := handler.HealthCheck{Name: "ai", Check: func(ctx context.Context) error { return nil }}
+	failing := handler.HealthCheck{Name: "database", Check: func(ctx context.Context) error { return errors.New("connection refused") }}
+
+	rr := httptest.NewRecorder()
+	handler.NewHealth(ok).Readyz(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.NewHealth(ok, failing).Readyz(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	assert.Equal(t, []interface{}{"database"}, body["failed"])
+	assert.Equal(t, "unavailable", body["checks"].(map[string]interface{})["database"])
+}
+
+func TestHealthHandler_Healthz_IgnoresDependencies(t *testing.T) {
+	failing := handler.HealthCheck{Name: "database", Check: func(ctx context.Context) error { return errors.New("down") }}
+
+	rr := httptest.NewRecorder()
+	handler.NewHealth(failing).Healthz(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
@@ -0,0 +1,129 @@
+// internal/handler/linkedin_account_handler.go
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// LinkedInAccountHandler connects users' LinkedIn accounts, so their posts
+// can be published with POST /posts/{id}/publish.
+type LinkedInAccountHandler struct {
+	svc service.LinkedInServiceInteractor
+	// cookiePath and cookieSecure scope the nonce cookie; see
+	// WithCallbackURL.
+	cookiePath   string
+	cookieSecure bool
+}
+
+// LinkedInAccountOption configures a LinkedInAccountHandler.
+type LinkedInAccountOption func(*LinkedInAccountHandler)
+
+// WithCallbackURL scopes the nonce cookie to callbackURL, the redirect URL
+// registered with LinkedIn: browsers only send it to that path, and only
+// over HTTPS when callbackURL is an HTTPS URL. Without it the cookie is sent
+// to every path, over HTTP too.
+func WithCallbackURL(callbackURL string) LinkedInAccountOption {
+	return func(h *LinkedInAccountHandler) {
+		u, err := url.Parse(callbackURL)
+		if err != nil || u.Path == "" {
+			return
+		}
+		h.cookiePath = u.Path
+		h.cookieSecure = u.Scheme == "https"
+	}
+}
+
+func NewLinkedInAccount(svc service.LinkedInServiceInteractor, opts ...LinkedInAccountOption) *LinkedInAccountHandler {
+	h := &LinkedInAccountHandler{svc: svc, cookiePath: "/"}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// oauthNonceCookie keeps the nonce of an authorization in the browser that
+// asked to connect, so the callback only completes it in that browser.
+const oauthNonceCookie = "linkedinify_oauth_nonce"
+
+func (h *LinkedInAccountHandler) Routes(secret []byte, opts ...middleware.AuthOption) chi.Router {
+	r := chi.NewRouter()
+	r.With(middleware.Auth(secret, opts...)).Get("/connect", h.connect)
+	// LinkedIn sends the user's browser here without our credentials; the
+	// state tells whose account it is, and the nonce cookie that it is the
+	// browser which asked to connect.
+	r.Get("/callback", h.callback)
+	return r
+}
+
+type connectResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type connectionResponse struct {
+	Connected bool      `json:"connected"`
+	MemberURN string    `json:"member_urn"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// connect returns the LinkedIn page where the user authorizes publishing.
+// Clients send the user there; LinkedIn then redirects to the callback.
+func (h *LinkedInAccountHandler) connect(w http.ResponseWriter, r *http.Request) {
+	url, nonce, err := h.svc.ConnectURL(r.Context(), middleware.UserID(r.Context()))
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to connect LinkedIn")
+		return
+	}
+	http.SetCookie(w, h.nonceCookie(nonce, int(service.OAuthStateTTL/time.Second)))
+	respondJSON(w, http.StatusOK, connectResponse{AuthorizationURL: url})
+}
+
+// nonceCookie is the oauthNonceCookie holding nonce for maxAge seconds; a
+// negative maxAge deletes it. It is sent on LinkedIn's redirect to the
+// callback, a top-level navigation, but not on requests other sites make.
+func (h *LinkedInAccountHandler) nonceCookie(nonce string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oauthNonceCookie,
+		Value:    nonce,
+		Path:     h.cookiePath,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   h.cookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// callback completes the authorization LinkedIn redirected back from.
+func (h *LinkedInAccountHandler) callback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("error") != "" {
+		respondError(w, http.StatusBadRequest, "LinkedIn authorization was not granted")
+		return
+	}
+	state, code := q.Get("state"), q.Get("code")
+	if state == "" || code == "" {
+		respondError(w, http.StatusBadRequest, "The 'state' and 'code' query parameters are required")
+		return
+	}
+
+	// A missing cookie leaves the nonce empty, which no state is issued
+	// with. The cookie has done its job either way.
+	var nonce string
+	if cookie, err := r.Cookie(oauthNonceCookie); err == nil {
+		nonce = cookie.Value
+	}
+	http.SetCookie(w, h.nonceCookie("", -1))
+
+	c, err := h.svc.Connect(r.Context(), state, nonce, code)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to connect LinkedIn")
+		return
+	}
+	respondJSON(w, http.StatusOK, connectionResponse{Connected: true, MemberURN: c.MemberURN, ExpiresAt: c.ExpiresAt})
+}
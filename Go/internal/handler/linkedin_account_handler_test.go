@@ -0,0 +1,91 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestLinkedInAccountHandler(t *testing.T) {
+	userID := uuid.New()
+	mockService := &service.LinkedInServiceInteractorMock{
+		ConnectURLFunc: func(ctx context.Context, id uuid.UUID) (string, string, error) {
+			return "https://www.linkedin.com/oauth/v2/authorization?state=signed", "nonce", nil
+		},
+		ConnectFunc: func(ctx context.Context, state, nonce, code string) (*model.LinkedInConnection, error) {
+			if state != "signed" || nonce != "nonce" {
+				return nil, service.ErrInvalidOAuthState
+			}
+			return &model.LinkedInConnection{UserID: userID, MemberURN: "urn:li:person:abc", AccessToken: "secret", ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	h := handler.NewLinkedInAccount(mockService, handler.WithCallbackURL("https://api.example.com/api/v1/linkedin/callback"))
+	server := httptest.NewServer(h.Routes(testSecret))
+	defer server.Close()
+
+	var nonceCookie *http.Cookie
+	get := func(path, token string) (int, map[string]interface{}) {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if nonceCookie != nil {
+			req.AddCookie(nonceCookie)
+		}
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		for _, c := range resp.Cookies() {
+			if c.Name == "linkedinify_oauth_nonce" {
+				nonceCookie = c
+			}
+		}
+		var out map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		return resp.StatusCode, out
+	}
+
+	status, _ := get("/connect", "")
+	assert.Equal(t, http.StatusUnauthorized, status, "Connecting needs a logged in user")
+	assert.Nil(t, nonceCookie)
+	status, body := get("/connect", generateTestToken(t, userID, testSecret))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "https://www.linkedin.com/oauth/v2/authorization?state=signed", body["authorization_url"])
+	assert.Equal(t, userID, mockService.ConnectURLCalls()[0].UserID)
+	require.NotNil(t, nonceCookie, "The nonce is kept in the browser asking to connect")
+	assert.Equal(t, "nonce", nonceCookie.Value)
+	assert.True(t, nonceCookie.HttpOnly)
+	assert.True(t, nonceCookie.Secure, "The callback URL is HTTPS")
+	assert.Equal(t, http.SameSiteLaxMode, nonceCookie.SameSite)
+	assert.Equal(t, "/api/v1/linkedin/callback", nonceCookie.Path)
+	assert.Equal(t, int(service.OAuthStateTTL/time.Second), nonceCookie.MaxAge)
+
+	status, body = get("/callback?state=signed&code=abc", "")
+	require.Equal(t, http.StatusOK, status, "LinkedIn redirects without our token")
+	assert.Equal(t, true, body["connected"])
+	assert.Equal(t, "urn:li:person:abc", body["member_urn"])
+	assert.NotContains(t, body, "access_token")
+	assert.Equal(t, "nonce", mockService.ConnectCalls()[0].Nonce)
+	assert.Negative(t, nonceCookie.MaxAge, "The callback deletes the cookie")
+
+	nonceCookie = nil
+	for _, query := range []string{"?state=signed&code=abc", "?state=forged&code=abc", "?state=signed", "?error=user_cancelled_authorize&state=signed"} {
+		status, body = get("/callback"+query, "")
+		assert.Equal(t, http.StatusBadRequest, status, query)
+		assert.Equal(t, "validation", body["error"].(map[string]interface{})["code"], query)
+	}
+	assert.Empty(t, mockService.ConnectCalls()[1].Nonce, "Without the cookie there is no nonce")
+	assert.Len(t, mockService.ConnectCalls(), 3)
+}
@@ -2,93 +2,1156 @@
 package handler
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/microcosm-cc/bluemonday"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
+	"github.com/you/linkedinify/api"
+	"github.com/you/linkedinify/internal/ai"
 	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
 	"github.com/you/linkedinify/internal/service"
 )
 
 type LinkedInHandler struct {
-	svc service.LinkedInServiceInteractor
+	svc       service.LinkedInServiceInteractor
+	limiter   middleware.RateLimitStore
+	limitOpts []middleware.RateLimitOption
+
+	requestTimeout time.Duration
+	longTimeout    time.Duration
+	provider       string
+}
+
+// LinkedInOption configures optional LinkedInHandler behaviour.
+type LinkedInOption func(*LinkedInHandler)
+
+// WithRateLimit limits how often each user can generate posts. Reading
+// history is not limited.
+func WithRateLimit(store middleware.RateLimitStore, opts ...middleware.RateLimitOption) LinkedInOption {
+	return func(h *LinkedInHandler) { h.limiter, h.limitOpts = store, opts }
+}
+
+// WithTimeouts bounds how long a request may take before it is cancelled
+// with a 504. Streams, batches and exports legitimately run longer and get
+// long instead; images are bounded by their own ImageOptions.Timeout. Zero
+// disables a timeout.
+func WithTimeouts(request, long time.Duration) LinkedInOption {
+	return func(h *LinkedInHandler) { h.requestTimeout, h.longTimeout = request, long }
 }
 
-func NewLinkedIn(svc service.LinkedInServiceInteractor) *LinkedInHandler {
-	return &LinkedInHandler{svc: svc}
+// WithProvider rejects requested models that the configured AI provider
+// does not serve, such as a Claude model on an OpenAI deployment, with a 400
+// instead of letting the provider fail. ai.ProviderMock serves every model.
+func WithProvider(provider string) LinkedInOption {
+	return func(h *LinkedInHandler) { h.provider = provider }
 }
 
-func (h *LinkedInHandler) Routes(secret []byte) chi.Router {
+func NewLinkedIn(svc service.LinkedInServiceInteractor, opts ...LinkedInOption) *LinkedInHandler {
+	h := &LinkedInHandler{svc: svc}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *LinkedInHandler) Routes(secret []byte, opts ...middleware.AuthOption) chi.Router {
 	r := chi.NewRouter()
-	r.Use(middleware.Auth(secret))
-	r.Post("/", h.transform)
-	r.Get("/", h.history)
+	r.Use(middleware.Auth(secret, opts...))
+	timeout := middleware.Timeout(h.requestTimeout)
+	longTimeout := middleware.Timeout(h.longTimeout)
+	r.Group(func(r chi.Router) {
+		if h.limiter != nil {
+			r.Use(middleware.RateLimit(h.limiter, h.limitOpts...))
+		}
+		r.With(longTimeout).Post("/stream", h.transformStream)
+		r.With(timeout).Post("/{id}/regenerate", h.regenerate)
+		r.Post("/{id}/image", h.image)
+		r.With(timeout).Post("/{id}/hashtags", h.hashtags)
+	})
+	// Transforms charge the rate limit themselves, so dry runs are free, and
+	// batches charge it once per item.
+	r.With(timeout).Post("/", h.transform)
+	r.With(longTimeout).Post("/batch", h.transformBatch)
+	r.With(longTimeout).Get("/export", h.export)
+	r.Group(func(r chi.Router) {
+		r.Use(timeout)
+		r.Get("/", h.history)
+		r.Get("/search", h.search)
+		r.Get("/{id}", h.get)
+		r.Patch("/{id}", h.update)
+		r.Delete("/{id}", h.delete)
+		r.Post("/{id}/restore", h.restore)
+		r.Post("/{id}/favorite", h.favorite)
+		r.Delete("/{id}/favorite", h.unfavorite)
+		r.Post("/{id}/feedback", h.feedback)
+		r.Post("/{id}/publish", h.publish)
+		r.Post("/{id}/schedule", h.schedule)
+		r.Get("/{id}/versions", h.versions)
+		r.Post("/{id}/versions/{versionID}/restore", h.restoreVersion)
+	})
 	return r
 }
 
+// reqBody is the body of the transform endpoints; see api.GenerateRequest.
 type reqBody struct {
-	Text string `json:"text"`
+	api.GenerateRequest
+}
+
+// validateSampling returns a client-facing message when a sampling parameter
+// is out of range.
+func validateSampling(b api.Sampling) string {
+	if b.Temperature != nil && !service.ValidTemperature(*b.Temperature) {
+		return fmt.Sprintf("The 'temperature' field must be between 0 and %g", service.MaxTemperature)
+	}
+	if b.TopP != nil && !service.ValidTopP(*b.TopP) {
+		return fmt.Sprintf("The 'top_p' field must be between 0 and %g", service.MaxTopP)
+	}
+	return ""
+}
+
+// transformBody is the body of transform. DryRun renders the prompt without
+// generating a post.
+type transformBody struct {
+	reqBody
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// dryRunResponse is the prompt a dry run would have sent to the model.
+type dryRunResponse struct {
+	DryRun bool   `json:"dry_run"`
+	Prompt string `json:"prompt"`
+}
+
+type usageResponse = api.Usage
+
+// toUsageResponse is the usage of a generation with model, priced.
+func toUsageResponse(u ai.Usage, model string) usageResponse {
+	return usageResponse{
+		PromptTokens:     u.PromptTokens,
+		CompletionTokens: u.CompletionTokens,
+		TotalTokens:      u.TotalTokens,
+		Model:            model,
+		EstimatedCostUSD: ai.EstimateCost(u, model),
+	}
+}
+
+type transformResponse = api.GeneratedPost
+
+// validate checks the parts of the body shared by every transform endpoint
+// and returns a client-facing message when something is wrong. A model must
+// be served by provider unless provider is empty or ai.ProviderMock.
+func (b reqBody) validate(provider string) string {
+	if b.Text == "" {
+		return "The 'text' field is required"
+	}
+	if b.Model != "" && !ai.IsKnownModel(b.Model) {
+		return "Unsupported model: " + b.Model
+	}
+	if b.Model != "" && provider != "" && provider != ai.ProviderMock && ai.ModelProvider(b.Model) != provider {
+		return "Unsupported model: " + b.Model + " is not served by the " + provider + " provider of this server"
+	}
+	if !service.Tone(b.Tone).Valid() {
+		return "Unsupported tone: " + b.Tone + " (expected one of " + service.ToneNames + ")"
+	}
+	if !service.Length(b.Length).Valid() {
+		return "Unsupported length: " + b.Length + " (expected one of " + service.LengthNames + ")"
+	}
+	if !service.ValidLanguage(b.language()) {
+		return "Unsupported language: " + b.Language + " (expected one of " + service.LanguageNames + ")"
+	}
+	return validateSampling(b.Sampling)
+}
+
+func (b reqBody) options() service.TransformOptions {
+	return service.TransformOptions{
+		Model:           b.Model,
+		Template:        b.Template,
+		Tone:            service.Tone(b.Tone),
+		Length:          service.Length(b.Length),
+		Language:        b.language(),
+		IncludeHashtags: b.IncludeHashtags,
+		WithoutProfile:  b.UseProfile != nil && !*b.UseProfile,
+		Temperature:     b.Temperature,
+		TopP:            b.TopP,
+		Force:           b.Force,
+	}
 }
 
+// language is the requested language code; codes are case-insensitive.
+func (b reqBody) language() string {
+	return strings.ToLower(strings.TrimSpace(b.Language))
+}
+
+// transform generates a post. A dry run instead responds with the prompt the
+// model would have been sent, which is neither rate limited nor counted
+// towards the quota, and neither is a replayed idempotency key.
 func (h *LinkedInHandler) transform(w http.ResponseWriter, r *http.Request) {
+	var in transformBody
+	if err := decodeJSON(r, &in); err != nil {
+		respondDecodeError(w, err)
+		return
+	}
+	if msg := in.validate(h.provider); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	opts := in.options()
+	opts.IdempotencyKey = r.Header.Get(idempotencyKeyHeader)
+	if len(opts.IdempotencyKey) > maxIdempotencyKeyLength {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("The %s header must be at most %d characters", idempotencyKeyHeader, maxIdempotencyKeyLength))
+		return
+	}
+
+	p := bluemonday.StrictPolicy()
+	sanitizedText := p.Sanitize(in.Text)
+	uid := middleware.UserID(r.Context())
+	if in.DryRun {
+		h.dryRun(w, r, uid, sanitizedText, in.Template, opts)
+		return
+	}
+	var out *service.TransformResult
+	var err error
+	if opts.IdempotencyKey != "" {
+		out, err = h.svc.Replay(r.Context(), uid, sanitizedText, opts)
+	}
+	if err == nil && out == nil {
+		if ok, retryAfter := h.allow(w, r, uid); !ok {
+			middleware.TooManyRequests(w, retryAfter)
+			return
+		}
+		out, err = h.svc.Transform(r.Context(), uid, sanitizedText, opts)
+	}
+	if errors.Is(err, service.ErrUnknownTemplate) {
+		respondError(w, http.StatusBadRequest, "Unknown template: "+in.Template)
+		return
+	}
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to transform text")
+		return
+	}
+	setCacheHeader(w, out.Cached)
+	if out.Replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	}
+	respondJSON(w, http.StatusCreated, transformResponse{
+		ID:        out.PostID,
+		Post:      out.Post,
+		Usage:     toUsageResponse(out.Usage, out.Model),
+		Truncated: out.Truncated,
+	})
+}
+
+func (h *LinkedInHandler) dryRun(w http.ResponseWriter, r *http.Request, uid uuid.UUID, text, template string, opts service.TransformOptions) {
+	prompt, err := h.svc.PreviewPrompt(r.Context(), uid, text, opts)
+	if errors.Is(err, service.ErrUnknownTemplate) {
+		respondError(w, http.StatusBadRequest, "Unknown template: "+template)
+		return
+	}
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to render prompt")
+		return
+	}
+	respondJSON(w, http.StatusOK, dryRunResponse{DryRun: true, Prompt: prompt})
+}
+
+type batchBody struct {
+	Items []reqBody `json:"items"`
+}
+
+// batchItemResponse is the outcome of one batch item. Status is the HTTP
+// status the item would have had on its own; on failure Error says why.
+type batchItemResponse struct {
+	Status     int             `json:"status"`
+	ID         *uuid.UUID      `json:"id,omitempty"`
+	Post       string          `json:"post,omitempty"`
+	Usage      *usageResponse  `json:"usage,omitempty"`
+	Truncated  bool            `json:"truncated,omitempty"`
+	Error      *batchItemError `json:"error,omitempty"`
+	Categories []string        `json:"categories,omitempty"`
+	Quota      *quotaResponse  `json:"quota,omitempty"`
+	// DuplicateOf is the recent post a rejected duplicate is nearly
+	// identical to.
+	DuplicateOf *uuid.UUID `json:"duplicate_of,omitempty"`
+}
+
+// batchItemError is the error of one batch item. The request ID is that of
+// the whole batch, so it is left out.
+type batchItemError struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// batchResponse counts the items that succeeded and failed; Abandoned are
+// the failed items the batch ran out of time for, each with status 504.
+type batchResponse struct {
+	Results   []batchItemResponse `json:"results"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Abandoned int                 `json:"abandoned"`
+}
+
+// batchResponseMargin is how long before the request deadline a batch stops
+// generating, so the items done by then can still be sent.
+const batchResponseMargin = time.Second
+
+const abandonedMessage = "The batch ran out of time before this item was done"
+
+// transformBatch generates a post for each item of the body, which takes the
+// same fields as transform. Every item counts towards the rate limit; items
+// over it fail with a 429 of their own, and so do any other failures, so one
+// bad item does not fail the batch. Only when no item is allowed at all is
+// the whole request rejected. A batch running out of time responds with the
+// items done by then.
+func (h *LinkedInHandler) transformBatch(w http.ResponseWriter, r *http.Request) {
+	var in batchBody
+	if err := decodeJSON(r, &in); err != nil {
+		respondDecodeError(w, err)
+		return
+	}
+	switch {
+	case len(in.Items) == 0:
+		respondError(w, http.StatusBadRequest, "The 'items' field must list at least one post")
+		return
+	case len(in.Items) > service.MaxBatchSize:
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("A batch can have at most %d items", service.MaxBatchSize))
+		return
+	}
+	for i, item := range in.Items {
+		if msg := item.validate(h.provider); msg != "" {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("items[%d]: %s", i, msg))
+			return
+		}
+	}
+
+	uid := middleware.UserID(r.Context())
+	results := make([]batchItemResponse, len(in.Items))
+	var items []service.BatchItem
+	var indexes []int // index in in.Items of each of items
+	p := bluemonday.StrictPolicy()
+	for i, item := range in.Items {
+		if ok, retryAfter := h.allow(w, r, uid); !ok {
+			if len(items) == 0 {
+				middleware.TooManyRequests(w, retryAfter)
+				return
+			}
+			results[i] = batchItemResponse{Status: http.StatusTooManyRequests, Error: &batchItemError{CodeRateLimited, "Rate limit exceeded"}}
+			continue
+		}
+		items = append(items, service.BatchItem{Text: p.Sanitize(item.Text), Options: item.options()})
+		indexes = append(indexes, i)
+	}
+
+	ctx := r.Context()
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline.Add(-batchResponseMargin))
+		defer cancel()
+	}
+	out, err := h.svc.TransformBatch(ctx, uid, items)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to transform batch")
+		return
+	}
+	for j, res := range out {
+		i := indexes[j]
+		results[i] = h.batchItemResult(r, uid, in.Items[i], res)
+	}
+
+	resp := batchResponse{Results: results}
+	for _, res := range results {
+		switch {
+		case res.Error == nil:
+			resp.Succeeded++
+		case res.Error.Code == CodeTimeout:
+			resp.Abandoned++
+			fallthrough
+		default:
+			resp.Failed++
+		}
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// batchItemResult describes the outcome of one batch item the way transform
+// would have responded to it.
+func (h *LinkedInHandler) batchItemResult(r *http.Request, uid uuid.UUID, in reqBody, res service.BatchResult) batchItemResponse {
+	var exceeded *service.QuotaError
+	var dup *service.DuplicateError
+	switch {
+	case res.Err == nil:
+		out := res.Result
+		usage := toUsageResponse(out.Usage, out.Model)
+		return batchItemResponse{
+			Status:    http.StatusCreated,
+			ID:        &out.PostID,
+			Post:      out.Post,
+			Usage:     &usage,
+			Truncated: out.Truncated,
+		}
+	case errors.Is(res.Err, ai.ErrAbandoned):
+		return batchItemResponse{Status: http.StatusGatewayTimeout, Error: &batchItemError{CodeTimeout, abandonedMessage}}
+	case errors.Is(res.Err, service.ErrUnknownTemplate):
+		return batchItemResponse{Status: http.StatusBadRequest, Error: &batchItemError{CodeValidation, "Unknown template: " + in.Template}}
+	case errors.As(res.Err, &exceeded):
+		quota := toQuotaResponse(exceeded.Usage)
+		return batchItemResponse{Status: http.StatusPaymentRequired, Error: &batchItemError{CodeQuotaExceeded, quotaExceededMessage}, Quota: &quota}
+	case errors.As(res.Err, &dup):
+		return batchItemResponse{Status: http.StatusConflict, Error: &batchItemError{CodeConflict, duplicateMessage}, DuplicateOf: &dup.PostID}
+	}
+	if message, categories, ok := flaggedDetails(res.Err); ok {
+		return batchItemResponse{Status: http.StatusUnprocessableEntity, Error: &batchItemError{CodeContentFlagged, message}, Categories: categories, Usage: flaggedUsage(res.Err)}
+	}
+	if e, ok := toAPIError(res.Err); ok {
+		return batchItemResponse{Status: e.status, Error: &batchItemError{e.code, e.message}}
+	}
+	slog.ErrorContext(r.Context(), "batch item failed", "error", res.Err)
+	return batchItemResponse{Status: http.StatusInternalServerError, Error: &batchItemError{CodeInternal, "Failed to transform text"}}
+}
+
+// allow charges one request to the user's rate limit. Like the RateLimit
+// middleware it fails open when the store is unavailable.
+func (h *LinkedInHandler) allow(w http.ResponseWriter, r *http.Request, uid uuid.UUID) (bool, time.Duration) {
+	if h.limiter == nil {
+		return true, 0
+	}
+	return middleware.Allow(w, r, h.limiter, uid.String(), h.limitOpts...)
+}
+
+type regenerateBody struct {
+	Tone   string `json:"tone,omitempty"`
+	Length string `json:"length,omitempty"`
+	api.Sampling
+}
+
+// regenerate replaces a post's text with a fresh generation from the same
+// input. The body is optional and may override the tone, length and
+// sampling parameters.
+func (h *LinkedInHandler) regenerate(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+	var in regenerateBody
+	if err := decodeJSON(r, &in); err != nil && !errors.Is(err, io.EOF) {
+		respondDecodeError(w, err)
+		return
+	}
+	if !service.Tone(in.Tone).Valid() {
+		respondError(w, http.StatusBadRequest, "Unsupported tone: "+in.Tone+" (expected one of "+service.ToneNames+")")
+		return
+	}
+	if !service.Length(in.Length).Valid() {
+		respondError(w, http.StatusBadRequest, "Unsupported length: "+in.Length+" (expected one of "+service.LengthNames+")")
+		return
+	}
+	if msg := validateSampling(in.Sampling); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	uid := middleware.UserID(r.Context())
+	out, err := h.svc.Regenerate(r.Context(), uid, postID, service.TransformOptions{
+		Tone:        service.Tone(in.Tone),
+		Length:      service.Length(in.Length),
+		Temperature: in.Temperature,
+		TopP:        in.TopP,
+	})
+	switch {
+	case err == nil:
+		setCacheHeader(w, out.Cached)
+		respondJSON(w, http.StatusOK, transformResponse{
+			ID:        out.PostID,
+			Post:      out.Post,
+			Usage:     toUsageResponse(out.Usage, out.Model),
+			Truncated: out.Truncated,
+		})
+	case errors.Is(err, service.ErrUnknownTemplate):
+		respondError(w, http.StatusBadRequest, "The template this post was generated with no longer exists")
+	default:
+		respondServiceError(w, r, err, "Failed to regenerate post")
+	}
+}
+
+type imageBody struct {
+	Size string `json:"size,omitempty"`
+}
+
+type imageResponse struct {
+	ID          uuid.UUID `json:"id"`
+	ImageURL    string    `json:"image_url"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	ImagePrompt string    `json:"image_prompt"`
+	Size        string    `json:"size"`
+}
+
+// image generates an illustration for a post and stores its URL on the post.
+// The body is optional and may choose the size.
+func (h *LinkedInHandler) image(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+	var in imageBody
+	if err := decodeJSON(r, &in); err != nil && !errors.Is(err, io.EOF) {
+		respondDecodeError(w, err)
+		return
+	}
+
+	uid := middleware.UserID(r.Context())
+	post, err := h.svc.GenerateImage(r.Context(), uid, postID, in.Size)
+	switch {
+	case err == nil:
+		respondJSON(w, http.StatusOK, imageResponse{
+			ID:          post.ID,
+			ImageURL:    post.ImageURL,
+			ExpiresAt:   post.ImageExpiresAt,
+			ImagePrompt: post.ImagePrompt,
+			Size:        post.ImageSize,
+		})
+	case errors.Is(err, service.ErrInvalidImageSize):
+		respondError(w, http.StatusBadRequest, "Unsupported size: "+in.Size+" (expected one of "+strings.Join(ai.ImageSizes, ", ")+")")
+	default:
+		respondServiceError(w, r, err, "Failed to generate image")
+	}
+}
+
+type publishResponse struct {
+	ID          uuid.UUID `json:"id"`
+	LinkedInURN string    `json:"linkedin_urn"`
+	LinkedInURL string    `json:"linkedin_url"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// publish posts a post to the user's LinkedIn feed.
+func (h *LinkedInHandler) publish(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	post, err := h.svc.Publish(r.Context(), middleware.UserID(r.Context()), postID)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to publish post")
+		return
+	}
+	respondJSON(w, http.StatusOK, publishResponse{
+		ID:          post.ID,
+		LinkedInURN: post.LinkedInURN,
+		LinkedInURL: linkedInPostURL(post.LinkedInURN),
+		PublishedAt: post.PublishedAt,
+	})
+}
+
+type scheduleBody struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+// scheduleItem is the scheduled publication of a post.
+type scheduleItem = api.Schedule
+
+// toScheduleItem is nil for a post that was never scheduled.
+func toScheduleItem(p model.LinkedInPost) *scheduleItem {
+	if p.ScheduleStatus == "" {
+		return nil
+	}
+	return &scheduleItem{
+		ScheduledAt: p.ScheduledAt,
+		Status:      p.ScheduleStatus,
+		Attempts:    p.ScheduleAttempts,
+		Error:       p.ScheduleError,
+	}
+}
+
+type scheduleResponse struct {
+	ID uuid.UUID `json:"id"`
+	scheduleItem
+}
+
+// schedule has a post published to the user's LinkedIn feed at a later
+// time. Scheduling it again moves it.
+func (h *LinkedInHandler) schedule(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+	var in scheduleBody
+	if err := decodeJSON(r, &in); err != nil {
+		respondDecodeError(w, err)
+		return
+	}
+	if in.ScheduledAt.IsZero() {
+		respondError(w, http.StatusBadRequest, "The 'scheduled_at' field is required")
+		return
+	}
+
+	post, err := h.svc.Schedule(r.Context(), middleware.UserID(r.Context()), postID, in.ScheduledAt)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to schedule post")
+		return
+	}
+	respondJSON(w, http.StatusAccepted, scheduleResponse{ID: post.ID, scheduleItem: *toScheduleItem(*post)})
+}
+
+// linkedInPostURL links to the live post with the given URN, or is empty
+// for a post never published.
+func linkedInPostURL(urn string) string {
+	if urn == "" {
+		return ""
+	}
+	return "https://www.linkedin.com/feed/update/" + urn
+}
+
+type hashtagsResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Hashtags []string  `json:"hashtags"`
+}
+
+// hashtags suggests hashtags for a post. They are returned, not added to the
+// post; the client decides which to keep.
+func (h *LinkedInHandler) hashtags(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	uid := middleware.UserID(r.Context())
+	tags, err := h.svc.PostHashtags(r.Context(), uid, postID)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to suggest hashtags")
+		return
+	}
+	respondJSON(w, http.StatusOK, hashtagsResponse{ID: postID, Hashtags: tags})
+}
+
+const (
+	// idempotencyKeyHeader lets clients retry POST /posts safely: a repeat
+	// of the key returns the original post instead of generating another.
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader marks a response replayed for a repeated key.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// setCacheHeader tells the client whether a post came from the AI cache.
+func setCacheHeader(w http.ResponseWriter, hit bool) {
+	if hit {
+		w.Header().Set("X-Cache", "HIT")
+	} else {
+		w.Header().Set("X-Cache", "MISS")
+	}
+}
+
+// transformStream is the streaming variant of transform. The post is sent as
+// Server-Sent Events: one "token" event per chunk, followed by either a
+// "done" event with the ID of the saved draft or an "error" event if
+// generation fails part way through. A client going away part way leaves
+// what was streamed as a draft.
+func (h *LinkedInHandler) transformStream(w http.ResponseWriter, r *http.Request) {
 	var in reqBody
-	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request payload")
+	if err := decodeJSON(r, &in); err != nil {
+		respondDecodeError(w, err)
 		return
 	}
-	if in.Text == "" {
-		respondError(w, http.StatusBadRequest, "The 'text' field is required")
+	if msg := in.validate(h.provider); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respondError(w, http.StatusInternalServerError, "Streaming unsupported")
 		return
 	}
 
 	p := bluemonday.StrictPolicy()
 	sanitizedText := p.Sanitize(in.Text)
 	uid := middleware.UserID(r.Context())
-	out, err := h.svc.Transform(r.Context(), uid, sanitizedText)
+	chunks, err := h.svc.TransformStream(r.Context(), uid, sanitizedText, in.options())
+	if errors.Is(err, service.ErrUnknownTemplate) {
+		respondError(w, http.StatusBadRequest, "Unknown template: "+in.Template)
+		return
+	}
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to transform text")
+		respondServiceError(w, r, err, "Failed to transform text")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	var done streamDone
+	for c := range chunks {
+		var flagged *service.ModerationError
+		if errors.As(c.Err, &flagged) {
+			writeEvent(w, "error", flaggedResponse{
+				errorResponse: middleware.NewErrorEnvelope(w, CodeContentFlagged, flaggedMessage),
+				Categories:    flagged.Categories,
+			})
+			flusher.Flush()
+			return
+		}
+		if e, ok := toAPIError(c.Err); ok {
+			writeEvent(w, "error", middleware.NewErrorEnvelope(w, e.code, e.message))
+			flusher.Flush()
+			return
+		}
+		if c.Err != nil {
+			slog.ErrorContext(r.Context(), "stream failed", "error", c.Err)
+			writeEvent(w, "error", middleware.NewErrorEnvelope(w, CodeInternal, "Failed to transform text"))
+			flusher.Flush()
+			return
+		}
+		if c.PostID != uuid.Nil {
+			done.ID = &c.PostID
+			continue
+		}
+		writeEvent(w, "token", map[string]string{"text": c.Text})
+		flusher.Flush()
+	}
+	if r.Context().Err() != nil {
 		return
 	}
-	respondJSON(w, http.StatusCreated, map[string]string{"post": out})
+	writeEvent(w, "done", done)
+	flusher.Flush()
+}
+
+// streamDone is the data of the "done" event ending a stream. ID is the
+// saved draft.
+type streamDone struct {
+	ID *uuid.UUID `json:"id,omitempty"`
+}
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type pageMeta = api.PageMeta
+
+type postPage = api.PostPage
+
+// parsePagination reads the limit and offset query parameters. Limits above
+// maxLimit are capped; a limit below 1, a negative offset and non-numeric
+// values are rejected with a client-facing message.
+func parsePagination(r *http.Request) (limit, offset int, msg string) {
+	limit, offset = defaultLimit, 0
+	q := r.URL.Query()
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, 0, "The 'limit' parameter must be a positive integer"
+		}
+		limit = min(n, maxLimit)
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, "The 'offset' parameter must be a non-negative integer"
+		}
+		offset = n
+	}
+	return limit, offset, ""
 }
 
 func (h *LinkedInHandler) history(w http.ResponseWriter, r *http.Request) {
 	uid := middleware.UserID(r.Context())
 
-	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
-	if page < 1 {
-		page = 1
+	limit, offset, msg := parsePagination(r)
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	filter := service.PostFilter{Status: r.URL.Query().Get("status")}
+	if filter.Status != "" && !service.ValidStatus(filter.Status) {
+		respondError(w, http.StatusBadRequest, "The 'status' parameter must be 'draft' or 'final'")
+		return
+	}
+	if v := r.URL.Query().Get("favorited"); v != "" {
+		favorited, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "The 'favorited' parameter must be 'true' or 'false'")
+			return
+		}
+		filter.Favorited = &favorited
+	}
+	filter.Sort = service.PostSort(r.URL.Query().Get("sort"))
+	if !filter.Sort.Valid() {
+		respondError(w, http.StatusBadRequest, "The 'sort' parameter must be 'created_desc', 'created_asc' or 'updated_desc'")
+		return
 	}
 
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10 // Default page size
+	if q := r.URL.Query(); q.Has("cursor") {
+		if q.Has("offset") {
+			respondError(w, http.StatusBadRequest, "Use either the 'cursor' or the 'offset' parameter, not both")
+			return
+		}
+		h.historyAfter(w, r, filter, q.Get("cursor"), limit)
+		return
 	}
 
-	items, err := h.svc.History(r.Context(), uid, page, pageSize)
+	items, total, err := h.svc.History(r.Context(), uid, filter, limit, offset)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to retrieve history")
+		respondServiceError(w, r, err, "Failed to retrieve history")
 		return
 	}
-	type item struct {
-		ID    uuid.UUID `json:"id"`
-		Input string    `json:"input"`
-		Post  string    `json:"post"`
+	respondPosts(w, items, pageMeta{Total: total, Limit: limit, Offset: offset})
+}
+
+// cursorMeta describes a page of history in cursor mode. NextCursor is null
+// on the last page.
+type cursorMeta struct {
+	Limit      int     `json:"limit"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+type cursorPostPage struct {
+	Data []postItem `json:"data"`
+	Meta cursorMeta `json:"meta"`
+}
+
+// historyAfter serves history in cursor mode. Counting every post is what
+// makes offsets slow on long histories, so no total is given.
+func (h *LinkedInHandler) historyAfter(w http.ResponseWriter, r *http.Request, filter service.PostFilter, cursor string, limit int) {
+	page, err := h.svc.HistoryAfter(r.Context(), middleware.UserID(r.Context()), filter, cursor, limit)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to retrieve history")
+		return
+	}
+	res := cursorPostPage{Data: make([]postItem, 0, len(page.Posts)), Meta: cursorMeta{Limit: limit}}
+	for _, p := range page.Posts {
+		res.Data = append(res.Data, toPostItem(p))
 	}
-	var res []item
-	for _, p := range items {
-		res = append(res, item{ID: p.ID, Input: p.InputText, Post: p.OutputText})
+	if page.NextCursor != "" {
+		res.Meta.NextCursor = &page.NextCursor
 	}
 	respondJSON(w, http.StatusOK, res)
 }
 
+// search is history filtered by the q query parameter and ordered by
+// relevance.
+func (h *LinkedInHandler) search(w http.ResponseWriter, r *http.Request) {
+	uid := middleware.UserID(r.Context())
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		respondError(w, http.StatusBadRequest, "The 'q' parameter is required")
+		return
+	}
+	limit, offset, msg := parsePagination(r)
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	items, total, err := h.svc.Search(r.Context(), uid, query, limit, offset)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to search posts")
+		return
+	}
+	respondPosts(w, items, pageMeta{Total: total, Limit: limit, Offset: offset})
+}
+
+// postItem is the JSON shape of a stored post.
+type postItem = api.Post
+
+func toPostItem(p model.LinkedInPost) postItem {
+	item := postItem{
+		ID:       p.ID,
+		Input:    p.InputText,
+		Post:     p.OutputText,
+		Status:   p.Status,
+		Tone:     p.Tone,
+		Length:   p.Length,
+		Language: p.Language,
+		ImageURL: p.ImageURL,
+
+		LinkedInURN: p.LinkedInURN,
+		LinkedInURL: linkedInPostURL(p.LinkedInURN),
+		Favorited:   p.Favorited,
+		Temperature: p.Temperature,
+		TopP:        p.TopP,
+		Schedule:    toScheduleItem(p),
+	}
+	if !p.ImageExpiresAt.IsZero() {
+		item.ImageExpiresAt = &p.ImageExpiresAt
+	}
+	return item
+}
+
+// respondPosts writes a page of posts in the shape shared by the listing
+// endpoints.
+func respondPosts(w http.ResponseWriter, posts []model.LinkedInPost, meta pageMeta) {
+	res := make([]postItem, 0, len(posts))
+	for _, p := range posts {
+		res = append(res, toPostItem(p))
+	}
+	respondJSON(w, http.StatusOK, postPage{Data: res, Meta: meta})
+}
+
+// postETag is the entity tag of a post as sent to clients: a hash of its
+// JSON, so it changes whenever any field of the response does. It is quoted,
+// as HTTP requires.
+func postETag(item postItem) string {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether etag is one of the entity tags listed in an
+// If-Match or If-None-Match header, or the header is "*". If-None-Match
+// compares weakly, ignoring a W/ prefix; If-Match must compare strongly.
+func etagMatches(header, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// get returns one post. It sends the post's ETag and answers 304 Not
+// Modified, without a body, when If-None-Match names it, so clients polling
+// a post only download it when it changed.
+func (h *LinkedInHandler) get(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+	post, err := h.svc.Get(r.Context(), middleware.UserID(r.Context()), postID)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to load post")
+		return
+	}
+
+	item := toPostItem(*post)
+	etag := postETag(item)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if inm := r.Header.Get("If-None-Match"); inm != "" && etagMatches(inm, etag, true) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	respondJSON(w, http.StatusOK, item)
+}
+
+type updateBody struct {
+	Post   *string `json:"post,omitempty"`
+	Status string  `json:"status,omitempty"`
+}
+
+// update edits a post's text and/or status, typically to finalise a draft.
+// Posts of other users are reported as not found so their existence is not
+// leaked. With If-Match, the edit is refused with 412 unless the post still
+// has that ETag, so two clients editing at once cannot overwrite each
+// other's changes unseen.
+func (h *LinkedInHandler) update(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+	var in updateBody
+	if err := decodeJSON(r, &in); err != nil {
+		respondDecodeError(w, err)
+		return
+	}
+	if in.Post == nil && in.Status == "" {
+		respondError(w, http.StatusBadRequest, "Nothing to update: set 'post' and/or 'status'")
+		return
+	}
+	if in.Post != nil {
+		sanitized := bluemonday.StrictPolicy().Sanitize(*in.Post)
+		if strings.TrimSpace(sanitized) == "" {
+			respondError(w, http.StatusBadRequest, "The 'post' field must not be empty")
+			return
+		}
+		in.Post = &sanitized
+	}
+	if in.Status != "" && !service.ValidStatus(in.Status) {
+		respondError(w, http.StatusBadRequest, "The 'status' field must be 'draft' or 'final'")
+		return
+	}
+
+	u := service.PostUpdate{Post: in.Post, Status: in.Status}
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+		u.Precondition = func(current *model.LinkedInPost) bool {
+			return etagMatches(ifMatch, postETag(toPostItem(*current)), false)
+		}
+	}
+
+	post, err := h.svc.Update(r.Context(), middleware.UserID(r.Context()), postID, u)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to update post")
+		return
+	}
+	item := toPostItem(*post)
+	w.Header().Set("ETag", postETag(item))
+	respondJSON(w, http.StatusOK, item)
+}
+
+type versionItem struct {
+	ID        uuid.UUID `json:"id"`
+	Post      string    `json:"post"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type versionList struct {
+	Data []versionItem `json:"data"`
+}
+
+// versions lists the earlier texts of a post, newest first.
+func (h *LinkedInHandler) versions(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+	versions, err := h.svc.Versions(r.Context(), middleware.UserID(r.Context()), postID)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to retrieve versions")
+		return
+	}
+	res := make([]versionItem, 0, len(versions))
+	for _, v := range versions {
+		res = append(res, versionItem{ID: v.ID, Post: v.OutputText, Source: v.Source, CreatedAt: v.CreatedAt})
+	}
+	respondJSON(w, http.StatusOK, versionList{Data: res})
+}
+
+// restoreVersion rolls a post back to one of its versions and responds with
+// the updated post.
+func (h *LinkedInHandler) restoreVersion(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+	versionID, err := uuid.Parse(chi.URLParam(r, "versionID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid version ID")
+		return
+	}
+	post, err := h.svc.RestoreVersion(r.Context(), middleware.UserID(r.Context()), postID, versionID)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to restore version")
+		return
+	}
+	respondJSON(w, http.StatusOK, toPostItem(*post))
+}
+
+func (h *LinkedInHandler) delete(w http.ResponseWriter, r *http.Request) {
+	h.changePost(w, r, h.svc.Delete)
+}
+
+func (h *LinkedInHandler) restore(w http.ResponseWriter, r *http.Request) {
+	h.changePost(w, r, h.svc.Restore)
+}
+
+// favorite and unfavorite are idempotent: repeating either still responds
+// 204.
+func (h *LinkedInHandler) favorite(w http.ResponseWriter, r *http.Request) {
+	h.changePost(w, r, h.svc.Favorite)
+}
+
+func (h *LinkedInHandler) unfavorite(w http.ResponseWriter, r *http.Request) {
+	h.changePost(w, r, h.svc.Unfavorite)
+}
+
+type feedbackBody struct {
+	Rating  string `json:"rating"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type feedbackResponse struct {
+	ID        uuid.UUID `json:"id"`
+	PostID    uuid.UUID `json:"post_id"`
+	Rating    string    `json:"rating"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// feedback records a thumbs up or down on one of the user's posts, replacing
+// their earlier rating of it.
+func (h *LinkedInHandler) feedback(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+	var in feedbackBody
+	if err := decodeJSON(r, &in); err != nil {
+		respondDecodeError(w, err)
+		return
+	}
+	if !service.ValidRating(in.Rating) {
+		respondError(w, http.StatusBadRequest, "The 'rating' field must be 'up' or 'down'")
+		return
+	}
+	comment := bluemonday.StrictPolicy().Sanitize(in.Comment)
+	if utf8.RuneCountInString(comment) > service.MaxFeedbackCommentLength {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("The 'comment' field must be at most %d characters", service.MaxFeedbackCommentLength))
+		return
+	}
+
+	f, err := h.svc.RatePost(r.Context(), middleware.UserID(r.Context()), postID, in.Rating, comment)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to save feedback")
+		return
+	}
+	respondJSON(w, http.StatusCreated, feedbackResponse{ID: f.ID, PostID: f.PostID, Rating: f.Rating, Comment: f.Comment, CreatedAt: f.CreatedAt})
+}
+
+// changePost runs an operation on the post named by the {id} URL parameter
+// and responds with 204 on success.
+func (h *LinkedInHandler) changePost(w http.ResponseWriter, r *http.Request, op func(ctx context.Context, userID, postID uuid.UUID) error) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+	err = op(r.Context(), middleware.UserID(r.Context()), postID)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to update post")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // --- Response Helpers ---
 
 // respondJSON writes a JSON response with a given status code and payload.
@@ -96,21 +1159,28 @@ func respondJSON(w http.ResponseWriter, status int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	response, err := json.Marshal(payload)
 	if err != nil {
-		log.Printf("ERROR: Failed to marshal JSON response: %v", err)
+		slog.Error("marshalling JSON response failed", "error", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		// We'll try to write an error response, but this might also fail
-		if _, writeErr := w.Write([]byte(`{"error":"Internal server error"}`)); writeErr != nil {
-			log.Printf("ERROR: Could not write error response: %v", writeErr)
+		if _, writeErr := w.Write([]byte(`{"error":{"code":"internal","message":"Internal server error"}}`)); writeErr != nil {
+			slog.Error("writing error response failed", "error", writeErr)
 		}
 		return
 	}
 	w.WriteHeader(status)
 	if _, err := w.Write(response); err != nil {
-		log.Printf("ERROR: Failed to write JSON response: %v", err)
+		slog.Error("writing JSON response failed", "error", err)
 	}
 }
 
-// respondError sends a structured JSON error response.
-func respondError(w http.ResponseWriter, code int, message string) {
-	respondJSON(w, code, map[string]string{"error": message})
+// writeEvent writes a single Server-Sent Event with a JSON-encoded payload.
+func writeEvent(w http.ResponseWriter, event string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		slog.Error("marshalling event payload failed", "error", err)
+		return
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		slog.Error("writing event failed", "error", err)
+	}
 }
@@ -0,0 +1,774 @@
+// internal/handler/openapi.go
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/openapi"
+	"github.com/you/linkedinify/internal/service"
+	"github.com/you/linkedinify/internal/webhook"
+)
+
+// Names of the JWT and API key security schemes in the spec.
+const (
+	bearerAuth = "bearerAuth"
+	apiKeyAuth = "apiKeyAuth"
+)
+
+// openAPISpec is built once, on first request, from the handler DTOs so it
+// cannot drift from what the handlers actually read and write.
+var openAPISpec = sync.OnceValues(func() ([]byte, error) {
+	return json.Marshal(OpenAPIDocument())
+})
+
+// OpenAPI serves the OpenAPI 3.0 description of the auth, users, orgs,
+// linkedin, posts and webhooks routes.
+func OpenAPI(w http.ResponseWriter, r *http.Request) {
+	spec, err := openAPISpec()
+	if err != nil {
+		slog.Error("marshalling OpenAPI spec failed", "error", err)
+		WriteError(w, http.StatusInternalServerError, CodeInternal, "Internal server error")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(spec)
+}
+
+// OpenAPIDocument describes the auth, users, orgs, linkedin, posts and
+// webhooks routes. Paths are relative to /api/v1.
+func OpenAPIDocument() *openapi.Document {
+	d := openapi.New("LinkedInify API", "1.0.0")
+	d.Info.Description = "Turns everyday text into LinkedIn posts."
+	d.Servers = []openapi.Server{{URL: "/api/v1"}}
+	d.Components.SecuritySchemes[bearerAuth] = openapi.SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}
+	d.Components.SecuritySchemes[apiKeyAuth] = openapi.SecurityScheme{Type: "apiKey", In: "header", Name: middleware.APIKeyHeader}
+
+	d.Component("ErrorDetail", middleware.ErrorDetail{})
+	setEnum(d, "ErrorDetail", "code", enumValues(errorCodes))
+	s := specSchemas{
+		err:     d.Component("Error", errorResponse{}),
+		flagged: d.Component("FlaggedError", flaggedResponse{}),
+		quota:   d.Component("QuotaExceededError", quotaExceededResponse{}),
+	}
+	addAuthPaths(d, s)
+	addUserPaths(d, s)
+	addOrgPaths(d, s)
+	addLinkedInAccountPaths(d, s)
+	addPostPaths(d, s)
+	addWebhookPaths(d, s)
+	return d
+}
+
+// specSchemas holds the error schemas shared by many operations.
+type specSchemas struct {
+	err, flagged, quota *openapi.Schema
+}
+
+func addAuthPaths(d *openapi.Document, s specSchemas) {
+	credentials := d.Component("Credentials", creds{})
+	tokens := d.Component("Tokens", tokenResponse{})
+	message := d.Component("Message", messageResponse{})
+	refresh := d.Component("RefreshRequest", refreshReq{})
+	forgot := d.Component("ForgotPasswordRequest", forgotPasswordReq{})
+	reset := d.Component("ResetPasswordRequest", resetPasswordReq{})
+
+	d.Add(http.MethodPost, "/auth/register", &openapi.Operation{
+		Summary:     "Create an account",
+		Tags:        []string{"auth"},
+		RequestBody: jsonBody(credentials),
+		Responses: map[string]*openapi.Response{
+			"201": jsonResponse("The new account's tokens", tokens),
+			"400": errorDoc("Missing email or password, or a password that breaks the password policy"),
+		},
+	})
+	d.Add(http.MethodPost, "/auth/login", &openapi.Operation{
+		Summary:     "Log in",
+		Tags:        []string{"auth"},
+		RequestBody: jsonBody(credentials),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("A short-lived access token and a refresh token", tokens),
+			"400": errorDoc("Missing email or password, or a password that breaks the password policy"),
+			"401": errorDoc("Invalid credentials"),
+			"429": loginLocked(),
+		},
+	})
+	d.Add(http.MethodPost, "/auth/refresh", &openapi.Operation{
+		Summary:     "Exchange a refresh token for a new token pair",
+		Tags:        []string{"auth"},
+		RequestBody: jsonBody(refresh),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("A new token pair", tokens),
+			"400": errorDoc("Missing refresh_token"),
+			"401": errorDoc("Invalid, expired or reused refresh token"),
+		},
+	})
+	d.Add(http.MethodPost, "/auth/forgot-password", &openapi.Operation{
+		Summary:     "Email a password reset link",
+		Tags:        []string{"auth"},
+		RequestBody: jsonBody(forgot),
+		Responses: map[string]*openapi.Response{
+			"202": jsonResponse("Accepted, whether or not the account exists", message),
+			"400": errorDoc("Missing email"),
+		},
+	})
+	d.Add(http.MethodPost, "/auth/reset-password", &openapi.Operation{
+		Summary:     "Set a new password with a reset token",
+		Tags:        []string{"auth"},
+		RequestBody: jsonBody(reset),
+		Responses: map[string]*openapi.Response{
+			"204": {Description: "Password changed"},
+			"400": errorDoc("Missing fields, an invalid or expired reset token, or a password that breaks the password policy"),
+		},
+	})
+	d.Add(http.MethodPost, "/auth/logout", &openapi.Operation{
+		Summary:  "Revoke the current access token",
+		Tags:     []string{"auth"},
+		Security: bearer(),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("Logged out", message),
+			"401": unauthorized(),
+		},
+	})
+}
+
+func addUserPaths(d *openapi.Document, s specSchemas) {
+	profile := d.Component("Profile", profileResponse{})
+	update := d.Component("ProfileUpdate", profileBody{})
+	for field, limit := range profileFieldLimits {
+		d.Components.Schemas["ProfileUpdate"].Properties[field].MaxLength = limit
+	}
+
+	d.Add(http.MethodGet, "/users/me", &openapi.Operation{
+		Summary:  "Get your account and profile",
+		Tags:     []string{"users"},
+		Security: bearer(),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("Your account", profile),
+			"401": unauthorized(),
+		},
+	})
+	d.Add(http.MethodPatch, "/users/me", &openapi.Operation{
+		Summary:     "Edit your profile, which personalises generated posts",
+		Tags:        []string{"users"},
+		Security:    bearer(),
+		RequestBody: jsonBody(update),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("The updated account", profile),
+			"400": jsonResponse("Validation failed, or an attempt to change email, id or role", s.err),
+			"401": unauthorized(),
+		},
+	})
+	d.Add(http.MethodDelete, "/users/me", &openapi.Operation{
+		Summary:     "Permanently delete your account and all of its data",
+		Tags:        []string{"users"},
+		Security:    bearer(),
+		RequestBody: jsonBody(d.Component("DeleteAccountRequest", deleteAccountBody{})),
+		Responses: map[string]*openapi.Response{
+			"204": {Description: "The account, its posts, versions, refresh tokens and API keys are gone"},
+			"400": jsonResponse("The password is missing", s.err),
+			"401": unauthorized(),
+			"403": jsonResponse("The password is wrong", s.err),
+		},
+	})
+
+	d.Add(http.MethodGet, "/users/me/usage", &openapi.Operation{
+		Summary:  "Get this month's post generations against your plan's quota",
+		Tags:     []string{"users"},
+		Security: bearer(),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("Your usage; limit and remaining are null on a plan without a quota", d.Component("Quota", quotaResponse{})),
+			"401": unauthorized(),
+		},
+	})
+
+	d.Add(http.MethodGet, "/users/me/stats", &openapi.Operation{
+		Summary:  "Get your generations per day and your most used tones and templates",
+		Tags:     []string{"users"},
+		Security: bearer(),
+		Parameters: []openapi.Parameter{
+			queryParam("days", fmt.Sprintf("Days covered, today included: 1 to %d, default %d", service.MaxStatsDays, service.DefaultStatsDays), &openapi.Schema{Type: "integer", Format: "int32"}),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("Your generations, with a count for every UTC day of the period", d.Component("GenerationStats", statsResponse{})),
+			"400": jsonResponse("days is out of range", s.err),
+			"401": unauthorized(),
+			"501": jsonResponse("Analytics are disabled on this server", s.err),
+		},
+	})
+
+	keyBody := d.Component("APIKeyRequest", apiKeyBody{})
+	d.Components.Schemas["APIKeyRequest"].Properties["name"].MaxLength = service.MaxAPIKeyNameLength
+	d.Add(http.MethodPost, "/users/me/api-keys", &openapi.Operation{
+		Summary:     "Create an API key for the posts routes",
+		Tags:        []string{"users"},
+		Security:    bearer(),
+		RequestBody: &openapi.RequestBody{Content: openapi.JSON(keyBody)},
+		Responses: map[string]*openapi.Response{
+			"201": jsonResponse("The new key; this is the only time it is shown", d.Component("CreatedAPIKey", createdAPIKeyResponse{})),
+			"400": jsonResponse("The name is too long", s.err),
+			"401": unauthorized(),
+			"409": jsonResponse(fmt.Sprintf("You already have %d keys", service.MaxAPIKeys), s.err),
+		},
+	})
+	d.Component("APIKey", apiKeyResponse{})
+	d.Add(http.MethodGet, "/users/me/api-keys", &openapi.Operation{
+		Summary:  "List your API keys, without their secrets",
+		Tags:     []string{"users"},
+		Security: bearer(),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("Your active keys, newest first", d.Component("APIKeyList", apiKeysResponse{})),
+			"401": unauthorized(),
+		},
+	})
+	d.Add(http.MethodDelete, "/users/me/api-keys/{id}", &openapi.Operation{
+		Summary:    "Revoke an API key",
+		Tags:       []string{"users"},
+		Security:   bearer(),
+		Parameters: []openapi.Parameter{pathParam("id", "API key ID")},
+		Responses: map[string]*openapi.Response{
+			"204": {Description: "The key no longer authenticates"},
+			"401": unauthorized(),
+			"404": jsonResponse("No such key", s.err),
+		},
+	})
+}
+
+func addOrgPaths(d *openapi.Document, s specSchemas) {
+	create := d.Component("OrgRequest", orgBody{})
+	d.Components.Schemas["OrgRequest"].Properties["name"].MaxLength = service.MaxOrgNameLength
+	d.Component("OrgMember", orgMemberResponse{})
+	org := d.Component("Org", orgResponse{})
+	roles := []string{model.OrgRoleOwner, model.OrgRoleMember}
+	setEnum(d, "Org", "role", roles)
+	setEnum(d, "OrgMember", "role", roles)
+
+	d.Add(http.MethodPost, "/orgs", &openapi.Operation{
+		Summary:     "Create a team organization and move into it as its owner",
+		Tags:        []string{"orgs"},
+		Security:    bearer(),
+		RequestBody: jsonBody(create),
+		Responses: map[string]*openapi.Response{
+			"201": jsonResponse("Your new organization", org),
+			"400": jsonResponse("The name is missing or too long", s.err),
+			"401": unauthorized(),
+			"409": jsonResponse("You are the only owner of a team with other members", s.err),
+		},
+	})
+	d.Add(http.MethodGet, "/orgs/current", &openapi.Operation{
+		Summary:  "Get the organization you are in and its members",
+		Tags:     []string{"orgs"},
+		Security: bearer(),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("Your organization", org),
+			"401": unauthorized(),
+		},
+	})
+	d.Add(http.MethodPost, "/orgs/current/invitations", &openapi.Operation{
+		Summary:     "Email someone an invitation to your organization",
+		Tags:        []string{"orgs"},
+		Security:    bearer(),
+		RequestBody: jsonBody(d.Component("InvitationRequest", invitationBody{})),
+		Responses: map[string]*openapi.Response{
+			"201": jsonResponse("The invitation was sent; the token is only in the email", d.Component("Invitation", invitationResponse{})),
+			"400": jsonResponse("The email is missing", s.err),
+			"401": unauthorized(),
+			"403": jsonResponse("Only owners can invite", s.err),
+			"409": jsonResponse("Your organization is personal, or they are already a member", s.err),
+		},
+	})
+	d.Add(http.MethodPost, "/orgs/invitations/accept", &openapi.Operation{
+		Summary:     "Join the organization of an invitation sent to your email",
+		Tags:        []string{"orgs"},
+		Security:    bearer(),
+		RequestBody: jsonBody(d.Component("AcceptInvitationRequest", acceptInvitationBody{})),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("The organization you joined", org),
+			"400": jsonResponse("The invitation is unknown, expired, used or for another email", s.err),
+			"401": unauthorized(),
+			"409": jsonResponse("You are already a member, or the only owner of a team with other members", s.err),
+		},
+	})
+}
+
+func addLinkedInAccountPaths(d *openapi.Document, s specSchemas) {
+	d.Add(http.MethodGet, "/linkedin/connect", &openapi.Operation{
+		Summary:  "Start connecting your LinkedIn account for publishing",
+		Tags:     []string{"linkedin"},
+		Security: bearer(),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("The LinkedIn page to send the user to; it expires after ten minutes and only works in the browser given the linkedinify_oauth_nonce cookie set here", d.Component("LinkedInConnect", connectResponse{})),
+			"401": unauthorized(),
+			"501": jsonResponse("Publishing to LinkedIn is not enabled", s.err),
+		},
+	})
+	str := &openapi.Schema{Type: "string"}
+	d.Add(http.MethodGet, "/linkedin/callback", &openapi.Operation{
+		Summary: "Where LinkedIn redirects back to after the user authorized publishing",
+		Tags:    []string{"linkedin"},
+		Parameters: []openapi.Parameter{
+			queryParam("code", "The authorization code issued by LinkedIn", str),
+			queryParam("state", "The state issued by /linkedin/connect, which identifies the user", str),
+			queryParam("error", "Set by LinkedIn when the user declined", str),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("The connected account", d.Component("LinkedInConnection", connectionResponse{})),
+			"400": jsonResponse("The user declined, the state or code is missing, invalid, expired or used, or the browser lacks the nonce cookie", s.err),
+			"501": jsonResponse("Publishing to LinkedIn is not enabled", s.err),
+		},
+	})
+}
+
+func addPostPaths(d *openapi.Document, s specSchemas) {
+	transformReq := d.Component("TransformRequest", reqBody{})
+	generateReq := d.Component("GenerateRequest", transformBody{})
+	d.Component("Usage", usageResponse{})
+	transform := d.Component("TransformResponse", transformResponse{})
+	regenerate := d.Component("RegenerateRequest", regenerateBody{})
+	post := d.Component("Post", postItem{})
+	d.Component("PageMeta", pageMeta{})
+	page := d.Component("PostPage", postPage{})
+	update := d.Component("PostUpdate", updateBody{})
+	d.Component("PostVersion", versionItem{})
+	versions := d.Component("PostVersionList", versionList{})
+	scheduled := d.Component("Schedule", scheduleResponse{})
+
+	tones, lengths := enumValues(service.Tones), enumValues(service.Lengths)
+	statuses := []string{model.PostStatusDraft, model.PostStatusFinal}
+	for _, name := range []string{"TransformRequest", "GenerateRequest"} {
+		setEnum(d, name, "tone", tones)
+		setEnum(d, name, "length", lengths)
+		setEnum(d, name, "language", service.LanguageCodes())
+	}
+	setEnum(d, "RegenerateRequest", "tone", tones)
+	setEnum(d, "RegenerateRequest", "length", lengths)
+	setEnum(d, "Post", "status", statuses)
+	setEnum(d, "PostUpdate", "status", statuses)
+	setEnum(d, "PostVersion", "source", []string{model.PostSourceAI, model.PostSourceManual})
+	setEnum(d, "Schedule", "status", []string{model.ScheduleStatusScheduled, model.ScheduleStatusPublishing, model.ScheduleStatusPublished, model.ScheduleStatusFailed})
+
+	notFound := jsonResponse("Post not found, or owned by another user", s.err)
+	tooLong := jsonResponse("The input is too long for the model", s.err)
+	aiFailed := jsonResponse("The AI provider failed, or rejected the server's credentials", s.err)
+	invalid := jsonResponse("Validation failed", s.err)
+	generated := func(desc string) *openapi.Response {
+		r := jsonResponse(desc, transform)
+		r.Headers = map[string]openapi.Header{
+			"X-Cache": {Description: "HIT when the post came from the AI response cache, otherwise MISS", Schema: &openapi.Schema{Type: "string", Enum: []string{"HIT", "MISS"}}},
+		}
+		return r
+	}
+	pagination := []openapi.Parameter{
+		queryParam("limit", "Page size, from 1, capped at 100", &openapi.Schema{Type: "integer", Format: "int32"}),
+		queryParam("offset", "Number of posts to skip", &openapi.Schema{Type: "integer", Format: "int32"}),
+	}
+	id := pathParam("id", "Post ID")
+
+	idempotencyKey := openapi.Parameter{
+		Name:        idempotencyKeyHeader,
+		In:          "header",
+		Description: fmt.Sprintf("Up to %d characters. Repeating the key returns the original post, marked with %s: true, instead of generating another", maxIdempotencyKeyLength, idempotentReplayedHeader),
+		Schema:      &openapi.Schema{Type: "string"},
+	}
+	d.Add(http.MethodPost, "/posts", &openapi.Operation{
+		Summary:     "Generate a LinkedIn post",
+		Tags:        []string{"posts"},
+		Security:    bearerOrAPIKey(),
+		Parameters:  []openapi.Parameter{idempotencyKey},
+		RequestBody: jsonBody(generateReq),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("With dry_run, the prompt the model would have been sent; nothing is generated, rate limited or counted towards the quota", d.Component("DryRunResponse", dryRunResponse{})),
+			"201": generated("The saved draft and token usage"),
+			"400": invalid,
+			"401": unauthorized(),
+			"402": jsonResponse("This month's generation quota is used up", s.quota),
+			"409": jsonResponse("The post is nearly identical to one you generated recently, named by duplicate_of; it is saved with force", d.Component("DuplicateError", duplicateResponse{})),
+			"422": jsonResponse("The text was rejected by the input filter, the generated post was flagged by moderation, or the Idempotency-Key was used for a different request", s.flagged),
+			"413": tooLong,
+			"429": rateLimited(),
+			"502": aiFailed,
+		},
+	})
+	d.Add(http.MethodPost, "/posts/stream", &openapi.Operation{
+		Summary:     "Generate a LinkedIn post as Server-Sent Events",
+		Tags:        []string{"posts"},
+		Security:    bearerOrAPIKey(),
+		RequestBody: jsonBody(transformReq),
+		Responses: map[string]*openapi.Response{
+			"200": {
+				Description: `"token" events with each chunk, then a "done" event with the "id" of the saved draft, or an "error" event`,
+				Content:     map[string]openapi.MediaType{"text/event-stream": {Schema: &openapi.Schema{Type: "string"}}},
+			},
+			"400": invalid,
+			"401": unauthorized(),
+			"402": jsonResponse("This month's generation quota is used up", s.quota),
+			"413": tooLong,
+			"429": rateLimited(),
+			"502": aiFailed,
+		},
+	})
+	d.Add(http.MethodPost, "/posts/batch", &openapi.Operation{
+		Summary:     fmt.Sprintf("Generate up to %d posts in one call", service.MaxBatchSize),
+		Tags:        []string{"posts"},
+		Security:    bearerOrAPIKey(),
+		RequestBody: jsonBody(d.Component("BatchRequest", batchBody{})),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("One result per item, in order; each item counts towards the rate limit and quota and may fail on its own", d.Component("BatchResult", batchResponse{})),
+			"400": invalid,
+			"401": unauthorized(),
+			"429": rateLimited(),
+		},
+	})
+	d.Add(http.MethodGet, "/posts", &openapi.Operation{
+		Summary:  "List your posts, newest first unless sorted otherwise",
+		Tags:     []string{"posts"},
+		Security: bearerOrAPIKey(),
+		Parameters: append(pagination,
+			queryParam("status", "Only posts with this status", &openapi.Schema{Type: "string", Enum: statuses}),
+			queryParam("favorited", "Only your favorites (true) or the other posts (false)", &openapi.Schema{Type: "boolean"}),
+			queryParam("sort", "Order of the posts, created_desc by default; updated_desc counts a never edited post as edited when it was created. A cursor only continues the sort it was issued for", &openapi.Schema{Type: "string", Enum: enumValues(service.PostSorts)}),
+			queryParam("cursor", "Page by cursor instead of offset: empty for the first page, then the previous page's meta.next_cursor. The meta then holds limit and next_cursor, null on the last page, instead of total and offset. Cannot be combined with offset", &openapi.Schema{Type: "string"})),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("A page of posts", page),
+			"400": invalid,
+			"401": unauthorized(),
+		},
+	})
+	d.Add(http.MethodGet, "/posts/search", &openapi.Operation{
+		Summary:    "Full-text search over your posts",
+		Tags:       []string{"posts"},
+		Security:   bearerOrAPIKey(),
+		Parameters: append([]openapi.Parameter{{Name: "q", In: "query", Required: true, Schema: &openapi.Schema{Type: "string"}}}, pagination...),
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("A page of posts, most relevant first", page),
+			"400": invalid,
+			"401": unauthorized(),
+		},
+	})
+	d.Add(http.MethodGet, "/posts/export", &openapi.Operation{
+		Summary:    "Download all your posts",
+		Tags:       []string{"posts"},
+		Security:   bearerOrAPIKey(),
+		Parameters: []openapi.Parameter{queryParam("format", "Export format; json by default", &openapi.Schema{Type: "string", Enum: []string{"json", "csv"}})},
+		Responses: map[string]*openapi.Response{
+			"200": {
+				Description: "Every post, newest first, as an attachment",
+				Headers: map[string]openapi.Header{
+					"Content-Disposition": {Description: "attachment with a dated file name", Schema: &openapi.Schema{Type: "string"}},
+				},
+				Content: map[string]openapi.MediaType{
+					"application/json": {Schema: &openapi.Schema{Type: "array", Items: d.Component("ExportedPost", exportItem{})}},
+					"text/csv":         {Schema: &openapi.Schema{Type: "string"}},
+				},
+			},
+			"400": invalid,
+			"401": unauthorized(),
+		},
+	})
+	withETag := func(r *openapi.Response) *openapi.Response {
+		r.Headers = map[string]openapi.Header{
+			"ETag": {Description: "Entity tag of the post, for If-None-Match and If-Match", Schema: &openapi.Schema{Type: "string"}},
+		}
+		return r
+	}
+	etagParam := func(name, description string) openapi.Parameter {
+		return openapi.Parameter{Name: name, In: "header", Description: description, Schema: &openapi.Schema{Type: "string"}}
+	}
+	d.Add(http.MethodGet, "/posts/{id}", &openapi.Operation{
+		Summary:    "Get a post",
+		Tags:       []string{"posts"},
+		Security:   bearerOrAPIKey(),
+		Parameters: []openapi.Parameter{id, etagParam("If-None-Match", "ETag of the copy you have; answered with 304 while the post is unchanged")},
+		Responses: map[string]*openapi.Response{
+			"200": withETag(jsonResponse("The post", post)),
+			"304": withETag(&openapi.Response{Description: "The post is unchanged since the ETag in If-None-Match"}),
+			"400": invalid,
+			"401": unauthorized(),
+			"404": notFound,
+		},
+	})
+	d.Add(http.MethodPatch, "/posts/{id}", &openapi.Operation{
+		Summary:     "Edit a post's text or status",
+		Tags:        []string{"posts"},
+		Security:    bearerOrAPIKey(),
+		Parameters:  []openapi.Parameter{id, etagParam("If-Match", "ETag the post must still have; otherwise nothing is changed and the response is 412")},
+		RequestBody: jsonBody(update),
+		Responses: map[string]*openapi.Response{
+			"200": withETag(jsonResponse("The updated post", post)),
+			"400": invalid,
+			"401": unauthorized(),
+			"404": notFound,
+			"412": jsonResponse("The post was changed since the ETag in If-Match was read", s.err),
+		},
+	})
+	d.Add(http.MethodDelete, "/posts/{id}", &openapi.Operation{
+		Summary:    "Soft-delete a post",
+		Tags:       []string{"posts"},
+		Security:   bearerOrAPIKey(),
+		Parameters: []openapi.Parameter{id},
+		Responses: map[string]*openapi.Response{
+			"204": {Description: "Deleted"},
+			"400": invalid,
+			"401": unauthorized(),
+			"404": notFound,
+		},
+	})
+	d.Add(http.MethodPost, "/posts/{id}/restore", &openapi.Operation{
+		Summary:    "Restore a deleted post",
+		Tags:       []string{"posts"},
+		Security:   bearerOrAPIKey(),
+		Parameters: []openapi.Parameter{id},
+		Responses: map[string]*openapi.Response{
+			"204": {Description: "Restored"},
+			"400": invalid,
+			"401": unauthorized(),
+			"404": notFound,
+		},
+	})
+	d.Add(http.MethodPost, "/posts/{id}/favorite", &openapi.Operation{
+		Summary:    "Favorite a post",
+		Tags:       []string{"posts"},
+		Security:   bearerOrAPIKey(),
+		Parameters: []openapi.Parameter{id},
+		Responses: map[string]*openapi.Response{
+			"204": {Description: "Favorited"},
+			"400": invalid,
+			"401": unauthorized(),
+			"404": notFound,
+		},
+	})
+	d.Add(http.MethodDelete, "/posts/{id}/favorite", &openapi.Operation{
+		Summary:    "Remove a post from your favorites",
+		Tags:       []string{"posts"},
+		Security:   bearerOrAPIKey(),
+		Parameters: []openapi.Parameter{id},
+		Responses: map[string]*openapi.Response{
+			"204": {Description: "Unfavorited"},
+			"400": invalid,
+			"401": unauthorized(),
+			"404": notFound,
+		},
+	})
+	feedback := d.Component("FeedbackRequest", feedbackBody{})
+	setEnum(d, "FeedbackRequest", "rating", []string{model.FeedbackUp, model.FeedbackDown})
+	d.Components.Schemas["FeedbackRequest"].Properties["comment"].MaxLength = service.MaxFeedbackCommentLength
+	d.Add(http.MethodPost, "/posts/{id}/feedback", &openapi.Operation{
+		Summary:     "Rate a post thumbs up or down, replacing your earlier rating",
+		Tags:        []string{"posts"},
+		Security:    bearerOrAPIKey(),
+		Parameters:  []openapi.Parameter{id},
+		RequestBody: jsonBody(feedback),
+		Responses: map[string]*openapi.Response{
+			"201": jsonResponse("The recorded rating", d.Component("Feedback", feedbackResponse{})),
+			"400": invalid,
+			"401": unauthorized(),
+			"404": notFound,
+		},
+	})
+	d.Add(http.MethodPost, "/posts/{id}/regenerate", &openapi.Operation{
+		Summary:     "Generate a new take on a post from its original input",
+		Tags:        []string{"posts"},
+		Security:    bearerOrAPIKey(),
+		Parameters:  []openapi.Parameter{id},
+		RequestBody: &openapi.RequestBody{Content: openapi.JSON(regenerate)},
+		Responses: map[string]*openapi.Response{
+			"200": generated("The regenerated post and token usage"),
+			"400": invalid,
+			"401": unauthorized(),
+			"402": jsonResponse("This month's generation quota is used up", s.quota),
+			"404": notFound,
+			"422": jsonResponse("The generated post was flagged by moderation", s.flagged),
+			"413": tooLong,
+			"429": rateLimited(),
+			"502": aiFailed,
+		},
+	})
+	image := d.Component("ImageRequest", imageBody{})
+	setEnum(d, "ImageRequest", "size", ai.ImageSizes)
+	d.Add(http.MethodPost, "/posts/{id}/image", &openapi.Operation{
+		Summary:     "Generate an image to accompany a post",
+		Tags:        []string{"posts"},
+		Security:    bearerOrAPIKey(),
+		Parameters:  []openapi.Parameter{id},
+		RequestBody: &openapi.RequestBody{Content: openapi.JSON(image)},
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("The image URL, stored on the post; it expires after about an hour", d.Component("Image", imageResponse{})),
+			"400": invalid,
+			"401": unauthorized(),
+			"404": notFound,
+			"429": rateLimited(),
+			"501": jsonResponse("Image generation is not enabled", s.err),
+			"504": jsonResponse("Image generation timed out", s.err),
+		},
+	})
+	d.Add(http.MethodPost, "/posts/{id}/hashtags", &openapi.Operation{
+		Summary:    "Suggest hashtags for a post",
+		Tags:       []string{"posts"},
+		Security:   bearerOrAPIKey(),
+		Parameters: []openapi.Parameter{id},
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("Between 5 and 10 lowercase hashtags; the post is not changed", d.Component("Hashtags", hashtagsResponse{})),
+			"400": invalid,
+			"401": unauthorized(),
+			"404": notFound,
+			"429": rateLimited(),
+			"502": jsonResponse("The AI did not return any hashtags", s.err),
+		},
+	})
+	d.Add(http.MethodPost, "/posts/{id}/publish", &openapi.Operation{
+		Summary:    "Publish a post to your LinkedIn feed",
+		Tags:       []string{"posts"},
+		Security:   bearerOrAPIKey(),
+		Parameters: []openapi.Parameter{id},
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("The post is live; linkedin_url links to it", d.Component("Published", publishResponse{})),
+			"400": invalid,
+			"401": unauthorized(),
+			"404": notFound,
+			"409": jsonResponse("The post was already published or is being published right now, or your LinkedIn account is not connected or has to be connected again (code linkedin_not_connected)", s.err),
+			"501": jsonResponse("Publishing to LinkedIn is not enabled", s.err),
+		},
+	})
+	d.Add(http.MethodPost, "/posts/{id}/schedule", &openapi.Operation{
+		Summary:     "Schedule a post to be published to your LinkedIn feed later",
+		Tags:        []string{"posts"},
+		Security:    bearerOrAPIKey(),
+		Parameters:  []openapi.Parameter{id},
+		RequestBody: jsonBody(d.Component("ScheduleRequest", scheduleBody{})),
+		Responses: map[string]*openapi.Response{
+			"202": jsonResponse("The post is scheduled; failed attempts are retried, and the post's schedule shows how it went", scheduled),
+			"400": jsonResponse("The time is missing, in the past or more than a year away", s.err),
+			"401": unauthorized(),
+			"404": notFound,
+			"409": jsonResponse("The post was already published or is being published, or your LinkedIn account is not connected (code linkedin_not_connected)", s.err),
+			"501": jsonResponse("Publishing to LinkedIn is not enabled", s.err),
+		},
+	})
+	d.Add(http.MethodGet, "/posts/{id}/versions", &openapi.Operation{
+		Summary:    "List a post's earlier versions, newest first",
+		Tags:       []string{"posts"},
+		Security:   bearerOrAPIKey(),
+		Parameters: []openapi.Parameter{id},
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("The post's versions", versions),
+			"400": invalid,
+			"401": unauthorized(),
+			"404": notFound,
+		},
+	})
+	d.Add(http.MethodPost, "/posts/{id}/versions/{versionID}/restore", &openapi.Operation{
+		Summary:    "Roll a post back to one of its versions",
+		Tags:       []string{"posts"},
+		Security:   bearerOrAPIKey(),
+		Parameters: []openapi.Parameter{id, pathParam("versionID", "Version ID")},
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse("The restored post", post),
+			"400": invalid,
+			"401": unauthorized(),
+			"404": jsonResponse("Post or version not found", s.err),
+		},
+	})
+}
+
+func bearer() []openapi.SecurityRequirement {
+	return []openapi.SecurityRequirement{{bearerAuth: {}}}
+}
+
+// bearerOrAPIKey is for the posts routes, which also accept API keys.
+func bearerOrAPIKey() []openapi.SecurityRequirement {
+	return []openapi.SecurityRequirement{{bearerAuth: {}}, {apiKeyAuth: {}}}
+}
+
+func addWebhookPaths(d *openapi.Document, s specSchemas) {
+	delivery := d.Component("DeliveryStatus", deliveryStatus{})
+	setEnum(d, "DeliveryStatus", "event", deliveryEvents)
+	d.Add(http.MethodPost, "/webhooks/linkedin", &openapi.Operation{
+		Summary: "Receive a delivery status callback for a post sent to LinkedIn; served when INBOUND_WEBHOOK_SECRET is set",
+		Tags:    []string{"webhooks"},
+		Parameters: []openapi.Parameter{{
+			Name:        webhook.SignatureHeader,
+			In:          "header",
+			Description: `"t=" and the Unix time of signing, then ",sha256=" and the hex HMAC-SHA256 of the time, a dot and the raw body, keyed with the shared secret`,
+			Required:    true,
+			Schema:      &openapi.Schema{Type: "string"},
+		}},
+		RequestBody: jsonBody(delivery),
+		Responses: map[string]*openapi.Response{
+			"204": {Description: "Received"},
+			"400": jsonResponse("Validation failed", s.err),
+			"401": jsonResponse("The signature is missing, invalid, or older than WEBHOOK_TOLERANCE", s.err),
+			"413": jsonResponse("The payload is larger than 64 KiB", s.err),
+		},
+	})
+}
+
+func jsonBody(s *openapi.Schema) *openapi.RequestBody {
+	return &openapi.RequestBody{Required: true, Content: openapi.JSON(s)}
+}
+
+func jsonResponse(desc string, s *openapi.Schema) *openapi.Response {
+	return &openapi.Response{Description: desc, Content: openapi.JSON(s)}
+}
+
+// errorDoc documents an error response with the envelope of WriteError.
+func errorDoc(desc string) *openapi.Response {
+	return jsonResponse(desc, openapi.Ref("Error"))
+}
+
+func unauthorized() *openapi.Response {
+	return errorDoc("Missing, invalid, expired or revoked access token")
+}
+
+func rateLimited() *openapi.Response {
+	r := errorDoc("Rate limit exceeded")
+	r.Headers = map[string]openapi.Header{
+		"Retry-After": {Description: "Seconds until the next request is allowed", Schema: &openapi.Schema{Type: "integer", Format: "int32"}},
+	}
+	for name, desc := range rateLimitHeaders {
+		r.Headers[name] = openapi.Header{Description: desc, Schema: &openapi.Schema{Type: "integer", Format: "int32"}}
+	}
+	return r
+}
+
+func loginLocked() *openapi.Response {
+	r := errorDoc("Too many failed logins for this email from this IP")
+	r.Headers = map[string]openapi.Header{
+		"Retry-After": {Description: "Seconds until logins are accepted again", Schema: &openapi.Schema{Type: "integer", Format: "int32"}},
+	}
+	return r
+}
+
+// rateLimitHeaders describes the headers every response of a rate-limited
+// route reports the limit in, under their default names.
+var rateLimitHeaders = map[string]string{
+	middleware.DefaultRateLimitHeaderPrefix + "Limit":     "Requests you may send in a burst; sent on every response of a rate-limited route",
+	middleware.DefaultRateLimitHeaderPrefix + "Remaining": "Requests you may send right now",
+	middleware.DefaultRateLimitHeaderPrefix + "Reset":     "Seconds until the full limit is available again",
+}
+
+func pathParam(name, desc string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "path", Description: desc, Required: true, Schema: &openapi.Schema{Type: "string", Format: "uuid"}}
+}
+
+func queryParam(name, desc string, s *openapi.Schema) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: desc, Schema: s}
+}
+
+// setEnum restricts a string property of a registered component.
+func setEnum(d *openapi.Document, component, property string, values []string) {
+	d.Components.Schemas[component].Properties[property].Enum = values
+}
+
+func enumValues[T ~string](values []T) []string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+)
+
+func TestOpenAPI_ServesSpec(t *testing.T) {
+	rr := httptest.NewRecorder()
+	handler.OpenAPI(rr, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var spec struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			Security  []map[string][]string      `json:"security"`
+			Responses map[string]json.RawMessage `json:"responses"`
+		} `json:"paths"`
+		Components struct {
+			Schemas         map[string]json.RawMessage `json:"schemas"`
+			SecuritySchemes map[string]struct {
+				Type         string `json:"type"`
+				Scheme       string `json:"scheme"`
+				BearerFormat string `json:"bearerFormat"`
+			} `json:"securitySchemes"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &spec))
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+
+	for _, path := range []string{"/auth/login", "/auth/register", "/orgs", "/orgs/current", "/linkedin/connect", "/posts", "/posts/{id}", "/posts/{id}/versions"} {
+		assert.Contains(t, spec.Paths, path)
+	}
+	scheme := spec.Components.SecuritySchemes["bearerAuth"]
+	assert.Equal(t, "http", scheme.Type)
+	assert.Equal(t, "bearer", scheme.Scheme)
+	assert.Equal(t, "JWT", scheme.BearerFormat)
+
+	create := spec.Paths["/posts"]["post"]
+	assert.Contains(t, create.Responses, "400")
+	assert.Contains(t, create.Responses, "429")
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}, {"apiKeyAuth": {}}}, create.Security, "posts accept a token or an API key")
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, spec.Paths["/users/me/api-keys"]["post"].Security, "API keys cannot create API keys")
+	assert.Empty(t, spec.Paths["/auth/login"]["post"].Security, "login must be public")
+}
+
+func TestOpenAPIDocument_SchemasFollowDTOs(t *testing.T) {
+	doc := handler.OpenAPIDocument()
+
+	req := doc.Components.Schemas["TransformRequest"]
+	require.NotNil(t, req)
+	assert.Equal(t, []string{"text"}, req.Required)
+	assert.Equal(t, []string{"professional", "casual", "inspirational", "humorous"}, req.Properties["tone"].Enum)
+
+	usage := doc.Components.Schemas["Usage"]
+	require.NotNil(t, usage)
+	assert.Contains(t, usage.Properties, "prompt_tokens", "embedded ai.Usage fields are flattened")
+	assert.Equal(t, "#/components/schemas/Usage", doc.Components.Schemas["TransformResponse"].Properties["usage"].Ref)
+	assert.Equal(t, "#/components/schemas/Quota", doc.Components.Schemas["BatchResult"].Properties["results"].Items.Properties["quota"].Ref, "Quotas and token usage are different schemas")
+
+	assert.Equal(t, "uuid", doc.Components.Schemas["Post"].Properties["id"].Format)
+	assert.Equal(t, "date-time", doc.Components.Schemas["PostVersion"].Properties["created_at"].Format)
+}
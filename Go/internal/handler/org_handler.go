@@ -0,0 +1,160 @@
+// internal/handler/org_handler.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/service"
+)
+
+type OrgHandler struct {
+	svc service.OrgServiceInteractor
+}
+
+func NewOrg(svc service.OrgServiceInteractor) *OrgHandler {
+	return &OrgHandler{svc: svc}
+}
+
+// Routes returns the API for the authenticated user's organization.
+func (h *OrgHandler) Routes(secret []byte, opts ...middleware.AuthOption) chi.Router {
+	r := chi.NewRouter()
+	r.Use(middleware.Auth(secret, opts...))
+	r.Post("/", h.create)
+	r.Get("/current", h.current)
+	r.Post("/current/invitations", h.invite)
+	r.Post("/invitations/accept", h.accept)
+	return r
+}
+
+type orgBody struct {
+	Name string `json:"name"`
+}
+
+type orgMemberResponse struct {
+	ID    uuid.UUID `json:"id"`
+	Email string    `json:"email"`
+	Name  string    `json:"name"`
+	Role  string    `json:"role"`
+}
+
+type orgResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Personal bool      `json:"personal"`
+	// Role is the authenticated user's role in the organization.
+	Role      string              `json:"role"`
+	Members   []orgMemberResponse `json:"members"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+func toOrgResponse(o *service.Org) orgResponse {
+	out := orgResponse{
+		ID:        o.ID,
+		Name:      o.Name,
+		Personal:  o.Personal,
+		Role:      o.Role,
+		Members:   make([]orgMemberResponse, len(o.Members)),
+		CreatedAt: o.CreatedAt,
+	}
+	for i, m := range o.Members {
+		out.Members[i] = orgMemberResponse{ID: m.ID, Email: m.Email, Name: m.Name, Role: m.OrgRole}
+	}
+	return out
+}
+
+type invitationBody struct {
+	Email string `json:"email"`
+}
+
+type invitationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type acceptInvitationBody struct {
+	Token string `json:"token"`
+}
+
+// create starts a team organization and moves the user into it as owner.
+func (h *OrgHandler) create(w http.ResponseWriter, r *http.Request) {
+	var in orgBody
+	if err := decodeJSON(r, &in); err != nil {
+		respondDecodeError(w, err)
+		return
+	}
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		respondError(w, http.StatusBadRequest, "The 'name' field is required")
+		return
+	}
+	if utf8.RuneCountInString(name) > service.MaxOrgNameLength {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("The 'name' field must be at most %d characters", service.MaxOrgNameLength))
+		return
+	}
+
+	org, err := h.svc.Create(r.Context(), middleware.UserID(r.Context()), name)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to create organization")
+		return
+	}
+	respondJSON(w, http.StatusCreated, toOrgResponse(org))
+}
+
+func (h *OrgHandler) current(w http.ResponseWriter, r *http.Request) {
+	org, err := h.svc.Current(r.Context(), middleware.UserID(r.Context()))
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to load organization")
+		return
+	}
+	respondJSON(w, http.StatusOK, toOrgResponse(org))
+}
+
+// invite emails an invitation to join the user's organization.
+func (h *OrgHandler) invite(w http.ResponseWriter, r *http.Request) {
+	var in invitationBody
+	if err := decodeJSON(r, &in); err != nil {
+		respondDecodeError(w, err)
+		return
+	}
+	if strings.TrimSpace(in.Email) == "" {
+		respondError(w, http.StatusBadRequest, "The 'email' field is required")
+		return
+	}
+
+	inv, err := h.svc.Invite(r.Context(), middleware.UserID(r.Context()), in.Email)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to send invitation")
+		return
+	}
+	respondJSON(w, http.StatusCreated, invitationResponse{ID: inv.ID, Email: inv.Email, ExpiresAt: inv.ExpiresAt})
+}
+
+// accept moves the user into the organization of an invitation sent to
+// their email.
+func (h *OrgHandler) accept(w http.ResponseWriter, r *http.Request) {
+	var in acceptInvitationBody
+	if err := decodeJSON(r, &in); err != nil {
+		respondDecodeError(w, err)
+		return
+	}
+	if in.Token == "" {
+		respondError(w, http.StatusBadRequest, "The 'token' field is required")
+		return
+	}
+
+	org, err := h.svc.AcceptInvitation(r.Context(), middleware.UserID(r.Context()), in.Token)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to accept invitation")
+		return
+	}
+	respondJSON(w, http.StatusOK, toOrgResponse(org))
+}
@@ -0,0 +1,96 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestOrgHandler(t *testing.T) {
+	userID := uuid.New()
+	team := &service.Org{
+		Organization: model.Organization{ID: uuid.New(), Name: "Acme", CreatedAt: time.Now()},
+		Role:         model.OrgRoleOwner,
+		Members:      []model.User{{ID: userID, Email: "owner@example.com", OrgRole: model.OrgRoleOwner, PasswordHash: "secret-hash"}},
+	}
+	mockService := &service.OrgServiceInteractorMock{
+		CreateFunc: func(ctx context.Context, id uuid.UUID, name string) (*service.Org, error) {
+			return team, nil
+		},
+		CurrentFunc: func(ctx context.Context, id uuid.UUID) (*service.Org, error) {
+			return team, nil
+		},
+		InviteFunc: func(ctx context.Context, id uuid.UUID, email string) (*model.OrgInvitation, error) {
+			if email == "member@example.com" {
+				return nil, service.ErrAlreadyMember
+			}
+			return &model.OrgInvitation{ID: uuid.New(), Email: email, TokenHash: "stored-hash", ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+		AcceptInvitationFunc: func(ctx context.Context, id uuid.UUID, token string) (*service.Org, error) {
+			return nil, service.ErrInvalidInvitation
+		},
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewOrg(mockService).Routes(testSecret))
+	defer server.Close()
+	token := generateTestToken(t, userID, testSecret)
+
+	do := func(method, path, body string) (int, map[string]interface{}) {
+		req, _ := http.NewRequest(method, server.URL+path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var out map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		return resp.StatusCode, out
+	}
+
+	status, body := do(http.MethodPost, "/", `{"name": "  Acme "}`)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, team.ID.String(), body["id"])
+	assert.Equal(t, "owner", body["role"])
+	require.Len(t, body["members"], 1)
+	assert.NotContains(t, body["members"].([]interface{})[0], "password_hash")
+	assert.Equal(t, "Acme", mockService.CreateCalls()[0].Name, "Names are trimmed")
+
+	for _, in := range []string{`{}`, `{"name": "   "}`, `{"name": "` + strings.Repeat("a", service.MaxOrgNameLength+1) + `"}`} {
+		status, body = do(http.MethodPost, "/", in)
+		assert.Equal(t, http.StatusBadRequest, status, in)
+		assert.Equal(t, "validation", body["error"].(map[string]interface{})["code"])
+	}
+	assert.Len(t, mockService.CreateCalls(), 1)
+
+	status, body = do(http.MethodGet, "/current", "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Acme", body["name"])
+	assert.Equal(t, false, body["personal"])
+
+	status, body = do(http.MethodPost, "/current/invitations", `{"email": "guest@example.com"}`)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "guest@example.com", body["email"])
+	assert.NotContains(t, body, "token_hash")
+	status, body = do(http.MethodPost, "/current/invitations", `{"email": "member@example.com"}`)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "conflict", body["error"].(map[string]interface{})["code"])
+
+	status, _ = do(http.MethodPost, "/invitations/accept", `{}`)
+	assert.Equal(t, http.StatusBadRequest, status, "The token is required")
+	assert.Empty(t, mockService.AcceptInvitationCalls())
+	status, body = do(http.MethodPost, "/invitations/accept", `{"token": "made-up"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "Invalid or expired invitation", body["error"].(map[string]interface{})["message"])
+}
@@ -0,0 +1,369 @@
+// internal/handler/user_handler.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/service"
+)
+
+type UserHandler struct {
+	svc service.UserServiceInteractor
+}
+
+func NewUser(svc service.UserServiceInteractor) *UserHandler {
+	return &UserHandler{svc: svc}
+}
+
+// UserFromContext returns the authenticated user, loaded once per request by
+// the Auth middleware when the routes are mounted with
+// middleware.WithUserLoader; ok is false otherwise. The user is shared by the
+// whole request and must not be modified.
+func UserFromContext(ctx context.Context) (*model.User, bool) {
+	return middleware.User(ctx)
+}
+
+// Routes returns the API for the authenticated user's own account.
+func (h *UserHandler) Routes(secret []byte, opts ...middleware.AuthOption) chi.Router {
+	r := chi.NewRouter()
+	r.Use(middleware.Auth(secret, opts...))
+	r.Get("/me", h.me)
+	r.Patch("/me", h.updateMe)
+	r.Delete("/me", h.deleteMe)
+	r.Get("/me/usage", h.usage)
+	r.Get("/me/stats", h.stats)
+	r.Get("/me/api-keys", h.listAPIKeys)
+	r.Post("/me/api-keys", h.createAPIKey)
+	r.Delete("/me/api-keys/{id}", h.revokeAPIKey)
+	return r
+}
+
+type profileResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	Plan      string    `json:"plan"`
+	Name      string    `json:"name"`
+	Headline  string    `json:"headline"`
+	Industry  string    `json:"industry"`
+	OrgID     uuid.UUID `json:"org_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toProfileResponse(u *model.User) profileResponse {
+	return profileResponse{
+		ID:        u.ID,
+		Email:     u.Email,
+		Role:      u.Role,
+		Plan:      u.Plan,
+		Name:      u.Name,
+		Headline:  u.Headline,
+		Industry:  u.Industry,
+		OrgID:     u.OrgID,
+		CreatedAt: u.CreatedAt,
+	}
+}
+
+type profileBody struct {
+	Name     *string `json:"name,omitempty"`
+	Headline *string `json:"headline,omitempty"`
+	Industry *string `json:"industry,omitempty"`
+}
+
+// profileFieldLimits maps each editable field to its maximum length.
+var profileFieldLimits = map[string]int{
+	"name":     service.MaxNameLength,
+	"headline": service.MaxHeadlineLength,
+	"industry": service.MaxIndustryLength,
+}
+
+// immutableProfileFields are returned by GET /users/me but cannot be edited.
+var immutableProfileFields = map[string]bool{"id": true, "email": true, "role": true, "plan": true, "org_id": true, "created_at": true}
+
+// validateProfile checks the raw fields of a profile update and returns a
+// client-facing message when something is wrong.
+func validateProfile(raw map[string]json.RawMessage) string {
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		limit, ok := profileFieldLimits[k]
+		if !ok {
+			if immutableProfileFields[k] {
+				return fmt.Sprintf("The '%s' field cannot be changed", k)
+			}
+			return fmt.Sprintf("Unknown field: %s", k)
+		}
+		var v string
+		if err := json.Unmarshal(raw[k], &v); err != nil {
+			return fmt.Sprintf("The '%s' field must be a string", k)
+		}
+		if utf8.RuneCountInString(v) > limit {
+			return fmt.Sprintf("The '%s' field must be at most %d characters", k, limit)
+		}
+	}
+	if len(keys) == 0 {
+		return "Nothing to update: set 'name', 'headline' and/or 'industry'"
+	}
+	return ""
+}
+
+func (h *UserHandler) me(w http.ResponseWriter, r *http.Request) {
+	u, err := h.svc.Get(r.Context(), middleware.UserID(r.Context()))
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to load profile")
+		return
+	}
+	respondJSON(w, http.StatusOK, toProfileResponse(u))
+}
+
+// updateMe edits the profile fields of the authenticated user. The account's
+// email, ID and role are not editable here.
+func (h *UserHandler) updateMe(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondDecodeError(w, err)
+		return
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if msg := validateProfile(raw); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	// validateProfile has checked every field is a string.
+	var in profileBody
+	_ = json.Unmarshal(data, &in)
+
+	u, err := h.svc.UpdateProfile(r.Context(), middleware.UserID(r.Context()), service.ProfileUpdate{
+		Name:     in.Name,
+		Headline: in.Headline,
+		Industry: in.Industry,
+	})
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to update profile")
+		return
+	}
+	respondJSON(w, http.StatusOK, toProfileResponse(u))
+}
+
+type deleteAccountBody struct {
+	Password string `json:"password"`
+}
+
+// deleteMe erases the authenticated user's account and all of its data after
+// the password has been re-entered. Repeating the request once the account is
+// gone gets a 401, as the token no longer names a user.
+func (h *UserHandler) deleteMe(w http.ResponseWriter, r *http.Request) {
+	var in deleteAccountBody
+	if err := decodeJSON(r, &in); err != nil {
+		respondDecodeError(w, err)
+		return
+	}
+	if in.Password == "" {
+		respondError(w, http.StatusBadRequest, "The 'password' field is required to delete your account")
+		return
+	}
+
+	err := h.svc.DeleteAccount(r.Context(), middleware.UserID(r.Context()), in.Password)
+	switch {
+	case err == nil:
+		slog.InfoContext(r.Context(), "account deleted")
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, service.ErrUserNotFound):
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+	default:
+		respondServiceError(w, r, err, "Failed to delete account")
+	}
+}
+
+type apiKeyBody struct {
+	Name string `json:"name"`
+}
+
+type apiKeyResponse struct {
+	ID uuid.UUID `json:"id"`
+	// Prefix is the start of the key, to tell keys apart.
+	Prefix    string    `json:"prefix"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// createdAPIKeyResponse is the only response that includes the key itself.
+type createdAPIKeyResponse struct {
+	apiKeyResponse
+	Key string `json:"key"`
+}
+
+type apiKeysResponse struct {
+	Data []apiKeyResponse `json:"data"`
+}
+
+func toAPIKeyResponse(k model.APIKey) apiKeyResponse {
+	return apiKeyResponse{ID: k.ID, Prefix: k.Prefix, Name: k.Name, CreatedAt: k.CreatedAt}
+}
+
+// createAPIKey issues an API key for server-to-server use of the posts
+// routes. The body, with an optional name, may be omitted.
+func (h *UserHandler) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	var in apiKeyBody
+	if err := decodeJSON(r, &in); err != nil && !errors.Is(err, io.EOF) {
+		respondDecodeError(w, err)
+		return
+	}
+	if utf8.RuneCountInString(in.Name) > service.MaxAPIKeyNameLength {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("The 'name' field must be at most %d characters", service.MaxAPIKeyNameLength))
+		return
+	}
+
+	k, err := h.svc.CreateAPIKey(r.Context(), middleware.UserID(r.Context()), in.Name)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to create API key")
+		return
+	}
+	respondJSON(w, http.StatusCreated, createdAPIKeyResponse{apiKeyResponse: toAPIKeyResponse(k.APIKey), Key: k.Key})
+}
+
+func (h *UserHandler) listAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.svc.ListAPIKeys(r.Context(), middleware.UserID(r.Context()))
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to list API keys")
+		return
+	}
+	out := apiKeysResponse{Data: make([]apiKeyResponse, len(keys))}
+	for i, k := range keys {
+		out.Data[i] = toAPIKeyResponse(k)
+	}
+	respondJSON(w, http.StatusOK, out)
+}
+
+func (h *UserHandler) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid API key ID")
+		return
+	}
+	err = h.svc.RevokeAPIKey(r.Context(), middleware.UserID(r.Context()), keyID)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to revoke API key")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// quotaResponse describes a user's generations this month. Limit and
+// Remaining are null on a plan without a quota.
+type quotaResponse struct {
+	Plan      string    `json:"plan"`
+	Limit     *int      `json:"limit"`
+	Used      int       `json:"used"`
+	Remaining *int      `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+func toQuotaResponse(u service.Usage) quotaResponse {
+	out := quotaResponse{Plan: u.Plan, Used: u.Used, ResetsAt: u.ResetsAt}
+	if u.Limit != service.Unlimited {
+		limit, remaining := u.Limit, u.Remaining()
+		out.Limit, out.Remaining = &limit, &remaining
+	}
+	return out
+}
+
+// usage reports how many posts the user generated this month and how many
+// their plan has left.
+func (h *UserHandler) usage(w http.ResponseWriter, r *http.Request) {
+	u, err := h.svc.Usage(r.Context(), middleware.UserID(r.Context()))
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to load usage")
+		return
+	}
+	respondJSON(w, http.StatusOK, toQuotaResponse(*u))
+}
+
+// statsResponse summarizes the user's generations over a period.
+type statsResponse struct {
+	Days        int          `json:"days"`
+	Since       time.Time    `json:"since"`
+	Generations int          `json:"generations"`
+	TotalTokens int          `json:"total_tokens"`
+	Cached      int          `json:"cached"`
+	Daily       []statsDay   `json:"daily"`
+	Tones       []usageCount `json:"tones"`
+	Templates   []usageCount `json:"templates"`
+}
+
+type statsDay struct {
+	Date        string `json:"date"`
+	Generations int    `json:"generations"`
+	TotalTokens int    `json:"total_tokens"`
+	Cached      int    `json:"cached"`
+}
+
+type usageCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func toStatsResponse(s *service.GenerationStats) statsResponse {
+	out := statsResponse{
+		Days:        s.Days,
+		Since:       s.Since,
+		Generations: s.Generations,
+		TotalTokens: s.TotalTokens,
+		Cached:      s.Cached,
+		Daily:       make([]statsDay, len(s.Daily)),
+		Tones:       make([]usageCount, len(s.Tones)),
+		Templates:   make([]usageCount, len(s.Templates)),
+	}
+	for i, d := range s.Daily {
+		out.Daily[i] = statsDay{Date: d.Day.Format(time.DateOnly), Generations: d.Generations, TotalTokens: d.TotalTokens, Cached: d.Cached}
+	}
+	for i, c := range s.Tones {
+		out.Tones[i] = usageCount{Name: c.Name, Count: c.Count}
+	}
+	for i, c := range s.Templates {
+		out.Templates[i] = usageCount{Name: c.Name, Count: c.Count}
+	}
+	return out
+}
+
+// stats reports the user's generations per day over the last 'days' days
+// and their most used tones and templates.
+func (h *UserHandler) stats(w http.ResponseWriter, r *http.Request) {
+	days := service.DefaultStatsDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > service.MaxStatsDays {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("The 'days' parameter must be an integer between 1 and %d", service.MaxStatsDays))
+			return
+		}
+		days = n
+	}
+	stats, err := h.svc.Stats(r.Context(), middleware.UserID(r.Context()), days)
+	if err != nil {
+		respondServiceError(w, r, err, "Failed to load generation stats")
+		return
+	}
+	respondJSON(w, http.StatusOK, toStatsResponse(stats))
+}
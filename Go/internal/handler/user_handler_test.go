@@ -0,0 +1,312 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestUserHandler_me(t *testing.T) {
+	userID := uuid.New()
+	mockService := &service.UserServiceInteractorMock{
+		GetFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			return &model.User{ID: id, Email: "user@example.com", Role: model.RoleUser, Headline: "Staff Engineer", PasswordHash: "secret-hash"}, nil
+		},
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewUser(mockService).Routes(testSecret))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/me", nil)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, userID, testSecret))
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, userID.String(), body["id"])
+	assert.Equal(t, "user@example.com", body["email"])
+	assert.Equal(t, "Staff Engineer", body["headline"])
+	assert.NotContains(t, body, "password_hash")
+	assert.Equal(t, userID, mockService.GetCalls()[0].UserID)
+}
+
+func TestUserHandler_updateMe(t *testing.T) {
+	userID := uuid.New()
+	mockService := &service.UserServiceInteractorMock{
+		UpdateProfileFunc: func(ctx context.Context, id uuid.UUID, u service.ProfileUpdate) (*model.User, error) {
+			return &model.User{ID: id, Email: "user@example.com", Name: *u.Name, Industry: *u.Industry}, nil
+		},
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewUser(mockService).Routes(testSecret))
+	defer server.Close()
+	token := generateTestToken(t, userID, testSecret)
+
+	patch := func(body string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPatch, server.URL+"/me", bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := patch(`{"name": "Ada Lovelace", "industry": "fintech"}`)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Ada Lovelace", body["name"])
+	assert.Equal(t, "fintech", body["industry"])
+	require.Len(t, mockService.UpdateProfileCalls(), 1)
+	call := mockService.UpdateProfileCalls()[0]
+	assert.Equal(t, userID, call.UserID)
+	assert.Nil(t, call.U.Headline, "Fields left out of the body are not changed")
+
+	for name, tc := range map[string]struct{ body, message string }{
+		"email":     {`{"email": "new@example.com"}`, "The 'email' field cannot be changed"},
+		"id":        {`{"name": "Ada", "id": "` + uuid.NewString() + `"}`, "The 'id' field cannot be changed"},
+		"unknown":   {`{"nickname": "ada"}`, "Unknown field: nickname"},
+		"too long":  {`{"headline": "` + strings.Repeat("a", service.MaxHeadlineLength+1) + `"}`, "The 'headline' field must be at most 200 characters"},
+		"not text":  {`{"name": 42}`, "The 'name' field must be a string"},
+		"empty":     {`{}`, "Nothing to update"},
+		"malformed": {`{"name":`, "Invalid request payload"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := patch(tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			var body map[string]map[string]string
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, "validation", body["error"]["code"])
+			assert.Contains(t, body["error"]["message"], tc.message)
+		})
+	}
+	assert.Len(t, mockService.UpdateProfileCalls(), 1, "Rejected updates do not reach the service")
+}
+
+func TestUserHandler_APIKeys(t *testing.T) {
+	userID, keyID := uuid.New(), uuid.New()
+	stored := model.APIKey{ID: keyID, UserID: userID, Name: "CRM sync", Prefix: "lk_abcdef", KeyHash: "hash"}
+	mockService := &service.UserServiceInteractorMock{
+		CreateAPIKeyFunc: func(ctx context.Context, id uuid.UUID, name string) (*service.NewAPIKey, error) {
+			if name == "full" {
+				return nil, service.ErrTooManyAPIKeys
+			}
+			return &service.NewAPIKey{APIKey: stored, Key: "lk_abcdef-secret"}, nil
+		},
+		ListAPIKeysFunc: func(ctx context.Context, id uuid.UUID) ([]model.APIKey, error) {
+			return []model.APIKey{stored}, nil
+		},
+		RevokeAPIKeyFunc: func(ctx context.Context, id, k uuid.UUID) error {
+			if k != keyID {
+				return service.ErrAPIKeyNotFound
+			}
+			return nil
+		},
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewUser(mockService).Routes(testSecret))
+	defer server.Close()
+	token := generateTestToken(t, userID, testSecret)
+
+	do := func(method, path, body string) (*http.Response, map[string]interface{}) {
+		req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var out map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&out)
+		return resp, out
+	}
+
+	resp, body := do(http.MethodPost, "/me/api-keys", `{"name":"CRM sync"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "lk_abcdef-secret", body["key"])
+	assert.Equal(t, "lk_abcdef", body["prefix"])
+	assert.Equal(t, keyID.String(), body["id"])
+	assert.NotContains(t, body, "key_hash")
+	assert.Equal(t, userID, mockService.CreateAPIKeyCalls()[0].UserID)
+
+	resp, _ = do(http.MethodPost, "/me/api-keys", "")
+	assert.Equal(t, http.StatusCreated, resp.StatusCode, "The name is optional")
+	resp, _ = do(http.MethodPost, "/me/api-keys", `{"name":"full"}`)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp, _ = do(http.MethodPost, "/me/api-keys", `{"name":"`+strings.Repeat("n", 101)+`"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, body = do(http.MethodGet, "/me/api-keys", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data := body["data"].([]interface{})
+	require.Len(t, data, 1)
+	assert.NotContains(t, data[0], "key", "Listed keys never include the secret")
+	assert.Equal(t, "CRM sync", data[0].(map[string]interface{})["name"])
+
+	resp, _ = do(http.MethodDelete, "/me/api-keys/"+keyID.String(), "")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp, _ = do(http.MethodDelete, "/me/api-keys/"+uuid.NewString(), "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp, _ = do(http.MethodDelete, "/me/api-keys/nope", "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestUserHandler_deleteMe(t *testing.T) {
+	userID := uuid.New()
+	deleted := false
+	mockService := &service.UserServiceInteractorMock{
+		DeleteAccountFunc: func(ctx context.Context, id uuid.UUID, password string) error {
+			switch {
+			case deleted:
+				return service.ErrUserNotFound
+			case password != "correct horse":
+				return service.ErrWrongPassword
+			}
+			deleted = true
+			return nil
+		},
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewUser(mockService).Routes(testSecret))
+	defer server.Close()
+	token := generateTestToken(t, userID, testSecret)
+
+	del := func(body string) int {
+		req, _ := http.NewRequest(http.MethodDelete, server.URL+"/me", bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusBadRequest, del(`{}`), "The password must be re-entered")
+	assert.Equal(t, http.StatusBadRequest, del(`{"password":`))
+	assert.Empty(t, mockService.DeleteAccountCalls(), "Rejected requests do not reach the service")
+
+	assert.Equal(t, http.StatusForbidden, del(`{"password": "wrong"}`))
+	assert.Equal(t, http.StatusNoContent, del(`{"password": "correct horse"}`))
+	assert.Equal(t, userID, mockService.DeleteAccountCalls()[1].UserID)
+	assert.Equal(t, http.StatusUnauthorized, del(`{"password": "correct horse"}`), "The token of a deleted account is no longer valid")
+}
+
+func TestUserHandler_usage(t *testing.T) {
+	userID := uuid.New()
+	resetsAt := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	limit := 50
+	mockService := &service.UserServiceInteractorMock{
+		UsageFunc: func(ctx context.Context, id uuid.UUID) (*service.Usage, error) {
+			return &service.Usage{Plan: "free", Limit: limit, Used: 12, ResetsAt: resetsAt}, nil
+		},
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewUser(mockService).Routes(testSecret))
+	defer server.Close()
+	token := generateTestToken(t, userID, testSecret)
+
+	get := func() map[string]interface{} {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/me/usage", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body
+	}
+
+	body := get()
+	assert.Equal(t, "free", body["plan"])
+	assert.EqualValues(t, 50, body["limit"])
+	assert.EqualValues(t, 12, body["used"])
+	assert.EqualValues(t, 38, body["remaining"])
+	assert.Equal(t, "2026-11-01T00:00:00Z", body["resets_at"])
+	assert.Equal(t, userID, mockService.UsageCalls()[0].UserID)
+
+	limit = service.Unlimited
+	body = get()
+	assert.Nil(t, body["limit"], "An unlimited plan has no limit")
+	assert.Nil(t, body["remaining"])
+}
+
+func TestUserHandler_stats(t *testing.T) {
+	userID := uuid.New()
+	since := time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)
+	mockService := &service.UserServiceInteractorMock{
+		StatsFunc: func(ctx context.Context, id uuid.UUID, days int) (*service.GenerationStats, error) {
+			return &service.GenerationStats{
+				Days:        days,
+				Since:       since,
+				Generations: 3,
+				TotalTokens: 90,
+				Daily: []repository.DailyGenerations{
+					{Day: since, Generations: 2, TotalTokens: 60},
+					{Day: since.AddDate(0, 0, 1), Generations: 1, TotalTokens: 30},
+				},
+				Tones: []repository.UsageCount{{Name: "witty", Count: 3}},
+			}, nil
+		},
+	}
+	testSecret := []byte("your-test-jwt-secret")
+	server := httptest.NewServer(handler.NewUser(mockService).Routes(testSecret))
+	defer server.Close()
+	token := generateTestToken(t, userID, testSecret)
+
+	get := func(query string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/me/stats"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := get("?days=2")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var body struct {
+		Days        int `json:"days"`
+		Generations int `json:"generations"`
+		Daily       []struct {
+			Date        string `json:"date"`
+			Generations int    `json:"generations"`
+		} `json:"daily"`
+		Tones     []map[string]interface{} `json:"tones"`
+		Templates []map[string]interface{} `json:"templates"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, 2, body.Days)
+	assert.Equal(t, 3, body.Generations)
+	require.Len(t, body.Daily, 2)
+	assert.Equal(t, "2026-10-13", body.Daily[0].Date)
+	assert.Equal(t, 1, body.Daily[1].Generations)
+	assert.Equal(t, "witty", body.Tones[0]["name"])
+	assert.NotNil(t, body.Templates, "No templates is an empty list")
+	assert.Equal(t, userID, mockService.StatsCalls()[0].UserID)
+
+	get("").Body.Close()
+	assert.Equal(t, service.DefaultStatsDays, mockService.StatsCalls()[1].Days)
+
+	for _, days := range []string{"0", "366", "week"} {
+		resp := get("?days=" + days)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, days)
+	}
+	assert.Len(t, mockService.StatsCalls(), 2)
+}
@@ -0,0 +1,104 @@
+// internal/handler/webhook_handler.go
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/webhook"
+)
+
+// Delivery status events accepted by POST /webhooks/linkedin.
+const (
+	DeliverySucceeded = "delivery.succeeded"
+	DeliveryFailed    = "delivery.failed"
+)
+
+var deliveryEvents = []string{DeliverySucceeded, DeliveryFailed}
+
+// maxWebhookBodyBytes bounds inbound webhook bodies, which are read whole to
+// check their signature.
+const maxWebhookBodyBytes = 64 << 10
+
+// WebhookHandler receives webhooks from other systems. They carry no user
+// credentials; each is authenticated by the signature its sender made with
+// the shared secret.
+type WebhookHandler struct {
+	secret    string
+	tolerance time.Duration
+}
+
+// NewWebhook accepts webhooks signed with secret by webhook.Sign no longer
+// than tolerance ago.
+func NewWebhook(secret string, tolerance time.Duration) *WebhookHandler {
+	return &WebhookHandler{secret: secret, tolerance: tolerance}
+}
+
+func (h *WebhookHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Post("/linkedin", h.linkedIn)
+	return r
+}
+
+// deliveryStatus reports whether a post reached LinkedIn.
+type deliveryStatus struct {
+	Event       string    `json:"event"`
+	PostID      uuid.UUID `json:"post_id"`
+	LinkedInURN string    `json:"linkedin_urn,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// linkedIn receives delivery status callbacks for posts sent to LinkedIn.
+func (h *WebhookHandler) linkedIn(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		respondError(w, http.StatusRequestEntityTooLarge, "Webhook payload too large")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	// Nothing in the body is trusted before its signature is checked.
+	if !webhook.VerifyWithin(h.secret, body, r.Header.Get(webhook.SignatureHeader), h.tolerance) {
+		respondError(w, http.StatusUnauthorized, "Invalid or expired webhook signature")
+		return
+	}
+
+	var in deliveryStatus
+	if err := json.Unmarshal(body, &in); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	switch {
+	case !slices.Contains(deliveryEvents, in.Event):
+		respondError(w, http.StatusBadRequest, "Unsupported event: "+in.Event)
+		return
+	case in.PostID == uuid.Nil:
+		respondError(w, http.StatusBadRequest, "The 'post_id' field is required")
+		return
+	}
+
+	level := slog.LevelInfo
+	if in.Event == DeliveryFailed {
+		level = slog.LevelWarn
+	}
+	slog.Log(r.Context(), level, "linkedin delivery status",
+		"event", in.Event,
+		"post_id", in.PostID,
+		"linkedin_urn", in.LinkedInURN,
+		"error", in.Error,
+		"occurred_at", in.OccurredAt,
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,63 @@
+// internal/handler/webhook_handler_test.go
+package handler_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/webhook"
+)
+
+func TestWebhookHandler_LinkedIn(t *testing.T) {
+	const secret = "inbound-s3cret"
+	server := httptest.NewServer(handler.NewWebhook(secret, time.Minute).Routes())
+	defer server.Close()
+
+	send := func(body, signature string) (int, string) {
+		req, _ := http.NewRequest(http.MethodPost, server.URL+"/linkedin", bytes.NewBufferString(body))
+		if signature != "" {
+			req.Header.Set(webhook.SignatureHeader, signature)
+		}
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(b)
+	}
+	signed := func(body string) (int, string) {
+		return send(body, webhook.Sign(secret, []byte(body)))
+	}
+
+	delivered := `{"event":"delivery.succeeded","post_id":"` + uuid.NewString() + `","linkedin_urn":"urn:li:share:1","occurred_at":"2026-10-14T09:00:00Z"}`
+	status, _ := signed(delivered)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	status, body := send(delivered, "")
+	assert.Equal(t, http.StatusUnauthorized, status, "Unsigned payloads are rejected")
+	assert.Contains(t, body, `"code":"unauthorized"`)
+	status, _ = send(delivered, webhook.Sign("another secret", []byte(delivered)))
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = send(delivered, webhook.SignAt(secret, []byte(delivered), time.Now().Add(-2*time.Minute)))
+	assert.Equal(t, http.StatusUnauthorized, status, "Payloads older than the tolerance are rejected as replays")
+
+	status, body = signed(`{"event":"delivery.lost","post_id":"` + uuid.NewString() + `"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "Unsupported event: delivery.lost")
+	status, _ = signed(`{"event":"delivery.failed"}`)
+	assert.Equal(t, http.StatusBadRequest, status, "A post ID is required")
+	status, _ = signed(`not json`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = signed(`{"event":"delivery.failed","post_id":"` + uuid.NewString() + `","error":"` + strings.Repeat("x", 70<<10) + `"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+}
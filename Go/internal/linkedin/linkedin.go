@@ -0,0 +1,277 @@
+// internal/linkedin/linkedin.go
+package linkedin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/you/linkedinify/internal/service"
+)
+
+// LinkedIn's endpoints, which WithEndpoints replaces in tests.
+const (
+	DefaultAuthURL  = "https://www.linkedin.com/oauth/v2/authorization"
+	DefaultTokenURL = "https://www.linkedin.com/oauth/v2/accessToken"
+	DefaultAPIURL   = "https://api.linkedin.com"
+)
+
+// Scopes are the permissions asked for: signing in with OpenID Connect tells
+// us the member's ID, and w_member_social lets us post as them.
+const Scopes = "openid profile w_member_social"
+
+// apiVersion is the LinkedIn-Version of the Posts API we were written
+// against.
+const apiVersion = "202405"
+
+const defaultTimeout = 15 * time.Second
+
+// Client talks to LinkedIn's OAuth and Posts APIs on behalf of one LinkedIn
+// app. It implements service.LinkedInAPI.
+type Client struct {
+	clientID, clientSecret, redirectURL string
+	authURL, tokenURL, apiURL           string
+	http                                *http.Client
+}
+
+// Option configures optional Client behaviour.
+type Option func(*Client)
+
+// WithEndpoints talks to other servers than LinkedIn's, such as a test
+// server.
+func WithEndpoints(authURL, tokenURL, apiURL string) Option {
+	return func(c *Client) {
+		c.authURL, c.tokenURL, c.apiURL = authURL, tokenURL, apiURL
+	}
+}
+
+// WithHTTPClient sends requests with hc instead of a client with a 15 second
+// timeout.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.http = hc }
+}
+
+// New returns a client for the LinkedIn app with the given credentials.
+// redirectURL is the callback registered with the app, where LinkedIn sends
+// users back after they authorize it.
+func New(clientID, clientSecret, redirectURL string, opts ...Option) *Client {
+	c := &Client{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		authURL:      DefaultAuthURL,
+		tokenURL:     DefaultTokenURL,
+		apiURL:       DefaultAPIURL,
+		http:         &http.Client{Timeout: defaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) AuthCodeURL(state string) string {
+	q := url.Values{
+		"response_type": {"code"},
+		"client_id":     {c.clientID},
+		"redirect_uri":  {c.redirectURL},
+		"scope":         {Scopes},
+		"state":         {state},
+	}
+	return c.authURL + "?" + q.Encode()
+}
+
+func (c *Client) Exchange(ctx context.Context, code string) (*service.LinkedInToken, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {c.redirectURL},
+	})
+}
+
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (*service.LinkedInToken, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+}
+
+type tokenResponse struct {
+	AccessToken           string `json:"access_token"`
+	ExpiresIn             int64  `json:"expires_in"`
+	RefreshToken          string `json:"refresh_token"`
+	RefreshTokenExpiresIn int64  `json:"refresh_token_expires_in"`
+}
+
+// token asks the token endpoint for tokens. LinkedIn answers 400 or 401 when
+// it rejects the code or refresh token, which only a new authorization fixes.
+func (c *Client) token(ctx context.Context, form url.Values) (*service.LinkedInToken, error) {
+	form.Set("client_id", c.clientID)
+	form.Set("client_secret", c.clientSecret)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("linkedin token request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("%w: %s", service.ErrLinkedInReconnect, errorBody(resp))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("linkedin token request: status %d: %s", resp.StatusCode, errorBody(resp))
+	}
+
+	var out tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decoding linkedin token: %w", err)
+	}
+	if out.AccessToken == "" {
+		return nil, errors.New("linkedin token response has no access_token")
+	}
+	now := time.Now()
+	token := &service.LinkedInToken{
+		AccessToken: out.AccessToken,
+		ExpiresAt:   now.Add(time.Duration(out.ExpiresIn) * time.Second),
+	}
+	if out.RefreshToken != "" {
+		token.RefreshToken = out.RefreshToken
+		token.RefreshExpiresAt = now.Add(time.Duration(out.RefreshTokenExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// MemberURN reads the member's ID from the OpenID Connect userinfo endpoint.
+func (c *Client) MemberURN(ctx context.Context, accessToken string) (string, error) {
+	req, err := c.apiRequest(ctx, http.MethodGet, "/v2/userinfo", accessToken, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var out struct {
+		Sub string `json:"sub"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("decoding linkedin userinfo: %w", err)
+	}
+	if out.Sub == "" {
+		return "", errors.New("linkedin userinfo has no sub")
+	}
+	return "urn:li:person:" + out.Sub, nil
+}
+
+type postRequest struct {
+	Author                    string       `json:"author"`
+	Commentary                string       `json:"commentary"`
+	Visibility                string       `json:"visibility"`
+	Distribution              distribution `json:"distribution"`
+	LifecycleState            string       `json:"lifecycleState"`
+	IsReshareDisabledByAuthor bool         `json:"isReshareDisabledByAuthor"`
+}
+
+type distribution struct {
+	FeedDistribution               string   `json:"feedDistribution"`
+	TargetEntities                 []string `json:"targetEntities"`
+	ThirdPartyDistributionChannels []string `json:"thirdPartyDistributionChannels"`
+}
+
+// Share creates a public post with the Posts API, which answers with the
+// URN of the new post in the x-restli-id header.
+func (c *Client) Share(ctx context.Context, accessToken, author, text string) (string, error) {
+	body, err := json.Marshal(postRequest{
+		Author:     author,
+		Commentary: escapeCommentary(text),
+		Visibility: "PUBLIC",
+		Distribution: distribution{
+			FeedDistribution:               "MAIN_FEED",
+			TargetEntities:                 []string{},
+			ThirdPartyDistributionChannels: []string{},
+		},
+		LifecycleState: "PUBLISHED",
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := c.apiRequest(ctx, http.MethodPost, "/rest/posts", accessToken, body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	urn := resp.Header.Get("X-Restli-Id")
+	if urn == "" {
+		return "", errors.New("linkedin did not return the URN of the post")
+	}
+	return urn, nil
+}
+
+// commentaryEscaper escapes the characters LinkedIn's "little text" format
+// reserves, which would otherwise be parsed as markup or rejected. # is left
+// alone so hashtags stay links.
+var commentaryEscaper = strings.NewReplacer(
+	`\`, `\\`, `|`, `\|`, `{`, `\{`, `}`, `\}`, `@`, `\@`, `[`, `\[`, `]`, `\]`,
+	`(`, `\(`, `)`, `\)`, `<`, `\<`, `>`, `\>`, `*`, `\*`, `_`, `\_`, `~`, `\~`,
+)
+
+func escapeCommentary(text string) string {
+	return commentaryEscaper.Replace(text)
+}
+
+func (c *Client) apiRequest(ctx context.Context, method, path, accessToken string, body []byte) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("LinkedIn-Version", apiVersion)
+	req.Header.Set("X-Restli-Protocol-Version", "2.0.0")
+	return req, nil
+}
+
+// do sends an API request, turning error statuses into errors. LinkedIn
+// answers 401 for an expired or revoked access token and 403 for one
+// lacking a scope we need; connecting again fixes both.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("linkedin request: %w", err)
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("%w: %s", service.ErrLinkedInReconnect, errorBody(resp))
+	}
+	return nil, fmt.Errorf("linkedin %s %s: status %d: %s", req.Method, req.URL.Path, resp.StatusCode, errorBody(resp))
+}
+
+// errorBody is the start of an error response, for error messages.
+func errorBody(resp *http.Response) string {
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return strings.TrimSpace(string(b))
+}
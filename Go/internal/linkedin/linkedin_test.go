@@ -0,0 +1,114 @@
+// internal/linkedin/linkedin_test.go
+package linkedin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/linkedin"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// newClient returns a client talking to a fake LinkedIn serving mux.
+func newClient(t *testing.T, mux *http.ServeMux) *linkedin.Client {
+	t.Helper()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return linkedin.New("client-id", "client-secret", "https://api.example.com/callback",
+		linkedin.WithEndpoints(srv.URL+"/authorization", srv.URL+"/accessToken", srv.URL))
+}
+
+func TestClient_AuthCodeURL(t *testing.T) {
+	c := linkedin.New("client-id", "client-secret", "https://api.example.com/callback")
+	u, err := url.Parse(c.AuthCodeURL("the-state"))
+	require.NoError(t, err)
+	assert.Equal(t, "www.linkedin.com", u.Host)
+	q := u.Query()
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "client-id", q.Get("client_id"))
+	assert.Equal(t, "https://api.example.com/callback", q.Get("redirect_uri"))
+	assert.Equal(t, "the-state", q.Get("state"))
+	assert.Contains(t, q.Get("scope"), "w_member_social")
+}
+
+func TestClient_Exchange(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /accessToken", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client-secret", r.PostForm.Get("client_secret"))
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			if r.PostForm.Get("code") != "good-code" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			assert.Equal(t, "https://api.example.com/callback", r.PostForm.Get("redirect_uri"))
+			w.Write([]byte(`{"access_token":"access","expires_in":5184000,"refresh_token":"refresh","refresh_token_expires_in":31536000}`))
+		case "refresh_token":
+			assert.Equal(t, "refresh", r.PostForm.Get("refresh_token"))
+			w.Write([]byte(`{"access_token":"fresh","expires_in":3600}`))
+		}
+	})
+	c := newClient(t, mux)
+	ctx := context.Background()
+
+	token, err := c.Exchange(ctx, "good-code")
+	require.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+	assert.Equal(t, "refresh", token.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(60*24*time.Hour), token.ExpiresAt, time.Minute)
+	assert.WithinDuration(t, time.Now().Add(365*24*time.Hour), token.RefreshExpiresAt, time.Minute)
+
+	token, err = c.Refresh(ctx, "refresh")
+	require.NoError(t, err)
+	assert.Equal(t, "fresh", token.AccessToken)
+	assert.Empty(t, token.RefreshToken)
+
+	_, err = c.Exchange(ctx, "used-code")
+	assert.ErrorIs(t, err, service.ErrLinkedInReconnect)
+}
+
+func TestClient_Share(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"sub":"abc123","name":"Ada"}`))
+	})
+	mux.HandleFunc("POST /rest/posts", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.NotEmpty(t, r.Header.Get("LinkedIn-Version"))
+		assert.Equal(t, "2.0.0", r.Header.Get("X-Restli-Protocol-Version"))
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "urn:li:person:abc123", body["author"])
+		assert.Equal(t, `Big news \(really\) at \@Acme #launch`, body["commentary"], "Reserved characters are escaped, hashtags are not")
+		assert.Equal(t, "PUBLIC", body["visibility"])
+		w.Header().Set("X-RestLi-Id", "urn:li:share:42")
+		w.WriteHeader(http.StatusCreated)
+	})
+	c := newClient(t, mux)
+	ctx := context.Background()
+
+	urn, err := c.MemberURN(ctx, "access")
+	require.NoError(t, err)
+	assert.Equal(t, "urn:li:person:abc123", urn)
+
+	postURN, err := c.Share(ctx, "access", urn, "Big news (really) at @Acme #launch")
+	require.NoError(t, err)
+	assert.Equal(t, "urn:li:share:42", postURN)
+
+	_, err = c.Share(ctx, "revoked", urn, "Big news")
+	assert.ErrorIs(t, err, service.ErrLinkedInReconnect)
+}
@@ -0,0 +1,77 @@
+// internal/logging/logging_test.go
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/logging"
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+func TestNew_ProductionLogsJSONWithRequestAndUserID(t *testing.T) {
+	secret := []byte("test-jwt-secret-for-logging")
+	userID := uuid.New()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": userID.String(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString(secret)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.EnvProduction, slog.LevelInfo)
+	h := middleware.RequestID(middleware.Auth(secret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.InfoContext(r.Context(), "post created", "post_id", 42)
+	})))
+
+	req := httptest.NewRequest(http.MethodPost, "/posts", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(middleware.RequestIDHeader, "req-123")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record), "production logs should be JSON: %s", buf.String())
+	assert.Equal(t, "post created", record["msg"])
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, float64(42), record["post_id"])
+	assert.Equal(t, "req-123", record["request_id"])
+	assert.Equal(t, userID.String(), record["user_id"])
+}
+
+func TestNew_DevelopmentLogsTextAtLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.EnvDevelopment, slog.LevelWarn)
+
+	logger.Info("hidden")
+	logger.Warn("key was rate limited", "key", "…abcd")
+
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), `level=WARN msg="key was rate limited" key=…abcd`)
+	assert.NotContains(t, buf.String(), "request_id", "records without a request context carry no request ID")
+}
+
+func TestParseLevel(t *testing.T) {
+	for in, want := range map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"INFO":  slog.LevelInfo,
+		" warn": slog.LevelWarn,
+		"error": slog.LevelError,
+	} {
+		got, err := logging.ParseLevel(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	_, err := logging.ParseLevel("verbose")
+	assert.Error(t, err)
+}
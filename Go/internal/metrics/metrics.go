@@ -0,0 +1,92 @@
+// internal/metrics/metrics.go
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+// Metrics holds the Prometheus collectors for the API. It records HTTP
+// requests for the middleware.Metrics middleware and generations for
+// ai.NewInstrumentedClient.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	aiRequests   *prometheus.CounterVec
+	aiDuration   *prometheus.HistogramVec
+	aiTokens     *prometheus.CounterVec
+}
+
+// New creates the collectors on a registry of their own, together with the
+// standard Go runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests by method, route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency by method and route pattern.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		aiRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ai_requests_total",
+			Help: "AI generations by provider, model, operation and outcome (success or error).",
+		}, []string{"provider", "model", "operation", "outcome"}),
+		// Generations take seconds rather than milliseconds.
+		aiDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "ai_request_duration_seconds",
+			Help:    "AI generation latency by provider, model and operation.",
+			Buckets: []float64{0.25, 0.5, 1, 2, 4, 8, 15, 30, 60},
+		}, []string{"provider", "model", "operation"}),
+		aiTokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ai_tokens_total",
+			Help: "Tokens used by non-streaming generations, by provider, model and type (prompt or completion).",
+		}, []string{"provider", "model", "type"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.aiRequests, m.aiDuration, m.aiTokens,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records a finished HTTP request. route should be the route
+// pattern, not the path, to keep the number of series bounded.
+func (m *Metrics) ObserveRequest(method, route string, status int, d time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(d.Seconds())
+}
+
+// ObserveGeneration implements ai.MetricsRecorder.
+func (m *Metrics) ObserveGeneration(provider, model, operation string, d time.Duration, usage ai.Usage, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.aiRequests.WithLabelValues(provider, model, operation, outcome).Inc()
+	m.aiDuration.WithLabelValues(provider, model, operation).Observe(d.Seconds())
+	if usage.PromptTokens > 0 {
+		m.aiTokens.WithLabelValues(provider, model, "prompt").Add(float64(usage.PromptTokens))
+	}
+	if usage.CompletionTokens > 0 {
+		m.aiTokens.WithLabelValues(provider, model, "completion").Add(float64(usage.CompletionTokens))
+	}
+}
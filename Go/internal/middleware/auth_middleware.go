@@ -3,47 +3,221 @@ package middleware
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
 	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/model"
 )
 
 type ctxKey string
 
-const userKey ctxKey = "userID"
+const (
+	userKey ctxKey = "userID"
+	roleKey ctxKey = "role"
+	acctKey ctxKey = "user"
+)
 
 func UserID(ctx context.Context) uuid.UUID {
 	id, _ := ctx.Value(userKey).(uuid.UUID)
 	return id
 }
 
-func Auth(secret []byte) func(http.Handler) http.Handler {
+// Role returns the role claim of the authenticated user, or "" when the
+// token carried none.
+func Role(ctx context.Context) string {
+	role, _ := ctx.Value(roleKey).(string)
+	return role
+}
+
+// User returns the authenticated user loaded by Auth with WithUserLoader, so
+// handlers and services needing more than the ID do not look it up again.
+// It is shared by the whole request and must not be modified.
+func User(ctx context.Context) (*model.User, bool) {
+	u, ok := ctx.Value(acctKey).(*model.User)
+	return u, ok
+}
+
+// UserLoader looks up a user by ID, returning sql.ErrNoRows when there is
+// none.
+type UserLoader interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+}
+
+// RevocationChecker reports whether an access token has been revoked, keyed
+// by its jti claim.
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// APIKeyHeader carries an API key, as an alternative to a bearer token.
+const APIKeyHeader = "X-API-Key"
+
+// ErrInvalidAPIKey is returned by an APIKeyVerifier for keys that are
+// unknown or revoked.
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// APIKeyVerifier returns the user an API key belongs to.
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (uuid.UUID, error)
+}
+
+type authOptions struct {
+	revocations RevocationChecker
+	issuer      string
+	apiKeys     APIKeyVerifier
+	users       UserLoader
+}
+
+// AuthOption configures optional Auth behaviour.
+type AuthOption func(*authOptions)
+
+// WithRevocationCheck rejects tokens whose jti has been revoked, such as
+// tokens that were logged out.
+func WithRevocationCheck(c RevocationChecker) AuthOption {
+	return func(o *authOptions) { o.revocations = c }
+}
+
+// WithAPIKeys also accepts API keys, checked with v, in the X-API-Key header.
+func WithAPIKeys(v APIKeyVerifier) AuthOption {
+	return func(o *authOptions) { o.apiKeys = v }
+}
+
+// WithUserLoader loads the authenticated user with l once per request,
+// making it available from User. Requests from users that no longer exist,
+// such as a deleted account whose token has not expired yet, are rejected
+// with 401.
+func WithUserLoader(l UserLoader) AuthOption {
+	return func(o *authOptions) { o.users = l }
+}
+
+// WithIssuer rejects tokens whose iss claim is not issuer.
+func WithIssuer(issuer string) AuthOption {
+	return func(o *authOptions) { o.issuer = issuer }
+}
+
+// Auth authenticates requests with an HS256 bearer token signed with secret
+// or, when WithAPIKeys is given, an API key in the X-API-Key header. The
+// bearer token is tried first. Tokens must carry an exp claim and are
+// rejected with 401 once expired or before their nbf time. Either way the
+// user ID is available from UserID; a role is only set by tokens.
+func Auth(secret []byte, opts ...AuthOption) func(http.Handler) http.Handler {
+	var o authOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}),
+		jwt.WithExpirationRequired(),
+	}
+	if o.issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(o.issuer))
+	}
+	parser := jwt.NewParser(parserOpts...)
+	keyFunc := func(*jwt.Token) (interface{}, error) { return secret, nil }
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			auth := r.Header.Get("Authorization")
-			if !strings.HasPrefix(auth, "Bearer ") {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
-				return
+			ctx, status := o.authenticateToken(r, parser, keyFunc)
+			if key := r.Header.Get(APIKeyHeader); status == http.StatusUnauthorized && key != "" && o.apiKeys != nil {
+				ctx, status = o.authenticateAPIKey(r, key)
 			}
-			raw := strings.TrimPrefix(auth, "Bearer ")
-			token, err := jwt.Parse(raw, func(t *jwt.Token) (interface{}, error) {
-				return secret, nil
-			}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}))
-			if err != nil || !token.Valid {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
-				return
+			if status == http.StatusOK && o.users != nil {
+				ctx, status = o.loadUser(ctx)
+			}
+			switch status {
+			case http.StatusOK:
+				setLoggedUser(ctx, UserID(ctx))
+				next.ServeHTTP(w, r.WithContext(ctx))
+			case http.StatusInternalServerError:
+				WriteError(w, http.StatusInternalServerError, CodeInternal, "Internal server error")
+			default:
+				WriteError(w, http.StatusUnauthorized, CodeUnauthorized, "Unauthorized")
+			}
+		})
+	}
+}
+
+// authenticateToken checks the bearer token of r and returns the context
+// for the next handler with http.StatusOK, or the status to fail with.
+func (o *authOptions) authenticateToken(r *http.Request, parser *jwt.Parser, keyFunc jwt.Keyfunc) (context.Context, int) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return nil, http.StatusUnauthorized
+	}
+	raw := strings.TrimPrefix(auth, "Bearer ")
+	token, err := parser.Parse(raw, keyFunc)
+	if err != nil || !token.Valid {
+		return nil, http.StatusUnauthorized
+	}
+	claims, _ := token.Claims.(jwt.MapClaims)
+	sub, ok := claims["sub"].(string)
+	if !ok {
+		return nil, http.StatusUnauthorized
+	}
+	if o.revocations != nil {
+		// Tokens minted before jti claims were added cannot be
+		// revoked and simply run until they expire.
+		if jti, _ := claims["jti"].(string); jti != "" {
+			revoked, err := o.revocations.IsRevoked(r.Context(), jti)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "revocation check failed", "error", err)
+				return nil, http.StatusInternalServerError
+			}
+			if revoked {
+				return nil, http.StatusUnauthorized
 			}
-			claims, _ := token.Claims.(jwt.MapClaims)
-			sub, ok := claims["sub"].(string)
-			if !ok {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		}
+	}
+	uid, _ := uuid.Parse(sub)
+	ctx := context.WithValue(r.Context(), userKey, uid)
+	if role, ok := claims["role"].(string); ok {
+		ctx = context.WithValue(ctx, roleKey, role)
+	}
+	return ctx, http.StatusOK
+}
+
+// authenticateAPIKey is authenticateToken for an API key.
+func (o *authOptions) authenticateAPIKey(r *http.Request, key string) (context.Context, int) {
+	uid, err := o.apiKeys.VerifyAPIKey(r.Context(), key)
+	if errors.Is(err, ErrInvalidAPIKey) {
+		return nil, http.StatusUnauthorized
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "api key check failed", "error", err)
+		return nil, http.StatusInternalServerError
+	}
+	return context.WithValue(r.Context(), userKey, uid), http.StatusOK
+}
+
+// loadUser adds the authenticated user to ctx.
+func (o *authOptions) loadUser(ctx context.Context) (context.Context, int) {
+	u, err := o.users.FindByID(ctx, UserID(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, http.StatusUnauthorized
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "loading the authenticated user failed", "error", err)
+		return nil, http.StatusInternalServerError
+	}
+	return context.WithValue(ctx, acctKey, u), http.StatusOK
+}
+
+// RequireRole rejects requests with 403 unless the authenticated user holds
+// role. It must be used after Auth.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if Role(r.Context()) != role {
+				WriteError(w, http.StatusForbidden, CodeForbidden, "Forbidden")
 				return
 			}
-			uid, _ := uuid.Parse(sub)
-			ctx := context.WithValue(r.Context(), userKey, uid)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r)
 		})
 	}
 }
@@ -2,6 +2,9 @@
 package middleware_test
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
 )
 
 var testAuthSecret = []byte("test-jwt-secret-for-middleware")
@@ -112,6 +116,123 @@ func TestAuthMiddleware_InvalidToken_Expired(t *testing.T) {
 	assert.False(t, nextHandler.called, "Next handler should not be called with expired token")
 }
 
+func TestAuthMiddleware_InvalidToken_ClaimChecks(t *testing.T) {
+	testUserID := uuid.New()
+	tests := []struct {
+		name   string
+		claims map[string]interface{}
+		opts   []middleware.AuthOption
+		want   int
+	}{
+		{"matching issuer", map[string]interface{}{"iss": "linkedinify"}, []middleware.AuthOption{middleware.WithIssuer("linkedinify")}, http.StatusOK},
+		{"wrong issuer", map[string]interface{}{"iss": "evil"}, []middleware.AuthOption{middleware.WithIssuer("linkedinify")}, http.StatusUnauthorized},
+		{"missing issuer", nil, []middleware.AuthOption{middleware.WithIssuer("linkedinify")}, http.StatusUnauthorized},
+		{"not yet valid", map[string]interface{}{"nbf": time.Now().Add(time.Hour).Unix()}, nil, http.StatusUnauthorized},
+		{"no expiry", map[string]interface{}{"exp": nil}, nil, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := map[string]interface{}{}
+			for k, v := range tt.claims {
+				claims[k] = v
+			}
+			token := generateTestToken(t, testUserID, testAuthSecret, time.Hour, claims)
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rr := httptest.NewRecorder()
+			middleware.Auth(testAuthSecret, tt.opts...)(&mockHandler{}).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Code)
+		})
+	}
+}
+
+type fakeAPIKeys map[string]uuid.UUID
+
+func (f fakeAPIKeys) VerifyAPIKey(ctx context.Context, key string) (uuid.UUID, error) {
+	if key == "lk_broken" {
+		return uuid.Nil, errors.New("database down")
+	}
+	uid, ok := f[key]
+	if !ok {
+		return uuid.Nil, middleware.ErrInvalidAPIKey
+	}
+	return uid, nil
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	keyUser, tokenUser := uuid.New(), uuid.New()
+	keys := fakeAPIKeys{"lk_valid": keyUser}
+	tests := []struct {
+		name     string
+		bearer   string
+		apiKey   string
+		opts     []middleware.AuthOption
+		want     int
+		wantUser uuid.UUID
+	}{
+		{"api key", "", "lk_valid", []middleware.AuthOption{middleware.WithAPIKeys(keys)}, http.StatusOK, keyUser},
+		{"token wins", generateTestToken(t, tokenUser, testAuthSecret, time.Hour), "lk_valid", []middleware.AuthOption{middleware.WithAPIKeys(keys)}, http.StatusOK, tokenUser},
+		{"invalid token falls back", "garbage", "lk_valid", []middleware.AuthOption{middleware.WithAPIKeys(keys)}, http.StatusOK, keyUser},
+		{"unknown key", "", "lk_unknown", []middleware.AuthOption{middleware.WithAPIKeys(keys)}, http.StatusUnauthorized, uuid.Nil},
+		{"lookup fails", "", "lk_broken", []middleware.AuthOption{middleware.WithAPIKeys(keys)}, http.StatusInternalServerError, uuid.Nil},
+		{"api keys not enabled", "", "lk_valid", nil, http.StatusUnauthorized, uuid.Nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen uuid.UUID
+			next := &mockHandler{handlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				seen = middleware.UserID(r.Context())
+			}}
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.bearer != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.bearer)
+			}
+			req.Header.Set(middleware.APIKeyHeader, tt.apiKey)
+			rr := httptest.NewRecorder()
+			middleware.Auth(testAuthSecret, tt.opts...)(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Code)
+			assert.Equal(t, tt.wantUser, seen)
+		})
+	}
+}
+
+type fakeUsers map[uuid.UUID]*model.User
+
+func (f fakeUsers) FindByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	if u, ok := f[id]; ok {
+		return u, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func TestAuthMiddleware_UserLoader(t *testing.T) {
+	existing := &model.User{ID: uuid.New(), Name: "Ada"}
+	users := fakeUsers{existing.ID: existing}
+
+	var seen *model.User
+	next := &mockHandler{handlerFunc: func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = middleware.User(r.Context())
+	}}
+	auth := middleware.Auth(testAuthSecret, middleware.WithUserLoader(users))
+	serve := func(userID uuid.UUID) int {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+generateTestToken(t, userID, testAuthSecret, time.Hour))
+		rr := httptest.NewRecorder()
+		auth(next).ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(existing.ID))
+	assert.Same(t, existing, seen)
+
+	next.called = false
+	assert.Equal(t, http.StatusUnauthorized, serve(uuid.New()), "A valid token of a deleted account is rejected")
+	assert.False(t, next.called)
+}
+
 func TestAuthMiddleware_NoAuthHeader(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/test", nil)
 	rr := httptest.NewRecorder()
@@ -185,3 +306,47 @@ func TestAuthMiddleware_InvalidToken_MissingSubClaim(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, rr.Code, "Expected Unauthorized for missing sub claim")
 	assert.False(t, nextHandler.called, "Next handler should not be called with missing sub claim")
 }
+
+// revocationList is a RevocationChecker backed by a fixed set of jti values.
+type revocationList map[string]bool
+
+func (l revocationList) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	return l[jti], nil
+}
+
+func TestAuthMiddleware_RevokedToken(t *testing.T) {
+	revoked := revocationList{"revoked-jti": true}
+	authMiddleware := middleware.Auth(testAuthSecret, middleware.WithRevocationCheck(revoked))
+
+	for jti, wantStatus := range map[string]int{"revoked-jti": http.StatusUnauthorized, "live-jti": http.StatusOK} {
+		token := generateTestToken(t, uuid.New(), testAuthSecret, time.Hour, map[string]interface{}{"jti": jti})
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+
+		nextHandler := &mockHandler{}
+		authMiddleware(nextHandler).ServeHTTP(rr, req)
+
+		assert.Equal(t, wantStatus, rr.Code, jti)
+		assert.Equal(t, wantStatus == http.StatusOK, nextHandler.called, jti)
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	protected := func(next http.Handler) http.Handler {
+		return middleware.Auth(testAuthSecret)(middleware.RequireRole("admin")(next))
+	}
+
+	for role, wantStatus := range map[string]int{"admin": http.StatusOK, "user": http.StatusForbidden} {
+		token := generateTestToken(t, uuid.New(), testAuthSecret, time.Hour, map[string]interface{}{"role": role})
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+
+		nextHandler := &mockHandler{}
+		protected(nextHandler).ServeHTTP(rr, req)
+
+		assert.Equal(t, wantStatus, rr.Code, role)
+		assert.Equal(t, wantStatus == http.StatusOK, nextHandler.called, role)
+	}
+}
@@ -0,0 +1,40 @@
+// internal/middleware/body_limit_test.go
+package middleware_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+func TestMaxBodyBytes(t *testing.T) {
+	var readErr error
+	h := middleware.MaxBodyBytes(10)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0123456789")))
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.NoError(t, readErr)
+
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0123456789a")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code, "A declared length over the limit is rejected up front")
+	assert.Equal(t, "The request body must be at most 10 bytes", decodeError(t, rr).Message)
+
+	// Without a Content-Length, the body is cut off while reading.
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0123456789a"))
+	req.ContentLength = -1
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	var tooLarge *http.MaxBytesError
+	assert.True(t, errors.As(readErr, &tooLarge), readErr)
+}
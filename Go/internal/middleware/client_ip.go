@@ -0,0 +1,103 @@
+// internal/middleware/client_ip.go
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+const clientIPKey ctxKey = "clientIP"
+
+// RealIP works out the address of the client behind each request, which
+// ClientIP and ClientIPFromContext return. Behind a load balancer the peer,
+// RemoteAddr, is the proxy: when the peer is in one of the trusted prefixes,
+// the client is the last address of X-Forwarded-For that is not itself a
+// trusted proxy, or X-Real-IP when there is no X-Forwarded-For. Forwarding
+// headers from any other peer are ignored, since anyone can send them.
+// Install it ahead of everything reading the client IP.
+func RealIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := clientIP(r, trusted)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIPKey, ip)))
+		})
+	}
+}
+
+// ClientIP is the address r came from, without the port, as worked out by
+// RealIP; without it, the address of the peer.
+func ClientIP(r *http.Request) string {
+	if ip := ClientIPFromContext(r.Context()); ip != "" {
+		return ip
+	}
+	return remoteHost(r)
+}
+
+// ClientIPFromContext returns the client IP RealIP stored in ctx, or "" when
+// it did not run.
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
+}
+
+// remoteHost is RemoteAddr without the port.
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func clientIP(r *http.Request, trusted []netip.Prefix) string {
+	peer, err := netip.ParseAddr(remoteHost(r))
+	if err != nil || !isTrusted(peer, trusted) {
+		return remoteHost(r)
+	}
+	forwarded := r.Header.Values("X-Forwarded-For")
+	if len(forwarded) == 0 {
+		if ip, ok := parseHop(r.Header.Get("X-Real-IP")); ok {
+			return ip.String()
+		}
+		return remoteHost(r)
+	}
+	// Each proxy appends the address it got the request from, so walking
+	// back from the end, the first one not added by a trusted proxy is the
+	// client. Anything to its left was sent by the client and may be forged.
+	client := peer.Unmap()
+	hops := strings.Split(strings.Join(forwarded, ","), ",")
+	for i := len(hops) - 1; i >= 0 && isTrusted(client, trusted); i-- {
+		hop, ok := parseHop(hops[i])
+		if !ok {
+			break
+		}
+		client = hop
+	}
+	return client.String()
+}
+
+// parseHop parses an address of a forwarding header, which some proxies send
+// with a port.
+func parseHop(s string) (netip.Addr, bool) {
+	s = strings.TrimSpace(s)
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return addr.Unmap(), true
+	}
+	if ap, err := netip.ParseAddrPort(s); err == nil {
+		return ap.Addr().Unmap(), true
+	}
+	return netip.Addr{}, false
+}
+
+func isTrusted(addr netip.Addr, trusted []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, p := range trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// internal/middleware/client_ip_test.go
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+func TestRealIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("fd00::/8")}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		{"no proxy", "203.0.113.7:4321", nil, "203.0.113.7"},
+		{"spoofed forwarded for from an untrusted peer", "203.0.113.7:4321", map[string]string{"X-Forwarded-For": "198.51.100.1"}, "203.0.113.7"},
+		{"spoofed real IP from an untrusted peer", "203.0.113.7:4321", map[string]string{"X-Real-IP": "198.51.100.1"}, "203.0.113.7"},
+		{"trusted proxy", "10.0.0.2:4321", map[string]string{"X-Forwarded-For": "203.0.113.7"}, "203.0.113.7"},
+		{"chain of trusted proxies", "10.0.0.2:4321", map[string]string{"X-Forwarded-For": "203.0.113.7, 10.1.1.1, 10.2.2.2"}, "203.0.113.7"},
+		{"spoofed hop ahead of the client", "10.0.0.2:4321", map[string]string{"X-Forwarded-For": "198.51.100.1, 203.0.113.7"}, "203.0.113.7"},
+		{"every hop trusted", "10.0.0.2:4321", map[string]string{"X-Forwarded-For": "10.3.3.3, 10.1.1.1"}, "10.3.3.3"},
+		{"garbage hop", "10.0.0.2:4321", map[string]string{"X-Forwarded-For": "not-an-ip, 10.1.1.1"}, "10.1.1.1"},
+		{"hop with a port", "10.0.0.2:4321", map[string]string{"X-Forwarded-For": "203.0.113.7:5555"}, "203.0.113.7"},
+		{"real IP", "10.0.0.2:4321", map[string]string{"X-Real-IP": "203.0.113.7"}, "203.0.113.7"},
+		{"forwarded for wins over real IP", "10.0.0.2:4321", map[string]string{"X-Forwarded-For": "203.0.113.7", "X-Real-IP": "198.51.100.1"}, "203.0.113.7"},
+		{"trusted proxy without headers", "10.0.0.2:4321", nil, "10.0.0.2"},
+		{"ipv6 proxy", "[fd00::1]:4321", map[string]string{"X-Forwarded-For": "2001:db8::7"}, "2001:db8::7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got, fromCtx string
+			h := middleware.RealIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, fromCtx = middleware.ClientIP(r), middleware.ClientIPFromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want, fromCtx)
+		})
+	}
+}
+
+func TestRealIP_NoTrustedProxies(t *testing.T) {
+	var got string
+	h := middleware.RealIP(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = middleware.ClientIP(r)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.2:4321"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "10.0.0.2", got, "Forwarding headers are ignored unless a proxy is trusted")
+}
+
+func TestClientIP_WithoutRealIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.7:4321"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	assert.Equal(t, "203.0.113.7", middleware.ClientIP(req))
+}
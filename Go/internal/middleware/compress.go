@@ -0,0 +1,194 @@
+// internal/middleware/compress.go
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultCompressionMinSize is the smallest response compressed unless
+// configured otherwise; below about a kilobyte gzip saves less than a
+// packet and costs CPU on every request.
+const DefaultCompressionMinSize = 1024
+
+// compressibleTypes are the media types worth compressing. Server-sent
+// events are left out on purpose: gzip buffers its output, which would hold
+// tokens back instead of delivering them as they are generated.
+var compressibleTypes = map[string]bool{
+	"application/json":         true,
+	"application/problem+json": true,
+	"text/plain":               true,
+	"text/html":                true,
+	"text/csv":                 true,
+	"text/markdown":            true,
+}
+
+// Compress gzips responses of at least minSize bytes to clients accepting
+// gzip, when their Content-Type is JSON or text. Other types, responses the
+// handler encoded itself and server-sent events, which must reach the
+// client as they are written, pass through untouched, as does anything the
+// handler flushes before minSize bytes. level is a compress/gzip level from
+// 1 to 9; zero disables compression.
+func Compress(level, minSize int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if level == gzip.NoCompression {
+			return next
+		}
+		pool := &sync.Pool{New: func() any {
+			gz, _ := gzip.NewWriterLevel(io.Discard, level)
+			return gz
+		}}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !acceptsGzip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			cw := &compressWriter{ResponseWriter: w, pool: pool, minSize: minSize, status: http.StatusOK}
+			defer cw.close()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// acceptsGzip reports whether the Accept-Encoding of r allows gzip.
+func acceptsGzip(r *http.Request) bool {
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(enc, ";")
+		if !strings.EqualFold(strings.TrimSpace(name), "gzip") {
+			continue
+		}
+		quality, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !ok {
+			return true
+		}
+		q, err := strconv.ParseFloat(quality, 64)
+		return err == nil && q > 0
+	}
+	return false
+}
+
+// compressWriter holds a response back until it knows whether to compress
+// it: once minSize bytes have been written, it is compressed; when the
+// handler returns or flushes first, it is sent as it is.
+type compressWriter struct {
+	http.ResponseWriter
+	pool    *sync.Pool
+	minSize int
+
+	status  int
+	buf     []byte
+	decided bool
+	gz      *gzip.Writer // nil unless the response is compressed
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.decided || status < http.StatusOK {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+	if status == http.StatusNoContent || status == http.StatusNotModified || !w.compressible() {
+		w.start(false)
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		if !w.compressible() {
+			w.start(false)
+		} else if len(w.buf)+len(b) < w.minSize {
+			w.buf = append(w.buf, b...)
+			return len(b), nil
+		} else {
+			w.start(true)
+		}
+	}
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends what has been written so far. A response flushed before it
+// was known to be compressed is sent uncompressed, so streams are never
+// held back.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.start(false)
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+// compressible reports whether the response, as declared so far, may be
+// compressed.
+func (w *compressWriter) compressible() bool {
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+	ct := h.Get("Content-Type")
+	if ct == "" {
+		// Not known until the first write.
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	return err == nil && compressibleTypes[mediaType]
+}
+
+// start sends the headers, compressed or not, followed by anything held
+// back.
+func (w *compressWriter) start(compress bool) {
+	w.decided = true
+	h := w.Header()
+	if w.compressible() {
+		h.Add("Vary", "Accept-Encoding")
+	}
+	if compress {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		w.gz = w.pool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.buf) == 0 {
+		return
+	}
+	if w.gz != nil {
+		_, _ = w.gz.Write(w.buf)
+	} else {
+		_, _ = w.ResponseWriter.Write(w.buf)
+	}
+	w.buf = nil
+}
+
+// close sends a response still held back, uncompressed since it is under
+// minSize, or finishes the compressed one.
+func (w *compressWriter) close() {
+	if !w.decided {
+		if len(w.buf) == 0 && w.status == http.StatusOK {
+			// The handler wrote nothing; let net/http send its default.
+			return
+		}
+		w.start(false)
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+		w.pool.Put(w.gz)
+		w.gz = nil
+	}
+}
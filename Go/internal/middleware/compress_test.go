@@ -0,0 +1,84 @@
+// internal/middleware/compress_test.go
+package middleware_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+func serveCompressed(t *testing.T, acceptEncoding string, h http.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+	rr := httptest.NewRecorder()
+	middleware.Compress(5, 100)(h).ServeHTTP(rr, req)
+	return rr
+}
+
+func TestCompress(t *testing.T) {
+	large := `{"post":"` + strings.Repeat("a", 200) + `"}`
+	writeJSON := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			// Written in two parts to cross the threshold part way.
+			io.WriteString(w, body[:len(body)/2])
+			io.WriteString(w, body[len(body)/2:])
+		}
+	}
+
+	rr := serveCompressed(t, "br, gzip", writeJSON(large))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+	gz, err := gzip.NewReader(rr.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, large, string(body))
+
+	rr = serveCompressed(t, "gzip", writeJSON(`{"ok":true}`))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Empty(t, rr.Header().Get("Content-Encoding"), "Small responses are not compressed")
+	assert.Equal(t, `{"ok":true}`, rr.Body.String())
+
+	rr = serveCompressed(t, "gzip;q=0", writeJSON(large))
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	assert.Equal(t, large, rr.Body.String())
+}
+
+func TestCompress_SkipsOtherTypes(t *testing.T) {
+	rr := serveCompressed(t, "gzip", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(make([]byte, 500))
+	})
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	assert.Len(t, rr.Body.Bytes(), 500)
+}
+
+func TestCompress_NeverBuffersEventStreams(t *testing.T) {
+	var flushed string
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	middleware.Compress(5, 100)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, "data: Hello\n\n")
+		w.(http.Flusher).Flush()
+		flushed = rr.Body.String()
+		io.WriteString(w, "data: "+strings.Repeat("a", 200)+"\n\n")
+	})).ServeHTTP(rr, req)
+
+	assert.Equal(t, "data: Hello\n\n", flushed, "Tokens reach the client as they are written")
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	assert.True(t, rr.Flushed)
+}
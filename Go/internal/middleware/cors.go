@@ -0,0 +1,83 @@
+// internal/middleware/cors.go
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	corsAllowedMethods = "GET, POST, PATCH, DELETE, OPTIONS"
+	corsAllowedHeaders = "Authorization, Content-Type, Idempotency-Key, If-Match, If-None-Match, X-API-Key"
+	corsExposedHeaders = "ETag, Retry-After, X-Cache, Idempotent-Replayed, Deprecation, Sunset, Link, " + RequestIDHeader
+	corsMaxAge         = "600"
+)
+
+// CORSOption configures CORS.
+type CORSOption func(*corsOptions)
+
+type corsOptions struct {
+	rateLimitHeaderPrefix string
+}
+
+// WithCORSRateLimitHeaderPrefix exposes the rate limit headers named with
+// prefix, as given to WithRateLimitHeaderPrefix, instead of those named
+// with DefaultRateLimitHeaderPrefix.
+func WithCORSRateLimitHeaderPrefix(prefix string) CORSOption {
+	return func(o *corsOptions) { o.rateLimitHeaderPrefix = prefix }
+}
+
+// CORS lets browsers on the given origins call the API. Requests from any
+// other origin get no CORS headers, so browsers block them; an empty list
+// therefore denies every cross-origin request. Preflight requests are
+// answered directly and never reach next. Allowed origins may send
+// credentials, so browsers keep the cookie GET /linkedin/connect sets; the
+// API authenticates by header alone, so no cookie makes a request more
+// privileged.
+func CORS(allowedOrigins []string, opts ...CORSOption) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, o := range allowedOrigins {
+		allowed[strings.TrimSuffix(o, "/")] = true
+	}
+	o := corsOptions{rateLimitHeaderPrefix: DefaultRateLimitHeaderPrefix}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	exposed := strings.Join(append([]string{corsExposedHeaders}, RateLimitHeaders(o.rateLimitHeaderPrefix)...), ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			// The response depends on Origin, so caches must key on it.
+			w.Header().Add("Vary", "Origin")
+
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if !allowed[origin] {
+				if preflight {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			if preflight {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+				w.Header().Set("Access-Control-Allow-Methods", corsAllowedMethods)
+				w.Header().Set("Access-Control-Allow-Headers", corsAllowedHeaders)
+				w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Header().Set("Access-Control-Expose-Headers", exposed)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
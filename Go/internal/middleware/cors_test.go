@@ -0,0 +1,82 @@
+// internal/middleware/cors_test.go
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+func TestCORS_AllowedOrigin(t *testing.T) {
+	next := &mockHandler{}
+	h := middleware.CORS([]string{"https://app.example.com"})(next)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/posts", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.True(t, next.called)
+	assert.Equal(t, "https://app.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", rr.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	next := &mockHandler{}
+	h := middleware.CORS([]string{"https://app.example.com"})(next)
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/posts", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "authorization, content-type")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.False(t, next.called, "Preflight requests should be answered by the middleware")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Contains(t, rr.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+	assert.Contains(t, rr.Header().Get("Access-Control-Allow-Methods"), http.MethodPost)
+	assert.Equal(t, "true", rr.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORS_DeniesByDefault(t *testing.T) {
+	next := &mockHandler{}
+	h := middleware.CORS(nil)(next)
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/posts", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/posts", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_ExposedHeaders(t *testing.T) {
+	serve := func(opts ...middleware.CORSOption) string {
+		h := middleware.CORS([]string{"https://app.example.com"}, opts...)(&mockHandler{})
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/posts", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr.Header().Get("Access-Control-Expose-Headers")
+	}
+
+	exposed := serve()
+	assert.Contains(t, exposed, "X-Request-ID")
+	assert.Contains(t, exposed, "X-RateLimit-Remaining")
+	exposed = serve(middleware.WithCORSRateLimitHeaderPrefix("RateLimit-"))
+	assert.Contains(t, exposed, "RateLimit-Reset")
+	assert.NotContains(t, exposed, "X-RateLimit-Reset")
+}
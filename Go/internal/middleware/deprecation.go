@@ -0,0 +1,54 @@
+// internal/middleware/deprecation.go
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Deprecation describes when an API version was or will be deprecated and
+// when it stops being served.
+type Deprecation struct {
+	// At is when the version is deprecated; it may be in the future to
+	// announce the deprecation ahead of time.
+	At time.Time
+	// Sunset is when the version stops being served; zero when no date is
+	// set yet.
+	Sunset time.Time
+	// Link points to the migration guide; empty for none.
+	Link string
+}
+
+// Deprecated marks every response as belonging to a deprecated API version,
+// so clients can warn about it before it goes away. It sets the Deprecation
+// header of RFC 9745, the Sunset header of RFC 8594 when d.Sunset is set,
+// and a Link with rel="deprecation" when d.Link is. A zero d.At disables
+// it.
+func Deprecated(d Deprecation) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if d.At.IsZero() {
+			return next
+		}
+		deprecation := "@" + strconv.FormatInt(d.At.Unix(), 10)
+		var sunset string
+		if !d.Sunset.IsZero() {
+			sunset = d.Sunset.UTC().Format(http.TimeFormat)
+		}
+		var link string
+		if d.Link != "" {
+			link = "<" + d.Link + `>; rel="deprecation"; type="text/html"`
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Set("Deprecation", deprecation)
+			if sunset != "" {
+				h.Set("Sunset", sunset)
+			}
+			if link != "" {
+				h.Add("Link", link)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// internal/middleware/deprecation_test.go
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+func TestDeprecated(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	serve := func(d middleware.Deprecation) http.Header {
+		rr := httptest.NewRecorder()
+		middleware.Deprecated(d)(ok).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNoContent, rr.Code, "The deprecated version is still served")
+		return rr.Header()
+	}
+
+	h := serve(middleware.Deprecation{
+		At:     time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2027, 1, 1, 0, 0, 0, 0, time.FixedZone("CET", 3600)),
+		Link:   "https://example.com/migrate-to-v2",
+	})
+	assert.Equal(t, "@1780272000", h.Get("Deprecation"))
+	assert.Equal(t, "Thu, 31 Dec 2026 23:00:00 GMT", h.Get("Sunset"))
+	assert.Equal(t, `<https://example.com/migrate-to-v2>; rel="deprecation"; type="text/html"`, h.Get("Link"))
+
+	h = serve(middleware.Deprecation{At: time.Unix(1780272000, 0)})
+	assert.Equal(t, "@1780272000", h.Get("Deprecation"))
+	assert.Empty(t, h.Get("Sunset"), "No sunset date yet")
+	assert.Empty(t, h.Get("Link"))
+
+	h = serve(middleware.Deprecation{})
+	assert.Empty(t, h.Get("Deprecation"), "A current version is not deprecated")
+}
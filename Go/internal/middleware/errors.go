@@ -0,0 +1,54 @@
+// internal/middleware/errors.go
+package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/you/linkedinify/api"
+)
+
+// ErrorCode classifies an error response so clients can act on it without
+// parsing the message; see api.ErrorCode.
+type ErrorCode = api.ErrorCode
+
+const (
+	CodeValidation            = api.CodeValidation
+	CodeUnauthorized          = api.CodeUnauthorized
+	CodeForbidden             = api.CodeForbidden
+	CodeNotFound              = api.CodeNotFound
+	CodeConflict              = api.CodeConflict
+	CodeQuotaExceeded         = api.CodeQuotaExceeded
+	CodeContentFlagged        = api.CodeContentFlagged
+	CodeRateLimited           = api.CodeRateLimited
+	CodeNotImplemented        = api.CodeNotImplemented
+	CodeUpstreamAI            = api.CodeUpstreamAI
+	CodeContextLengthExceeded = api.CodeContextLengthExceeded
+	CodeLinkedInNotConnected  = api.CodeLinkedInNotConnected
+	CodeTimeout               = api.CodeTimeout
+	CodeInternal              = api.CodeInternal
+)
+
+// ErrorDetail describes what went wrong. RequestID is the ID assigned by
+// RequestID, for users to quote when reporting a problem.
+type ErrorDetail = api.ErrorDetail
+
+// ErrorEnvelope is the body of every error response. Responses with more to
+// say embed it next to their own fields.
+type ErrorEnvelope = api.ErrorEnvelope
+
+// NewErrorEnvelope is the envelope of an error response written to w, taking
+// the request ID from the header RequestID set on it.
+func NewErrorEnvelope(w http.ResponseWriter, code ErrorCode, message string) ErrorEnvelope {
+	return ErrorEnvelope{Error: ErrorDetail{Code: code, Message: message, RequestID: w.Header().Get(RequestIDHeader)}}
+}
+
+// WriteError writes an error response with status, code and message.
+func WriteError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(NewErrorEnvelope(w, code, message)); err != nil {
+		slog.Error("writing error response failed", "error", err)
+	}
+}
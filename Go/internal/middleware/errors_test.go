@@ -0,0 +1,81 @@
+// internal/middleware/errors_test.go
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+func decodeError(t *testing.T, rr *httptest.ResponseRecorder) middleware.ErrorDetail {
+	t.Helper()
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var body middleware.ErrorEnvelope
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	return body.Error
+}
+
+func TestWriteError(t *testing.T) {
+	h := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		middleware.WriteError(w, http.StatusNotFound, middleware.CodeNotFound, "Post not found")
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "lb-1234")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, middleware.ErrorDetail{Code: middleware.CodeNotFound, Message: "Post not found", RequestID: "lb-1234"}, decodeError(t, rr))
+}
+
+func TestMiddlewareErrors(t *testing.T) {
+	secret := []byte("test-secret")
+	tests := map[string]struct {
+		handler    http.Handler
+		wantStatus int
+		wantCode   middleware.ErrorCode
+	}{
+		"unauthorized": {
+			handler:    middleware.Auth(secret)(http.NotFoundHandler()),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   middleware.CodeUnauthorized,
+		},
+		"forbidden": {
+			handler:    middleware.RequireRole("admin")(http.NotFoundHandler()),
+			wantStatus: http.StatusForbidden,
+			wantCode:   middleware.CodeForbidden,
+		},
+		"rate limited": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				middleware.TooManyRequests(w, time.Second)
+			}),
+			wantStatus: http.StatusTooManyRequests,
+			wantCode:   middleware.CodeRateLimited,
+		},
+		"timeout": {
+			handler: middleware.Timeout(time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+			})),
+			wantStatus: http.StatusGatewayTimeout,
+			wantCode:   middleware.CodeTimeout,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			middleware.RequestID(tt.handler).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			e := decodeError(t, rr)
+			assert.Equal(t, tt.wantCode, e.Code)
+			assert.NotEmpty(t, e.Message)
+			assert.Equal(t, rr.Header().Get(middleware.RequestIDHeader), e.RequestID)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// internal/middleware/logger.go
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+)
+
+const loggedUserKey ctxKey = "loggedUser"
+
+// loggedUser is where Auth, running further down the chain, leaves the
+// authenticated user for the Logger that wraps it; the Logger's own context
+// never sees the values Auth adds.
+type loggedUser struct {
+	id uuid.UUID
+}
+
+// setLoggedUser records uid as the user of the request ctx belongs to, if a
+// Logger is logging it.
+func setLoggedUser(ctx context.Context, uid uuid.UUID) {
+	if u, ok := ctx.Value(loggedUserKey).(*loggedUser); ok {
+		u.id = uid
+	}
+}
+
+// Logger logs one structured event per request with its method, path,
+// status, response size and duration, and the user_id of authenticated
+// requests. Server errors are logged at error level, everything else at
+// info. Install it after RequestID so the events carry the request ID.
+func Logger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+		user := new(loggedUser)
+		next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), loggedUserKey, user)))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		attrs := []any{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", status,
+			"bytes", ww.BytesWritten(),
+			"duration", time.Since(start),
+			"remote_addr", r.RemoteAddr,
+			"client_ip", ClientIP(r),
+		}
+		if user.id != uuid.Nil {
+			attrs = append(attrs, "user_id", user.id.String())
+		}
+		slog.Log(r.Context(), level, "request served", attrs...)
+	})
+}
@@ -0,0 +1,67 @@
+// internal/middleware/logger_test.go
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+func TestLogger_LogsStructuredRequest(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	h := middleware.Logger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("upstream failed"))
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/posts", nil))
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "request served", record["msg"])
+	assert.Equal(t, "ERROR", record["level"], "5xx responses should be logged as errors")
+	assert.Equal(t, "POST", record["method"])
+	assert.Equal(t, "/api/v1/posts", record["path"])
+	assert.Equal(t, float64(http.StatusBadGateway), record["status"])
+	assert.Equal(t, float64(len("upstream failed")), record["bytes"])
+	assert.Contains(t, record, "duration")
+}
+
+func TestLogger_LogsAuthenticatedUser(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	secret := []byte("test-secret")
+	userID := uuid.New()
+	h := middleware.Logger(middleware.Auth(secret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts", nil)
+	req.Header.Set("Authorization", "Bearer "+generateTestToken(t, userID, secret, time.Hour))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, userID.String(), record["user_id"], "The user set by Auth reaches the request log")
+
+	buf.Reset()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/posts", nil))
+	record = nil
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, float64(http.StatusUnauthorized), record["status"])
+	assert.NotContains(t, record, "user_id")
+}
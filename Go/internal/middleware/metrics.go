@@ -0,0 +1,43 @@
+// internal/middleware/metrics.go
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestRecorder receives one observation per HTTP request.
+type RequestRecorder interface {
+	ObserveRequest(method, route string, status int, d time.Duration)
+}
+
+// unmatchedRoute labels requests that matched no route, so scanners probing
+// random paths cannot create a series per path.
+const unmatchedRoute = "unmatched"
+
+// Metrics reports every request to rec, labelled with the chi route pattern
+// it matched, such as /api/v1/posts/{id}. It must be installed on the root
+// router so the pattern is complete once the request has been served.
+func Metrics(rec RequestRecorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			route := unmatchedRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+			status := ww.Status()
+			if status == 0 {
+				// Nothing was written, which net/http sends as a 200.
+				status = http.StatusOK
+			}
+			rec.ObserveRequest(r.Method, route, status, time.Since(start))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// internal/middleware/metrics_test.go
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+type observation struct {
+	method, route string
+	status        int
+}
+
+type fakeRecorder struct {
+	mu   sync.Mutex
+	seen []observation
+}
+
+func (f *fakeRecorder) ObserveRequest(method, route string, status int, d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seen = append(f.seen, observation{method, route, status})
+}
+
+func TestMetrics_LabelsByRoutePattern(t *testing.T) {
+	rec := &fakeRecorder{}
+	r := chi.NewRouter()
+	r.Use(middleware.Metrics(rec))
+	posts := chi.NewRouter()
+	posts.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	posts.Delete("/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.Mount("/api/v1/posts", posts)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/posts/123", nil),
+		httptest.NewRequest(http.MethodDelete, "/api/v1/posts/456", nil),
+		httptest.NewRequest(http.MethodGet, "/wp-login.php", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	require.Len(t, rec.seen, 3)
+	assert.Equal(t, observation{http.MethodGet, "/api/v1/posts/{id}", http.StatusOK}, rec.seen[0], "IDs are not part of the label")
+	assert.Equal(t, observation{http.MethodDelete, "/api/v1/posts/{id}", http.StatusNoContent}, rec.seen[1])
+	assert.Equal(t, observation{http.MethodGet, "unmatched", http.StatusNotFound}, rec.seen[2])
+}
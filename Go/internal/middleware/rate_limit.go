@@ -0,0 +1,199 @@
+// internal/middleware/rate_limit.go
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RateLimitStore decides whether a request identified by key may proceed.
+// When it may not, retryAfter is how long until it would be allowed.
+// Implementations must be safe for concurrent use; a shared store such as
+// Redis lets the limit apply across instances.
+type RateLimitStore interface {
+	Allow(ctx context.Context, key string) (ok bool, retryAfter time.Duration, err error)
+}
+
+// RateLimitState is where a key stands in its limit after a request.
+type RateLimitState struct {
+	// Limit is how many requests the key may make in a burst.
+	Limit int
+	// Remaining is how many more it may make right now.
+	Remaining int
+	// Reset is how long until the full Limit is available again.
+	Reset time.Duration
+}
+
+// RateLimitReporter is a RateLimitStore that can also tell where a key stands.
+// RateLimit sends the state in response headers when its store is one.
+type RateLimitReporter interface {
+	RateLimitStore
+	// Take is Allow also returning the key's state after the request,
+	// computed from the same state that decided it.
+	Take(ctx context.Context, key string) (ok bool, retryAfter time.Duration, state RateLimitState, err error)
+}
+
+// DefaultRateLimitHeaderPrefix names the X-RateLimit-Limit,
+// X-RateLimit-Remaining and X-RateLimit-Reset headers.
+const DefaultRateLimitHeaderPrefix = "X-RateLimit-"
+
+type rateLimitOptions struct {
+	headerPrefix string
+}
+
+// RateLimitOption configures optional RateLimit behaviour.
+type RateLimitOption func(*rateLimitOptions)
+
+// RateLimitHeaders are the names of the Limit, Remaining and Reset headers
+// reporting the state of a rate limit with the header prefix.
+func RateLimitHeaders(prefix string) []string {
+	return []string{prefix + "Limit", prefix + "Remaining", prefix + "Reset"}
+}
+
+// WithRateLimitHeaderPrefix names the state headers prefix followed by
+// Limit, Remaining and Reset, such as "RateLimit-" for the IETF draft's
+// names. The default is DefaultRateLimitHeaderPrefix.
+func WithRateLimitHeaderPrefix(prefix string) RateLimitOption {
+	return func(o *rateLimitOptions) { o.headerPrefix = prefix }
+}
+
+// RateLimit limits each authenticated user through store. It must be used
+// after Auth; requests without a user are passed through untouched.
+func RateLimit(store RateLimitStore, opts ...RateLimitOption) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			uid := UserID(r.Context())
+			if uid == uuid.Nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if ok, retryAfter := Allow(w, r, store, uid.String(), opts...); !ok {
+				TooManyRequests(w, retryAfter)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Allow charges a request by key to store, for handlers that decide for
+// themselves when a request counts. When store is a RateLimitReporter, the
+// key's state is set in w's headers, whether the request is allowed or not.
+// If the store fails the request is allowed, which keeps the API usable
+// while it is down.
+func Allow(w http.ResponseWriter, r *http.Request, store RateLimitStore, key string, opts ...RateLimitOption) (ok bool, retryAfter time.Duration) {
+	o := rateLimitOptions{headerPrefix: DefaultRateLimitHeaderPrefix}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var err error
+	if reporter, isReporter := store.(RateLimitReporter); isReporter {
+		var state RateLimitState
+		ok, retryAfter, state, err = reporter.Take(r.Context(), key)
+		if err == nil {
+			h := w.Header()
+			h.Set(o.headerPrefix+"Limit", strconv.Itoa(state.Limit))
+			h.Set(o.headerPrefix+"Remaining", strconv.Itoa(state.Remaining))
+			h.Set(o.headerPrefix+"Reset", strconv.Itoa(int(math.Ceil(state.Reset.Seconds()))))
+		}
+	} else {
+		ok, retryAfter, err = store.Allow(r.Context(), key)
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "rate limit store failed, allowing request", "error", err)
+		return true, 0
+	}
+	return ok, retryAfter
+}
+
+// TooManyRequests writes the 429 RateLimit sends, telling the client to retry
+// after retryAfter, rounded up to a whole second.
+func TooManyRequests(w http.ResponseWriter, retryAfter time.Duration) {
+	secs := int(math.Ceil(retryAfter.Seconds()))
+	if secs < 1 {
+		secs = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(secs))
+	WriteError(w, http.StatusTooManyRequests, CodeRateLimited, "Too many requests, please slow down")
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// MemoryRateLimitStore is an in-process token bucket store. Each key may
+// burst up to the per-minute limit and then refills continuously.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	capacity  float64
+	perSecond float64
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimitStore allows perMinute requests per key per minute.
+func NewMemoryRateLimitStore(perMinute int) *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets:   make(map[string]*bucket),
+		capacity:  float64(perMinute),
+		perSecond: float64(perMinute) / 60,
+	}
+}
+
+func (s *MemoryRateLimitStore) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	ok, retryAfter, _, err := s.Take(ctx, key)
+	return ok, retryAfter, err
+}
+
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string) (bool, time.Duration, RateLimitState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: s.capacity, last: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(s.capacity, b.tokens+now.Sub(b.last).Seconds()*s.perSecond)
+	b.last = now
+
+	allowed := b.tokens >= 1
+	var wait time.Duration
+	if allowed {
+		b.tokens--
+	} else {
+		wait = time.Duration((1 - b.tokens) / s.perSecond * float64(time.Second))
+	}
+	return allowed, wait, RateLimitState{
+		Limit:     int(s.capacity),
+		Remaining: int(b.tokens),
+		Reset:     time.Duration((s.capacity - b.tokens) / s.perSecond * float64(time.Second)),
+	}, nil
+}
+
+// sweep drops buckets that have been idle long enough to be full again, since
+// they are indistinguishable from a new bucket. Callers must hold s.mu.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	full := time.Duration(s.capacity / s.perSecond * float64(time.Second))
+	for k, b := range s.buckets {
+		if now.Sub(b.last) >= full {
+			delete(s.buckets, k)
+		}
+	}
+}
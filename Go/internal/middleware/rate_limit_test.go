@@ -0,0 +1,97 @@
+// internal/middleware/rate_limit_test.go
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+func TestMemoryRateLimitStore_BurstThenLimit(t *testing.T) {
+	store := middleware.NewMemoryRateLimitStore(3)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		ok, _, err := store.Allow(ctx, "user-a")
+		require.NoError(t, err)
+		assert.True(t, ok, "request %d should be within the burst", i+1)
+	}
+	ok, retryAfter, err := store.Allow(ctx, "user-a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.InDelta(t, (20 * time.Second).Seconds(), retryAfter.Seconds(), 1, "3/min refills one token every 20s")
+
+	ok, _, err = store.Allow(ctx, "user-b")
+	require.NoError(t, err)
+	assert.True(t, ok, "Limits are per key")
+}
+
+func TestRateLimit_Returns429WithRetryAfter(t *testing.T) {
+	limited := middleware.Auth(testAuthSecret)(middleware.RateLimit(middleware.NewMemoryRateLimitStore(1))(&mockHandler{}))
+	token := generateTestToken(t, uuid.New(), testAuthSecret, time.Hour)
+
+	var codes []int
+	var rr *httptest.ResponseRecorder
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr = httptest.NewRecorder()
+		limited.ServeHTTP(rr, req)
+		codes = append(codes, rr.Code)
+	}
+
+	assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, codes)
+	retryAfter, err := strconv.Atoi(rr.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	assert.InDelta(t, 60, retryAfter, 1)
+}
+
+func TestRateLimit_StateHeaders(t *testing.T) {
+	token := generateTestToken(t, uuid.New(), testAuthSecret, time.Hour)
+	request := func(h http.Handler) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+
+	limited := middleware.Auth(testAuthSecret)(middleware.RateLimit(middleware.NewMemoryRateLimitStore(2))(&mockHandler{}))
+	rr := request(limited)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rr.Header().Get("X-RateLimit-Remaining"), "Successful responses report the state too")
+	assert.Equal(t, "30", rr.Header().Get("X-RateLimit-Reset"), "2/min refills the spent token in 30s")
+
+	request(limited)
+	rr = request(limited)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "0", rr.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", rr.Header().Get("X-RateLimit-Reset"))
+
+	draft := middleware.Auth(testAuthSecret)(middleware.RateLimit(middleware.NewMemoryRateLimitStore(5), middleware.WithRateLimitHeaderPrefix("RateLimit-"))(&mockHandler{}))
+	rr = request(draft)
+	assert.Equal(t, "5", rr.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "4", rr.Header().Get("RateLimit-Remaining"))
+	assert.Empty(t, rr.Header().Get("X-RateLimit-Limit"))
+}
+
+func TestRateLimit_SkipsUnauthenticatedRequests(t *testing.T) {
+	next := &mockHandler{}
+	limited := middleware.RateLimit(middleware.NewMemoryRateLimitStore(1))(next)
+
+	for i := 0; i < 3; i++ {
+		rr := httptest.NewRecorder()
+		limited.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/test", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	}
+}
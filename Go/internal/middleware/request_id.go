@@ -0,0 +1,59 @@
+// internal/middleware/request_id.go
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/requestid"
+)
+
+// RequestIDHeader carries the request ID in both directions.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied IDs so they stay log-friendly.
+const maxRequestIDLength = 128
+
+// RequestID tags every request with an ID for correlating logs. A valid
+// incoming X-Request-ID is reused, otherwise a UUID is generated. The ID is
+// echoed in the response header and also stored under chi's key so that
+// chi's Logger prints it.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := requestid.NewContext(r.Context(), id)
+		ctx = context.WithValue(ctx, chimw.RequestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the ID assigned by RequestID, or "" outside a
+// request.
+func RequestIDFromContext(ctx context.Context) string {
+	return requestid.FromContext(ctx)
+}
+
+// validRequestID accepts short IDs made of characters that cannot forge log
+// lines or headers.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
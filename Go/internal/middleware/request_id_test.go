@@ -0,0 +1,48 @@
+// internal/middleware/request_id_test.go
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/middleware"
+)
+
+func TestRequestID_GeneratesID(t *testing.T) {
+	var seen string
+	h := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = middleware.RequestIDFromContext(r.Context())
+	}))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	_, err := uuid.Parse(seen)
+	require.NoError(t, err, "A generated request ID should be a UUID")
+	assert.Equal(t, seen, rr.Header().Get(middleware.RequestIDHeader))
+}
+
+func TestRequestID_ReusesIncomingID(t *testing.T) {
+	var seen string
+	h := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = middleware.RequestIDFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "lb-1234")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	assert.Equal(t, "lb-1234", seen)
+	assert.Equal(t, "lb-1234", rr.Header().Get(middleware.RequestIDHeader))
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "bad id\" injected")
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	assert.NotEqual(t, "bad id\" injected", seen, "Unsafe IDs should be replaced")
+}
@@ -0,0 +1,78 @@
+// internal/middleware/timeout.go
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Timeout cancels the request context after d. A handler that has not
+// started its response by then gets a 504 instead; whatever it writes after
+// the deadline is discarded. Once a response has started it cannot be
+// replaced, so a stream cut off by the deadline just ends. A zero d disables
+// the timeout.
+//
+// The handler keeps running until it returns, so it must honour the context
+// for the deadline to free its goroutine and abort upstream calls.
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if d <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			tw := &timeoutWriter{ResponseWriter: w, ctx: ctx}
+			next.ServeHTTP(tw, r.WithContext(ctx))
+			if !tw.wroteHeader && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				WriteError(w, http.StatusGatewayTimeout, CodeTimeout, "The request took too long, please try again")
+			}
+		})
+	}
+}
+
+// timeoutWriter passes a response through until the deadline, and swallows
+// it if it only starts afterwards.
+type timeoutWriter struct {
+	http.ResponseWriter
+	ctx         context.Context
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (w *timeoutWriter) WriteHeader(status int) {
+	if w.wroteHeader || w.timedOut {
+		return
+	}
+	if w.ctx.Err() != nil {
+		w.timedOut = true
+		return
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush keeps server-sent events working behind the timeout.
+func (w *timeoutWriter) Flush() {
+	f, ok := w.ResponseWriter.(http.Flusher)
+	if !ok {
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if !w.timedOut {
+		f.Flush()
+	}
+}
+
+func (w *timeoutWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
@@ -0,0 +1,276 @@
+// internal/migrate/migrate.go
+
+// Package migrate applies the SQL migrations in the migrations directory and
+// records them in the schema_migrations table.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Migration is one schema change. Up applies it and Down reverts it.
+type Migration struct {
+	Version int
+	// Name is the file name without .sql, such as 001_init.
+	Name string
+	Up   string
+	Down string
+}
+
+// Load reads the migrations in fsys, ordered by version. Every NNN_name.sql
+// file at the root is a migration, and must have a down/NNN_name.sql that
+// reverts it.
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, file := range files {
+		name := strings.TrimSuffix(file, ".sql")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("migration %s: name must start with a version number, as in 001_init.sql", file)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", other, file)
+		}
+		seen[version] = file
+
+		up, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		down, err := fs.ReadFile(fsys, path.Join("down", file))
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("migration %s has no down/%s to revert it", file, file)
+		}
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name, Up: string(up), Down: string(down)})
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+	return migrations, nil
+}
+
+// appliedMigration is a row of schema_migrations.
+type appliedMigration struct {
+	bun.BaseModel `bun:"table:schema_migrations"`
+
+	Version   int       `bun:",pk"`
+	Name      string    `bun:",notnull"`
+	AppliedAt time.Time `bun:",notnull,default:current_timestamp"`
+}
+
+// Status is a migration and when it was applied.
+type Status struct {
+	Migration
+	AppliedAt *time.Time // nil while pending
+}
+
+// lockID is the Postgres advisory lock that keeps instances starting at the
+// same time from migrating concurrently.
+const lockID = 7_283_104_561
+
+// Migrator applies and reverts migrations. Each migration runs in its own
+// transaction together with its schema_migrations row, so a failed one
+// leaves no trace and the ones before it stay applied.
+type Migrator struct {
+	db         *bun.DB
+	migrations []Migration
+	dryRun     bool
+}
+
+// Option configures a Migrator.
+type Option func(*Migrator)
+
+// WithDryRun makes Up, Down and Baseline report what they would do without
+// changing the database.
+func WithDryRun(dryRun bool) Option {
+	return func(m *Migrator) { m.dryRun = dryRun }
+}
+
+// New creates a Migrator for the given migrations, as returned by Load.
+func New(db *bun.DB, migrations []Migration, opts ...Option) *Migrator {
+	m := &Migrator{db: db, migrations: migrations}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Up applies every pending migration in version order and returns them.
+// Migrations older than the newest applied one are applied too, so branches
+// merged out of order still get their changes.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn bun.Conn, applied map[int]time.Time) error {
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if !m.dryRun {
+				err := run(ctx, conn, mig.Up, func(ctx context.Context, tx bun.Tx) error {
+					_, err := tx.NewInsert().Model(&appliedMigration{Version: mig.Version, Name: mig.Name}).Exec(ctx)
+					return err
+				})
+				if err != nil {
+					return fmt.Errorf("applying %s: %w", mig.Name, err)
+				}
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns
+// them. It fails without reverting anything if one of them is unknown, as
+// happens when the database was migrated by a newer release.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn bun.Conn, applied map[int]time.Time) error {
+		versions := make([]int, 0, len(applied))
+		for v := range applied {
+			versions = append(versions, v)
+		}
+		slices.Sort(versions)
+		slices.Reverse(versions)
+
+		var revert []Migration
+		for _, v := range versions[:min(steps, len(versions))] {
+			i := slices.IndexFunc(m.migrations, func(mig Migration) bool { return mig.Version == v })
+			if i < 0 {
+				return fmt.Errorf("migration %d is applied but unknown to this release", v)
+			}
+			revert = append(revert, m.migrations[i])
+		}
+		for _, mig := range revert {
+			if !m.dryRun {
+				err := run(ctx, conn, mig.Down, func(ctx context.Context, tx bun.Tx) error {
+					_, err := tx.NewDelete().Model((*appliedMigration)(nil)).Where("version = ?", mig.Version).Exec(ctx)
+					return err
+				})
+				if err != nil {
+					return fmt.Errorf("reverting %s: %w", mig.Name, err)
+				}
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Baseline records the migrations up to and including version as applied
+// without running them, for databases whose schema was set up by hand. It
+// returns the migrations it recorded.
+func (m *Migrator) Baseline(ctx context.Context, version int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn bun.Conn, applied map[int]time.Time) error {
+		var rows []appliedMigration
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok || mig.Version > version {
+				continue
+			}
+			rows = append(rows, appliedMigration{Version: mig.Version, Name: mig.Name})
+			done = append(done, mig)
+		}
+		if m.dryRun || len(rows) == 0 {
+			return nil
+		}
+		_, err := conn.NewInsert().Model(&rows).Exec(ctx)
+		return err
+	})
+	return done, err
+}
+
+// Status reports every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := appliedVersions(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		out[i] = Status{Migration: mig}
+		if at, ok := applied[mig.Version]; ok {
+			out[i].AppliedAt = &at
+		}
+	}
+	return out, nil
+}
+
+// locked runs fn on a connection holding the migration lock, passing it the
+// versions applied so far. The schema_migrations table is created first
+// unless this is a dry run.
+func (m *Migrator) locked(ctx context.Context, fn func(conn bun.Conn, applied map[int]time.Time) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(?)", lockID); err != nil {
+		return fmt.Errorf("taking the migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock(?)", lockID)
+
+	if !m.dryRun {
+		if _, err := conn.NewCreateTable().Model((*appliedMigration)(nil)).IfNotExists().Exec(ctx); err != nil {
+			return err
+		}
+	}
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return err
+	}
+	return fn(conn, applied)
+}
+
+// appliedVersions returns when each applied migration was applied. A
+// database without a schema_migrations table has none.
+func appliedVersions(ctx context.Context, db bun.IDB) (map[int]time.Time, error) {
+	var exists bool
+	if err := db.NewRaw("SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(ctx, &exists); err != nil {
+		return nil, err
+	}
+	applied := make(map[int]time.Time)
+	if !exists {
+		return applied, nil
+	}
+	var rows []appliedMigration
+	if err := db.NewSelect().Model(&rows).Scan(ctx); err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		applied[r.Version] = r.AppliedAt
+	}
+	return applied, nil
+}
+
+// run executes script and then record in one transaction. The script is
+// sent as is, without bun's placeholder formatting, so it may hold several
+// statements and literal question marks.
+func run(ctx context.Context, conn bun.Conn, script string, record func(ctx context.Context, tx bun.Tx) error) error {
+	return conn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.Tx.ExecContext(ctx, script); err != nil {
+			return err
+		}
+		return record(ctx, tx)
+	})
+}
@@ -0,0 +1,64 @@
+// internal/migrate/migrate_test.go
+package migrate_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/migrate"
+	"github.com/you/linkedinify/migrations"
+)
+
+func TestLoad_OrdersByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"010_b.sql":      {Data: []byte("create table b ();")},
+		"002_a.sql":      {Data: []byte("create table a ();")},
+		"down/010_b.sql": {Data: []byte("drop table b;")},
+		"down/002_a.sql": {Data: []byte("drop table a;")},
+		"README.md":      {Data: []byte("not a migration")},
+	}
+	got, err := migrate.Load(fsys)
+	require.NoError(t, err)
+	assert.Equal(t, []migrate.Migration{
+		{Version: 2, Name: "002_a", Up: "create table a ();", Down: "drop table a;"},
+		{Version: 10, Name: "010_b", Up: "create table b ();", Down: "drop table b;"},
+	}, got)
+}
+
+func TestLoad_Rejects(t *testing.T) {
+	for name, tc := range map[string]struct {
+		fsys fstest.MapFS
+		want string
+	}{
+		"no version": {fstest.MapFS{
+			"init.sql": {}, "down/init.sql": {},
+		}, "name must start with a version number"},
+		"same version": {fstest.MapFS{
+			"001_a.sql": {}, "down/001_a.sql": {},
+			"1_b.sql": {}, "down/1_b.sql": {},
+		}, "have the same version"},
+		"no down": {fstest.MapFS{
+			"001_a.sql": {},
+		}, "001_a.sql has no down/001_a.sql"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := migrate.Load(tc.fsys)
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	all, err := migrate.Load(migrations.FS)
+	require.NoError(t, err, "Every migration needs a down script")
+	require.NotEmpty(t, all)
+	for i, m := range all {
+		assert.Equal(t, i+1, m.Version, "Versions run 1, 2, 3… without gaps")
+		assert.True(t, strings.HasPrefix(m.Up, "-- migrations/"+m.Name+".sql\n"), m.Name)
+		assert.True(t, strings.HasPrefix(m.Down, "-- migrations/down/"+m.Name+".sql\n"), m.Name)
+	}
+}
@@ -0,0 +1,27 @@
+// internal/model/analytics_event.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// AnalyticsEvent records one generated post: who generated it, when, and
+// with what. Cached events were served from the response cache and used no
+// tokens.
+type AnalyticsEvent struct {
+	bun.BaseModel    `bun:"table:analytics_events"`
+	ID               int64     `bun:",pk,autoincrement"`
+	UserID           uuid.UUID `bun:"type:uuid,notnull"`
+	PostID           uuid.UUID `bun:"type:uuid,notnull"`
+	Model            string    `bun:",notnull"`
+	Tone             string    `bun:",notnull"`
+	Template         string    `bun:",notnull"`
+	PromptTokens     int       `bun:",notnull"`
+	CompletionTokens int       `bun:",notnull"`
+	TotalTokens      int       `bun:",notnull"`
+	Cached           bool      `bun:",notnull"`
+	CreatedAt        time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,23 @@
+// internal/model/api_key.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// APIKey is a long-lived credential for server-to-server integrations. Only
+// a hash of the key is stored; Prefix is its first characters, kept so
+// users can tell their keys apart.
+type APIKey struct {
+	bun.BaseModel `bun:"table:api_keys"`
+	ID            uuid.UUID  `bun:"type:uuid,pk"`
+	UserID        uuid.UUID  `bun:"type:uuid,notnull"`
+	Name          string     `bun:",notnull"`
+	Prefix        string     `bun:",notnull"`
+	KeyHash       string     `bun:",notnull,unique"`
+	RevokedAt     *time.Time `bun:",nullzero"`
+	CreatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,24 @@
+// internal/model/audit_log.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// AuditLog records a sensitive action: what was done to which subject, by
+// whom, from where and when. ActorID is the authenticated user and is zero
+// for actions taken before authenticating, such as logging in, where the
+// subject is the user. Metadata holds details specific to the action.
+type AuditLog struct {
+	bun.BaseModel `bun:"table:audit_logs"`
+	ID            uuid.UUID      `bun:"type:uuid,pk"`
+	ActorID       uuid.UUID      `bun:"type:uuid,nullzero"`
+	SubjectID     uuid.UUID      `bun:"type:uuid,nullzero"`
+	Action        string         `bun:",notnull"`
+	IP            string         `bun:"ip,notnull,default:''"`
+	Metadata      map[string]any `bun:"type:jsonb,notnull,default:'{}'"`
+	CreatedAt     time.Time      `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,34 @@
+// internal/model/linkedin_connection.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// LinkedInConnection is a user's LinkedIn account, connected through OAuth so
+// posts can be published to their feed. MemberURN is the author of those
+// posts. RefreshToken is empty, and RefreshExpiresAt zero, when LinkedIn did
+// not issue one; the user then has to reconnect once AccessToken expires.
+type LinkedInConnection struct {
+	bun.BaseModel    `bun:"table:linkedin_connections"`
+	UserID           uuid.UUID `bun:"type:uuid,pk"`
+	MemberURN        string    `bun:",notnull"`
+	AccessToken      string    `bun:",notnull"`
+	ExpiresAt        time.Time `bun:",notnull"`
+	RefreshToken     string    `bun:",notnull,default:''"`
+	RefreshExpiresAt time.Time `bun:",nullzero"`
+	CreatedAt        time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt        time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// UsedOAuthState is the ID of an OAuth state that completed a LinkedIn
+// authorization. It is kept until the state expires, so the state cannot
+// complete another one.
+type UsedOAuthState struct {
+	bun.BaseModel `bun:"table:linkedin_oauth_states"`
+	ID            string    `bun:",pk"`
+	ExpiresAt     time.Time `bun:",notnull"`
+}
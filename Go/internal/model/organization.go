@@ -0,0 +1,45 @@
+// internal/model/organization.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Roles a user can hold in their organization. Owners invite members.
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleMember = "member"
+)
+
+// PersonalOrgName is the name of the organization every account starts in.
+const PersonalOrgName = "Personal"
+
+// Organization is a workspace whose members share their posts. Every user
+// belongs to exactly one. A personal organization is created with each
+// account and only ever has that user in it; teams are created on purpose
+// and grow by invitation.
+type Organization struct {
+	bun.BaseModel `bun:"table:organizations"`
+	ID            uuid.UUID `bun:"type:uuid,pk"`
+	Name          string    `bun:",notnull"`
+	Personal      bool      `bun:",notnull,default:false"`
+	CreatedBy     uuid.UUID `bun:"type:uuid,nullzero"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// OrgInvitation is a single-use, time-limited invitation for Email to join
+// an organization. Only a hash of the token is stored.
+type OrgInvitation struct {
+	bun.BaseModel `bun:"table:org_invitations"`
+	ID            uuid.UUID  `bun:"type:uuid,pk"`
+	OrgID         uuid.UUID  `bun:"type:uuid,notnull"`
+	Email         string     `bun:",notnull"`
+	TokenHash     string     `bun:",notnull,unique"`
+	InvitedBy     uuid.UUID  `bun:"type:uuid,notnull"`
+	ExpiresAt     time.Time  `bun:",notnull"`
+	AcceptedAt    *time.Time `bun:",nullzero"`
+	CreatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
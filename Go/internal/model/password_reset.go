@@ -0,0 +1,21 @@
+// internal/model/password_reset.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// PasswordReset is a single-use, time-limited password reset token. Only a
+// hash of the token is stored.
+type PasswordReset struct {
+	bun.BaseModel `bun:"table:password_resets"`
+	ID            uuid.UUID  `bun:"type:uuid,pk"`
+	UserID        uuid.UUID  `bun:"type:uuid,notnull"`
+	TokenHash     string     `bun:",notnull,unique"`
+	ExpiresAt     time.Time  `bun:",notnull"`
+	UsedAt        *time.Time `bun:",nullzero"`
+	CreatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -8,6 +8,28 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// Post statuses. Generations start as drafts and become final once the user
+// has reviewed them.
+const (
+	PostStatusDraft = "draft"
+	PostStatusFinal = "final"
+)
+
+// Schedule statuses of a post scheduled for publishing. Posts that were
+// never scheduled have an empty status.
+const (
+	// ScheduleStatusScheduled posts wait for their time, or for their next
+	// attempt after one failed.
+	ScheduleStatusScheduled = "scheduled"
+	// ScheduleStatusPublishing posts have been claimed by an instance that
+	// is publishing them.
+	ScheduleStatusPublishing = "publishing"
+	ScheduleStatusPublished  = "published"
+	// ScheduleStatusFailed posts ran out of attempts, or failed in a way no
+	// retry fixes; ScheduleError says why.
+	ScheduleStatusFailed = "failed"
+)
+
 type LinkedInPost struct {
 	bun.BaseModel `bun:"table:linkedin_posts"`
 	ID            uuid.UUID `bun:"type:uuid,pk"`
@@ -15,4 +37,69 @@ type LinkedInPost struct {
 	InputText     string    `bun:",notnull"`
 	OutputText    string    `bun:",notnull"`
 	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt     time.Time `bun:",nullzero"`
+	Status        string    `bun:",notnull,default:'draft'"`
+	// Source tells whether OutputText was generated or edited by hand; see
+	// PostSourceAI and PostSourceManual.
+	Source string `bun:",notnull,default:'ai'"`
+
+	// Model and token usage of the generation that produced OutputText.
+	Model            string `bun:",notnull"`
+	PromptTokens     int    `bun:",notnull"`
+	CompletionTokens int    `bun:",notnull"`
+	TotalTokens      int    `bun:",notnull"`
+
+	// Template, Tone and Length are the options the post was generated with.
+	// Tone is empty when the template's own voice was used. Together with
+	// InputText and Model they let a post be regenerated.
+	Template string `bun:",notnull"`
+	Tone     string `bun:",notnull"`
+	Length   string `bun:",notnull"`
+	// Language is the ISO 639-1 code the post was written in.
+	Language string `bun:",notnull,default:'en'"`
+	// Temperature and TopP are the sampling parameters of the generation.
+	Temperature float64 `bun:",notnull,default:1"`
+	TopP        float64 `bun:"top_p,notnull,default:1"`
+
+	// ImageURL is the latest image generated for the post, empty if none,
+	// and ImageExpiresAt when the provider stops serving it. ImagePrompt
+	// and ImageSize are what it was generated with.
+	ImageURL       string    `bun:",notnull,default:''"`
+	ImageExpiresAt time.Time `bun:",nullzero"`
+	ImagePrompt    string    `bun:",notnull,default:''"`
+	ImageSize      string    `bun:",notnull,default:''"`
+
+	// Favorited reports whether the user the post was loaded for
+	// bookmarked it for quick access. It is read from post_favorites, so it
+	// is never written with the post.
+	Favorited bool `bun:",scanonly"`
+
+	// LinkedInURN identifies the post on LinkedIn once it has been
+	// published there, empty until then.
+	LinkedInURN string    `bun:"linkedin_urn,notnull,default:''"`
+	PublishedAt time.Time `bun:",nullzero"`
+	// PublishingUntil is when the claim of whoever is sharing the post to
+	// LinkedIn right now runs out, zero when no one is; see
+	// repository.PostRepository.ClaimPublish.
+	PublishingUntil time.Time `bun:",nullzero"`
+
+	// ScheduledAt is when the post is to be published to the LinkedIn feed
+	// of ScheduledBy, zero unless it was scheduled. ScheduleStatus is one of
+	// the ScheduleStatus constants, ScheduleAttempts counts the attempts so
+	// far and ScheduleError is the error of the last failed one.
+	// NextAttemptAt is when the scheduler may pick the post up next.
+	ScheduledAt      time.Time `bun:",nullzero"`
+	ScheduledBy      uuid.UUID `bun:"type:uuid,nullzero"`
+	ScheduleStatus   string    `bun:",notnull,default:''"`
+	ScheduleAttempts int       `bun:",notnull,default:0"`
+	ScheduleError    string    `bun:",notnull,default:''"`
+	NextAttemptAt    time.Time `bun:",nullzero"`
+
+	// OrgID is the organization the post is shared with: the one its
+	// author was in when writing it.
+	OrgID uuid.UUID `bun:"type:uuid,notnull"`
+
+	// DeletedAt is set when the user deletes the post. bun excludes
+	// soft-deleted rows from every query unless asked otherwise.
+	DeletedAt time.Time `bun:",soft_delete,nullzero"`
 }
@@ -0,0 +1,19 @@
+// internal/model/post_favorite.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// PostFavorite marks a post as one of UserID's favorites. Favorites are kept
+// per user, so bookmarking a post the organization shares does not bookmark
+// it for everyone.
+type PostFavorite struct {
+	bun.BaseModel `bun:"table:post_favorites"`
+	UserID        uuid.UUID `bun:"type:uuid,pk"`
+	PostID        uuid.UUID `bun:"type:uuid,pk"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
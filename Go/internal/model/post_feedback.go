@@ -0,0 +1,31 @@
+// internal/model/post_feedback.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Ratings a user can give a generated post.
+const (
+	FeedbackUp   = "up"
+	FeedbackDown = "down"
+)
+
+// PostFeedback is a user's rating of one of their posts. Model, Tone and
+// Template are those the post was generated with when it was rated.
+type PostFeedback struct {
+	bun.BaseModel `bun:"table:post_feedback"`
+	ID            uuid.UUID `bun:"type:uuid,pk"`
+	PostID        uuid.UUID `bun:"type:uuid,notnull"`
+	UserID        uuid.UUID `bun:"type:uuid,notnull"`
+	// Rating is FeedbackUp or FeedbackDown.
+	Rating    string    `bun:",notnull"`
+	Comment   string    `bun:",notnull"`
+	Model     string    `bun:",notnull"`
+	Tone      string    `bun:",notnull"`
+	Template  string    `bun:",notnull"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
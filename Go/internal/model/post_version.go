@@ -0,0 +1,27 @@
+// internal/model/post_version.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Where the text of a post or version came from.
+const (
+	PostSourceAI     = "ai"
+	PostSourceManual = "manual"
+)
+
+// PostVersion is an earlier text of a post, saved when the post was edited.
+type PostVersion struct {
+	bun.BaseModel `bun:"table:post_versions"`
+	ID            uuid.UUID `bun:"type:uuid,pk"`
+	PostID        uuid.UUID `bun:"type:uuid,notnull"`
+	OutputText    string    `bun:",notnull"`
+	// Source is PostSourceAI or PostSourceManual.
+	Source string `bun:",notnull"`
+	// CreatedAt is when this text was written, not when it was replaced.
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,23 @@
+// internal/model/refresh_token.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// RefreshToken is a stored, hashed refresh token. Every token minted by
+// rotating another shares its FamilyID, so a whole login session can be
+// revoked at once.
+type RefreshToken struct {
+	bun.BaseModel `bun:"table:refresh_tokens"`
+	ID            uuid.UUID  `bun:"type:uuid,pk"`
+	UserID        uuid.UUID  `bun:"type:uuid,notnull"`
+	FamilyID      uuid.UUID  `bun:"type:uuid,notnull"`
+	TokenHash     string     `bun:",notnull,unique"`
+	ExpiresAt     time.Time  `bun:",notnull"`
+	RevokedAt     *time.Time `bun:",nullzero"`
+	CreatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,16 @@
+// internal/model/revoked_token.go
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// RevokedToken records the jti of a logged out access token. It only needs
+// to be kept until the token would have expired anyway.
+type RevokedToken struct {
+	bun.BaseModel `bun:"table:revoked_tokens"`
+	JTI           string    `bun:"jti,pk"`
+	ExpiresAt     time.Time `bun:",notnull"`
+}
@@ -0,0 +1,18 @@
+// internal/model/usage_counter.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// UsageCounter counts the posts a user generated in one calendar month.
+// Period is the first day of that month, in UTC.
+type UsageCounter struct {
+	bun.BaseModel `bun:"table:usage_counters"`
+	UserID        uuid.UUID `bun:"type:uuid,pk"`
+	Period        time.Time `bun:"type:date,pk"`
+	Generations   int       `bun:",notnull"`
+}
@@ -8,11 +8,30 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// Roles a user can hold. Every account starts out as RoleUser.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// PlanFree is the plan every account starts on.
+const PlanFree = "free"
+
+// User is an account. Name, Headline and Industry form the optional profile
+// that prompt templates can use. OrgID is the organization the user works
+// in and OrgRole their role there.
 type User struct {
 	bun.BaseModel `bun:"table:users"`
 	ID            uuid.UUID `bun:"type:uuid,pk"`
 	Email         string    `bun:",notnull,unique"`
 	PasswordHash  string    `bun:",notnull"`
 	APIToken      string    `bun:",notnull,unique"`
+	Role          string    `bun:",notnull,default:'user'"`
+	Plan          string    `bun:",notnull,default:'free'"`
+	Name          string    `bun:",notnull,default:''"`
+	Headline      string    `bun:",notnull,default:''"`
+	Industry      string    `bun:",notnull,default:''"`
+	OrgID         uuid.UUID `bun:"type:uuid,notnull"`
+	OrgRole       string    `bun:",notnull,default:'owner'"`
 	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
 }
@@ -0,0 +1,94 @@
+// internal/repository/analytics_repository.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+// AnalyticsRepository stores generation events and summarizes a user's.
+type AnalyticsRepository interface {
+	// Insert stores events in one statement.
+	Insert(ctx context.Context, events []model.AnalyticsEvent) error
+	// Daily counts the user's generations since since per UTC day, oldest
+	// first. Days without any are left out.
+	Daily(ctx context.Context, userID uuid.UUID, since time.Time) ([]DailyGenerations, error)
+	// TopTones and TopTemplates count the user's generations since since by
+	// tone or template, most used first, returning at most limit of them.
+	// Posts in their template's own voice have an empty tone.
+	TopTones(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]UsageCount, error)
+	TopTemplates(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]UsageCount, error)
+}
+
+// DailyGenerations counts the generations of one UTC day, the tokens they
+// used and how many were served from the cache.
+type DailyGenerations struct {
+	Day         time.Time `bun:"day"`
+	Generations int       `bun:"generations"`
+	TotalTokens int       `bun:"total_tokens"`
+	Cached      int       `bun:"cached"`
+}
+
+// UsageCount is how often a tone or template was used.
+type UsageCount struct {
+	Name  string `bun:"name"`
+	Count int    `bun:"count"`
+}
+
+type analyticsRepo struct{ db bun.IDB }
+
+func NewAnalyticsRepo(db bun.IDB) AnalyticsRepository { return &analyticsRepo{db} }
+
+func (r *analyticsRepo) Insert(ctx context.Context, events []model.AnalyticsEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	_, err := r.db.NewInsert().Model(&events).Exec(ctx)
+	return err
+}
+
+func (r *analyticsRepo) Daily(ctx context.Context, userID uuid.UUID, since time.Time) ([]DailyGenerations, error) {
+	var days []DailyGenerations
+	err := r.db.NewSelect().
+		Model((*model.AnalyticsEvent)(nil)).
+		ColumnExpr("date_trunc('day', created_at AT TIME ZONE 'UTC') AS day").
+		ColumnExpr("count(*) AS generations").
+		ColumnExpr("coalesce(sum(total_tokens), 0) AS total_tokens").
+		ColumnExpr("count(*) FILTER (WHERE cached) AS cached").
+		Where("user_id = ?", userID).
+		Where("created_at >= ?", since).
+		GroupExpr("day").
+		OrderExpr("day").
+		Scan(ctx, &days)
+	return days, err
+}
+
+func (r *analyticsRepo) TopTones(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]UsageCount, error) {
+	return r.top(ctx, "tone", userID, since, limit)
+}
+
+func (r *analyticsRepo) TopTemplates(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]UsageCount, error) {
+	return r.top(ctx, "template", userID, since, limit)
+}
+
+// top counts generations by column, which is one of the fixed names above
+// and never user input.
+func (r *analyticsRepo) top(ctx context.Context, column string, userID uuid.UUID, since time.Time, limit int) ([]UsageCount, error) {
+	var counts []UsageCount
+	err := r.db.NewSelect().
+		Model((*model.AnalyticsEvent)(nil)).
+		ColumnExpr("? AS name", bun.Ident(column)).
+		ColumnExpr("count(*) AS count").
+		Where("user_id = ?", userID).
+		Where("created_at >= ?", since).
+		GroupExpr("?", bun.Ident(column)).
+		OrderExpr("count DESC, name").
+		Limit(limit).
+		Scan(ctx, &counts)
+	return counts, err
+}
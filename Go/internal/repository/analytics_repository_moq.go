@@ -0,0 +1,264 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+	"time"
+)
+
+// Ensure, that AnalyticsRepositoryMock does implement AnalyticsRepository.
+// If this is not the case, regenerate this file with moq.
+var _ AnalyticsRepository = &AnalyticsRepositoryMock{}
+
+// AnalyticsRepositoryMock is a mock implementation of AnalyticsRepository.
+//
+//	func TestSomethingThatUsesAnalyticsRepository(t *testing.T) {
+//
+//		// make and configure a mocked AnalyticsRepository
+//		mockedAnalyticsRepository := &AnalyticsRepositoryMock{
+//			DailyFunc: func(ctx context.Context, userID uuid.UUID, since time.Time) ([]DailyGenerations, error) {
+//				panic("mock out the Daily method")
+//			},
+//			InsertFunc: func(ctx context.Context, events []model.AnalyticsEvent) error {
+//				panic("mock out the Insert method")
+//			},
+//			TopTemplatesFunc: func(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]UsageCount, error) {
+//				panic("mock out the TopTemplates method")
+//			},
+//			TopTonesFunc: func(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]UsageCount, error) {
+//				panic("mock out the TopTones method")
+//			},
+//		}
+//
+//		// use mockedAnalyticsRepository in code that requires AnalyticsRepository
+//		// and then make assertions.
+//
+//	}
+type AnalyticsRepositoryMock struct {
+	// DailyFunc mocks the Daily method.
+	DailyFunc func(ctx context.Context, userID uuid.UUID, since time.Time) ([]DailyGenerations, error)
+
+	// InsertFunc mocks the Insert method.
+	InsertFunc func(ctx context.Context, events []model.AnalyticsEvent) error
+
+	// TopTemplatesFunc mocks the TopTemplates method.
+	TopTemplatesFunc func(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]UsageCount, error)
+
+	// TopTonesFunc mocks the TopTones method.
+	TopTonesFunc func(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]UsageCount, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Daily holds details about calls to the Daily method.
+		Daily []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Since is the since argument value.
+			Since time.Time
+		}
+		// Insert holds details about calls to the Insert method.
+		Insert []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Events is the events argument value.
+			Events []model.AnalyticsEvent
+		}
+		// TopTemplates holds details about calls to the TopTemplates method.
+		TopTemplates []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Since is the since argument value.
+			Since time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// TopTones holds details about calls to the TopTones method.
+		TopTones []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Since is the since argument value.
+			Since time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+	}
+	lockDaily        sync.RWMutex
+	lockInsert       sync.RWMutex
+	lockTopTemplates sync.RWMutex
+	lockTopTones     sync.RWMutex
+}
+
+// Daily calls DailyFunc.
+func (mock *AnalyticsRepositoryMock) Daily(ctx context.Context, userID uuid.UUID, since time.Time) ([]DailyGenerations, error) {
+	if mock.DailyFunc == nil {
+		panic("AnalyticsRepositoryMock.DailyFunc: method is nil but AnalyticsRepository.Daily was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Since:  since,
+	}
+	mock.lockDaily.Lock()
+	mock.calls.Daily = append(mock.calls.Daily, callInfo)
+	mock.lockDaily.Unlock()
+	return mock.DailyFunc(ctx, userID, since)
+}
+
+// DailyCalls gets all the calls that were made to Daily.
+// Check the length with:
+//
+//	len(mockedAnalyticsRepository.DailyCalls())
+func (mock *AnalyticsRepositoryMock) DailyCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Since  time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+	}
+	mock.lockDaily.RLock()
+	calls = mock.calls.Daily
+	mock.lockDaily.RUnlock()
+	return calls
+}
+
+// Insert calls InsertFunc.
+func (mock *AnalyticsRepositoryMock) Insert(ctx context.Context, events []model.AnalyticsEvent) error {
+	if mock.InsertFunc == nil {
+		panic("AnalyticsRepositoryMock.InsertFunc: method is nil but AnalyticsRepository.Insert was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Events []model.AnalyticsEvent
+	}{
+		Ctx:    ctx,
+		Events: events,
+	}
+	mock.lockInsert.Lock()
+	mock.calls.Insert = append(mock.calls.Insert, callInfo)
+	mock.lockInsert.Unlock()
+	return mock.InsertFunc(ctx, events)
+}
+
+// InsertCalls gets all the calls that were made to Insert.
+// Check the length with:
+//
+//	len(mockedAnalyticsRepository.InsertCalls())
+func (mock *AnalyticsRepositoryMock) InsertCalls() []struct {
+	Ctx    context.Context
+	Events []model.AnalyticsEvent
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Events []model.AnalyticsEvent
+	}
+	mock.lockInsert.RLock()
+	calls = mock.calls.Insert
+	mock.lockInsert.RUnlock()
+	return calls
+}
+
+// TopTemplates calls TopTemplatesFunc.
+func (mock *AnalyticsRepositoryMock) TopTemplates(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]UsageCount, error) {
+	if mock.TopTemplatesFunc == nil {
+		panic("AnalyticsRepositoryMock.TopTemplatesFunc: method is nil but AnalyticsRepository.TopTemplates was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Since:  since,
+		Limit:  limit,
+	}
+	mock.lockTopTemplates.Lock()
+	mock.calls.TopTemplates = append(mock.calls.TopTemplates, callInfo)
+	mock.lockTopTemplates.Unlock()
+	return mock.TopTemplatesFunc(ctx, userID, since, limit)
+}
+
+// TopTemplatesCalls gets all the calls that were made to TopTemplates.
+// Check the length with:
+//
+//	len(mockedAnalyticsRepository.TopTemplatesCalls())
+func (mock *AnalyticsRepositoryMock) TopTemplatesCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Since  time.Time
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+		Limit  int
+	}
+	mock.lockTopTemplates.RLock()
+	calls = mock.calls.TopTemplates
+	mock.lockTopTemplates.RUnlock()
+	return calls
+}
+
+// TopTones calls TopTonesFunc.
+func (mock *AnalyticsRepositoryMock) TopTones(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]UsageCount, error) {
+	if mock.TopTonesFunc == nil {
+		panic("AnalyticsRepositoryMock.TopTonesFunc: method is nil but AnalyticsRepository.TopTones was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Since:  since,
+		Limit:  limit,
+	}
+	mock.lockTopTones.Lock()
+	mock.calls.TopTones = append(mock.calls.TopTones, callInfo)
+	mock.lockTopTones.Unlock()
+	return mock.TopTonesFunc(ctx, userID, since, limit)
+}
+
+// TopTonesCalls gets all the calls that were made to TopTones.
+// Check the length with:
+//
+//	len(mockedAnalyticsRepository.TopTonesCalls())
+func (mock *AnalyticsRepositoryMock) TopTonesCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Since  time.Time
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+		Limit  int
+	}
+	mock.lockTopTones.RLock()
+	calls = mock.calls.TopTones
+	mock.lockTopTones.RUnlock()
+	return calls
+}
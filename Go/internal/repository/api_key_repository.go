@@ -0,0 +1,68 @@
+// internal/repository/api_key_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, k *model.APIKey) error
+	// FindByHash returns the key with the given hash unless it was revoked.
+	FindByHash(ctx context.Context, hash string) (*model.APIKey, error)
+	// ListByUser returns the user's keys that are not revoked, newest first.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error)
+	// Revoke revokes a key of userID and reports whether it did; false means
+	// the user has no such key or it was already revoked.
+	Revoke(ctx context.Context, userID, id uuid.UUID) (bool, error)
+}
+
+type apiKeyRepo struct{ db bun.IDB }
+
+func NewAPIKeyRepo(db bun.IDB) APIKeyRepository { return &apiKeyRepo{db} }
+
+func (r *apiKeyRepo) Create(ctx context.Context, k *model.APIKey) error {
+	_, err := r.db.NewInsert().Model(k).Exec(ctx)
+	return err
+}
+
+func (r *apiKeyRepo) FindByHash(ctx context.Context, hash string) (*model.APIKey, error) {
+	k := new(model.APIKey)
+	err := r.db.NewSelect().Model(k).
+		Where("key_hash = ?", hash).
+		Where("revoked_at IS NULL").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (r *apiKeyRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error) {
+	var keys []model.APIKey
+	err := r.db.NewSelect().Model(&keys).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Order("created_at DESC").
+		Scan(ctx)
+	return keys, err
+}
+
+func (r *apiKeyRepo) Revoke(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+	res, err := r.db.NewUpdate().
+		Model((*model.APIKey)(nil)).
+		Set("revoked_at = current_timestamp").
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
@@ -0,0 +1,239 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that APIKeyRepositoryMock does implement APIKeyRepository.
+// If this is not the case, regenerate this file with moq.
+var _ APIKeyRepository = &APIKeyRepositoryMock{}
+
+// APIKeyRepositoryMock is a mock implementation of APIKeyRepository.
+//
+//	func TestSomethingThatUsesAPIKeyRepository(t *testing.T) {
+//
+//		// make and configure a mocked APIKeyRepository
+//		mockedAPIKeyRepository := &APIKeyRepositoryMock{
+//			CreateFunc: func(ctx context.Context, k *model.APIKey) error {
+//				panic("mock out the Create method")
+//			},
+//			FindByHashFunc: func(ctx context.Context, hash string) (*model.APIKey, error) {
+//				panic("mock out the FindByHash method")
+//			},
+//			ListByUserFunc: func(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error) {
+//				panic("mock out the ListByUser method")
+//			},
+//			RevokeFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (bool, error) {
+//				panic("mock out the Revoke method")
+//			},
+//		}
+//
+//		// use mockedAPIKeyRepository in code that requires APIKeyRepository
+//		// and then make assertions.
+//
+//	}
+type APIKeyRepositoryMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, k *model.APIKey) error
+
+	// FindByHashFunc mocks the FindByHash method.
+	FindByHashFunc func(ctx context.Context, hash string) (*model.APIKey, error)
+
+	// ListByUserFunc mocks the ListByUser method.
+	ListByUserFunc func(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error)
+
+	// RevokeFunc mocks the Revoke method.
+	RevokeFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// K is the k argument value.
+			K *model.APIKey
+		}
+		// FindByHash holds details about calls to the FindByHash method.
+		FindByHash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// ListByUser holds details about calls to the ListByUser method.
+		ListByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// Revoke holds details about calls to the Revoke method.
+		Revoke []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+	}
+	lockCreate     sync.RWMutex
+	lockFindByHash sync.RWMutex
+	lockListByUser sync.RWMutex
+	lockRevoke     sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *APIKeyRepositoryMock) Create(ctx context.Context, k *model.APIKey) error {
+	if mock.CreateFunc == nil {
+		panic("APIKeyRepositoryMock.CreateFunc: method is nil but APIKeyRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		K   *model.APIKey
+	}{
+		Ctx: ctx,
+		K:   k,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, k)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.CreateCalls())
+func (mock *APIKeyRepositoryMock) CreateCalls() []struct {
+	Ctx context.Context
+	K   *model.APIKey
+} {
+	var calls []struct {
+		Ctx context.Context
+		K   *model.APIKey
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// FindByHash calls FindByHashFunc.
+func (mock *APIKeyRepositoryMock) FindByHash(ctx context.Context, hash string) (*model.APIKey, error) {
+	if mock.FindByHashFunc == nil {
+		panic("APIKeyRepositoryMock.FindByHashFunc: method is nil but APIKeyRepository.FindByHash was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash string
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockFindByHash.Lock()
+	mock.calls.FindByHash = append(mock.calls.FindByHash, callInfo)
+	mock.lockFindByHash.Unlock()
+	return mock.FindByHashFunc(ctx, hash)
+}
+
+// FindByHashCalls gets all the calls that were made to FindByHash.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.FindByHashCalls())
+func (mock *APIKeyRepositoryMock) FindByHashCalls() []struct {
+	Ctx  context.Context
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash string
+	}
+	mock.lockFindByHash.RLock()
+	calls = mock.calls.FindByHash
+	mock.lockFindByHash.RUnlock()
+	return calls
+}
+
+// ListByUser calls ListByUserFunc.
+func (mock *APIKeyRepositoryMock) ListByUser(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error) {
+	if mock.ListByUserFunc == nil {
+		panic("APIKeyRepositoryMock.ListByUserFunc: method is nil but APIKeyRepository.ListByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListByUser.Lock()
+	mock.calls.ListByUser = append(mock.calls.ListByUser, callInfo)
+	mock.lockListByUser.Unlock()
+	return mock.ListByUserFunc(ctx, userID)
+}
+
+// ListByUserCalls gets all the calls that were made to ListByUser.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.ListByUserCalls())
+func (mock *APIKeyRepositoryMock) ListByUserCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockListByUser.RLock()
+	calls = mock.calls.ListByUser
+	mock.lockListByUser.RUnlock()
+	return calls
+}
+
+// Revoke calls RevokeFunc.
+func (mock *APIKeyRepositoryMock) Revoke(ctx context.Context, userID uuid.UUID, id uuid.UUID) (bool, error) {
+	if mock.RevokeFunc == nil {
+		panic("APIKeyRepositoryMock.RevokeFunc: method is nil but APIKeyRepository.Revoke was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockRevoke.Lock()
+	mock.calls.Revoke = append(mock.calls.Revoke, callInfo)
+	mock.lockRevoke.Unlock()
+	return mock.RevokeFunc(ctx, userID, id)
+}
+
+// RevokeCalls gets all the calls that were made to Revoke.
+// Check the length with:
+//
+//	len(mockedAPIKeyRepository.RevokeCalls())
+func (mock *APIKeyRepositoryMock) RevokeCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockRevoke.RLock()
+	calls = mock.calls.Revoke
+	mock.lockRevoke.RUnlock()
+	return calls
+}
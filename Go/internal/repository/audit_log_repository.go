@@ -0,0 +1,65 @@
+// internal/repository/audit_log_repository.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, e *model.AuditLog) error
+	// List returns one page of the entries matching f, newest first,
+	// together with the total number of matching entries.
+	List(ctx context.Context, f AuditFilter, limit, offset int) ([]model.AuditLog, int, error)
+}
+
+// AuditFilter narrows List. The zero value matches every entry.
+type AuditFilter struct {
+	// UserID keeps the entries where the user is the actor or the subject,
+	// so logins and actions by the user are found alike.
+	UserID uuid.UUID
+	// Action keeps only entries of this action.
+	Action string
+	// From and To keep entries created at or after From and before To;
+	// zero times leave that end open.
+	From, To time.Time
+}
+
+type auditLogRepo struct{ db bun.IDB }
+
+func NewAuditLogRepo(db bun.IDB) AuditLogRepository { return &auditLogRepo{db} }
+
+func (r *auditLogRepo) Create(ctx context.Context, e *model.AuditLog) error {
+	_, err := r.db.NewInsert().Model(e).Exec(ctx)
+	return err
+}
+
+func (r *auditLogRepo) List(ctx context.Context, f AuditFilter, limit, offset int) ([]model.AuditLog, int, error) {
+	var entries []model.AuditLog
+	q := r.db.NewSelect().Model(&entries)
+	if f.UserID != uuid.Nil {
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("actor_id = ?", f.UserID).WhereOr("subject_id = ?", f.UserID)
+		})
+	}
+	if f.Action != "" {
+		q = q.Where("action = ?", f.Action)
+	}
+	if !f.From.IsZero() {
+		q = q.Where("created_at >= ?", f.From)
+	}
+	if !f.To.IsZero() {
+		q = q.Where("created_at < ?", f.To)
+	}
+	total, err := q.
+		OrderExpr("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	return entries, total, err
+}
@@ -0,0 +1,144 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that AuditLogRepositoryMock does implement AuditLogRepository.
+// If this is not the case, regenerate this file with moq.
+var _ AuditLogRepository = &AuditLogRepositoryMock{}
+
+// AuditLogRepositoryMock is a mock implementation of AuditLogRepository.
+//
+//	func TestSomethingThatUsesAuditLogRepository(t *testing.T) {
+//
+//		// make and configure a mocked AuditLogRepository
+//		mockedAuditLogRepository := &AuditLogRepositoryMock{
+//			CreateFunc: func(ctx context.Context, e *model.AuditLog) error {
+//				panic("mock out the Create method")
+//			},
+//			ListFunc: func(ctx context.Context, f AuditFilter, limit int, offset int) ([]model.AuditLog, int, error) {
+//				panic("mock out the List method")
+//			},
+//		}
+//
+//		// use mockedAuditLogRepository in code that requires AuditLogRepository
+//		// and then make assertions.
+//
+//	}
+type AuditLogRepositoryMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, e *model.AuditLog) error
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, f AuditFilter, limit int, offset int) ([]model.AuditLog, int, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// E is the e argument value.
+			E *model.AuditLog
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// F is the f argument value.
+			F AuditFilter
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
+	}
+	lockCreate sync.RWMutex
+	lockList   sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *AuditLogRepositoryMock) Create(ctx context.Context, e *model.AuditLog) error {
+	if mock.CreateFunc == nil {
+		panic("AuditLogRepositoryMock.CreateFunc: method is nil but AuditLogRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		E   *model.AuditLog
+	}{
+		Ctx: ctx,
+		E:   e,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, e)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedAuditLogRepository.CreateCalls())
+func (mock *AuditLogRepositoryMock) CreateCalls() []struct {
+	Ctx context.Context
+	E   *model.AuditLog
+} {
+	var calls []struct {
+		Ctx context.Context
+		E   *model.AuditLog
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *AuditLogRepositoryMock) List(ctx context.Context, f AuditFilter, limit int, offset int) ([]model.AuditLog, int, error) {
+	if mock.ListFunc == nil {
+		panic("AuditLogRepositoryMock.ListFunc: method is nil but AuditLogRepository.List was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		F      AuditFilter
+		Limit  int
+		Offset int
+	}{
+		Ctx:    ctx,
+		F:      f,
+		Limit:  limit,
+		Offset: offset,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, f, limit, offset)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedAuditLogRepository.ListCalls())
+func (mock *AuditLogRepositoryMock) ListCalls() []struct {
+	Ctx    context.Context
+	F      AuditFilter
+	Limit  int
+	Offset int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		F      AuditFilter
+		Limit  int
+		Offset int
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
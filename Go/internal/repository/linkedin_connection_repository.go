@@ -0,0 +1,72 @@
+// internal/repository/linkedin_connection_repository.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+type LinkedInConnectionRepository interface {
+	// Get returns the user's connection or sql.ErrNoRows.
+	Get(ctx context.Context, userID uuid.UUID) (*model.LinkedInConnection, error)
+	// Save stores c, replacing any earlier connection of the same user.
+	Save(ctx context.Context, c *model.LinkedInConnection) error
+	// UseState records that the OAuth state with this ID was used and
+	// reports whether it was the first use. The ID is kept until expiresAt,
+	// when the state expires anyway.
+	UseState(ctx context.Context, id string, expiresAt time.Time) (bool, error)
+}
+
+type linkedInConnectionRepo struct{ db bun.IDB }
+
+func NewLinkedInConnectionRepo(db bun.IDB) LinkedInConnectionRepository {
+	return &linkedInConnectionRepo{db}
+}
+
+func (r *linkedInConnectionRepo) Get(ctx context.Context, userID uuid.UUID) (*model.LinkedInConnection, error) {
+	c := new(model.LinkedInConnection)
+	if err := r.db.NewSelect().Model(c).Where("user_id = ?", userID).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *linkedInConnectionRepo) Save(ctx context.Context, c *model.LinkedInConnection) error {
+	c.UpdatedAt = time.Now()
+	_, err := r.db.NewInsert().
+		Model(c).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("member_urn = EXCLUDED.member_urn").
+		Set("access_token = EXCLUDED.access_token").
+		Set("expires_at = EXCLUDED.expires_at").
+		Set("refresh_token = EXCLUDED.refresh_token").
+		Set("refresh_expires_at = EXCLUDED.refresh_expires_at").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	return err
+}
+
+// UseState prunes the states that expired as Revoke does revoked tokens.
+func (r *linkedInConnectionRepo) UseState(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+	res, err := r.db.NewInsert().
+		Model(&model.UsedOAuthState{ID: id, ExpiresAt: expiresAt}).
+		On("CONFLICT (id) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	_, err = r.db.NewDelete().
+		Model((*model.UsedOAuthState)(nil)).
+		Where("expires_at < current_timestamp").
+		Exec(ctx)
+	return n == 1, err
+}
@@ -0,0 +1,190 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+	"time"
+)
+
+// Ensure, that LinkedInConnectionRepositoryMock does implement LinkedInConnectionRepository.
+// If this is not the case, regenerate this file with moq.
+var _ LinkedInConnectionRepository = &LinkedInConnectionRepositoryMock{}
+
+// LinkedInConnectionRepositoryMock is a mock implementation of LinkedInConnectionRepository.
+//
+//	func TestSomethingThatUsesLinkedInConnectionRepository(t *testing.T) {
+//
+//		// make and configure a mocked LinkedInConnectionRepository
+//		mockedLinkedInConnectionRepository := &LinkedInConnectionRepositoryMock{
+//			GetFunc: func(ctx context.Context, userID uuid.UUID) (*model.LinkedInConnection, error) {
+//				panic("mock out the Get method")
+//			},
+//			SaveFunc: func(ctx context.Context, c *model.LinkedInConnection) error {
+//				panic("mock out the Save method")
+//			},
+//			UseStateFunc: func(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+//				panic("mock out the UseState method")
+//			},
+//		}
+//
+//		// use mockedLinkedInConnectionRepository in code that requires LinkedInConnectionRepository
+//		// and then make assertions.
+//
+//	}
+type LinkedInConnectionRepositoryMock struct {
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, userID uuid.UUID) (*model.LinkedInConnection, error)
+
+	// SaveFunc mocks the Save method.
+	SaveFunc func(ctx context.Context, c *model.LinkedInConnection) error
+
+	// UseStateFunc mocks the UseState method.
+	UseStateFunc func(ctx context.Context, id string, expiresAt time.Time) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// Save holds details about calls to the Save method.
+		Save []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// C is the c argument value.
+			C *model.LinkedInConnection
+		}
+		// UseState holds details about calls to the UseState method.
+		UseState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// ExpiresAt is the expiresAt argument value.
+			ExpiresAt time.Time
+		}
+	}
+	lockGet      sync.RWMutex
+	lockSave     sync.RWMutex
+	lockUseState sync.RWMutex
+}
+
+// Get calls GetFunc.
+func (mock *LinkedInConnectionRepositoryMock) Get(ctx context.Context, userID uuid.UUID) (*model.LinkedInConnection, error) {
+	if mock.GetFunc == nil {
+		panic("LinkedInConnectionRepositoryMock.GetFunc: method is nil but LinkedInConnectionRepository.Get was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, userID)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedLinkedInConnectionRepository.GetCalls())
+func (mock *LinkedInConnectionRepositoryMock) GetCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// Save calls SaveFunc.
+func (mock *LinkedInConnectionRepositoryMock) Save(ctx context.Context, c *model.LinkedInConnection) error {
+	if mock.SaveFunc == nil {
+		panic("LinkedInConnectionRepositoryMock.SaveFunc: method is nil but LinkedInConnectionRepository.Save was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		C   *model.LinkedInConnection
+	}{
+		Ctx: ctx,
+		C:   c,
+	}
+	mock.lockSave.Lock()
+	mock.calls.Save = append(mock.calls.Save, callInfo)
+	mock.lockSave.Unlock()
+	return mock.SaveFunc(ctx, c)
+}
+
+// SaveCalls gets all the calls that were made to Save.
+// Check the length with:
+//
+//	len(mockedLinkedInConnectionRepository.SaveCalls())
+func (mock *LinkedInConnectionRepositoryMock) SaveCalls() []struct {
+	Ctx context.Context
+	C   *model.LinkedInConnection
+} {
+	var calls []struct {
+		Ctx context.Context
+		C   *model.LinkedInConnection
+	}
+	mock.lockSave.RLock()
+	calls = mock.calls.Save
+	mock.lockSave.RUnlock()
+	return calls
+}
+
+// UseState calls UseStateFunc.
+func (mock *LinkedInConnectionRepositoryMock) UseState(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+	if mock.UseStateFunc == nil {
+		panic("LinkedInConnectionRepositoryMock.UseStateFunc: method is nil but LinkedInConnectionRepository.UseState was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ID        string
+		ExpiresAt time.Time
+	}{
+		Ctx:       ctx,
+		ID:        id,
+		ExpiresAt: expiresAt,
+	}
+	mock.lockUseState.Lock()
+	mock.calls.UseState = append(mock.calls.UseState, callInfo)
+	mock.lockUseState.Unlock()
+	return mock.UseStateFunc(ctx, id, expiresAt)
+}
+
+// UseStateCalls gets all the calls that were made to UseState.
+// Check the length with:
+//
+//	len(mockedLinkedInConnectionRepository.UseStateCalls())
+func (mock *LinkedInConnectionRepositoryMock) UseStateCalls() []struct {
+	Ctx       context.Context
+	ID        string
+	ExpiresAt time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ID        string
+		ExpiresAt time.Time
+	}
+	mock.lockUseState.RLock()
+	calls = mock.calls.UseState
+	mock.lockUseState.RUnlock()
+	return calls
+}
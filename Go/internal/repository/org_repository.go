@@ -0,0 +1,138 @@
+// internal/repository/org_repository.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+type OrgRepository interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Organization, error)
+	// Create inserts a team organization and moves owner into it as its
+	// owner, as Join would.
+	Create(ctx context.Context, org *model.Organization, owner uuid.UUID) error
+	// Members returns the users in an organization, longest-standing
+	// account first.
+	Members(ctx context.Context, orgID uuid.UUID) ([]model.User, error)
+	CreateInvitation(ctx context.Context, inv *model.OrgInvitation) error
+	FindInvitationByHash(ctx context.Context, hash string) (*model.OrgInvitation, error)
+	// AcceptInvitation consumes inv and moves userID into its organization
+	// as a member, as Join would, in one transaction. It reports whether
+	// this call consumed the invitation; false means it had already been
+	// accepted and nothing changed.
+	AcceptInvitation(ctx context.Context, inv *model.OrgInvitation, userID uuid.UUID) (bool, error)
+}
+
+type orgRepo struct{ db bun.IDB }
+
+func NewOrgRepo(db bun.IDB) OrgRepository { return &orgRepo{db} }
+
+func (r *orgRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
+	org := new(model.Organization)
+	err := r.db.NewSelect().Model(org).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func (r *orgRepo) Create(ctx context.Context, org *model.Organization, owner uuid.UUID) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(org).Exec(ctx); err != nil {
+			return err
+		}
+		return join(ctx, tx, owner, org.ID, model.OrgRoleOwner)
+	})
+}
+
+func (r *orgRepo) Members(ctx context.Context, orgID uuid.UUID) ([]model.User, error) {
+	var users []model.User
+	err := r.db.NewSelect().
+		Model(&users).
+		Where("org_id = ?", orgID).
+		Order("created_at ASC").
+		Scan(ctx)
+	return users, err
+}
+
+func (r *orgRepo) CreateInvitation(ctx context.Context, inv *model.OrgInvitation) error {
+	_, err := r.db.NewInsert().Model(inv).Exec(ctx)
+	return err
+}
+
+func (r *orgRepo) FindInvitationByHash(ctx context.Context, hash string) (*model.OrgInvitation, error) {
+	inv := new(model.OrgInvitation)
+	err := r.db.NewSelect().Model(inv).Where("token_hash = ?", hash).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (r *orgRepo) AcceptInvitation(ctx context.Context, inv *model.OrgInvitation, userID uuid.UUID) (bool, error) {
+	accepted := false
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		now := time.Now()
+		res, err := tx.NewUpdate().
+			Model((*model.OrgInvitation)(nil)).
+			Set("accepted_at = ?", now).
+			Where("id = ?", inv.ID).
+			Where("accepted_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			return err
+		}
+		if err := join(ctx, tx, userID, inv.OrgID, model.OrgRoleMember); err != nil {
+			return err
+		}
+		inv.AcceptedAt = &now
+		accepted = true
+		return nil
+	})
+	return accepted && err == nil, err
+}
+
+// join moves a user into orgID with role. Posts stay in the organization
+// they were written in, except that leaving a personal organization takes
+// its posts along and deletes it, as nobody else could ever see them.
+func join(ctx context.Context, tx bun.Tx, userID, orgID uuid.UUID, role string) error {
+	u := new(model.User)
+	err := tx.NewSelect().Model(u).Column("org_id").Where("id = ?", userID).For("UPDATE").Scan(ctx)
+	if err != nil {
+		return err
+	}
+	from := new(model.Organization)
+	if err := tx.NewSelect().Model(from).Where("id = ?", u.OrgID).Scan(ctx); err != nil {
+		return err
+	}
+	if from.Personal {
+		_, err := tx.NewUpdate().
+			Model((*model.LinkedInPost)(nil)).
+			WhereAllWithDeleted().
+			Set("org_id = ?", orgID).
+			Where("org_id = ?", from.ID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = tx.NewUpdate().
+		Model((*model.User)(nil)).
+		Set("org_id = ?", orgID).
+		Set("org_role = ?", role).
+		Where("id = ?", userID).
+		Exec(ctx)
+	if err != nil || !from.Personal {
+		return err
+	}
+	_, err = tx.NewDelete().Model(from).WherePK().Exec(ctx)
+	return err
+}
@@ -0,0 +1,345 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that OrgRepositoryMock does implement OrgRepository.
+// If this is not the case, regenerate this file with moq.
+var _ OrgRepository = &OrgRepositoryMock{}
+
+// OrgRepositoryMock is a mock implementation of OrgRepository.
+//
+//	func TestSomethingThatUsesOrgRepository(t *testing.T) {
+//
+//		// make and configure a mocked OrgRepository
+//		mockedOrgRepository := &OrgRepositoryMock{
+//			AcceptInvitationFunc: func(ctx context.Context, inv *model.OrgInvitation, userID uuid.UUID) (bool, error) {
+//				panic("mock out the AcceptInvitation method")
+//			},
+//			CreateFunc: func(ctx context.Context, org *model.Organization, owner uuid.UUID) error {
+//				panic("mock out the Create method")
+//			},
+//			CreateInvitationFunc: func(ctx context.Context, inv *model.OrgInvitation) error {
+//				panic("mock out the CreateInvitation method")
+//			},
+//			FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
+//				panic("mock out the FindByID method")
+//			},
+//			FindInvitationByHashFunc: func(ctx context.Context, hash string) (*model.OrgInvitation, error) {
+//				panic("mock out the FindInvitationByHash method")
+//			},
+//			MembersFunc: func(ctx context.Context, orgID uuid.UUID) ([]model.User, error) {
+//				panic("mock out the Members method")
+//			},
+//		}
+//
+//		// use mockedOrgRepository in code that requires OrgRepository
+//		// and then make assertions.
+//
+//	}
+type OrgRepositoryMock struct {
+	// AcceptInvitationFunc mocks the AcceptInvitation method.
+	AcceptInvitationFunc func(ctx context.Context, inv *model.OrgInvitation, userID uuid.UUID) (bool, error)
+
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, org *model.Organization, owner uuid.UUID) error
+
+	// CreateInvitationFunc mocks the CreateInvitation method.
+	CreateInvitationFunc func(ctx context.Context, inv *model.OrgInvitation) error
+
+	// FindByIDFunc mocks the FindByID method.
+	FindByIDFunc func(ctx context.Context, id uuid.UUID) (*model.Organization, error)
+
+	// FindInvitationByHashFunc mocks the FindInvitationByHash method.
+	FindInvitationByHashFunc func(ctx context.Context, hash string) (*model.OrgInvitation, error)
+
+	// MembersFunc mocks the Members method.
+	MembersFunc func(ctx context.Context, orgID uuid.UUID) ([]model.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AcceptInvitation holds details about calls to the AcceptInvitation method.
+		AcceptInvitation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Inv is the inv argument value.
+			Inv *model.OrgInvitation
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Org is the org argument value.
+			Org *model.Organization
+			// Owner is the owner argument value.
+			Owner uuid.UUID
+		}
+		// CreateInvitation holds details about calls to the CreateInvitation method.
+		CreateInvitation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Inv is the inv argument value.
+			Inv *model.OrgInvitation
+		}
+		// FindByID holds details about calls to the FindByID method.
+		FindByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// FindInvitationByHash holds details about calls to the FindInvitationByHash method.
+		FindInvitationByHash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// Members holds details about calls to the Members method.
+		Members []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrgID is the orgID argument value.
+			OrgID uuid.UUID
+		}
+	}
+	lockAcceptInvitation     sync.RWMutex
+	lockCreate               sync.RWMutex
+	lockCreateInvitation     sync.RWMutex
+	lockFindByID             sync.RWMutex
+	lockFindInvitationByHash sync.RWMutex
+	lockMembers              sync.RWMutex
+}
+
+// AcceptInvitation calls AcceptInvitationFunc.
+func (mock *OrgRepositoryMock) AcceptInvitation(ctx context.Context, inv *model.OrgInvitation, userID uuid.UUID) (bool, error) {
+	if mock.AcceptInvitationFunc == nil {
+		panic("OrgRepositoryMock.AcceptInvitationFunc: method is nil but OrgRepository.AcceptInvitation was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Inv    *model.OrgInvitation
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		Inv:    inv,
+		UserID: userID,
+	}
+	mock.lockAcceptInvitation.Lock()
+	mock.calls.AcceptInvitation = append(mock.calls.AcceptInvitation, callInfo)
+	mock.lockAcceptInvitation.Unlock()
+	return mock.AcceptInvitationFunc(ctx, inv, userID)
+}
+
+// AcceptInvitationCalls gets all the calls that were made to AcceptInvitation.
+// Check the length with:
+//
+//	len(mockedOrgRepository.AcceptInvitationCalls())
+func (mock *OrgRepositoryMock) AcceptInvitationCalls() []struct {
+	Ctx    context.Context
+	Inv    *model.OrgInvitation
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Inv    *model.OrgInvitation
+		UserID uuid.UUID
+	}
+	mock.lockAcceptInvitation.RLock()
+	calls = mock.calls.AcceptInvitation
+	mock.lockAcceptInvitation.RUnlock()
+	return calls
+}
+
+// Create calls CreateFunc.
+func (mock *OrgRepositoryMock) Create(ctx context.Context, org *model.Organization, owner uuid.UUID) error {
+	if mock.CreateFunc == nil {
+		panic("OrgRepositoryMock.CreateFunc: method is nil but OrgRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Org   *model.Organization
+		Owner uuid.UUID
+	}{
+		Ctx:   ctx,
+		Org:   org,
+		Owner: owner,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, org, owner)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedOrgRepository.CreateCalls())
+func (mock *OrgRepositoryMock) CreateCalls() []struct {
+	Ctx   context.Context
+	Org   *model.Organization
+	Owner uuid.UUID
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Org   *model.Organization
+		Owner uuid.UUID
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// CreateInvitation calls CreateInvitationFunc.
+func (mock *OrgRepositoryMock) CreateInvitation(ctx context.Context, inv *model.OrgInvitation) error {
+	if mock.CreateInvitationFunc == nil {
+		panic("OrgRepositoryMock.CreateInvitationFunc: method is nil but OrgRepository.CreateInvitation was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Inv *model.OrgInvitation
+	}{
+		Ctx: ctx,
+		Inv: inv,
+	}
+	mock.lockCreateInvitation.Lock()
+	mock.calls.CreateInvitation = append(mock.calls.CreateInvitation, callInfo)
+	mock.lockCreateInvitation.Unlock()
+	return mock.CreateInvitationFunc(ctx, inv)
+}
+
+// CreateInvitationCalls gets all the calls that were made to CreateInvitation.
+// Check the length with:
+//
+//	len(mockedOrgRepository.CreateInvitationCalls())
+func (mock *OrgRepositoryMock) CreateInvitationCalls() []struct {
+	Ctx context.Context
+	Inv *model.OrgInvitation
+} {
+	var calls []struct {
+		Ctx context.Context
+		Inv *model.OrgInvitation
+	}
+	mock.lockCreateInvitation.RLock()
+	calls = mock.calls.CreateInvitation
+	mock.lockCreateInvitation.RUnlock()
+	return calls
+}
+
+// FindByID calls FindByIDFunc.
+func (mock *OrgRepositoryMock) FindByID(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
+	if mock.FindByIDFunc == nil {
+		panic("OrgRepositoryMock.FindByIDFunc: method is nil but OrgRepository.FindByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockFindByID.Lock()
+	mock.calls.FindByID = append(mock.calls.FindByID, callInfo)
+	mock.lockFindByID.Unlock()
+	return mock.FindByIDFunc(ctx, id)
+}
+
+// FindByIDCalls gets all the calls that were made to FindByID.
+// Check the length with:
+//
+//	len(mockedOrgRepository.FindByIDCalls())
+func (mock *OrgRepositoryMock) FindByIDCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockFindByID.RLock()
+	calls = mock.calls.FindByID
+	mock.lockFindByID.RUnlock()
+	return calls
+}
+
+// FindInvitationByHash calls FindInvitationByHashFunc.
+func (mock *OrgRepositoryMock) FindInvitationByHash(ctx context.Context, hash string) (*model.OrgInvitation, error) {
+	if mock.FindInvitationByHashFunc == nil {
+		panic("OrgRepositoryMock.FindInvitationByHashFunc: method is nil but OrgRepository.FindInvitationByHash was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash string
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockFindInvitationByHash.Lock()
+	mock.calls.FindInvitationByHash = append(mock.calls.FindInvitationByHash, callInfo)
+	mock.lockFindInvitationByHash.Unlock()
+	return mock.FindInvitationByHashFunc(ctx, hash)
+}
+
+// FindInvitationByHashCalls gets all the calls that were made to FindInvitationByHash.
+// Check the length with:
+//
+//	len(mockedOrgRepository.FindInvitationByHashCalls())
+func (mock *OrgRepositoryMock) FindInvitationByHashCalls() []struct {
+	Ctx  context.Context
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash string
+	}
+	mock.lockFindInvitationByHash.RLock()
+	calls = mock.calls.FindInvitationByHash
+	mock.lockFindInvitationByHash.RUnlock()
+	return calls
+}
+
+// Members calls MembersFunc.
+func (mock *OrgRepositoryMock) Members(ctx context.Context, orgID uuid.UUID) ([]model.User, error) {
+	if mock.MembersFunc == nil {
+		panic("OrgRepositoryMock.MembersFunc: method is nil but OrgRepository.Members was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		OrgID uuid.UUID
+	}{
+		Ctx:   ctx,
+		OrgID: orgID,
+	}
+	mock.lockMembers.Lock()
+	mock.calls.Members = append(mock.calls.Members, callInfo)
+	mock.lockMembers.Unlock()
+	return mock.MembersFunc(ctx, orgID)
+}
+
+// MembersCalls gets all the calls that were made to Members.
+// Check the length with:
+//
+//	len(mockedOrgRepository.MembersCalls())
+func (mock *OrgRepositoryMock) MembersCalls() []struct {
+	Ctx   context.Context
+	OrgID uuid.UUID
+} {
+	var calls []struct {
+		Ctx   context.Context
+		OrgID uuid.UUID
+	}
+	mock.lockMembers.RLock()
+	calls = mock.calls.Members
+	mock.lockMembers.RUnlock()
+	return calls
+}
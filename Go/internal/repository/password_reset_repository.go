@@ -0,0 +1,51 @@
+// internal/repository/password_reset_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, p *model.PasswordReset) error
+	FindByHash(ctx context.Context, hash string) (*model.PasswordReset, error)
+	// MarkUsed consumes the reset and reports whether this call did so;
+	// false means it had already been used.
+	MarkUsed(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+type passwordResetRepo struct{ db bun.IDB }
+
+func NewPasswordResetRepo(db bun.IDB) PasswordResetRepository { return &passwordResetRepo{db} }
+
+func (r *passwordResetRepo) Create(ctx context.Context, p *model.PasswordReset) error {
+	_, err := r.db.NewInsert().Model(p).Exec(ctx)
+	return err
+}
+
+func (r *passwordResetRepo) FindByHash(ctx context.Context, hash string) (*model.PasswordReset, error) {
+	p := new(model.PasswordReset)
+	err := r.db.NewSelect().Model(p).Where("token_hash = ?", hash).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *passwordResetRepo) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	res, err := r.db.NewUpdate().
+		Model((*model.PasswordReset)(nil)).
+		Set("used_at = current_timestamp").
+		Where("id = ?", id).
+		Where("used_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
@@ -0,0 +1,183 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that PasswordResetRepositoryMock does implement PasswordResetRepository.
+// If this is not the case, regenerate this file with moq.
+var _ PasswordResetRepository = &PasswordResetRepositoryMock{}
+
+// PasswordResetRepositoryMock is a mock implementation of PasswordResetRepository.
+//
+//	func TestSomethingThatUsesPasswordResetRepository(t *testing.T) {
+//
+//		// make and configure a mocked PasswordResetRepository
+//		mockedPasswordResetRepository := &PasswordResetRepositoryMock{
+//			CreateFunc: func(ctx context.Context, p *model.PasswordReset) error {
+//				panic("mock out the Create method")
+//			},
+//			FindByHashFunc: func(ctx context.Context, hash string) (*model.PasswordReset, error) {
+//				panic("mock out the FindByHash method")
+//			},
+//			MarkUsedFunc: func(ctx context.Context, id uuid.UUID) (bool, error) {
+//				panic("mock out the MarkUsed method")
+//			},
+//		}
+//
+//		// use mockedPasswordResetRepository in code that requires PasswordResetRepository
+//		// and then make assertions.
+//
+//	}
+type PasswordResetRepositoryMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, p *model.PasswordReset) error
+
+	// FindByHashFunc mocks the FindByHash method.
+	FindByHashFunc func(ctx context.Context, hash string) (*model.PasswordReset, error)
+
+	// MarkUsedFunc mocks the MarkUsed method.
+	MarkUsedFunc func(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// P is the p argument value.
+			P *model.PasswordReset
+		}
+		// FindByHash holds details about calls to the FindByHash method.
+		FindByHash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// MarkUsed holds details about calls to the MarkUsed method.
+		MarkUsed []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+	}
+	lockCreate     sync.RWMutex
+	lockFindByHash sync.RWMutex
+	lockMarkUsed   sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *PasswordResetRepositoryMock) Create(ctx context.Context, p *model.PasswordReset) error {
+	if mock.CreateFunc == nil {
+		panic("PasswordResetRepositoryMock.CreateFunc: method is nil but PasswordResetRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		P   *model.PasswordReset
+	}{
+		Ctx: ctx,
+		P:   p,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, p)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedPasswordResetRepository.CreateCalls())
+func (mock *PasswordResetRepositoryMock) CreateCalls() []struct {
+	Ctx context.Context
+	P   *model.PasswordReset
+} {
+	var calls []struct {
+		Ctx context.Context
+		P   *model.PasswordReset
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// FindByHash calls FindByHashFunc.
+func (mock *PasswordResetRepositoryMock) FindByHash(ctx context.Context, hash string) (*model.PasswordReset, error) {
+	if mock.FindByHashFunc == nil {
+		panic("PasswordResetRepositoryMock.FindByHashFunc: method is nil but PasswordResetRepository.FindByHash was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash string
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockFindByHash.Lock()
+	mock.calls.FindByHash = append(mock.calls.FindByHash, callInfo)
+	mock.lockFindByHash.Unlock()
+	return mock.FindByHashFunc(ctx, hash)
+}
+
+// FindByHashCalls gets all the calls that were made to FindByHash.
+// Check the length with:
+//
+//	len(mockedPasswordResetRepository.FindByHashCalls())
+func (mock *PasswordResetRepositoryMock) FindByHashCalls() []struct {
+	Ctx  context.Context
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash string
+	}
+	mock.lockFindByHash.RLock()
+	calls = mock.calls.FindByHash
+	mock.lockFindByHash.RUnlock()
+	return calls
+}
+
+// MarkUsed calls MarkUsedFunc.
+func (mock *PasswordResetRepositoryMock) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	if mock.MarkUsedFunc == nil {
+		panic("PasswordResetRepositoryMock.MarkUsedFunc: method is nil but PasswordResetRepository.MarkUsed was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockMarkUsed.Lock()
+	mock.calls.MarkUsed = append(mock.calls.MarkUsed, callInfo)
+	mock.lockMarkUsed.Unlock()
+	return mock.MarkUsedFunc(ctx, id)
+}
+
+// MarkUsedCalls gets all the calls that were made to MarkUsed.
+// Check the length with:
+//
+//	len(mockedPasswordResetRepository.MarkUsedCalls())
+func (mock *PasswordResetRepositoryMock) MarkUsedCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockMarkUsed.RLock()
+	calls = mock.calls.MarkUsed
+	mock.lockMarkUsed.RUnlock()
+	return calls
+}
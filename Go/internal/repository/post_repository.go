@@ -3,6 +3,9 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -10,29 +13,682 @@ import (
 	"github.com/you/linkedinify/internal/model"
 )
 
+// PostRepository stores posts. Posts are shared within an organization for
+// reading, so the methods reading posts for the userID of the caller match
+// every post in the organization that user is in now, whoever wrote it.
+// Changing a post is left to its author.
 type PostRepository interface {
+	// Save inserts p. A zero p.OrgID is set to the organization of p.UserID.
 	Save(ctx context.Context, p *model.LinkedInPost) error
-	ListByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error)
+	// Get returns a post in userID's organization, or sql.ErrNoRows.
+	Get(ctx context.Context, userID, id uuid.UUID) (*model.LinkedInPost, error)
+	// GetOwned is Get for callers about to change the post: it only
+	// returns posts userID wrote, and reads from the primary even
+	// WithReader, so the change never starts from a copy the replica has
+	// not caught up on.
+	GetOwned(ctx context.Context, userID, id uuid.UUID) (*model.LinkedInPost, error)
+	// Update writes the output text, status and generation details (source,
+	// model, style, sampling and token usage) of a post owned by p.UserID
+	// and returns sql.ErrNoRows when there is no such post. When the text
+	// changes, the previous text is kept as a PostVersion.
+	Update(ctx context.Context, p *model.LinkedInPost) error
+	// UpdateIf is Update, but first passes the stored post, locked for the
+	// update, to check, and returns the error of check without writing
+	// anything when it fails.
+	UpdateIf(ctx context.Context, p *model.LinkedInPost, check func(current *model.LinkedInPost) error) error
+	// UpdateImage writes the image fields of a post owned by p.UserID and
+	// returns sql.ErrNoRows when there is no such post. The text is left
+	// alone, so no version is saved.
+	UpdateImage(ctx context.Context, p *model.LinkedInPost) error
+	// ClaimPublish claims a post userID wrote for sharing it to LinkedIn,
+	// so no one else shares it at the same time, and returns it as read from
+	// the primary. The claim is held for lease, until UpdatePublished or
+	// ReleasePublish end it. It returns sql.ErrNoRows when there is no such
+	// post, or it is published or claimed already.
+	ClaimPublish(ctx context.Context, userID, id uuid.UUID, now time.Time, lease time.Duration) (*model.LinkedInPost, error)
+	// ReleasePublish ends the claim of a post returned by ClaimPublish
+	// without publishing it, so it can be claimed again straight away.
+	ReleasePublish(ctx context.Context, p *model.LinkedInPost) error
+	// UpdatePublished writes the LinkedIn URN and publication time of a
+	// post returned by ClaimPublish and ends the claim. It returns
+	// sql.ErrNoRows when the claim ran out and someone else holds the post.
+	UpdatePublished(ctx context.Context, p *model.LinkedInPost) error
+	// Schedule writes the schedule fields of a post owned by p.UserID that
+	// is neither published nor being published, and returns sql.ErrNoRows
+	// when there is no such post.
+	Schedule(ctx context.Context, p *model.LinkedInPost) error
+	// ClaimDue claims up to limit scheduled posts whose next attempt is due
+	// by now, marking them model.ScheduleStatusPublishing, counting the
+	// attempt and holding them for lease. Rows another instance is claiming
+	// at the same time are skipped, so no post is claimed twice; a post
+	// whose lease ran out is claimed again.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.LinkedInPost, error)
+	// UpdateSchedule writes the schedule status, attempts, error and next
+	// attempt of a post owned by p.UserID and returns sql.ErrNoRows when
+	// there is no such post.
+	UpdateSchedule(ctx context.Context, p *model.LinkedInPost) error
+	// ListVersions returns the saved versions of a post, newest first, and
+	// GetVersion returns one of them or sql.ErrNoRows. Callers are expected
+	// to have checked that the post is in the user's organization.
+	ListVersions(ctx context.Context, postID uuid.UUID) ([]model.PostVersion, error)
+	GetVersion(ctx context.Context, postID, versionID uuid.UUID) (*model.PostVersion, error)
+	// ListByUser returns one page of the posts in a user's organization
+	// matching f, in the order of f.Sort, together with the total number of
+	// matching posts.
+	ListByUser(ctx context.Context, userID uuid.UUID, f PostFilter, limit, offset int) ([]model.LinkedInPost, int, error)
+	// ListByUserAfter returns up to limit of the posts in a user's
+	// organization matching f that come after the cursor in the order of
+	// f.Sort; a nil cursor starts at the first post. Posts with the same sort
+	// key are ordered by ID, so pages neither skip nor repeat posts when
+	// others are created meanwhile.
+	ListByUserAfter(ctx context.Context, userID uuid.UUID, f PostFilter, after *PostCursor, limit int) ([]model.LinkedInPost, error)
+	// Search returns a page of the posts in a user's organization matching
+	// query, most relevant first, together with the total number of matches.
+	Search(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]model.LinkedInPost, int, error)
+	// Each calls fn with every post in a user's organization, newest first,
+	// stopping at the first error. Posts are loaded a page at a time, so any
+	// number of them can be walked without holding them all in memory.
+	Each(ctx context.Context, userID uuid.UUID, fn func(model.LinkedInPost) error) error
+	// Recent returns the ID and output text of up to limit of the posts
+	// userID wrote since since, newest first. The posts of colleagues are
+	// left out.
+	Recent(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]model.LinkedInPost, error)
+	// Delete soft-deletes a post userID wrote and Restore undoes it. Both
+	// return sql.ErrNoRows when there is no matching post to change.
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	Restore(ctx context.Context, userID, id uuid.UUID) error
+	// SetFavorite marks or unmarks a post userID wrote as one of their
+	// favorites, whatever it was before, and returns sql.ErrNoRows when
+	// there is no such post.
+	SetFavorite(ctx context.Context, userID, id uuid.UUID, favorited bool) error
+	// HardDelete permanently removes a post, deleted or not.
+	HardDelete(ctx context.Context, id uuid.UUID) error
+	// SaveFeedback stores f, replacing any earlier feedback of f.UserID on
+	// the same post. Callers are expected to have checked that the post
+	// is in the user's organization.
+	SaveFeedback(ctx context.Context, f *model.PostFeedback) error
+	// FeedbackStats counts the ratings of every template and model
+	// combination that has been rated, ordered by template and model.
+	FeedbackStats(ctx context.Context) ([]FeedbackStat, error)
 }
 
-type postRepo struct{ db *bun.DB }
+// FeedbackStat counts the ratings of posts generated with one template and
+// model. ApprovalRate is the share of them, from 0 to 1, that were
+// model.FeedbackUp.
+type FeedbackStat struct {
+	Template     string  `bun:"template"`
+	Model        string  `bun:"model"`
+	Total        int     `bun:"total"`
+	ThumbsUp     int     `bun:"thumbs_up"`
+	ApprovalRate float64 `bun:"approval_rate"`
+}
+
+// PostFilter narrows ListByUser and ListByUserAfter. The zero value matches
+// every post, newest first.
+type PostFilter struct {
+	// Status keeps only posts with this status.
+	Status string
+	// Favorited keeps only the listing user's favorites when true and only
+	// the others when false.
+	Favorited *bool
+	// Sort orders the posts; empty means SortCreatedDesc.
+	Sort PostSort
+}
+
+// PostSort is an order ListByUser and ListByUserAfter can list posts in.
+// Posts with the same sort key are ordered by ID, so the order is stable.
+type PostSort string
+
+const (
+	SortCreatedDesc PostSort = "created_desc"
+	SortCreatedAsc  PostSort = "created_asc"
+	// SortUpdatedDesc lists recently edited posts first. Posts that were
+	// never edited count as edited when they were created.
+	SortUpdatedDesc PostSort = "updated_desc"
+)
+
+// postOrder is how a PostSort is written in SQL. These fixed fragments are
+// the only ones ever put into a query, so a sort cannot inject SQL.
+type postOrder struct {
+	// key is the expression sorted by; the cursor of ListByUserAfter is a
+	// value of it.
+	key  string
+	desc bool
+}
 
-func NewPostRepo(db *bun.DB) PostRepository { return &postRepo{db} }
+var postOrders = map[PostSort]postOrder{
+	SortCreatedDesc: {key: "created_at", desc: true},
+	SortCreatedAsc:  {key: "created_at"},
+	SortUpdatedDesc: {key: "coalesce(updated_at, created_at)", desc: true},
+}
+
+// Valid reports whether s is a known sort. Empty is valid and means
+// SortCreatedDesc.
+func (s PostSort) Valid() bool {
+	_, ok := postOrders[s]
+	return ok || s == ""
+}
+
+// order is s in SQL, defaulting to SortCreatedDesc for an empty or unknown
+// sort.
+func (s PostSort) order() postOrder {
+	if o, ok := postOrders[s]; ok {
+		return o
+	}
+	return postOrders[SortCreatedDesc]
+}
+
+func (o postOrder) orderBy() string {
+	if o.desc {
+		return o.key + " DESC, id DESC"
+	}
+	return o.key + " ASC, id ASC"
+}
+
+// after is the condition selecting the posts that come after a cursor.
+func (o postOrder) after() string {
+	if o.desc {
+		return "(" + o.key + ", id) < (?, ?)"
+	}
+	return "(" + o.key + ", id) > (?, ?)"
+}
+
+func (f PostFilter) apply(q *bun.SelectQuery, userID uuid.UUID) *bun.SelectQuery {
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if f.Favorited != nil {
+		q = q.Where(favoritedBy+" = ?", userID, *f.Favorited)
+	}
+	return q
+}
+
+// PostCursor is the position of a post in ListByUserAfter's order: the sort
+// key of the filter's PostSort, such as the creation time, and the post ID.
+type PostCursor struct {
+	Key time.Time
+	ID  uuid.UUID
+}
+
+// DefaultVersionLimit is how many versions are kept per post unless
+// WithVersionLimit says otherwise.
+const DefaultVersionLimit = 20
+
+type postRepo struct {
+	db           bun.IDB
+	reader       bun.IDB
+	versionLimit int
+}
+
+// PostRepoOption configures a PostRepository.
+type PostRepoOption func(*postRepo)
+
+// WithVersionLimit sets how many versions are kept per post; older ones are
+// pruned on Update.
+func WithVersionLimit(n int) PostRepoOption {
+	return func(p *postRepo) { p.versionLimit = n }
+}
+
+// WithReader sends Get, ListByUser and Search to reader, typically a read
+// replica, instead of the database given to NewPostRepo. Those reads may then
+// lag behind writes by the replication delay. GetOwned and every write keep
+// using the primary.
+func WithReader(reader bun.IDB) PostRepoOption {
+	return func(p *postRepo) { p.reader = reader }
+}
+
+func NewPostRepo(db bun.IDB, opts ...PostRepoOption) PostRepository {
+	p := &postRepo{db: db, reader: db, versionLimit: DefaultVersionLimit}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// inOrgOf matches the posts in the organization of the user given as its
+// argument. The organization is looked up by every query rather than taken
+// from the caller, so a user who changes organization sees the new one's
+// posts straight away.
+const inOrgOf = "org_id = (SELECT org_id FROM users WHERE id = ?)"
+
+// ownedBy matches the posts the user given as both its arguments wrote, as
+// long as they are still in that user's organization.
+const ownedBy = "user_id = ? AND " + inOrgOf
+
+// favoritedBy is whether the user given as its argument favorited the post.
+const favoritedBy = "EXISTS (SELECT 1 FROM post_favorites AS f WHERE f.post_id = ?TableAlias.id AND f.user_id = ?)"
+
+// selectPosts selects posts into dest with Favorited as userID sees it.
+func selectPosts(db bun.IDB, dest any, userID uuid.UUID) *bun.SelectQuery {
+	return db.NewSelect().
+		Model(dest).
+		ColumnExpr("?TableAlias.*").
+		ColumnExpr(favoritedBy+" AS favorited", userID)
+}
 
 func (p *postRepo) Save(ctx context.Context, post *model.LinkedInPost) error {
-	_, err := p.db.NewInsert().Model(post).Exec(ctx)
+	q := p.db.NewInsert().Model(post)
+	if post.OrgID == uuid.Nil {
+		q = q.Value("org_id", "(SELECT org_id FROM users WHERE id = ?)", post.UserID).Returning("org_id")
+	}
+	_, err := q.Exec(ctx)
+	return err
+}
+
+func (p *postRepo) Get(ctx context.Context, userID, id uuid.UUID) (*model.LinkedInPost, error) {
+	post := new(model.LinkedInPost)
+	err := selectPosts(p.reader, post, userID).
+		Where("id = ?", id).
+		Where(inOrgOf, userID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (p *postRepo) GetOwned(ctx context.Context, userID, id uuid.UUID) (*model.LinkedInPost, error) {
+	post := new(model.LinkedInPost)
+	err := selectPosts(p.db, post, userID).
+		Where("id = ?", id).
+		Where(ownedBy, userID, userID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (p *postRepo) Update(ctx context.Context, post *model.LinkedInPost) error {
+	return p.UpdateIf(ctx, post, nil)
+}
+
+func (p *postRepo) UpdateIf(ctx context.Context, post *model.LinkedInPost, check func(current *model.LinkedInPost) error) error {
+	return p.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		prev := new(model.LinkedInPost)
+		err := tx.NewSelect().
+			Model(prev).
+			Where("id = ?", post.ID).
+			Where("user_id = ?", post.UserID).
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			return err
+		}
+		if check != nil {
+			if err := check(prev); err != nil {
+				return err
+			}
+		}
+		if prev.OutputText != post.OutputText {
+			if err := p.snapshot(ctx, tx, prev); err != nil {
+				return err
+			}
+		}
+
+		post.UpdatedAt = time.Now()
+		_, err = tx.NewUpdate().
+			Model(post).
+			Column("output_text", "source", "status", "updated_at",
+				"model", "tone", "length", "temperature", "top_p", "prompt_tokens", "completion_tokens", "total_tokens").
+			Where("id = ?", post.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+// snapshot saves the current text of prev as a version and prunes versions
+// beyond the limit.
+func (p *postRepo) snapshot(ctx context.Context, tx bun.Tx, prev *model.LinkedInPost) error {
+	writtenAt := prev.UpdatedAt
+	if writtenAt.IsZero() {
+		writtenAt = prev.CreatedAt
+	}
+	_, err := tx.NewInsert().Model(&model.PostVersion{
+		ID:         uuid.New(),
+		PostID:     prev.ID,
+		OutputText: prev.OutputText,
+		Source:     prev.Source,
+		CreatedAt:  writtenAt,
+	}).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	keep := tx.NewSelect().
+		Model((*model.PostVersion)(nil)).
+		Column("id").
+		Where("post_id = ?", prev.ID).
+		Order("created_at DESC").
+		Limit(p.versionLimit)
+	_, err = tx.NewDelete().
+		Model((*model.PostVersion)(nil)).
+		Where("post_id = ?", prev.ID).
+		Where("id NOT IN (?)", keep).
+		Exec(ctx)
 	return err
 }
 
-func (p *postRepo) ListByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error) {
+func (p *postRepo) ListVersions(ctx context.Context, postID uuid.UUID) ([]model.PostVersion, error) {
+	var versions []model.PostVersion
+	err := p.db.NewSelect().
+		Model(&versions).
+		Where("post_id = ?", postID).
+		Order("created_at DESC").
+		Scan(ctx)
+	return versions, err
+}
+
+func (p *postRepo) GetVersion(ctx context.Context, postID, versionID uuid.UUID) (*model.PostVersion, error) {
+	v := new(model.PostVersion)
+	err := p.db.NewSelect().
+		Model(v).
+		Where("id = ?", versionID).
+		Where("post_id = ?", postID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (p *postRepo) ListByUser(ctx context.Context, userID uuid.UUID, f PostFilter, limit, offset int) ([]model.LinkedInPost, int, error) {
+	var posts []model.LinkedInPost
+	q := selectPosts(p.reader, &posts, userID).
+		Where(inOrgOf, userID)
+	q = f.apply(q, userID)
+	total, err := q.
+		OrderExpr(f.Sort.order().orderBy()).
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	return posts, total, err
+}
+
+func (p *postRepo) ListByUserAfter(ctx context.Context, userID uuid.UUID, f PostFilter, after *PostCursor, limit int) ([]model.LinkedInPost, error) {
+	var posts []model.LinkedInPost
+	q := selectPosts(p.reader, &posts, userID).
+		Where(inOrgOf, userID)
+	q = f.apply(q, userID)
+	order := f.Sort.order()
+	if after != nil {
+		q = q.Where(order.after(), after.Key, after.ID)
+	}
+	err := q.
+		OrderExpr(order.orderBy()).
+		Limit(limit).
+		Scan(ctx)
+	return posts, err
+}
+
+// Search ranks matches of the search_vector column built by the 008_post_search
+// migration. Queries that full-text search cannot handle, such as partial
+// words or stop words, still match through a substring ILIKE.
+func (p *postRepo) Search(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]model.LinkedInPost, int, error) {
+	var posts []model.LinkedInPost
+	pattern := "%" + likeEscaper.Replace(query) + "%"
+	total, err := selectPosts(p.reader, &posts, userID).
+		Where(inOrgOf, userID).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("search_vector @@ websearch_to_tsquery('english', ?)", query).
+				WhereOr("input_text ILIKE ?", pattern).
+				WhereOr("output_text ILIKE ?", pattern)
+		}).
+		OrderExpr("ts_rank(search_vector, websearch_to_tsquery('english', ?)) DESC", query).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	return posts, total, err
+}
+
+// eachPageSize is how many posts Each loads per query.
+const eachPageSize = 500
+
+// Each pages by (created_at, id) rather than offset, so every page is an
+// index lookup and posts created meanwhile do not shift the pages.
+func (p *postRepo) Each(ctx context.Context, userID uuid.UUID, fn func(model.LinkedInPost) error) error {
+	var last *model.LinkedInPost
+	for {
+		var page []model.LinkedInPost
+		q := selectPosts(p.db, &page, userID).
+			Where(inOrgOf, userID)
+		if last != nil {
+			q = q.Where("(created_at, id) < (?, ?)", last.CreatedAt, last.ID)
+		}
+		if err := q.OrderExpr("created_at DESC, id DESC").Limit(eachPageSize).Scan(ctx); err != nil {
+			return err
+		}
+		for _, post := range page {
+			if err := fn(post); err != nil {
+				return err
+			}
+		}
+		if len(page) < eachPageSize {
+			return nil
+		}
+		last = &page[len(page)-1]
+	}
+}
+
+// Recent reads the primary rather than the reader, so a post saved a moment
+// ago is already there.
+func (p *postRepo) Recent(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]model.LinkedInPost, error) {
 	var posts []model.LinkedInPost
-	offset := (page - 1) * pageSize
 	err := p.db.NewSelect().
 		Model(&posts).
+		Column("id", "output_text", "created_at").
 		Where("user_id = ?", userID).
-		Order("created_at DESC").
-		Limit(pageSize).
-		Offset(offset).
+		Where("created_at >= ?", since).
+		OrderExpr("created_at DESC, id DESC").
+		Limit(limit).
 		Scan(ctx)
 	return posts, err
 }
+
+// likeEscaper makes user input literal inside a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (p *postRepo) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	res, err := p.db.NewDelete().
+		Model((*model.LinkedInPost)(nil)).
+		Where("id = ?", id).
+		Where(ownedBy, userID, userID).
+		Exec(ctx)
+	return expectOneRow(res, err)
+}
+
+func (p *postRepo) UpdateImage(ctx context.Context, post *model.LinkedInPost) error {
+	post.UpdatedAt = time.Now()
+	res, err := p.db.NewUpdate().
+		Model(post).
+		Column("image_url", "image_expires_at", "image_prompt", "image_size", "updated_at").
+		WherePK().
+		Where("user_id = ?", post.UserID).
+		Exec(ctx)
+	return expectOneRow(res, err)
+}
+
+// ClaimPublish checks and claims the post in one statement, so of two
+// callers claiming it at once only one gets it.
+func (p *postRepo) ClaimPublish(ctx context.Context, userID, id uuid.UUID, now time.Time, lease time.Duration) (*model.LinkedInPost, error) {
+	post := new(model.LinkedInPost)
+	res, err := p.db.NewUpdate().
+		Model(post).
+		Set("publishing_until = ?", now.Add(lease)).
+		Where("id = ?", id).
+		Where(ownedBy, userID, userID).
+		Where("linkedin_urn = ''").
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.Where("publishing_until IS NULL").WhereOr("publishing_until <= ?", now)
+		}).
+		Returning("?TableAlias.*, "+favoritedBy+" AS favorited", userID).
+		Exec(ctx)
+	if err := expectOneRow(res, err); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// ReleasePublish and UpdatePublished only change the post while it still
+// carries the claim it was returned with.
+func (p *postRepo) ReleasePublish(ctx context.Context, post *model.LinkedInPost) error {
+	res, err := p.db.NewUpdate().
+		Model((*model.LinkedInPost)(nil)).
+		Set("publishing_until = NULL").
+		Where("id = ?", post.ID).
+		Where("publishing_until = ?", post.PublishingUntil).
+		Exec(ctx)
+	if err := expectOneRow(res, err); err != nil {
+		return err
+	}
+	post.PublishingUntil = time.Time{}
+	return nil
+}
+
+func (p *postRepo) UpdatePublished(ctx context.Context, post *model.LinkedInPost) error {
+	claim := post.PublishingUntil
+	post.PublishingUntil = time.Time{}
+	post.UpdatedAt = time.Now()
+	res, err := p.db.NewUpdate().
+		Model(post).
+		Column("linkedin_urn", "published_at", "publishing_until", "updated_at").
+		WherePK().
+		Where("user_id = ?", post.UserID).
+		Where("publishing_until = ?", claim).
+		Exec(ctx)
+	return expectOneRow(res, err)
+}
+
+func (p *postRepo) Schedule(ctx context.Context, post *model.LinkedInPost) error {
+	res, err := p.db.NewUpdate().
+		Model(post).
+		Column("scheduled_at", "scheduled_by", "schedule_status", "schedule_attempts", "schedule_error", "next_attempt_at").
+		WherePK().
+		Where("user_id = ?", post.UserID).
+		Where("linkedin_urn = ''").
+		Where("schedule_status <> ?", model.ScheduleStatusPublishing).
+		Exec(ctx)
+	return expectOneRow(res, err)
+}
+
+// ClaimDue locks the due rows with FOR UPDATE SKIP LOCKED and claims them in
+// the same statement, so the claim is one transaction.
+func (p *postRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.LinkedInPost, error) {
+	due := p.db.NewSelect().
+		Model((*model.LinkedInPost)(nil)).
+		Column("id").
+		Where("schedule_status IN (?)", bun.In([]string{model.ScheduleStatusScheduled, model.ScheduleStatusPublishing})).
+		Where("next_attempt_at <= ?", now).
+		Order("next_attempt_at").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED")
+	var posts []model.LinkedInPost
+	_, err := p.db.NewUpdate().
+		Model((*model.LinkedInPost)(nil)).
+		Set("schedule_status = ?", model.ScheduleStatusPublishing).
+		Set("schedule_attempts = schedule_attempts + 1").
+		Set("next_attempt_at = ?", now.Add(lease)).
+		Where("id IN (?)", due).
+		Returning("*").
+		Exec(ctx, &posts)
+	return posts, err
+}
+
+func (p *postRepo) UpdateSchedule(ctx context.Context, post *model.LinkedInPost) error {
+	res, err := p.db.NewUpdate().
+		Model(post).
+		Column("schedule_status", "schedule_attempts", "schedule_error", "next_attempt_at").
+		WherePK().
+		Where("user_id = ?", post.UserID).
+		Exec(ctx)
+	return expectOneRow(res, err)
+}
+
+func (p *postRepo) Restore(ctx context.Context, userID, id uuid.UUID) error {
+	res, err := p.db.NewUpdate().
+		Model((*model.LinkedInPost)(nil)).
+		WhereDeleted().
+		Set("deleted_at = NULL").
+		Where("id = ?", id).
+		Where(ownedBy, userID, userID).
+		Exec(ctx)
+	return expectOneRow(res, err)
+}
+
+func (p *postRepo) SetFavorite(ctx context.Context, userID, id uuid.UUID, favorited bool) error {
+	owned, err := p.db.NewSelect().
+		Model((*model.LinkedInPost)(nil)).
+		Where("id = ?", id).
+		Where(ownedBy, userID, userID).
+		Exists(ctx)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return sql.ErrNoRows
+	}
+	fav := &model.PostFavorite{UserID: userID, PostID: id}
+	if favorited {
+		_, err = p.db.NewInsert().Model(fav).On("CONFLICT DO NOTHING").Exec(ctx)
+	} else {
+		_, err = p.db.NewDelete().Model(fav).WherePK().Exec(ctx)
+	}
+	return err
+}
+
+func (p *postRepo) HardDelete(ctx context.Context, id uuid.UUID) error {
+	res, err := p.db.NewDelete().
+		Model((*model.LinkedInPost)(nil)).
+		WhereAllWithDeleted().
+		Where("id = ?", id).
+		ForceDelete().
+		Exec(ctx)
+	return expectOneRow(res, err)
+}
+
+func (p *postRepo) SaveFeedback(ctx context.Context, f *model.PostFeedback) error {
+	f.CreatedAt = time.Now()
+	_, err := p.db.NewInsert().
+		Model(f).
+		On("CONFLICT (post_id, user_id) DO UPDATE").
+		Set("rating = EXCLUDED.rating").
+		Set("comment = EXCLUDED.comment").
+		Set("model = EXCLUDED.model").
+		Set("tone = EXCLUDED.tone").
+		Set("template = EXCLUDED.template").
+		Set("created_at = EXCLUDED.created_at").
+		Returning("id").
+		Exec(ctx)
+	return err
+}
+
+func (p *postRepo) FeedbackStats(ctx context.Context) ([]FeedbackStat, error) {
+	var stats []FeedbackStat
+	err := p.db.NewSelect().
+		Model((*model.PostFeedback)(nil)).
+		ColumnExpr("template, model").
+		ColumnExpr("count(*) AS total").
+		ColumnExpr("count(*) FILTER (WHERE rating = ?) AS thumbs_up", model.FeedbackUp).
+		ColumnExpr("avg((rating = ?)::int)::float8 AS approval_rate", model.FeedbackUp).
+		Group("template", "model").
+		Order("template", "model").
+		Scan(ctx, &stats)
+	return stats, err
+}
+
+// expectOneRow turns a write that matched nothing into sql.ErrNoRows.
+func expectOneRow(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
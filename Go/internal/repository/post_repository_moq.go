@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/you/linkedinify/internal/model"
 	"sync"
+	"time"
 )
 
 // Ensure, that PostRepositoryMock does implement PostRepository.
@@ -20,12 +21,81 @@ var _ PostRepository = &PostRepositoryMock{}
 //
 //		// make and configure a mocked PostRepository
 //		mockedPostRepository := &PostRepositoryMock{
-//			ListByUserFunc: func(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]model.LinkedInPost, error) {
+//			ClaimDueFunc: func(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.LinkedInPost, error) {
+//				panic("mock out the ClaimDue method")
+//			},
+//			ClaimPublishFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID, now time.Time, lease time.Duration) (*model.LinkedInPost, error) {
+//				panic("mock out the ClaimPublish method")
+//			},
+//			DeleteFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+//				panic("mock out the Delete method")
+//			},
+//			EachFunc: func(ctx context.Context, userID uuid.UUID, fn func(model.LinkedInPost) error) error {
+//				panic("mock out the Each method")
+//			},
+//			FeedbackStatsFunc: func(ctx context.Context) ([]FeedbackStat, error) {
+//				panic("mock out the FeedbackStats method")
+//			},
+//			GetFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.LinkedInPost, error) {
+//				panic("mock out the Get method")
+//			},
+//			GetOwnedFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.LinkedInPost, error) {
+//				panic("mock out the GetOwned method")
+//			},
+//			GetVersionFunc: func(ctx context.Context, postID uuid.UUID, versionID uuid.UUID) (*model.PostVersion, error) {
+//				panic("mock out the GetVersion method")
+//			},
+//			HardDeleteFunc: func(ctx context.Context, id uuid.UUID) error {
+//				panic("mock out the HardDelete method")
+//			},
+//			ListByUserFunc: func(ctx context.Context, userID uuid.UUID, f PostFilter, limit int, offset int) ([]model.LinkedInPost, int, error) {
 //				panic("mock out the ListByUser method")
 //			},
+//			ListByUserAfterFunc: func(ctx context.Context, userID uuid.UUID, f PostFilter, after *PostCursor, limit int) ([]model.LinkedInPost, error) {
+//				panic("mock out the ListByUserAfter method")
+//			},
+//			ListVersionsFunc: func(ctx context.Context, postID uuid.UUID) ([]model.PostVersion, error) {
+//				panic("mock out the ListVersions method")
+//			},
+//			RecentFunc: func(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]model.LinkedInPost, error) {
+//				panic("mock out the Recent method")
+//			},
+//			ReleasePublishFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+//				panic("mock out the ReleasePublish method")
+//			},
+//			RestoreFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+//				panic("mock out the Restore method")
+//			},
 //			SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
 //				panic("mock out the Save method")
 //			},
+//			SaveFeedbackFunc: func(ctx context.Context, f *model.PostFeedback) error {
+//				panic("mock out the SaveFeedback method")
+//			},
+//			ScheduleFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+//				panic("mock out the Schedule method")
+//			},
+//			SearchFunc: func(ctx context.Context, userID uuid.UUID, query string, limit int, offset int) ([]model.LinkedInPost, int, error) {
+//				panic("mock out the Search method")
+//			},
+//			SetFavoriteFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID, favorited bool) error {
+//				panic("mock out the SetFavorite method")
+//			},
+//			UpdateFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+//				panic("mock out the Update method")
+//			},
+//			UpdateIfFunc: func(ctx context.Context, p *model.LinkedInPost, check func(current *model.LinkedInPost) error) error {
+//				panic("mock out the UpdateIf method")
+//			},
+//			UpdateImageFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+//				panic("mock out the UpdateImage method")
+//			},
+//			UpdatePublishedFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+//				panic("mock out the UpdatePublished method")
+//			},
+//			UpdateScheduleFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+//				panic("mock out the UpdateSchedule method")
+//			},
 //		}
 //
 //		// use mockedPostRepository in code that requires PostRepository
@@ -33,24 +103,223 @@ var _ PostRepository = &PostRepositoryMock{}
 //
 //	}
 type PostRepositoryMock struct {
+	// ClaimDueFunc mocks the ClaimDue method.
+	ClaimDueFunc func(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.LinkedInPost, error)
+
+	// ClaimPublishFunc mocks the ClaimPublish method.
+	ClaimPublishFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID, now time.Time, lease time.Duration) (*model.LinkedInPost, error)
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+
+	// EachFunc mocks the Each method.
+	EachFunc func(ctx context.Context, userID uuid.UUID, fn func(model.LinkedInPost) error) error
+
+	// FeedbackStatsFunc mocks the FeedbackStats method.
+	FeedbackStatsFunc func(ctx context.Context) ([]FeedbackStat, error)
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.LinkedInPost, error)
+
+	// GetOwnedFunc mocks the GetOwned method.
+	GetOwnedFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.LinkedInPost, error)
+
+	// GetVersionFunc mocks the GetVersion method.
+	GetVersionFunc func(ctx context.Context, postID uuid.UUID, versionID uuid.UUID) (*model.PostVersion, error)
+
+	// HardDeleteFunc mocks the HardDelete method.
+	HardDeleteFunc func(ctx context.Context, id uuid.UUID) error
+
 	// ListByUserFunc mocks the ListByUser method.
-	ListByUserFunc func(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]model.LinkedInPost, error)
+	ListByUserFunc func(ctx context.Context, userID uuid.UUID, f PostFilter, limit int, offset int) ([]model.LinkedInPost, int, error)
+
+	// ListByUserAfterFunc mocks the ListByUserAfter method.
+	ListByUserAfterFunc func(ctx context.Context, userID uuid.UUID, f PostFilter, after *PostCursor, limit int) ([]model.LinkedInPost, error)
+
+	// ListVersionsFunc mocks the ListVersions method.
+	ListVersionsFunc func(ctx context.Context, postID uuid.UUID) ([]model.PostVersion, error)
+
+	// RecentFunc mocks the Recent method.
+	RecentFunc func(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]model.LinkedInPost, error)
+
+	// ReleasePublishFunc mocks the ReleasePublish method.
+	ReleasePublishFunc func(ctx context.Context, p *model.LinkedInPost) error
+
+	// RestoreFunc mocks the Restore method.
+	RestoreFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
 
 	// SaveFunc mocks the Save method.
 	SaveFunc func(ctx context.Context, p *model.LinkedInPost) error
 
+	// SaveFeedbackFunc mocks the SaveFeedback method.
+	SaveFeedbackFunc func(ctx context.Context, f *model.PostFeedback) error
+
+	// ScheduleFunc mocks the Schedule method.
+	ScheduleFunc func(ctx context.Context, p *model.LinkedInPost) error
+
+	// SearchFunc mocks the Search method.
+	SearchFunc func(ctx context.Context, userID uuid.UUID, query string, limit int, offset int) ([]model.LinkedInPost, int, error)
+
+	// SetFavoriteFunc mocks the SetFavorite method.
+	SetFavoriteFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID, favorited bool) error
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, p *model.LinkedInPost) error
+
+	// UpdateIfFunc mocks the UpdateIf method.
+	UpdateIfFunc func(ctx context.Context, p *model.LinkedInPost, check func(current *model.LinkedInPost) error) error
+
+	// UpdateImageFunc mocks the UpdateImage method.
+	UpdateImageFunc func(ctx context.Context, p *model.LinkedInPost) error
+
+	// UpdatePublishedFunc mocks the UpdatePublished method.
+	UpdatePublishedFunc func(ctx context.Context, p *model.LinkedInPost) error
+
+	// UpdateScheduleFunc mocks the UpdateSchedule method.
+	UpdateScheduleFunc func(ctx context.Context, p *model.LinkedInPost) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// ClaimDue holds details about calls to the ClaimDue method.
+		ClaimDue []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Now is the now argument value.
+			Now time.Time
+			// Lease is the lease argument value.
+			Lease time.Duration
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// ClaimPublish holds details about calls to the ClaimPublish method.
+		ClaimPublish []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Now is the now argument value.
+			Now time.Time
+			// Lease is the lease argument value.
+			Lease time.Duration
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// Each holds details about calls to the Each method.
+		Each []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Fn is the fn argument value.
+			Fn func(model.LinkedInPost) error
+		}
+		// FeedbackStats holds details about calls to the FeedbackStats method.
+		FeedbackStats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetOwned holds details about calls to the GetOwned method.
+		GetOwned []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetVersion holds details about calls to the GetVersion method.
+		GetVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+			// VersionID is the versionID argument value.
+			VersionID uuid.UUID
+		}
+		// HardDelete holds details about calls to the HardDelete method.
+		HardDelete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
 		// ListByUser holds details about calls to the ListByUser method.
 		ListByUser []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// UserID is the userID argument value.
 			UserID uuid.UUID
-			// Page is the page argument value.
-			Page int
-			// PageSize is the pageSize argument value.
-			PageSize int
+			// F is the f argument value.
+			F PostFilter
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
+		// ListByUserAfter holds details about calls to the ListByUserAfter method.
+		ListByUserAfter []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// F is the f argument value.
+			F PostFilter
+			// After is the after argument value.
+			After *PostCursor
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// ListVersions holds details about calls to the ListVersions method.
+		ListVersions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
+		// Recent holds details about calls to the Recent method.
+		Recent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Since is the since argument value.
+			Since time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// ReleasePublish holds details about calls to the ReleasePublish method.
+		ReleasePublish []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// P is the p argument value.
+			P *model.LinkedInPost
+		}
+		// Restore holds details about calls to the Restore method.
+		Restore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
 		}
 		// Save holds details about calls to the Save method.
 		Save []struct {
@@ -59,87 +328,1101 @@ type PostRepositoryMock struct {
 			// P is the p argument value.
 			P *model.LinkedInPost
 		}
+		// SaveFeedback holds details about calls to the SaveFeedback method.
+		SaveFeedback []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// F is the f argument value.
+			F *model.PostFeedback
+		}
+		// Schedule holds details about calls to the Schedule method.
+		Schedule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// P is the p argument value.
+			P *model.LinkedInPost
+		}
+		// Search holds details about calls to the Search method.
+		Search []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Query is the query argument value.
+			Query string
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
+		// SetFavorite holds details about calls to the SetFavorite method.
+		SetFavorite []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Favorited is the favorited argument value.
+			Favorited bool
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// P is the p argument value.
+			P *model.LinkedInPost
+		}
+		// UpdateIf holds details about calls to the UpdateIf method.
+		UpdateIf []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// P is the p argument value.
+			P *model.LinkedInPost
+			// Check is the check argument value.
+			Check func(current *model.LinkedInPost) error
+		}
+		// UpdateImage holds details about calls to the UpdateImage method.
+		UpdateImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// P is the p argument value.
+			P *model.LinkedInPost
+		}
+		// UpdatePublished holds details about calls to the UpdatePublished method.
+		UpdatePublished []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// P is the p argument value.
+			P *model.LinkedInPost
+		}
+		// UpdateSchedule holds details about calls to the UpdateSchedule method.
+		UpdateSchedule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// P is the p argument value.
+			P *model.LinkedInPost
+		}
 	}
-	lockListByUser sync.RWMutex
-	lockSave       sync.RWMutex
+	lockClaimDue        sync.RWMutex
+	lockClaimPublish    sync.RWMutex
+	lockDelete          sync.RWMutex
+	lockEach            sync.RWMutex
+	lockFeedbackStats   sync.RWMutex
+	lockGet             sync.RWMutex
+	lockGetOwned        sync.RWMutex
+	lockGetVersion      sync.RWMutex
+	lockHardDelete      sync.RWMutex
+	lockListByUser      sync.RWMutex
+	lockListByUserAfter sync.RWMutex
+	lockListVersions    sync.RWMutex
+	lockRecent          sync.RWMutex
+	lockReleasePublish  sync.RWMutex
+	lockRestore         sync.RWMutex
+	lockSave            sync.RWMutex
+	lockSaveFeedback    sync.RWMutex
+	lockSchedule        sync.RWMutex
+	lockSearch          sync.RWMutex
+	lockSetFavorite     sync.RWMutex
+	lockUpdate          sync.RWMutex
+	lockUpdateIf        sync.RWMutex
+	lockUpdateImage     sync.RWMutex
+	lockUpdatePublished sync.RWMutex
+	lockUpdateSchedule  sync.RWMutex
 }
 
-// ListByUser calls ListByUserFunc.
-func (mock *PostRepositoryMock) ListByUser(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]model.LinkedInPost, error) {
-	if mock.ListByUserFunc == nil {
-		panic("PostRepositoryMock.ListByUserFunc: method is nil but PostRepository.ListByUser was just called")
+// ClaimDue calls ClaimDueFunc.
+func (mock *PostRepositoryMock) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.LinkedInPost, error) {
+	if mock.ClaimDueFunc == nil {
+		panic("PostRepositoryMock.ClaimDueFunc: method is nil but PostRepository.ClaimDue was just called")
 	}
 	callInfo := struct {
-		Ctx      context.Context
-		UserID   uuid.UUID
-		Page     int
-		PageSize int
+		Ctx   context.Context
+		Now   time.Time
+		Lease time.Duration
+		Limit int
 	}{
-		Ctx:      ctx,
-		UserID:   userID,
-		Page:     page,
-		PageSize: pageSize,
+		Ctx:   ctx,
+		Now:   now,
+		Lease: lease,
+		Limit: limit,
 	}
-	mock.lockListByUser.Lock()
-	mock.calls.ListByUser = append(mock.calls.ListByUser, callInfo)
-	mock.lockListByUser.Unlock()
-	return mock.ListByUserFunc(ctx, userID, page, pageSize)
+	mock.lockClaimDue.Lock()
+	mock.calls.ClaimDue = append(mock.calls.ClaimDue, callInfo)
+	mock.lockClaimDue.Unlock()
+	return mock.ClaimDueFunc(ctx, now, lease, limit)
 }
 
-// ListByUserCalls gets all the calls that were made to ListByUser.
+// ClaimDueCalls gets all the calls that were made to ClaimDue.
 // Check the length with:
 //
-//	len(mockedPostRepository.ListByUserCalls())
-func (mock *PostRepositoryMock) ListByUserCalls() []struct {
-	Ctx      context.Context
-	UserID   uuid.UUID
-	Page     int
-	PageSize int
+//	len(mockedPostRepository.ClaimDueCalls())
+func (mock *PostRepositoryMock) ClaimDueCalls() []struct {
+	Ctx   context.Context
+	Now   time.Time
+	Lease time.Duration
+	Limit int
 } {
 	var calls []struct {
-		Ctx      context.Context
-		UserID   uuid.UUID
-		Page     int
-		PageSize int
+		Ctx   context.Context
+		Now   time.Time
+		Lease time.Duration
+		Limit int
 	}
-	mock.lockListByUser.RLock()
-	calls = mock.calls.ListByUser
-	mock.lockListByUser.RUnlock()
+	mock.lockClaimDue.RLock()
+	calls = mock.calls.ClaimDue
+	mock.lockClaimDue.RUnlock()
 	return calls
 }
 
-// Save calls SaveFunc.
-func (mock *PostRepositoryMock) Save(ctx context.Context, p *model.LinkedInPost) error {
-	if mock.SaveFunc == nil {
-		panic("PostRepositoryMock.SaveFunc: method is nil but PostRepository.Save was just called")
+// ClaimPublish calls ClaimPublishFunc.
+func (mock *PostRepositoryMock) ClaimPublish(ctx context.Context, userID uuid.UUID, id uuid.UUID, now time.Time, lease time.Duration) (*model.LinkedInPost, error) {
+	if mock.ClaimPublishFunc == nil {
+		panic("PostRepositoryMock.ClaimPublishFunc: method is nil but PostRepository.ClaimPublish was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+		Now    time.Time
+		Lease  time.Duration
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+		Now:    now,
+		Lease:  lease,
+	}
+	mock.lockClaimPublish.Lock()
+	mock.calls.ClaimPublish = append(mock.calls.ClaimPublish, callInfo)
+	mock.lockClaimPublish.Unlock()
+	return mock.ClaimPublishFunc(ctx, userID, id, now, lease)
+}
+
+// ClaimPublishCalls gets all the calls that were made to ClaimPublish.
+// Check the length with:
+//
+//	len(mockedPostRepository.ClaimPublishCalls())
+func (mock *PostRepositoryMock) ClaimPublishCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+	Now    time.Time
+	Lease  time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+		Now    time.Time
+		Lease  time.Duration
+	}
+	mock.lockClaimPublish.RLock()
+	calls = mock.calls.ClaimPublish
+	mock.lockClaimPublish.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *PostRepositoryMock) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	if mock.DeleteFunc == nil {
+		panic("PostRepositoryMock.DeleteFunc: method is nil but PostRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, userID, id)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedPostRepository.DeleteCalls())
+func (mock *PostRepositoryMock) DeleteCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// Each calls EachFunc.
+func (mock *PostRepositoryMock) Each(ctx context.Context, userID uuid.UUID, fn func(model.LinkedInPost) error) error {
+	if mock.EachFunc == nil {
+		panic("PostRepositoryMock.EachFunc: method is nil but PostRepository.Each was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Fn     func(model.LinkedInPost) error
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Fn:     fn,
+	}
+	mock.lockEach.Lock()
+	mock.calls.Each = append(mock.calls.Each, callInfo)
+	mock.lockEach.Unlock()
+	return mock.EachFunc(ctx, userID, fn)
+}
+
+// EachCalls gets all the calls that were made to Each.
+// Check the length with:
+//
+//	len(mockedPostRepository.EachCalls())
+func (mock *PostRepositoryMock) EachCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Fn     func(model.LinkedInPost) error
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Fn     func(model.LinkedInPost) error
+	}
+	mock.lockEach.RLock()
+	calls = mock.calls.Each
+	mock.lockEach.RUnlock()
+	return calls
+}
+
+// FeedbackStats calls FeedbackStatsFunc.
+func (mock *PostRepositoryMock) FeedbackStats(ctx context.Context) ([]FeedbackStat, error) {
+	if mock.FeedbackStatsFunc == nil {
+		panic("PostRepositoryMock.FeedbackStatsFunc: method is nil but PostRepository.FeedbackStats was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		P   *model.LinkedInPost
 	}{
 		Ctx: ctx,
-		P:   p,
 	}
-	mock.lockSave.Lock()
-	mock.calls.Save = append(mock.calls.Save, callInfo)
-	mock.lockSave.Unlock()
-	return mock.SaveFunc(ctx, p)
+	mock.lockFeedbackStats.Lock()
+	mock.calls.FeedbackStats = append(mock.calls.FeedbackStats, callInfo)
+	mock.lockFeedbackStats.Unlock()
+	return mock.FeedbackStatsFunc(ctx)
 }
 
-// SaveCalls gets all the calls that were made to Save.
+// FeedbackStatsCalls gets all the calls that were made to FeedbackStats.
 // Check the length with:
 //
-//	len(mockedPostRepository.SaveCalls())
-func (mock *PostRepositoryMock) SaveCalls() []struct {
+//	len(mockedPostRepository.FeedbackStatsCalls())
+func (mock *PostRepositoryMock) FeedbackStatsCalls() []struct {
 	Ctx context.Context
-	P   *model.LinkedInPost
 } {
 	var calls []struct {
 		Ctx context.Context
-		P   *model.LinkedInPost
 	}
-	mock.lockSave.RLock()
-	calls = mock.calls.Save
-	mock.lockSave.RUnlock()
+	mock.lockFeedbackStats.RLock()
+	calls = mock.calls.FeedbackStats
+	mock.lockFeedbackStats.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *PostRepositoryMock) Get(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.LinkedInPost, error) {
+	if mock.GetFunc == nil {
+		panic("PostRepositoryMock.GetFunc: method is nil but PostRepository.Get was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, userID, id)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedPostRepository.GetCalls())
+func (mock *PostRepositoryMock) GetCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// GetOwned calls GetOwnedFunc.
+func (mock *PostRepositoryMock) GetOwned(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*model.LinkedInPost, error) {
+	if mock.GetOwnedFunc == nil {
+		panic("PostRepositoryMock.GetOwnedFunc: method is nil but PostRepository.GetOwned was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockGetOwned.Lock()
+	mock.calls.GetOwned = append(mock.calls.GetOwned, callInfo)
+	mock.lockGetOwned.Unlock()
+	return mock.GetOwnedFunc(ctx, userID, id)
+}
+
+// GetOwnedCalls gets all the calls that were made to GetOwned.
+// Check the length with:
+//
+//	len(mockedPostRepository.GetOwnedCalls())
+func (mock *PostRepositoryMock) GetOwnedCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockGetOwned.RLock()
+	calls = mock.calls.GetOwned
+	mock.lockGetOwned.RUnlock()
+	return calls
+}
+
+// GetVersion calls GetVersionFunc.
+func (mock *PostRepositoryMock) GetVersion(ctx context.Context, postID uuid.UUID, versionID uuid.UUID) (*model.PostVersion, error) {
+	if mock.GetVersionFunc == nil {
+		panic("PostRepositoryMock.GetVersionFunc: method is nil but PostRepository.GetVersion was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		PostID    uuid.UUID
+		VersionID uuid.UUID
+	}{
+		Ctx:       ctx,
+		PostID:    postID,
+		VersionID: versionID,
+	}
+	mock.lockGetVersion.Lock()
+	mock.calls.GetVersion = append(mock.calls.GetVersion, callInfo)
+	mock.lockGetVersion.Unlock()
+	return mock.GetVersionFunc(ctx, postID, versionID)
+}
+
+// GetVersionCalls gets all the calls that were made to GetVersion.
+// Check the length with:
+//
+//	len(mockedPostRepository.GetVersionCalls())
+func (mock *PostRepositoryMock) GetVersionCalls() []struct {
+	Ctx       context.Context
+	PostID    uuid.UUID
+	VersionID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		PostID    uuid.UUID
+		VersionID uuid.UUID
+	}
+	mock.lockGetVersion.RLock()
+	calls = mock.calls.GetVersion
+	mock.lockGetVersion.RUnlock()
+	return calls
+}
+
+// HardDelete calls HardDeleteFunc.
+func (mock *PostRepositoryMock) HardDelete(ctx context.Context, id uuid.UUID) error {
+	if mock.HardDeleteFunc == nil {
+		panic("PostRepositoryMock.HardDeleteFunc: method is nil but PostRepository.HardDelete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockHardDelete.Lock()
+	mock.calls.HardDelete = append(mock.calls.HardDelete, callInfo)
+	mock.lockHardDelete.Unlock()
+	return mock.HardDeleteFunc(ctx, id)
+}
+
+// HardDeleteCalls gets all the calls that were made to HardDelete.
+// Check the length with:
+//
+//	len(mockedPostRepository.HardDeleteCalls())
+func (mock *PostRepositoryMock) HardDeleteCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockHardDelete.RLock()
+	calls = mock.calls.HardDelete
+	mock.lockHardDelete.RUnlock()
+	return calls
+}
+
+// ListByUser calls ListByUserFunc.
+func (mock *PostRepositoryMock) ListByUser(ctx context.Context, userID uuid.UUID, f PostFilter, limit int, offset int) ([]model.LinkedInPost, int, error) {
+	if mock.ListByUserFunc == nil {
+		panic("PostRepositoryMock.ListByUserFunc: method is nil but PostRepository.ListByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		F      PostFilter
+		Limit  int
+		Offset int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		F:      f,
+		Limit:  limit,
+		Offset: offset,
+	}
+	mock.lockListByUser.Lock()
+	mock.calls.ListByUser = append(mock.calls.ListByUser, callInfo)
+	mock.lockListByUser.Unlock()
+	return mock.ListByUserFunc(ctx, userID, f, limit, offset)
+}
+
+// ListByUserCalls gets all the calls that were made to ListByUser.
+// Check the length with:
+//
+//	len(mockedPostRepository.ListByUserCalls())
+func (mock *PostRepositoryMock) ListByUserCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	F      PostFilter
+	Limit  int
+	Offset int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		F      PostFilter
+		Limit  int
+		Offset int
+	}
+	mock.lockListByUser.RLock()
+	calls = mock.calls.ListByUser
+	mock.lockListByUser.RUnlock()
+	return calls
+}
+
+// ListByUserAfter calls ListByUserAfterFunc.
+func (mock *PostRepositoryMock) ListByUserAfter(ctx context.Context, userID uuid.UUID, f PostFilter, after *PostCursor, limit int) ([]model.LinkedInPost, error) {
+	if mock.ListByUserAfterFunc == nil {
+		panic("PostRepositoryMock.ListByUserAfterFunc: method is nil but PostRepository.ListByUserAfter was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		F      PostFilter
+		After  *PostCursor
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		F:      f,
+		After:  after,
+		Limit:  limit,
+	}
+	mock.lockListByUserAfter.Lock()
+	mock.calls.ListByUserAfter = append(mock.calls.ListByUserAfter, callInfo)
+	mock.lockListByUserAfter.Unlock()
+	return mock.ListByUserAfterFunc(ctx, userID, f, after, limit)
+}
+
+// ListByUserAfterCalls gets all the calls that were made to ListByUserAfter.
+// Check the length with:
+//
+//	len(mockedPostRepository.ListByUserAfterCalls())
+func (mock *PostRepositoryMock) ListByUserAfterCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	F      PostFilter
+	After  *PostCursor
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		F      PostFilter
+		After  *PostCursor
+		Limit  int
+	}
+	mock.lockListByUserAfter.RLock()
+	calls = mock.calls.ListByUserAfter
+	mock.lockListByUserAfter.RUnlock()
+	return calls
+}
+
+// ListVersions calls ListVersionsFunc.
+func (mock *PostRepositoryMock) ListVersions(ctx context.Context, postID uuid.UUID) ([]model.PostVersion, error) {
+	if mock.ListVersionsFunc == nil {
+		panic("PostRepositoryMock.ListVersionsFunc: method is nil but PostRepository.ListVersions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		PostID: postID,
+	}
+	mock.lockListVersions.Lock()
+	mock.calls.ListVersions = append(mock.calls.ListVersions, callInfo)
+	mock.lockListVersions.Unlock()
+	return mock.ListVersionsFunc(ctx, postID)
+}
+
+// ListVersionsCalls gets all the calls that were made to ListVersions.
+// Check the length with:
+//
+//	len(mockedPostRepository.ListVersionsCalls())
+func (mock *PostRepositoryMock) ListVersionsCalls() []struct {
+	Ctx    context.Context
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		PostID uuid.UUID
+	}
+	mock.lockListVersions.RLock()
+	calls = mock.calls.ListVersions
+	mock.lockListVersions.RUnlock()
+	return calls
+}
+
+// Recent calls RecentFunc.
+func (mock *PostRepositoryMock) Recent(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]model.LinkedInPost, error) {
+	if mock.RecentFunc == nil {
+		panic("PostRepositoryMock.RecentFunc: method is nil but PostRepository.Recent was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Since:  since,
+		Limit:  limit,
+	}
+	mock.lockRecent.Lock()
+	mock.calls.Recent = append(mock.calls.Recent, callInfo)
+	mock.lockRecent.Unlock()
+	return mock.RecentFunc(ctx, userID, since, limit)
+}
+
+// RecentCalls gets all the calls that were made to Recent.
+// Check the length with:
+//
+//	len(mockedPostRepository.RecentCalls())
+func (mock *PostRepositoryMock) RecentCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Since  time.Time
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+		Limit  int
+	}
+	mock.lockRecent.RLock()
+	calls = mock.calls.Recent
+	mock.lockRecent.RUnlock()
+	return calls
+}
+
+// ReleasePublish calls ReleasePublishFunc.
+func (mock *PostRepositoryMock) ReleasePublish(ctx context.Context, p *model.LinkedInPost) error {
+	if mock.ReleasePublishFunc == nil {
+		panic("PostRepositoryMock.ReleasePublishFunc: method is nil but PostRepository.ReleasePublish was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}{
+		Ctx: ctx,
+		P:   p,
+	}
+	mock.lockReleasePublish.Lock()
+	mock.calls.ReleasePublish = append(mock.calls.ReleasePublish, callInfo)
+	mock.lockReleasePublish.Unlock()
+	return mock.ReleasePublishFunc(ctx, p)
+}
+
+// ReleasePublishCalls gets all the calls that were made to ReleasePublish.
+// Check the length with:
+//
+//	len(mockedPostRepository.ReleasePublishCalls())
+func (mock *PostRepositoryMock) ReleasePublishCalls() []struct {
+	Ctx context.Context
+	P   *model.LinkedInPost
+} {
+	var calls []struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}
+	mock.lockReleasePublish.RLock()
+	calls = mock.calls.ReleasePublish
+	mock.lockReleasePublish.RUnlock()
+	return calls
+}
+
+// Restore calls RestoreFunc.
+func (mock *PostRepositoryMock) Restore(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	if mock.RestoreFunc == nil {
+		panic("PostRepositoryMock.RestoreFunc: method is nil but PostRepository.Restore was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockRestore.Lock()
+	mock.calls.Restore = append(mock.calls.Restore, callInfo)
+	mock.lockRestore.Unlock()
+	return mock.RestoreFunc(ctx, userID, id)
+}
+
+// RestoreCalls gets all the calls that were made to Restore.
+// Check the length with:
+//
+//	len(mockedPostRepository.RestoreCalls())
+func (mock *PostRepositoryMock) RestoreCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockRestore.RLock()
+	calls = mock.calls.Restore
+	mock.lockRestore.RUnlock()
+	return calls
+}
+
+// Save calls SaveFunc.
+func (mock *PostRepositoryMock) Save(ctx context.Context, p *model.LinkedInPost) error {
+	if mock.SaveFunc == nil {
+		panic("PostRepositoryMock.SaveFunc: method is nil but PostRepository.Save was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}{
+		Ctx: ctx,
+		P:   p,
+	}
+	mock.lockSave.Lock()
+	mock.calls.Save = append(mock.calls.Save, callInfo)
+	mock.lockSave.Unlock()
+	return mock.SaveFunc(ctx, p)
+}
+
+// SaveCalls gets all the calls that were made to Save.
+// Check the length with:
+//
+//	len(mockedPostRepository.SaveCalls())
+func (mock *PostRepositoryMock) SaveCalls() []struct {
+	Ctx context.Context
+	P   *model.LinkedInPost
+} {
+	var calls []struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}
+	mock.lockSave.RLock()
+	calls = mock.calls.Save
+	mock.lockSave.RUnlock()
+	return calls
+}
+
+// SaveFeedback calls SaveFeedbackFunc.
+func (mock *PostRepositoryMock) SaveFeedback(ctx context.Context, f *model.PostFeedback) error {
+	if mock.SaveFeedbackFunc == nil {
+		panic("PostRepositoryMock.SaveFeedbackFunc: method is nil but PostRepository.SaveFeedback was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		F   *model.PostFeedback
+	}{
+		Ctx: ctx,
+		F:   f,
+	}
+	mock.lockSaveFeedback.Lock()
+	mock.calls.SaveFeedback = append(mock.calls.SaveFeedback, callInfo)
+	mock.lockSaveFeedback.Unlock()
+	return mock.SaveFeedbackFunc(ctx, f)
+}
+
+// SaveFeedbackCalls gets all the calls that were made to SaveFeedback.
+// Check the length with:
+//
+//	len(mockedPostRepository.SaveFeedbackCalls())
+func (mock *PostRepositoryMock) SaveFeedbackCalls() []struct {
+	Ctx context.Context
+	F   *model.PostFeedback
+} {
+	var calls []struct {
+		Ctx context.Context
+		F   *model.PostFeedback
+	}
+	mock.lockSaveFeedback.RLock()
+	calls = mock.calls.SaveFeedback
+	mock.lockSaveFeedback.RUnlock()
+	return calls
+}
+
+// Schedule calls ScheduleFunc.
+func (mock *PostRepositoryMock) Schedule(ctx context.Context, p *model.LinkedInPost) error {
+	if mock.ScheduleFunc == nil {
+		panic("PostRepositoryMock.ScheduleFunc: method is nil but PostRepository.Schedule was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}{
+		Ctx: ctx,
+		P:   p,
+	}
+	mock.lockSchedule.Lock()
+	mock.calls.Schedule = append(mock.calls.Schedule, callInfo)
+	mock.lockSchedule.Unlock()
+	return mock.ScheduleFunc(ctx, p)
+}
+
+// ScheduleCalls gets all the calls that were made to Schedule.
+// Check the length with:
+//
+//	len(mockedPostRepository.ScheduleCalls())
+func (mock *PostRepositoryMock) ScheduleCalls() []struct {
+	Ctx context.Context
+	P   *model.LinkedInPost
+} {
+	var calls []struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}
+	mock.lockSchedule.RLock()
+	calls = mock.calls.Schedule
+	mock.lockSchedule.RUnlock()
+	return calls
+}
+
+// Search calls SearchFunc.
+func (mock *PostRepositoryMock) Search(ctx context.Context, userID uuid.UUID, query string, limit int, offset int) ([]model.LinkedInPost, int, error) {
+	if mock.SearchFunc == nil {
+		panic("PostRepositoryMock.SearchFunc: method is nil but PostRepository.Search was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Query  string
+		Limit  int
+		Offset int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Query:  query,
+		Limit:  limit,
+		Offset: offset,
+	}
+	mock.lockSearch.Lock()
+	mock.calls.Search = append(mock.calls.Search, callInfo)
+	mock.lockSearch.Unlock()
+	return mock.SearchFunc(ctx, userID, query, limit, offset)
+}
+
+// SearchCalls gets all the calls that were made to Search.
+// Check the length with:
+//
+//	len(mockedPostRepository.SearchCalls())
+func (mock *PostRepositoryMock) SearchCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Query  string
+	Limit  int
+	Offset int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Query  string
+		Limit  int
+		Offset int
+	}
+	mock.lockSearch.RLock()
+	calls = mock.calls.Search
+	mock.lockSearch.RUnlock()
+	return calls
+}
+
+// SetFavorite calls SetFavoriteFunc.
+func (mock *PostRepositoryMock) SetFavorite(ctx context.Context, userID uuid.UUID, id uuid.UUID, favorited bool) error {
+	if mock.SetFavoriteFunc == nil {
+		panic("PostRepositoryMock.SetFavoriteFunc: method is nil but PostRepository.SetFavorite was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		ID        uuid.UUID
+		Favorited bool
+	}{
+		Ctx:       ctx,
+		UserID:    userID,
+		ID:        id,
+		Favorited: favorited,
+	}
+	mock.lockSetFavorite.Lock()
+	mock.calls.SetFavorite = append(mock.calls.SetFavorite, callInfo)
+	mock.lockSetFavorite.Unlock()
+	return mock.SetFavoriteFunc(ctx, userID, id, favorited)
+}
+
+// SetFavoriteCalls gets all the calls that were made to SetFavorite.
+// Check the length with:
+//
+//	len(mockedPostRepository.SetFavoriteCalls())
+func (mock *PostRepositoryMock) SetFavoriteCalls() []struct {
+	Ctx       context.Context
+	UserID    uuid.UUID
+	ID        uuid.UUID
+	Favorited bool
+} {
+	var calls []struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		ID        uuid.UUID
+		Favorited bool
+	}
+	mock.lockSetFavorite.RLock()
+	calls = mock.calls.SetFavorite
+	mock.lockSetFavorite.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *PostRepositoryMock) Update(ctx context.Context, p *model.LinkedInPost) error {
+	if mock.UpdateFunc == nil {
+		panic("PostRepositoryMock.UpdateFunc: method is nil but PostRepository.Update was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}{
+		Ctx: ctx,
+		P:   p,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, p)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedPostRepository.UpdateCalls())
+func (mock *PostRepositoryMock) UpdateCalls() []struct {
+	Ctx context.Context
+	P   *model.LinkedInPost
+} {
+	var calls []struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// UpdateIf calls UpdateIfFunc.
+func (mock *PostRepositoryMock) UpdateIf(ctx context.Context, p *model.LinkedInPost, check func(current *model.LinkedInPost) error) error {
+	if mock.UpdateIfFunc == nil {
+		panic("PostRepositoryMock.UpdateIfFunc: method is nil but PostRepository.UpdateIf was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		P     *model.LinkedInPost
+		Check func(current *model.LinkedInPost) error
+	}{
+		Ctx:   ctx,
+		P:     p,
+		Check: check,
+	}
+	mock.lockUpdateIf.Lock()
+	mock.calls.UpdateIf = append(mock.calls.UpdateIf, callInfo)
+	mock.lockUpdateIf.Unlock()
+	return mock.UpdateIfFunc(ctx, p, check)
+}
+
+// UpdateIfCalls gets all the calls that were made to UpdateIf.
+// Check the length with:
+//
+//	len(mockedPostRepository.UpdateIfCalls())
+func (mock *PostRepositoryMock) UpdateIfCalls() []struct {
+	Ctx   context.Context
+	P     *model.LinkedInPost
+	Check func(current *model.LinkedInPost) error
+} {
+	var calls []struct {
+		Ctx   context.Context
+		P     *model.LinkedInPost
+		Check func(current *model.LinkedInPost) error
+	}
+	mock.lockUpdateIf.RLock()
+	calls = mock.calls.UpdateIf
+	mock.lockUpdateIf.RUnlock()
+	return calls
+}
+
+// UpdateImage calls UpdateImageFunc.
+func (mock *PostRepositoryMock) UpdateImage(ctx context.Context, p *model.LinkedInPost) error {
+	if mock.UpdateImageFunc == nil {
+		panic("PostRepositoryMock.UpdateImageFunc: method is nil but PostRepository.UpdateImage was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}{
+		Ctx: ctx,
+		P:   p,
+	}
+	mock.lockUpdateImage.Lock()
+	mock.calls.UpdateImage = append(mock.calls.UpdateImage, callInfo)
+	mock.lockUpdateImage.Unlock()
+	return mock.UpdateImageFunc(ctx, p)
+}
+
+// UpdateImageCalls gets all the calls that were made to UpdateImage.
+// Check the length with:
+//
+//	len(mockedPostRepository.UpdateImageCalls())
+func (mock *PostRepositoryMock) UpdateImageCalls() []struct {
+	Ctx context.Context
+	P   *model.LinkedInPost
+} {
+	var calls []struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}
+	mock.lockUpdateImage.RLock()
+	calls = mock.calls.UpdateImage
+	mock.lockUpdateImage.RUnlock()
+	return calls
+}
+
+// UpdatePublished calls UpdatePublishedFunc.
+func (mock *PostRepositoryMock) UpdatePublished(ctx context.Context, p *model.LinkedInPost) error {
+	if mock.UpdatePublishedFunc == nil {
+		panic("PostRepositoryMock.UpdatePublishedFunc: method is nil but PostRepository.UpdatePublished was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}{
+		Ctx: ctx,
+		P:   p,
+	}
+	mock.lockUpdatePublished.Lock()
+	mock.calls.UpdatePublished = append(mock.calls.UpdatePublished, callInfo)
+	mock.lockUpdatePublished.Unlock()
+	return mock.UpdatePublishedFunc(ctx, p)
+}
+
+// UpdatePublishedCalls gets all the calls that were made to UpdatePublished.
+// Check the length with:
+//
+//	len(mockedPostRepository.UpdatePublishedCalls())
+func (mock *PostRepositoryMock) UpdatePublishedCalls() []struct {
+	Ctx context.Context
+	P   *model.LinkedInPost
+} {
+	var calls []struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}
+	mock.lockUpdatePublished.RLock()
+	calls = mock.calls.UpdatePublished
+	mock.lockUpdatePublished.RUnlock()
+	return calls
+}
+
+// UpdateSchedule calls UpdateScheduleFunc.
+func (mock *PostRepositoryMock) UpdateSchedule(ctx context.Context, p *model.LinkedInPost) error {
+	if mock.UpdateScheduleFunc == nil {
+		panic("PostRepositoryMock.UpdateScheduleFunc: method is nil but PostRepository.UpdateSchedule was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}{
+		Ctx: ctx,
+		P:   p,
+	}
+	mock.lockUpdateSchedule.Lock()
+	mock.calls.UpdateSchedule = append(mock.calls.UpdateSchedule, callInfo)
+	mock.lockUpdateSchedule.Unlock()
+	return mock.UpdateScheduleFunc(ctx, p)
+}
+
+// UpdateScheduleCalls gets all the calls that were made to UpdateSchedule.
+// Check the length with:
+//
+//	len(mockedPostRepository.UpdateScheduleCalls())
+func (mock *PostRepositoryMock) UpdateScheduleCalls() []struct {
+	Ctx context.Context
+	P   *model.LinkedInPost
+} {
+	var calls []struct {
+		Ctx context.Context
+		P   *model.LinkedInPost
+	}
+	mock.lockUpdateSchedule.RLock()
+	calls = mock.calls.UpdateSchedule
+	mock.lockUpdateSchedule.RUnlock()
 	return calls
 }
@@ -0,0 +1,73 @@
+// internal/repository/refresh_token_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, t *model.RefreshToken) error
+	FindByHash(ctx context.Context, hash string) (*model.RefreshToken, error)
+	// Revoke marks the token as used and reports whether this call did so;
+	// false means it had already been revoked.
+	Revoke(ctx context.Context, id uuid.UUID) (bool, error)
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type refreshTokenRepo struct{ db bun.IDB }
+
+func NewRefreshTokenRepo(db bun.IDB) RefreshTokenRepository { return &refreshTokenRepo{db} }
+
+func (r *refreshTokenRepo) Create(ctx context.Context, t *model.RefreshToken) error {
+	_, err := r.db.NewInsert().Model(t).Exec(ctx)
+	return err
+}
+
+func (r *refreshTokenRepo) FindByHash(ctx context.Context, hash string) (*model.RefreshToken, error) {
+	t := new(model.RefreshToken)
+	err := r.db.NewSelect().Model(t).Where("token_hash = ?", hash).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *refreshTokenRepo) Revoke(ctx context.Context, id uuid.UUID) (bool, error) {
+	res, err := r.db.NewUpdate().
+		Model((*model.RefreshToken)(nil)).
+		Set("revoked_at = current_timestamp").
+		Where("id = ?", id).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (r *refreshTokenRepo) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	_, err := r.db.NewUpdate().
+		Model((*model.RefreshToken)(nil)).
+		Set("revoked_at = current_timestamp").
+		Where("family_id = ?", familyID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	return err
+}
+
+func (r *refreshTokenRepo) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.NewUpdate().
+		Model((*model.RefreshToken)(nil)).
+		Set("revoked_at = current_timestamp").
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,283 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that RefreshTokenRepositoryMock does implement RefreshTokenRepository.
+// If this is not the case, regenerate this file with moq.
+var _ RefreshTokenRepository = &RefreshTokenRepositoryMock{}
+
+// RefreshTokenRepositoryMock is a mock implementation of RefreshTokenRepository.
+//
+//	func TestSomethingThatUsesRefreshTokenRepository(t *testing.T) {
+//
+//		// make and configure a mocked RefreshTokenRepository
+//		mockedRefreshTokenRepository := &RefreshTokenRepositoryMock{
+//			CreateFunc: func(ctx context.Context, t *model.RefreshToken) error {
+//				panic("mock out the Create method")
+//			},
+//			FindByHashFunc: func(ctx context.Context, hash string) (*model.RefreshToken, error) {
+//				panic("mock out the FindByHash method")
+//			},
+//			RevokeFunc: func(ctx context.Context, id uuid.UUID) (bool, error) {
+//				panic("mock out the Revoke method")
+//			},
+//			RevokeAllForUserFunc: func(ctx context.Context, userID uuid.UUID) error {
+//				panic("mock out the RevokeAllForUser method")
+//			},
+//			RevokeFamilyFunc: func(ctx context.Context, familyID uuid.UUID) error {
+//				panic("mock out the RevokeFamily method")
+//			},
+//		}
+//
+//		// use mockedRefreshTokenRepository in code that requires RefreshTokenRepository
+//		// and then make assertions.
+//
+//	}
+type RefreshTokenRepositoryMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, t *model.RefreshToken) error
+
+	// FindByHashFunc mocks the FindByHash method.
+	FindByHashFunc func(ctx context.Context, hash string) (*model.RefreshToken, error)
+
+	// RevokeFunc mocks the Revoke method.
+	RevokeFunc func(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// RevokeAllForUserFunc mocks the RevokeAllForUser method.
+	RevokeAllForUserFunc func(ctx context.Context, userID uuid.UUID) error
+
+	// RevokeFamilyFunc mocks the RevokeFamily method.
+	RevokeFamilyFunc func(ctx context.Context, familyID uuid.UUID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// T is the t argument value.
+			T *model.RefreshToken
+		}
+		// FindByHash holds details about calls to the FindByHash method.
+		FindByHash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// Revoke holds details about calls to the Revoke method.
+		Revoke []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// RevokeAllForUser holds details about calls to the RevokeAllForUser method.
+		RevokeAllForUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// RevokeFamily holds details about calls to the RevokeFamily method.
+		RevokeFamily []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FamilyID is the familyID argument value.
+			FamilyID uuid.UUID
+		}
+	}
+	lockCreate           sync.RWMutex
+	lockFindByHash       sync.RWMutex
+	lockRevoke           sync.RWMutex
+	lockRevokeAllForUser sync.RWMutex
+	lockRevokeFamily     sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *RefreshTokenRepositoryMock) Create(ctx context.Context, t *model.RefreshToken) error {
+	if mock.CreateFunc == nil {
+		panic("RefreshTokenRepositoryMock.CreateFunc: method is nil but RefreshTokenRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		T   *model.RefreshToken
+	}{
+		Ctx: ctx,
+		T:   t,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, t)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.CreateCalls())
+func (mock *RefreshTokenRepositoryMock) CreateCalls() []struct {
+	Ctx context.Context
+	T   *model.RefreshToken
+} {
+	var calls []struct {
+		Ctx context.Context
+		T   *model.RefreshToken
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// FindByHash calls FindByHashFunc.
+func (mock *RefreshTokenRepositoryMock) FindByHash(ctx context.Context, hash string) (*model.RefreshToken, error) {
+	if mock.FindByHashFunc == nil {
+		panic("RefreshTokenRepositoryMock.FindByHashFunc: method is nil but RefreshTokenRepository.FindByHash was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash string
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockFindByHash.Lock()
+	mock.calls.FindByHash = append(mock.calls.FindByHash, callInfo)
+	mock.lockFindByHash.Unlock()
+	return mock.FindByHashFunc(ctx, hash)
+}
+
+// FindByHashCalls gets all the calls that were made to FindByHash.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.FindByHashCalls())
+func (mock *RefreshTokenRepositoryMock) FindByHashCalls() []struct {
+	Ctx  context.Context
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash string
+	}
+	mock.lockFindByHash.RLock()
+	calls = mock.calls.FindByHash
+	mock.lockFindByHash.RUnlock()
+	return calls
+}
+
+// Revoke calls RevokeFunc.
+func (mock *RefreshTokenRepositoryMock) Revoke(ctx context.Context, id uuid.UUID) (bool, error) {
+	if mock.RevokeFunc == nil {
+		panic("RefreshTokenRepositoryMock.RevokeFunc: method is nil but RefreshTokenRepository.Revoke was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockRevoke.Lock()
+	mock.calls.Revoke = append(mock.calls.Revoke, callInfo)
+	mock.lockRevoke.Unlock()
+	return mock.RevokeFunc(ctx, id)
+}
+
+// RevokeCalls gets all the calls that were made to Revoke.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockRevoke.RLock()
+	calls = mock.calls.Revoke
+	mock.lockRevoke.RUnlock()
+	return calls
+}
+
+// RevokeAllForUser calls RevokeAllForUserFunc.
+func (mock *RefreshTokenRepositoryMock) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	if mock.RevokeAllForUserFunc == nil {
+		panic("RefreshTokenRepositoryMock.RevokeAllForUserFunc: method is nil but RefreshTokenRepository.RevokeAllForUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRevokeAllForUser.Lock()
+	mock.calls.RevokeAllForUser = append(mock.calls.RevokeAllForUser, callInfo)
+	mock.lockRevokeAllForUser.Unlock()
+	return mock.RevokeAllForUserFunc(ctx, userID)
+}
+
+// RevokeAllForUserCalls gets all the calls that were made to RevokeAllForUser.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeAllForUserCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeAllForUserCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockRevokeAllForUser.RLock()
+	calls = mock.calls.RevokeAllForUser
+	mock.lockRevokeAllForUser.RUnlock()
+	return calls
+}
+
+// RevokeFamily calls RevokeFamilyFunc.
+func (mock *RefreshTokenRepositoryMock) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	if mock.RevokeFamilyFunc == nil {
+		panic("RefreshTokenRepositoryMock.RevokeFamilyFunc: method is nil but RefreshTokenRepository.RevokeFamily was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		FamilyID uuid.UUID
+	}{
+		Ctx:      ctx,
+		FamilyID: familyID,
+	}
+	mock.lockRevokeFamily.Lock()
+	mock.calls.RevokeFamily = append(mock.calls.RevokeFamily, callInfo)
+	mock.lockRevokeFamily.Unlock()
+	return mock.RevokeFamilyFunc(ctx, familyID)
+}
+
+// RevokeFamilyCalls gets all the calls that were made to RevokeFamily.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeFamilyCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeFamilyCalls() []struct {
+	Ctx      context.Context
+	FamilyID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		FamilyID uuid.UUID
+	}
+	mock.lockRevokeFamily.RLock()
+	calls = mock.calls.RevokeFamily
+	mock.lockRevokeFamily.RUnlock()
+	return calls
+}
@@ -0,0 +1,46 @@
+// internal/repository/revoked_token_repository.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+type RevokedTokenRepository interface {
+	// Revoke adds jti to the revocation list. Revoking the same jti twice is
+	// not an error.
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+type revokedTokenRepo struct{ db bun.IDB }
+
+func NewRevokedTokenRepo(db bun.IDB) RevokedTokenRepository { return &revokedTokenRepo{db} }
+
+// Revoke also prunes entries whose tokens have expired, which keeps the list
+// bounded by the number of live tokens without a separate cleanup job.
+func (r *revokedTokenRepo) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	_, err := r.db.NewInsert().
+		Model(&model.RevokedToken{JTI: jti, ExpiresAt: expiresAt}).
+		On("CONFLICT (jti) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.NewDelete().
+		Model((*model.RevokedToken)(nil)).
+		Where("expires_at < current_timestamp").
+		Exec(ctx)
+	return err
+}
+
+func (r *revokedTokenRepo) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	return r.db.NewSelect().
+		Model((*model.RevokedToken)(nil)).
+		Where("jti = ?", jti).
+		Exists(ctx)
+}
@@ -0,0 +1,138 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Ensure, that RevokedTokenRepositoryMock does implement RevokedTokenRepository.
+// If this is not the case, regenerate this file with moq.
+var _ RevokedTokenRepository = &RevokedTokenRepositoryMock{}
+
+// RevokedTokenRepositoryMock is a mock implementation of RevokedTokenRepository.
+//
+//	func TestSomethingThatUsesRevokedTokenRepository(t *testing.T) {
+//
+//		// make and configure a mocked RevokedTokenRepository
+//		mockedRevokedTokenRepository := &RevokedTokenRepositoryMock{
+//			IsRevokedFunc: func(ctx context.Context, jti string) (bool, error) {
+//				panic("mock out the IsRevoked method")
+//			},
+//			RevokeFunc: func(ctx context.Context, jti string, expiresAt time.Time) error {
+//				panic("mock out the Revoke method")
+//			},
+//		}
+//
+//		// use mockedRevokedTokenRepository in code that requires RevokedTokenRepository
+//		// and then make assertions.
+//
+//	}
+type RevokedTokenRepositoryMock struct {
+	// IsRevokedFunc mocks the IsRevoked method.
+	IsRevokedFunc func(ctx context.Context, jti string) (bool, error)
+
+	// RevokeFunc mocks the Revoke method.
+	RevokeFunc func(ctx context.Context, jti string, expiresAt time.Time) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// IsRevoked holds details about calls to the IsRevoked method.
+		IsRevoked []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Jti is the jti argument value.
+			Jti string
+		}
+		// Revoke holds details about calls to the Revoke method.
+		Revoke []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Jti is the jti argument value.
+			Jti string
+			// ExpiresAt is the expiresAt argument value.
+			ExpiresAt time.Time
+		}
+	}
+	lockIsRevoked sync.RWMutex
+	lockRevoke    sync.RWMutex
+}
+
+// IsRevoked calls IsRevokedFunc.
+func (mock *RevokedTokenRepositoryMock) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	if mock.IsRevokedFunc == nil {
+		panic("RevokedTokenRepositoryMock.IsRevokedFunc: method is nil but RevokedTokenRepository.IsRevoked was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Jti string
+	}{
+		Ctx: ctx,
+		Jti: jti,
+	}
+	mock.lockIsRevoked.Lock()
+	mock.calls.IsRevoked = append(mock.calls.IsRevoked, callInfo)
+	mock.lockIsRevoked.Unlock()
+	return mock.IsRevokedFunc(ctx, jti)
+}
+
+// IsRevokedCalls gets all the calls that were made to IsRevoked.
+// Check the length with:
+//
+//	len(mockedRevokedTokenRepository.IsRevokedCalls())
+func (mock *RevokedTokenRepositoryMock) IsRevokedCalls() []struct {
+	Ctx context.Context
+	Jti string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Jti string
+	}
+	mock.lockIsRevoked.RLock()
+	calls = mock.calls.IsRevoked
+	mock.lockIsRevoked.RUnlock()
+	return calls
+}
+
+// Revoke calls RevokeFunc.
+func (mock *RevokedTokenRepositoryMock) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	if mock.RevokeFunc == nil {
+		panic("RevokedTokenRepositoryMock.RevokeFunc: method is nil but RevokedTokenRepository.Revoke was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Jti       string
+		ExpiresAt time.Time
+	}{
+		Ctx:       ctx,
+		Jti:       jti,
+		ExpiresAt: expiresAt,
+	}
+	mock.lockRevoke.Lock()
+	mock.calls.Revoke = append(mock.calls.Revoke, callInfo)
+	mock.lockRevoke.Unlock()
+	return mock.RevokeFunc(ctx, jti, expiresAt)
+}
+
+// RevokeCalls gets all the calls that were made to Revoke.
+// Check the length with:
+//
+//	len(mockedRevokedTokenRepository.RevokeCalls())
+func (mock *RevokedTokenRepositoryMock) RevokeCalls() []struct {
+	Ctx       context.Context
+	Jti       string
+	ExpiresAt time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Jti       string
+		ExpiresAt time.Time
+	}
+	mock.lockRevoke.RLock()
+	calls = mock.calls.Revoke
+	mock.lockRevoke.RUnlock()
+	return calls
+}
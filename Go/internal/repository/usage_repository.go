@@ -0,0 +1,85 @@
+// internal/repository/usage_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+// UsageRepository keeps the monthly generation counters. period is the first
+// day of the month being counted.
+type UsageRepository interface {
+	// Increment adds a generation to the user's count for period unless the
+	// count has reached limit, checking and incrementing in one transaction.
+	// It returns the count afterwards and whether it was incremented. A
+	// negative limit never refuses.
+	Increment(ctx context.Context, userID uuid.UUID, period time.Time, limit int) (int, bool, error)
+	// Decrement takes back a generation counted by Increment.
+	Decrement(ctx context.Context, userID uuid.UUID, period time.Time) error
+	// Count returns the user's generations in period; zero when there were
+	// none.
+	Count(ctx context.Context, userID uuid.UUID, period time.Time) (int, error)
+}
+
+type usageRepo struct{ db bun.IDB }
+
+func NewUsageRepo(db bun.IDB) UsageRepository { return &usageRepo{db} }
+
+func (r *usageRepo) Increment(ctx context.Context, userID uuid.UUID, period time.Time, limit int) (int, bool, error) {
+	var used int
+	var ok bool
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Creating the row first gives FOR UPDATE a row to lock, so
+		// concurrent generations of one user are counted one at a time.
+		c := &model.UsageCounter{UserID: userID, Period: period}
+		if _, err := tx.NewInsert().Model(c).On("CONFLICT DO NOTHING").Exec(ctx); err != nil {
+			return err
+		}
+		if err := tx.NewSelect().Model(c).WherePK().For("UPDATE").Scan(ctx); err != nil {
+			return err
+		}
+		used = c.Generations
+		if limit >= 0 && used >= limit {
+			return nil
+		}
+		if _, err := tx.NewUpdate().Model(c).Set("generations = generations + 1").WherePK().Exec(ctx); err != nil {
+			return err
+		}
+		used++
+		ok = true
+		return nil
+	})
+	return used, ok, err
+}
+
+func (r *usageRepo) Decrement(ctx context.Context, userID uuid.UUID, period time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*model.UsageCounter)(nil)).
+		Set("generations = generations - 1").
+		Where("user_id = ?", userID).
+		Where("period = ?", period).
+		Where("generations > 0").
+		Exec(ctx)
+	return err
+}
+
+func (r *usageRepo) Count(ctx context.Context, userID uuid.UUID, period time.Time) (int, error) {
+	var used int
+	err := r.db.NewSelect().
+		Model((*model.UsageCounter)(nil)).
+		Column("generations").
+		Where("user_id = ?", userID).
+		Where("period = ?", period).
+		Scan(ctx, &used)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return used, err
+}
@@ -0,0 +1,207 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// Ensure, that UsageRepositoryMock does implement UsageRepository.
+// If this is not the case, regenerate this file with moq.
+var _ UsageRepository = &UsageRepositoryMock{}
+
+// UsageRepositoryMock is a mock implementation of UsageRepository.
+//
+//	func TestSomethingThatUsesUsageRepository(t *testing.T) {
+//
+//		// make and configure a mocked UsageRepository
+//		mockedUsageRepository := &UsageRepositoryMock{
+//			CountFunc: func(ctx context.Context, userID uuid.UUID, period time.Time) (int, error) {
+//				panic("mock out the Count method")
+//			},
+//			DecrementFunc: func(ctx context.Context, userID uuid.UUID, period time.Time) error {
+//				panic("mock out the Decrement method")
+//			},
+//			IncrementFunc: func(ctx context.Context, userID uuid.UUID, period time.Time, limit int) (int, bool, error) {
+//				panic("mock out the Increment method")
+//			},
+//		}
+//
+//		// use mockedUsageRepository in code that requires UsageRepository
+//		// and then make assertions.
+//
+//	}
+type UsageRepositoryMock struct {
+	// CountFunc mocks the Count method.
+	CountFunc func(ctx context.Context, userID uuid.UUID, period time.Time) (int, error)
+
+	// DecrementFunc mocks the Decrement method.
+	DecrementFunc func(ctx context.Context, userID uuid.UUID, period time.Time) error
+
+	// IncrementFunc mocks the Increment method.
+	IncrementFunc func(ctx context.Context, userID uuid.UUID, period time.Time, limit int) (int, bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Count holds details about calls to the Count method.
+		Count []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Period is the period argument value.
+			Period time.Time
+		}
+		// Decrement holds details about calls to the Decrement method.
+		Decrement []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Period is the period argument value.
+			Period time.Time
+		}
+		// Increment holds details about calls to the Increment method.
+		Increment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Period is the period argument value.
+			Period time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+	}
+	lockCount     sync.RWMutex
+	lockDecrement sync.RWMutex
+	lockIncrement sync.RWMutex
+}
+
+// Count calls CountFunc.
+func (mock *UsageRepositoryMock) Count(ctx context.Context, userID uuid.UUID, period time.Time) (int, error) {
+	if mock.CountFunc == nil {
+		panic("UsageRepositoryMock.CountFunc: method is nil but UsageRepository.Count was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Period time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Period: period,
+	}
+	mock.lockCount.Lock()
+	mock.calls.Count = append(mock.calls.Count, callInfo)
+	mock.lockCount.Unlock()
+	return mock.CountFunc(ctx, userID, period)
+}
+
+// CountCalls gets all the calls that were made to Count.
+// Check the length with:
+//
+//	len(mockedUsageRepository.CountCalls())
+func (mock *UsageRepositoryMock) CountCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Period time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Period time.Time
+	}
+	mock.lockCount.RLock()
+	calls = mock.calls.Count
+	mock.lockCount.RUnlock()
+	return calls
+}
+
+// Decrement calls DecrementFunc.
+func (mock *UsageRepositoryMock) Decrement(ctx context.Context, userID uuid.UUID, period time.Time) error {
+	if mock.DecrementFunc == nil {
+		panic("UsageRepositoryMock.DecrementFunc: method is nil but UsageRepository.Decrement was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Period time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Period: period,
+	}
+	mock.lockDecrement.Lock()
+	mock.calls.Decrement = append(mock.calls.Decrement, callInfo)
+	mock.lockDecrement.Unlock()
+	return mock.DecrementFunc(ctx, userID, period)
+}
+
+// DecrementCalls gets all the calls that were made to Decrement.
+// Check the length with:
+//
+//	len(mockedUsageRepository.DecrementCalls())
+func (mock *UsageRepositoryMock) DecrementCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Period time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Period time.Time
+	}
+	mock.lockDecrement.RLock()
+	calls = mock.calls.Decrement
+	mock.lockDecrement.RUnlock()
+	return calls
+}
+
+// Increment calls IncrementFunc.
+func (mock *UsageRepositoryMock) Increment(ctx context.Context, userID uuid.UUID, period time.Time, limit int) (int, bool, error) {
+	if mock.IncrementFunc == nil {
+		panic("UsageRepositoryMock.IncrementFunc: method is nil but UsageRepository.Increment was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Period time.Time
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Period: period,
+		Limit:  limit,
+	}
+	mock.lockIncrement.Lock()
+	mock.calls.Increment = append(mock.calls.Increment, callInfo)
+	mock.lockIncrement.Unlock()
+	return mock.IncrementFunc(ctx, userID, period, limit)
+}
+
+// IncrementCalls gets all the calls that were made to Increment.
+// Check the length with:
+//
+//	len(mockedUsageRepository.IncrementCalls())
+func (mock *UsageRepositoryMock) IncrementCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Period time.Time
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Period time.Time
+		Limit  int
+	}
+	mock.lockIncrement.RLock()
+	calls = mock.calls.Increment
+	mock.lockIncrement.RUnlock()
+	return calls
+}
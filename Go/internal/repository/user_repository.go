@@ -11,18 +11,31 @@ import (
 )
 
 type UserRepository interface {
+	// FindByEmail matches email case-insensitively.
 	FindByEmail(ctx context.Context, email string) (*model.User, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+	// Create inserts u. A user without an OrgID gets a personal
+	// organization, created in the same transaction, and owns it.
 	Create(ctx context.Context, u *model.User) error
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
+	// UpdateProfile writes the name, headline and industry of u and returns
+	// sql.ErrNoRows when there is no such user.
+	UpdateProfile(ctx context.Context, u *model.User) error
+	List(ctx context.Context) ([]model.User, error)
+	// Delete erases the user and everything they own, soft-deleted posts
+	// and their personal organization included, in one transaction. Posts
+	// they wrote in a team are erased too. It returns sql.ErrNoRows when
+	// there is no such user.
+	Delete(ctx context.Context, id uuid.UUID) error
 }
 
-type userRepo struct{ db *bun.DB }
+type userRepo struct{ db bun.IDB }
 
-func NewUserRepo(db *bun.DB) UserRepository { return &userRepo{db} }
+func NewUserRepo(db bun.IDB) UserRepository { return &userRepo{db} }
 
 func (r *userRepo) FindByEmail(ctx context.Context, email string) (*model.User, error) {
 	u := new(model.User)
-	err := r.db.NewSelect().Model(u).Where("email = ?", email).Scan(ctx)
+	err := r.db.NewSelect().Model(u).Where("lower(email) = lower(?)", email).Scan(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +49,88 @@ func (r *userRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.User, err
 }
 
 func (r *userRepo) Create(ctx context.Context, u *model.User) error {
-	_, err := r.db.NewInsert().Model(u).Exec(ctx)
+	if u.OrgID != uuid.Nil {
+		_, err := r.db.NewInsert().Model(u).Exec(ctx)
+		return err
+	}
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		org := &model.Organization{ID: uuid.New(), Name: model.PersonalOrgName, Personal: true, CreatedBy: u.ID}
+		if _, err := tx.NewInsert().Model(org).Exec(ctx); err != nil {
+			return err
+		}
+		u.OrgID, u.OrgRole = org.ID, model.OrgRoleOwner
+		_, err := tx.NewInsert().Model(u).Exec(ctx)
+		return err
+	})
+}
+
+func (r *userRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	_, err := r.db.NewUpdate().
+		Model((*model.User)(nil)).
+		Set("password_hash = ?", passwordHash).
+		Where("id = ?", id).
+		Exec(ctx)
 	return err
 }
+
+func (r *userRepo) UpdateProfile(ctx context.Context, u *model.User) error {
+	res, err := r.db.NewUpdate().
+		Model(u).
+		Column("name", "headline", "industry").
+		WherePK().
+		Exec(ctx)
+	return expectOneRow(res, err)
+}
+
+func (r *userRepo) List(ctx context.Context) ([]model.User, error) {
+	var users []model.User
+	err := r.db.NewSelect().Model(&users).Order("created_at ASC").Scan(ctx)
+	return users, err
+}
+
+func (r *userRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		posts := tx.NewSelect().
+			Model((*model.LinkedInPost)(nil)).
+			Column("id").
+			WhereAllWithDeleted().
+			Where("user_id = ?", id)
+		if _, err := tx.NewDelete().
+			Model((*model.PostVersion)(nil)).
+			Where("post_id IN (?)", posts).
+			Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.NewDelete().
+			Model((*model.PostFeedback)(nil)).
+			Where("post_id IN (?)", posts).
+			Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.NewDelete().
+			Model((*model.LinkedInPost)(nil)).
+			Where("user_id = ?", id).
+			ForceDelete().
+			Exec(ctx); err != nil {
+			return err
+		}
+		for _, m := range []any{(*model.RefreshToken)(nil), (*model.PasswordReset)(nil), (*model.APIKey)(nil)} {
+			if _, err := tx.NewDelete().Model(m).Where("user_id = ?", id).Exec(ctx); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.NewDelete().Model((*model.OrgInvitation)(nil)).Where("invited_by = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+		res, err := tx.NewDelete().Model((*model.User)(nil)).Where("id = ?", id).Exec(ctx)
+		if err := expectOneRow(res, err); err != nil {
+			return err
+		}
+		_, err = tx.NewDelete().
+			Model((*model.Organization)(nil)).
+			Where("created_by = ?", id).
+			Where("personal").
+			Exec(ctx)
+		return err
+	})
+}
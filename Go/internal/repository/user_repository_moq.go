@@ -23,12 +23,24 @@ var _ UserRepository = &UserRepositoryMock{}
 //			CreateFunc: func(ctx context.Context, u *model.User) error {
 //				panic("mock out the Create method")
 //			},
+//			DeleteFunc: func(ctx context.Context, id uuid.UUID) error {
+//				panic("mock out the Delete method")
+//			},
 //			FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
 //				panic("mock out the FindByEmail method")
 //			},
 //			FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
 //				panic("mock out the FindByID method")
 //			},
+//			ListFunc: func(ctx context.Context) ([]model.User, error) {
+//				panic("mock out the List method")
+//			},
+//			UpdatePasswordFunc: func(ctx context.Context, id uuid.UUID, passwordHash string) error {
+//				panic("mock out the UpdatePassword method")
+//			},
+//			UpdateProfileFunc: func(ctx context.Context, u *model.User) error {
+//				panic("mock out the UpdateProfile method")
+//			},
 //		}
 //
 //		// use mockedUserRepository in code that requires UserRepository
@@ -39,12 +51,24 @@ type UserRepositoryMock struct {
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, u *model.User) error
 
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, id uuid.UUID) error
+
 	// FindByEmailFunc mocks the FindByEmail method.
 	FindByEmailFunc func(ctx context.Context, email string) (*model.User, error)
 
 	// FindByIDFunc mocks the FindByID method.
 	FindByIDFunc func(ctx context.Context, id uuid.UUID) (*model.User, error)
 
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context) ([]model.User, error)
+
+	// UpdatePasswordFunc mocks the UpdatePassword method.
+	UpdatePasswordFunc func(ctx context.Context, id uuid.UUID, passwordHash string) error
+
+	// UpdateProfileFunc mocks the UpdateProfile method.
+	UpdateProfileFunc func(ctx context.Context, u *model.User) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Create holds details about calls to the Create method.
@@ -54,6 +78,13 @@ type UserRepositoryMock struct {
 			// U is the u argument value.
 			U *model.User
 		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
 		// FindByEmail holds details about calls to the FindByEmail method.
 		FindByEmail []struct {
 			// Ctx is the ctx argument value.
@@ -68,10 +99,35 @@ type UserRepositoryMock struct {
 			// ID is the id argument value.
 			ID uuid.UUID
 		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// UpdatePassword holds details about calls to the UpdatePassword method.
+		UpdatePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// PasswordHash is the passwordHash argument value.
+			PasswordHash string
+		}
+		// UpdateProfile holds details about calls to the UpdateProfile method.
+		UpdateProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// U is the u argument value.
+			U *model.User
+		}
 	}
-	lockCreate      sync.RWMutex
-	lockFindByEmail sync.RWMutex
-	lockFindByID    sync.RWMutex
+	lockCreate         sync.RWMutex
+	lockDelete         sync.RWMutex
+	lockFindByEmail    sync.RWMutex
+	lockFindByID       sync.RWMutex
+	lockList           sync.RWMutex
+	lockUpdatePassword sync.RWMutex
+	lockUpdateProfile  sync.RWMutex
 }
 
 // Create calls CreateFunc.
@@ -110,6 +166,42 @@ func (mock *UserRepositoryMock) CreateCalls() []struct {
 	return calls
 }
 
+// Delete calls DeleteFunc.
+func (mock *UserRepositoryMock) Delete(ctx context.Context, id uuid.UUID) error {
+	if mock.DeleteFunc == nil {
+		panic("UserRepositoryMock.DeleteFunc: method is nil but UserRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, id)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedUserRepository.DeleteCalls())
+func (mock *UserRepositoryMock) DeleteCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
 // FindByEmail calls FindByEmailFunc.
 func (mock *UserRepositoryMock) FindByEmail(ctx context.Context, email string) (*model.User, error) {
 	if mock.FindByEmailFunc == nil {
@@ -181,3 +273,111 @@ func (mock *UserRepositoryMock) FindByIDCalls() []struct {
 	mock.lockFindByID.RUnlock()
 	return calls
 }
+
+// List calls ListFunc.
+func (mock *UserRepositoryMock) List(ctx context.Context) ([]model.User, error) {
+	if mock.ListFunc == nil {
+		panic("UserRepositoryMock.ListFunc: method is nil but UserRepository.List was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedUserRepository.ListCalls())
+func (mock *UserRepositoryMock) ListCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// UpdatePassword calls UpdatePasswordFunc.
+func (mock *UserRepositoryMock) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	if mock.UpdatePasswordFunc == nil {
+		panic("UserRepositoryMock.UpdatePasswordFunc: method is nil but UserRepository.UpdatePassword was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		ID           uuid.UUID
+		PasswordHash string
+	}{
+		Ctx:          ctx,
+		ID:           id,
+		PasswordHash: passwordHash,
+	}
+	mock.lockUpdatePassword.Lock()
+	mock.calls.UpdatePassword = append(mock.calls.UpdatePassword, callInfo)
+	mock.lockUpdatePassword.Unlock()
+	return mock.UpdatePasswordFunc(ctx, id, passwordHash)
+}
+
+// UpdatePasswordCalls gets all the calls that were made to UpdatePassword.
+// Check the length with:
+//
+//	len(mockedUserRepository.UpdatePasswordCalls())
+func (mock *UserRepositoryMock) UpdatePasswordCalls() []struct {
+	Ctx          context.Context
+	ID           uuid.UUID
+	PasswordHash string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		ID           uuid.UUID
+		PasswordHash string
+	}
+	mock.lockUpdatePassword.RLock()
+	calls = mock.calls.UpdatePassword
+	mock.lockUpdatePassword.RUnlock()
+	return calls
+}
+
+// UpdateProfile calls UpdateProfileFunc.
+func (mock *UserRepositoryMock) UpdateProfile(ctx context.Context, u *model.User) error {
+	if mock.UpdateProfileFunc == nil {
+		panic("UserRepositoryMock.UpdateProfileFunc: method is nil but UserRepository.UpdateProfile was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		U   *model.User
+	}{
+		Ctx: ctx,
+		U:   u,
+	}
+	mock.lockUpdateProfile.Lock()
+	mock.calls.UpdateProfile = append(mock.calls.UpdateProfile, callInfo)
+	mock.lockUpdateProfile.Unlock()
+	return mock.UpdateProfileFunc(ctx, u)
+}
+
+// UpdateProfileCalls gets all the calls that were made to UpdateProfile.
+// Check the length with:
+//
+//	len(mockedUserRepository.UpdateProfileCalls())
+func (mock *UserRepositoryMock) UpdateProfileCalls() []struct {
+	Ctx context.Context
+	U   *model.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		U   *model.User
+	}
+	mock.lockUpdateProfile.RLock()
+	calls = mock.calls.UpdateProfile
+	mock.lockUpdateProfile.RUnlock()
+	return calls
+}
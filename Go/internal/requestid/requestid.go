@@ -0,0 +1,21 @@
+// internal/requestid/requestid.go
+
+// Package requestid carries the ID of the request being served in a context,
+// so that packages below the HTTP layer, such as the AI clients, can pass it
+// on without depending on the middleware that assigns it.
+package requestid
+
+import "context"
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the ID stored by NewContext, or "" when there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
@@ -2,56 +2,415 @@
 package router
 
 import (
-	"log"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"unicode/utf8"
 
 	"github.com/Treblle/treblle-go/v2"
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
 
 	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/analytics"
+	"github.com/you/linkedinify/internal/audit"
 	"github.com/you/linkedinify/internal/config"
 	"github.com/you/linkedinify/internal/db"
+	"github.com/you/linkedinify/internal/email"
 	"github.com/you/linkedinify/internal/handler"
+	"github.com/you/linkedinify/internal/linkedin"
+	"github.com/you/linkedinify/internal/metrics"
+	appmw "github.com/you/linkedinify/internal/middleware"
 	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/scheduler"
 	"github.com/you/linkedinify/internal/service"
+	"github.com/you/linkedinify/internal/webhook"
 )
 
-func New(cfg config.Config) *chi.Mux {
-	database := db.New(cfg)
+// New builds the application's routes on top of database, and starts the
+// scheduler publishing scheduled posts, which is nil when publishing is
+// disabled. It also returns the post service, whose drafts of cancelled
+// streams are saved in the background. The caller owns the database handle,
+// the webhook dispatcher and the analytics writer, which are nil when
+// disabled, the scheduler and the post service, and closes them on shutdown.
+func New(cfg config.Config, database *db.DB, webhooks *webhook.Dispatcher, events *analytics.Writer) (*chi.Mux, *scheduler.Scheduler, service.LinkedInServiceInteractor) {
 	userRepo := repository.NewUserRepo(database)
-	postRepo := repository.NewPostRepo(database)
+	postRepo := repository.NewPostRepo(database,
+		repository.WithVersionLimit(cfg.PostVersionLimit),
+		repository.WithReader(database.Reader()),
+	)
+	refreshRepo := repository.NewRefreshTokenRepo(database)
+	resetRepo := repository.NewPasswordResetRepo(database)
+	revokedRepo := repository.NewRevokedTokenRepo(database)
 
-	authSvc := service.NewAuth(userRepo, cfg)
-	aiClient := ai.NewOpenAI(cfg.OpenAIToken)
-	liSvc := service.NewLinkedIn(aiClient, postRepo)
+	mailer := newEmailSender(cfg)
+
+	authOpts := []service.AuthOption{
+		service.WithRefreshTokens(refreshRepo),
+		service.WithPasswordResets(resetRepo, mailer),
+		service.WithLogout(revokedRepo),
+	}
+	if cfg.MaxLoginAttempts > 0 {
+		authOpts = append(authOpts, service.WithLoginLockout(service.NewMemoryLoginAttempts(cfg.MaxLoginAttempts, cfg.LoginLockoutDuration)))
+		slog.Info("login lockout enabled", "max_attempts", cfg.MaxLoginAttempts, "lockout", cfg.LoginLockoutDuration)
+	}
+	if cfg.MaxLoginAttemptsPerEmail > 0 {
+		authOpts = append(authOpts, service.WithEmailLockout(service.NewMemoryLoginAttempts(cfg.MaxLoginAttemptsPerEmail, cfg.LoginLockoutDuration)))
+		slog.Info("per-email login lockout enabled", "max_attempts", cfg.MaxLoginAttemptsPerEmail, "lockout", cfg.LoginLockoutDuration)
+	}
+	authSvc := service.NewAuth(userRepo, cfg, authOpts...)
+	var m *metrics.Metrics
+	if cfg.EnableMetrics {
+		m = metrics.New()
+	}
+	aiClient := newAIClient(cfg)
+	if m != nil {
+		aiClient = ai.NewInstrumentedClient(aiClient, cfg.AIProvider, cfg.AIModel(), m)
+	}
+	warmup := warmUpAI(cfg, aiClient)
+	quotas := service.NewQuotas(userRepo, repository.NewUsageRepo(database), quotaLimits(cfg.MonthlyQuotas))
+	liSvcOpts := append(newLinkedInOptions(cfg),
+		service.WithQuotas(quotas),
+		service.WithProfiles(userRepo),
+		service.WithMaxPostLength(cfg.MaxPostLength),
+		service.WithContextCheck(cfg.AIModel(), cfg.SystemPrompt),
+		service.WithBatchConcurrency(cfg.BatchConcurrency),
+		service.WithBatchItemTimeout(cfg.BatchItemTimeout),
+		service.WithIdempotencyTTL(cfg.IdempotencyTTL),
+		service.WithCursorSecret(cfg.JWTSecret),
+		service.WithSampling(cfg.AITemperature, cfg.AITopP),
+	)
+	if cfg.AITemperature != service.DefaultTemperature && cfg.AITopP != service.DefaultTopP {
+		slog.Warn("both AI_TEMPERATURE and AI_TOP_P are set; tuning one of them is usually enough",
+			"temperature", cfg.AITemperature, "top_p", cfg.AITopP)
+	}
+	if webhooks != nil {
+		liSvcOpts = append(liSvcOpts, service.WithPostEvents(webhooks))
+	}
+	if events != nil {
+		liSvcOpts = append(liSvcOpts, service.WithAnalytics(events))
+	}
+	if cfg.DuplicateThreshold > 0 {
+		liSvcOpts = append(liSvcOpts, service.WithDuplicateCheck(cfg.DuplicateThreshold, cfg.DuplicateWindow))
+	} else {
+		slog.Info("duplicate post check disabled")
+	}
+	if cfg.LinkedInClientID != "" {
+		li := linkedin.New(cfg.LinkedInClientID, cfg.LinkedInClientSecret, cfg.LinkedInRedirectURL)
+		liSvcOpts = append(liSvcOpts, service.WithPublishing(li, repository.NewLinkedInConnectionRepo(database), cfg.JWTSecret))
+		slog.Info("publishing to LinkedIn enabled", "redirect_url", cfg.LinkedInRedirectURL)
+	} else {
+		slog.Info("LINKEDIN_CLIENT_ID not set, publishing to LinkedIn disabled")
+	}
+	liSvc := service.NewLinkedIn(aiClient, postRepo, liSvcOpts...)
+	var jobs *scheduler.Scheduler
+	if cfg.LinkedInClientID != "" {
+		jobs = scheduler.New(postRepo, liSvc,
+			scheduler.WithInterval(cfg.SchedulerInterval),
+			scheduler.WithMaxAttempts(cfg.SchedulerMaxAttempts),
+		)
+		slog.Info("publishing scheduled posts", "interval", cfg.SchedulerInterval)
+	}
+	auditRepo := repository.NewAuditLogRepo(database)
+	adminSvc := service.NewAdmin(userRepo, postRepo, service.WithAuditLog(auditRepo))
+	userSvcOpts := []service.UserOption{
+		service.WithAPIKeys(repository.NewAPIKeyRepo(database)),
+		service.WithUsage(quotas),
+	}
+	if events != nil {
+		userSvcOpts = append(userSvcOpts, service.WithStats(repository.NewAnalyticsRepo(database.Reader())))
+	}
+	userSvc := service.NewUser(userRepo, userSvcOpts...)
+	orgSvc := service.NewOrg(repository.NewOrgRepo(database), userRepo,
+		service.WithInvitations(mailer, cfg.InvitationURL),
+	)
 
 	authH := handler.NewAuth(authSvc)
-	liH := handler.NewLinkedIn(liSvc)
+	liOpts := []handler.LinkedInOption{
+		handler.WithTimeouts(cfg.RequestTimeout, cfg.LongRequestTimeout),
+		handler.WithProvider(cfg.AIProvider),
+	}
+	if cfg.RateLimitPerMinute > 0 {
+		liOpts = append(liOpts, handler.WithRateLimit(appmw.NewMemoryRateLimitStore(cfg.RateLimitPerMinute),
+			appmw.WithRateLimitHeaderPrefix(cfg.RateLimitHeaderPrefix)))
+		slog.Info("rate limiting post generation", "per_user_per_minute", cfg.RateLimitPerMinute)
+	}
+	liH := handler.NewLinkedIn(liSvc, liOpts...)
+	adminH := handler.NewAdmin(adminSvc)
+	userH := handler.NewUser(userSvc)
+	orgH := handler.NewOrg(orgSvc)
+	liAccountH := handler.NewLinkedInAccount(liSvc, handler.WithCallbackURL(cfg.LinkedInRedirectURL))
 
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
-	r.Use(middleware.Compress(5, "gzip"))
+	// Set before mounting anything, so every subrouter inherits them.
+	r.NotFound(handler.NotFound)
+	r.MethodNotAllowed(handler.MethodNotAllowed)
+	// RequestID runs first so the Logger and every handler can see the ID.
+	r.Use(appmw.RequestID)
+	// Everything after reads the client IP, which is only the peer's when
+	// no proxy is trusted.
+	r.Use(appmw.RealIP(cfg.TrustedProxies))
+	if len(cfg.TrustedProxies) > 0 {
+		slog.Info("client IPs taken from forwarding headers of trusted proxies", "proxies", cfg.TrustedProxies)
+	}
+	if m != nil {
+		r.Use(appmw.Metrics(m))
+	}
+	r.Use(appmw.Logger)
+	r.Use(audit.Middleware(auditRepo))
+	r.Use(appmw.CORS(cfg.AllowedOrigins, appmw.WithCORSRateLimitHeaderPrefix(cfg.RateLimitHeaderPrefix)))
+	// Only JSON and text of some size are compressed; the SSE stream must
+	// never be, or tokens would wait in the gzip buffer.
+	r.Use(appmw.Compress(cfg.CompressionLevel, cfg.CompressionMinSize))
 
 	// Initialize and apply Treblle middleware
 	if cfg.TreblleToken != "" && cfg.TreblleAPIKey != "" {
-		treblle.Configure(treblle.Configuration{
-			SDK_TOKEN: cfg.TreblleToken,
-			API_KEY:   cfg.TreblleAPIKey,
-			Debug:     true,
+		treblle.Configure(treblleConfig(cfg))
+		// Treblle buffers the whole response, which would hold back every
+		// token of the SSE endpoint until the stream ends.
+		r.Use(skipPaths(maskHeaders(cfg.TreblleMaskedFields, treblle.Middleware), "/api/v1/posts/stream", "/healthz", "/readyz", "/metrics"))
+		slog.Info("Treblle monitoring enabled")
+	} else {
+		slog.Warn("Treblle monitoring disabled: missing credentials")
+	}
+
+	// Probes live outside /api/v1 so they are neither versioned nor subject
+	// to auth or rate limits.
+	healthH := handler.NewHealth(
+		handler.HealthCheck{Name: "database", Check: database.Ping},
+		handler.HealthCheck{Name: "ai", Check: aiReadyCheck(cfg, warmup)},
+	)
+	r.Get("/healthz", healthH.Healthz)
+	r.Get("/readyz", healthH.Readyz)
+	if m != nil {
+		// Metrics are unauthenticated; keep /metrics behind a network
+		// policy or an authenticating proxy.
+		r.Handle("/metrics", m.Handler())
+		slog.Info("Prometheus metrics enabled", "path", "/metrics")
+	}
+
+	// Breaking changes go into a new version mounted alongside v1, which
+	// keeps working until its sunset.
+	mountVersions(r, cfg.MaxBodyBytes, apiVersion{
+		name:        "v1",
+		deprecation: v1Deprecation(cfg),
+		routes: func(v1 chi.Router) {
+			v1.Get("/openapi.json", handler.OpenAPI)
+			v1.Mount("/auth", authH.Routes())
+			// The user is loaded once per request for the handlers and
+			// services needing more than their ID, which also turns away
+			// tokens of deleted accounts.
+			authOpts := []appmw.AuthOption{appmw.WithRevocationCheck(revokedRepo), appmw.WithIssuer(cfg.JWTIssuer), appmw.WithUserLoader(userRepo)}
+			// API keys are for integrations generating posts; account and
+			// admin routes need a logged in user.
+			v1.Mount("/posts", liH.Routes(cfg.JWTSecret, append(authOpts, appmw.WithAPIKeys(userSvc))...))
+			v1.Mount("/users", userH.Routes(cfg.JWTSecret, authOpts...))
+			v1.Mount("/orgs", orgH.Routes(cfg.JWTSecret, authOpts...))
+			v1.Mount("/linkedin", liAccountH.Routes(cfg.JWTSecret, authOpts...))
+			v1.Mount("/admin", adminH.Routes(cfg.JWTSecret, authOpts...))
+			if cfg.InboundWebhookSecret != "" {
+				// Webhooks are authenticated by their signatures, not by
+				// users.
+				v1.Mount("/webhooks", handler.NewWebhook(cfg.InboundWebhookSecret, cfg.WebhookTolerance).Routes())
+				slog.Info("inbound webhooks enabled", "path", "/api/v1/webhooks/linkedin")
+			}
+		},
+	})
+
+	return r, jobs, liSvc
+}
+
+// quotaLimits converts MONTHLY_QUOTAS into the limits of service.NewQuotas.
+func quotaLimits(quotas map[string]int) map[string]int {
+	limits := make(map[string]int, len(quotas))
+	for plan, n := range quotas {
+		if n == config.QuotaUnlimited {
+			n = service.Unlimited
+		}
+		limits[plan] = n
+	}
+	if len(limits) > 0 {
+		slog.Info("monthly generation quotas enabled", "plans", quotas)
+	}
+	return limits
+}
+
+// newAIClient builds the AI client for the configured provider, warning about
+// models we have not tested against.
+func newAIClient(cfg config.Config) ai.Client {
+	logSystemPrompt(cfg)
+	if cfg.AIProvider == ai.ProviderMock {
+		slog.Warn("using the mock AI provider, posts are canned and not generated",
+			"latency", cfg.MockAILatency, "error_percent", cfg.MockAIErrorPercent)
+		return ai.NewMock(ai.WithLatency(cfg.MockAILatency), ai.WithErrorPercent(cfg.MockAIErrorPercent))
+	}
+	if cfg.AIProvider == ai.ProviderAnthropic {
+		if ai.ModelProvider(cfg.AnthropicModel) != ai.ProviderAnthropic {
+			slog.Warn("ANTHROPIC_MODEL is not a known model, requests may fail", "model", cfg.AnthropicModel)
+		}
+		slog.Info("using Anthropic", "model", cfg.AnthropicModel)
+		return ai.NewAnthropicWithConfig(ai.AnthropicConfig{
+			Token:        cfg.AnthropicToken,
+			Model:        cfg.AnthropicModel,
+			SystemPrompt: cfg.SystemPrompt,
 		})
-		r.Use(treblle.Middleware)
-		log.Println("✓ Treblle monitoring enabled")
+	}
+
+	if ai.ModelProvider(cfg.OpenAIModel) != ai.ProviderOpenAI {
+		slog.Warn("OPENAI_MODEL is not a known model, requests may fail", "model", cfg.OpenAIModel)
+	}
+	slog.Info("using OpenAI", "model", cfg.OpenAIModel)
+	oc := openAIConfig(cfg)
+	oc.Model = cfg.OpenAIModel
+	oc.SystemPrompt = cfg.SystemPrompt
+	return ai.NewOpenAIWithConfig(oc)
+}
+
+// logSystemPrompt reports the system prompt generations use, which is paid
+// for on every one of them.
+func logSystemPrompt(cfg config.Config) {
+	prompt, source := cfg.SystemPrompt, "SYSTEM_PROMPT"
+	switch {
+	case prompt == "":
+		prompt, source = ai.DefaultSystemPrompt, "default"
+	case cfg.SystemPromptFile != "":
+		source = cfg.SystemPromptFile
+	}
+	slog.Info("system prompt", "source", source, "characters", utf8.RuneCountInString(prompt), "estimated_tokens", ai.EstimateTokens(prompt, cfg.AIModel()))
+}
+
+// openAIConfig holds the OpenAI keys and retry settings shared by every
+// OpenAI-backed client.
+func openAIConfig(cfg config.Config) ai.OpenAIConfig {
+	maxRetries := cfg.OpenAIMaxRetries
+	if maxRetries == 0 {
+		// To the ai package zero means DefaultMaxRetries.
+		maxRetries = -1
+	}
+	return ai.OpenAIConfig{
+		Token:          cfg.OpenAIToken,
+		Tokens:         cfg.OpenAITokens,
+		KeyCooldown:    cfg.OpenAIKeyCooldown,
+		MaxRetries:     maxRetries,
+		RetryBaseDelay: cfg.OpenAIRetryBaseDelay,
+	}
+}
+
+// newLinkedInOptions configures caching, moderation, the input filter, image
+// generation and custom prompt templates for the LinkedIn service.
+func newLinkedInOptions(cfg config.Config) []service.LinkedInOption {
+	var opts []service.LinkedInOption
+	switch {
+	case !cfg.EnableModeration:
+		slog.Info("content moderation disabled")
+	case cfg.OpenAIToken == "" && len(cfg.OpenAITokens) == 0:
+		slog.Warn("ENABLE_MODERATION needs an OpenAI key, content moderation disabled")
+	default:
+		opts = append(opts, service.WithModeration(ai.NewOpenAIModerator(openAIConfig(cfg))))
+		slog.Info("content moderation enabled")
+	}
+	if cfg.EnableInputFilter {
+		opts = append(opts, service.WithInputFilter(newInputFilter(cfg)))
+	}
+	if len(cfg.PostProcessors) > 0 {
+		processors, err := service.PostProcessorsByName(cfg.PostProcessors)
+		if err != nil {
+			slog.Error("invalid POST_PROCESSORS", "error", err)
+			os.Exit(1)
+		}
+		opts = append(opts, service.WithPostProcessors(processors...))
+		slog.Info("post processors enabled", "processors", cfg.PostProcessors)
+	}
+	if cfg.OpenAIToken == "" && len(cfg.OpenAITokens) == 0 {
+		slog.Warn("image generation needs an OpenAI key, POST /posts/{id}/image disabled")
 	} else {
-		log.Println("⚠ Treblle monitoring disabled - missing credentials")
+		opts = append(opts, service.WithImages(ai.NewOpenAIImageGenerator(openAIConfig(cfg)), service.ImageOptions{Size: cfg.ImageSize, Timeout: cfg.ImageTimeout}))
 	}
+	if cfg.AICacheEnabled {
+		opts = append(opts, service.WithCache(ai.NewLRUCache(cfg.AICacheSize, cfg.AICacheTTL)))
+	} else {
+		slog.Info("AI response cache disabled")
+		opts = append(opts, service.WithCache(nil))
+	}
+	if cfg.PromptTemplatesDir == "" {
+		return opts
+	}
+	templates, err := service.LoadPromptTemplates(os.DirFS(cfg.PromptTemplatesDir))
+	if err != nil {
+		slog.Error("loading PROMPT_TEMPLATES_DIR failed", "dir", cfg.PromptTemplatesDir, "error", err)
+		os.Exit(1)
+	}
+	slog.Info("loaded prompt templates", "templates", templates.Names(), "dir", cfg.PromptTemplatesDir)
+	return append(opts, service.WithPromptTemplates(templates))
+}
+
+// newInputFilter builds the filter screening the text of posts, exiting when
+// INPUT_BLOCKLIST_FILE cannot be loaded.
+func newInputFilter(cfg config.Config) *service.InputFilter {
+	var moderator ai.Moderator
+	switch {
+	case !cfg.InputFilterModeration:
+	case cfg.OpenAIToken == "" && len(cfg.OpenAITokens) == 0:
+		slog.Warn("INPUT_FILTER_MODERATION needs an OpenAI key, screening text with the blocklist only")
+	default:
+		moderator = ai.NewOpenAIModerator(openAIConfig(cfg))
+	}
+	if cfg.InputBlocklistFile == "" {
+		slog.Info("input filter enabled", "blocklist", "built-in", "moderation", moderator != nil)
+		return service.NewInputFilter(service.DefaultBlocklist(), moderator)
+	}
+	f, err := service.NewFileInputFilter(cfg.InputBlocklistFile, moderator)
+	if err != nil {
+		slog.Error("loading INPUT_BLOCKLIST_FILE failed", "file", cfg.InputBlocklistFile, "error", err)
+		os.Exit(1)
+	}
+	slog.Info("input filter enabled", "blocklist", cfg.InputBlocklistFile, "moderation", moderator != nil)
+	return f
+}
 
-	// Create API v1 router
-	v1Router := chi.NewRouter()
-	v1Router.Mount("/auth", authH.Routes())
-	v1Router.Mount("/posts", liH.Routes(cfg.JWTSecret))
+// aiCredentialsCheck verifies the configured AI provider has an API key. It
+// does not call the provider, so probes cost nothing.
+func aiCredentialsCheck(cfg config.Config) func(context.Context) error {
+	return func(context.Context) error {
+		if cfg.AIProvider == ai.ProviderMock {
+			return nil
+		}
+		token := cfg.OpenAIToken
+		if len(cfg.OpenAITokens) > 0 {
+			token = cfg.OpenAITokens[0]
+		}
+		if cfg.AIProvider == ai.ProviderAnthropic {
+			token = cfg.AnthropicToken
+		}
+		if token == "" {
+			return errors.New(cfg.AIProvider + " API key is not configured")
+		}
+		return nil
+	}
+}
 
-	// Mount v1 router under /api/v1
-	r.Mount("/api/v1", v1Router)
+// newEmailSender delivers over SMTP when configured and logs emails otherwise.
+func newEmailSender(cfg config.Config) service.EmailSender {
+	if cfg.SMTPHost == "" {
+		slog.Warn("SMTP_HOST not set, emails will be written to the log")
+		return email.NewLogSender()
+	}
+	return email.NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+}
 
-	return r
+// skipPaths applies mw to every request except those for the given paths.
+func skipPaths(mw func(http.Handler) http.Handler, paths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := mw(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, p := range paths {
+				if r.URL.Path == p {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			wrapped.ServeHTTP(w, r)
+		})
+	}
 }
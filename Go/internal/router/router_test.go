@@ -0,0 +1,15 @@
+// internal/router/router_test.go
+package router
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/you/linkedinify/internal/config"
+)
+
+func TestOpenAIConfig_MaxRetries(t *testing.T) {
+	assert.Equal(t, 3, openAIConfig(config.Config{OpenAIMaxRetries: 3}).MaxRetries)
+	assert.Negative(t, openAIConfig(config.Config{OpenAIMaxRetries: 0}).MaxRetries, "OPENAI_MAX_RETRIES=0 disables retries")
+}
@@ -0,0 +1,58 @@
+// internal/router/treblle.go
+package router
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Treblle/treblle-go/v2"
+
+	"github.com/you/linkedinify/internal/config"
+)
+
+// maskedValue is what the Treblle SDK puts in place of masked body fields.
+const maskedValue = "*********"
+
+// treblleConfig builds the Treblle SDK configuration. The SDK matches field
+// names in lower case, so the masked fields are lowered here.
+func treblleConfig(cfg config.Config) treblle.Configuration {
+	masked := make([]string, len(cfg.TreblleMaskedFields))
+	for i, f := range cfg.TreblleMaskedFields {
+		masked[i] = strings.ToLower(f)
+	}
+	return treblle.Configuration{
+		SDK_TOKEN:              cfg.TreblleToken,
+		API_KEY:                cfg.TreblleAPIKey,
+		AdditionalFieldsToMask: masked,
+		Debug:                  cfg.Debug,
+	}
+}
+
+type originalHeaderKey struct{}
+
+// maskHeaders runs mw on a copy of the request whose headers named in fields
+// are masked, and hands the request with its real headers on to next. The
+// Treblle SDK masks body fields but reports request headers verbatim, which
+// would leak bearer tokens.
+func maskHeaders(fields []string, mw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		inner := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if h, ok := r.Context().Value(originalHeaderKey{}).(http.Header); ok {
+				r.Header = h
+			}
+			next.ServeHTTP(w, r)
+		}))
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			masked := r.Header.Clone()
+			for _, f := range fields {
+				if masked.Get(f) != "" {
+					masked.Set(f, maskedValue)
+				}
+			}
+			r = r.WithContext(context.WithValue(r.Context(), originalHeaderKey{}, r.Header))
+			r.Header = masked
+			inner.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// internal/router/treblle_test.go
+package router
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Treblle/treblle-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/config"
+)
+
+// TestTreblle_MasksCredentials sends a login through the Treblle middleware
+// and checks the payload reaching Treblle contains none of the credentials.
+func TestTreblle_MasksCredentials(t *testing.T) {
+	t.Setenv("GO_ENV", "")
+	payloads := make(chan []byte, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		payloads <- body
+	}))
+	defer collector.Close()
+
+	cfg := config.Load()
+	cfg.TreblleToken, cfg.TreblleAPIKey = "sdk-token", "api-key"
+	tc := treblleConfig(cfg)
+	tc.Endpoint = collector.URL
+	treblle.Configure(tc)
+
+	var seenAuth string
+	login := maskHeaders(cfg.TreblleMaskedFields, treblle.Middleware)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token":"issued-access-token","refresh_token":"issued-refresh-token"}`))
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login",
+		bytes.NewBufferString(`{"email":"user@example.com","password":"correct-horse-battery"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer old-access-token")
+	login.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "Bearer old-access-token", seenAuth, "The API must still see the real header")
+
+	var payload []byte
+	select {
+	case payload = <-payloads:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Treblle payload was not sent")
+	}
+	require.Contains(t, string(payload), "user@example.com", "The payload should carry the request body")
+	for _, secret := range []string{"correct-horse-battery", "issued-access-token", "issued-refresh-token", "old-access-token"} {
+		assert.NotContains(t, string(payload), secret)
+	}
+}
@@ -0,0 +1,45 @@
+// internal/router/versions.go
+package router
+
+import (
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/you/linkedinify/internal/config"
+	appmw "github.com/you/linkedinify/internal/middleware"
+)
+
+// apiVersion is one version of the API, served under /api/<name>.
+type apiVersion struct {
+	name string
+	// deprecation is announced on every response of the version; the zero
+	// value leaves it current.
+	deprecation appmw.Deprecation
+	// routes registers the endpoints of the version.
+	routes func(r chi.Router)
+}
+
+// mountVersions serves every version under /api/<name>, side by side. Each
+// one gets its own router with the middleware all versions share, so a new
+// version only has to register its routes.
+func mountVersions(r chi.Router, maxBodyBytes int64, versions ...apiVersion) {
+	for _, v := range versions {
+		vr := chi.NewRouter()
+		vr.Use(appmw.Deprecated(v.deprecation))
+		// Handlers read whole JSON bodies, so a huge one would tie up
+		// memory; inbound webhooks have a tighter limit of their own.
+		vr.Use(appmw.MaxBodyBytes(maxBodyBytes))
+		v.routes(vr)
+		r.Mount("/api/"+v.name, vr)
+		if !v.deprecation.At.IsZero() {
+			slog.Warn("API version is deprecated", "version", v.name, "deprecated_at", v.deprecation.At, "sunset_at", v.deprecation.Sunset)
+		}
+	}
+}
+
+// v1Deprecation is the deprecation of /api/v1 configured with
+// API_V1_DEPRECATED_AT, API_V1_SUNSET_AT and API_V1_DEPRECATION_LINK.
+func v1Deprecation(cfg config.Config) appmw.Deprecation {
+	return appmw.Deprecation{At: cfg.V1DeprecatedAt, Sunset: cfg.V1SunsetAt, Link: cfg.V1DeprecationLink}
+}
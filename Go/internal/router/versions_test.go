@@ -0,0 +1,38 @@
+// internal/router/versions_test.go
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+
+	appmw "github.com/you/linkedinify/internal/middleware"
+)
+
+func TestMountVersions(t *testing.T) {
+	ping := func(r chi.Router) {
+		r.Get("/ping", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	}
+	r := chi.NewRouter()
+	mountVersions(r, 0,
+		apiVersion{name: "v1", deprecation: appmw.Deprecation{At: time.Unix(1780272000, 0)}, routes: ping},
+		apiVersion{name: "v2", routes: ping},
+	)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr
+	}
+	v1 := get("/api/v1/ping")
+	assert.Equal(t, http.StatusNoContent, v1.Code, "A deprecated version keeps working")
+	assert.Equal(t, "@1780272000", v1.Header().Get("Deprecation"))
+
+	v2 := get("/api/v2/ping")
+	assert.Equal(t, http.StatusNoContent, v2.Code)
+	assert.Empty(t, v2.Header().Get("Deprecation"), "Only the deprecated version is marked")
+}
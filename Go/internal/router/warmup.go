@@ -0,0 +1,97 @@
+// internal/router/warmup.go
+package router
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/config"
+)
+
+// warmupRetryInterval is how often /readyz pings the provider again after
+// the startup ping failed; probes in between report the last failure.
+const warmupRetryInterval = 30 * time.Second
+
+// aiWarmup remembers whether the AI provider answered the startup ping and,
+// until it does, retries it from the readiness probe.
+type aiWarmup struct {
+	client ai.Client
+	now    func() time.Time
+
+	mu   sync.Mutex
+	err  error
+	last time.Time
+}
+
+// warmUpAI pings the AI provider before the server starts, bounded by
+// WARMUP_AI_TIMEOUT. A failure exits with WARMUP_AI_STRICT and otherwise
+// starts the server degraded, not ready until a later ping succeeds. It
+// returns nil when WARMUP_AI is off.
+func warmUpAI(cfg config.Config, client ai.Client) *aiWarmup {
+	if !cfg.WarmupAI {
+		return nil
+	}
+	w := &aiWarmup{client: client, now: time.Now}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.WarmupAITimeout)
+	defer cancel()
+	start := time.Now()
+	err := w.ping(ctx)
+	switch {
+	case err == nil:
+		slog.Info("AI provider is reachable", "provider", cfg.AIProvider, "duration", time.Since(start))
+	case cfg.WarmupAIStrict:
+		slog.Error("AI provider warm-up failed, exiting (WARMUP_AI_STRICT=true)", "provider", cfg.AIProvider, "error", err)
+		os.Exit(1)
+	default:
+		slog.Warn("AI provider warm-up failed, starting degraded: /readyz reports not ready until the provider answers", "provider", cfg.AIProvider, "error", err)
+	}
+	return w
+}
+
+// ping pings the provider and records the outcome.
+func (w *aiWarmup) ping(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.pingLocked(ctx)
+}
+
+func (w *aiWarmup) pingLocked(ctx context.Context) error {
+	w.err = ai.Ping(ctx, w.client)
+	w.last = w.now()
+	return w.err
+}
+
+// Check reports whether the provider has answered a ping. Once it has, it
+// never pings again, so probes stay free; until then it retries at most
+// every warmupRetryInterval.
+func (w *aiWarmup) Check(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err == nil || w.now().Sub(w.last) < warmupRetryInterval {
+		return w.err
+	}
+	if err := w.pingLocked(ctx); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "AI provider is reachable, no longer degraded")
+	return nil
+}
+
+// aiReadyCheck is the "ai" readiness check: the provider must have an API
+// key and, with WARMUP_AI, have answered a ping.
+func aiReadyCheck(cfg config.Config, warmup *aiWarmup) func(context.Context) error {
+	credentials := aiCredentialsCheck(cfg)
+	return func(ctx context.Context) error {
+		if err := credentials(ctx); err != nil {
+			return err
+		}
+		if warmup == nil {
+			return nil
+		}
+		return warmup.Check(ctx)
+	}
+}
@@ -0,0 +1,47 @@
+// internal/router/warmup_test.go
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+// pingClient is an ai.Client whose Ping returns err.
+type pingClient struct {
+	ai.ClientMock
+	err   error
+	pings int
+}
+
+func (c *pingClient) Ping(context.Context) error {
+	c.pings++
+	return c.err
+}
+
+func TestAIWarmup_Check(t *testing.T) {
+	now := time.Unix(1780272000, 0)
+	client := &pingClient{err: errors.New("connection refused")}
+	w := &aiWarmup{client: client, now: func() time.Time { return now }}
+	ctx := context.Background()
+
+	require.Error(t, w.ping(ctx))
+	assert.EqualError(t, w.Check(ctx), "connection refused")
+	assert.Equal(t, 1, client.pings, "Probes right after a failed ping report it without pinging")
+
+	client.err = nil
+	now = now.Add(warmupRetryInterval)
+	assert.NoError(t, w.Check(ctx))
+	assert.Equal(t, 2, client.pings)
+
+	client.err = errors.New("connection refused")
+	now = now.Add(time.Hour)
+	assert.NoError(t, w.Check(ctx), "Once warm, the provider is not pinged again")
+	assert.Equal(t, 2, client.pings)
+}
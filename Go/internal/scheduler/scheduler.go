@@ -0,0 +1,235 @@
+// internal/scheduler/scheduler.go
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// Defaults used unless overridden with an Option.
+const (
+	DefaultInterval    = 30 * time.Second
+	DefaultBatchSize   = 10
+	DefaultMaxAttempts = 5
+	DefaultBackoff     = time.Minute
+	// DefaultLease is how long a claimed post is left to the instance that
+	// claimed it. An instance that dies mid-publish holds its posts this
+	// long before another one picks them up.
+	DefaultLease = 5 * time.Minute
+	// publishTimeout bounds one attempt; it is well under the lease, so a
+	// post is never published by two instances at once.
+	publishTimeout = time.Minute
+)
+
+// Publisher publishes a post to the LinkedIn feed of userID.
+// *service.LinkedInService is one.
+type Publisher interface {
+	Publish(ctx context.Context, userID, postID uuid.UUID) (*model.LinkedInPost, error)
+}
+
+// Scheduler publishes scheduled posts when they are due. Every instance of
+// the API runs one; each poll claims a batch of due posts in the database,
+// so instances never publish the same post twice. Failed attempts are
+// retried with exponential backoff until the post runs out of attempts and
+// is marked model.ScheduleStatusFailed.
+type Scheduler struct {
+	posts       repository.PostRepository
+	publisher   Publisher
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	backoff     time.Duration
+	lease       time.Duration
+
+	ctx    context.Context // cancelled when Close gives up waiting
+	cancel context.CancelFunc
+	stop   chan struct{}
+	once   sync.Once
+	done   chan struct{}
+}
+
+// Option configures optional Scheduler behaviour.
+type Option func(*Scheduler)
+
+// WithInterval sets how often the database is polled for due posts.
+func WithInterval(d time.Duration) Option {
+	return func(s *Scheduler) { s.interval = d }
+}
+
+// WithBatchSize sets how many due posts are claimed per poll.
+func WithBatchSize(n int) Option {
+	return func(s *Scheduler) { s.batchSize = n }
+}
+
+// WithMaxAttempts sets how often a post is tried before it is marked
+// failed.
+func WithMaxAttempts(n int) Option {
+	return func(s *Scheduler) { s.maxAttempts = n }
+}
+
+// WithBackoff sets the wait before the first retry; it doubles on each
+// further attempt.
+func WithBackoff(b time.Duration) Option {
+	return func(s *Scheduler) { s.backoff = b }
+}
+
+// WithLease sets how long a claimed post is left to this instance. It must
+// be longer than an attempt can take.
+func WithLease(d time.Duration) Option {
+	return func(s *Scheduler) { s.lease = d }
+}
+
+// New starts a Scheduler publishing the due posts of posts through
+// publisher. Call Close to stop it on shutdown.
+func New(posts repository.PostRepository, publisher Publisher, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		posts:       posts,
+		publisher:   publisher,
+		interval:    DefaultInterval,
+		batchSize:   DefaultBatchSize,
+		maxAttempts: DefaultMaxAttempts,
+		backoff:     DefaultBackoff,
+		lease:       DefaultLease,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	go s.run()
+	return s
+}
+
+// Close stops polling and waits until the posts being published are done
+// or ctx is done. Attempts still running then are cancelled and their posts
+// put back in the queue, to be published by the next instance to poll.
+func (s *Scheduler) Close(ctx context.Context) error {
+	s.once.Do(func() { close(s.stop) })
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-s.done
+		return fmt.Errorf("scheduler: publishing cancelled: %w", ctx.Err())
+	}
+}
+
+func (s *Scheduler) run() {
+	defer close(s.done)
+	t := time.NewTicker(s.interval)
+	defer t.Stop()
+	for {
+		s.poll()
+		select {
+		case <-t.C:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// poll publishes the posts due now, a batch at a time, until none are left
+// or the scheduler is stopped.
+func (s *Scheduler) poll() {
+	for {
+		posts, err := s.posts.ClaimDue(s.ctx, time.Now(), s.lease, s.batchSize)
+		if err != nil {
+			slog.Warn("claiming scheduled posts failed", "error", err)
+			return
+		}
+		for _, post := range posts {
+			s.publish(&post)
+		}
+		if len(posts) < s.batchSize || s.stopped() {
+			return
+		}
+	}
+}
+
+func (s *Scheduler) stopped() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// publish makes one attempt at publishing a claimed post and records the
+// outcome on it.
+func (s *Scheduler) publish(post *model.LinkedInPost) {
+	log := slog.With("post_id", post.ID, "attempt", post.ScheduleAttempts)
+	var err error
+	switch {
+	case s.stopped():
+		// Claimed in the batch before Close; leave it to another instance.
+		err = context.Canceled
+	case post.ScheduleAttempts > s.maxAttempts:
+		// Its earlier claims expired, so an instance died publishing it,
+		// possibly because of it.
+		err = errors.New("abandoned by the instances publishing it")
+	default:
+		ctx, cancel := context.WithTimeout(s.ctx, publishTimeout)
+		_, err = s.publisher.Publish(ctx, post.ScheduledBy, post.ID)
+		cancel()
+	}
+
+	now := time.Now()
+	switch {
+	case err == nil || errors.Is(err, service.ErrAlreadyPublished):
+		post.ScheduleStatus = model.ScheduleStatusPublished
+		post.ScheduleError = ""
+		post.NextAttemptAt = time.Time{}
+		log.Info("published scheduled post")
+	case errors.Is(err, context.Canceled) && (s.stopped() || s.ctx.Err() != nil):
+		// Shutting down: requeue the post without counting the attempt.
+		post.ScheduleStatus = model.ScheduleStatusScheduled
+		post.ScheduleAttempts--
+		post.NextAttemptAt = now
+		log.Info("requeued scheduled post on shutdown")
+	case errors.Is(err, service.ErrPublishInProgress):
+		// Claimed by its author publishing it by hand. Look again once they
+		// are done, without counting the attempt against the post.
+		post.ScheduleStatus = model.ScheduleStatusScheduled
+		post.ScheduleAttempts--
+		post.ScheduleError = err.Error()
+		post.NextAttemptAt = now.Add(s.backoff)
+		log.Info("scheduled post is being published by hand, will check again", "next_attempt_at", post.NextAttemptAt)
+	case permanent(err) || post.ScheduleAttempts >= s.maxAttempts:
+		post.ScheduleStatus = model.ScheduleStatusFailed
+		post.ScheduleError = err.Error()
+		post.NextAttemptAt = time.Time{}
+		log.Warn("publishing scheduled post failed", "error", err)
+	default:
+		post.ScheduleStatus = model.ScheduleStatusScheduled
+		post.ScheduleError = err.Error()
+		post.NextAttemptAt = now.Add(s.backoff << (post.ScheduleAttempts - 1))
+		log.Warn("publishing scheduled post failed, will retry", "error", err, "next_attempt_at", post.NextAttemptAt)
+	}
+	// Recorded even once Close has given up, or the post would stay
+	// claimed until its lease runs out.
+	if err := s.posts.UpdateSchedule(context.WithoutCancel(s.ctx), post); err != nil {
+		log.Warn("recording scheduled post outcome failed", "status", post.ScheduleStatus, "error", err)
+	}
+}
+
+// permanent reports whether err fails every attempt alike, so retrying is
+// pointless.
+func permanent(err error) bool {
+	return errors.Is(err, service.ErrPostNotFound) ||
+		errors.Is(err, service.ErrLinkedInNotConnected) ||
+		errors.Is(err, service.ErrLinkedInReconnect) ||
+		errors.Is(err, service.ErrPublishingDisabled)
+}
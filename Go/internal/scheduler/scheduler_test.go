@@ -0,0 +1,187 @@
+// internal/scheduler/scheduler_test.go
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/scheduler"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// duePosts is a post repository handing out post, claimed, on the first
+// poll and recording the outcomes written back.
+type duePosts struct {
+	repository.PostRepositoryMock
+	mu       sync.Mutex
+	outcomes []model.LinkedInPost
+	updated  chan struct{}
+}
+
+func newDuePosts(post model.LinkedInPost) *duePosts {
+	p := &duePosts{updated: make(chan struct{}, 1)}
+	claimed := false
+	p.ClaimDueFunc = func(_ context.Context, _ time.Time, _ time.Duration, _ int) ([]model.LinkedInPost, error) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if claimed {
+			return nil, nil
+		}
+		claimed = true
+		post.ScheduleStatus = model.ScheduleStatusPublishing
+		post.ScheduleAttempts++
+		return []model.LinkedInPost{post}, nil
+	}
+	p.UpdateScheduleFunc = func(_ context.Context, post *model.LinkedInPost) error {
+		p.mu.Lock()
+		p.outcomes = append(p.outcomes, *post)
+		p.mu.Unlock()
+		p.updated <- struct{}{}
+		return nil
+	}
+	return p
+}
+
+func scheduledPost(attempts int) model.LinkedInPost {
+	return model.LinkedInPost{
+		ID:               uuid.New(),
+		UserID:           uuid.New(),
+		ScheduledBy:      uuid.New(),
+		ScheduleStatus:   model.ScheduleStatusScheduled,
+		ScheduleAttempts: attempts,
+	}
+}
+
+func publisher(err error) *service.LinkedInServiceInteractorMock {
+	return &service.LinkedInServiceInteractorMock{
+		PublishFunc: func(ctx context.Context, userID, postID uuid.UUID) (*model.LinkedInPost, error) {
+			return nil, err
+		},
+	}
+}
+
+func TestScheduler_Outcomes(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		err      error
+		status   string
+		retry    bool
+		// uncounted is set when the attempt is not counted against the post.
+		uncounted bool
+	}{
+		{name: "published", err: nil, status: model.ScheduleStatusPublished},
+		{name: "published meanwhile", err: service.ErrAlreadyPublished, status: model.ScheduleStatusPublished},
+		{name: "retried", err: errors.New("linkedin: 503"), status: model.ScheduleStatusScheduled, retry: true},
+		{name: "out of attempts", attempts: 2, err: errors.New("linkedin: 503"), status: model.ScheduleStatusFailed},
+		{name: "permanent", err: service.ErrLinkedInReconnect, status: model.ScheduleStatusFailed},
+		{name: "published by hand meanwhile", attempts: 2, err: service.ErrPublishInProgress, status: model.ScheduleStatusScheduled, retry: true, uncounted: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts := newDuePosts(scheduledPost(tt.attempts))
+			pub := publisher(tt.err)
+			start := time.Now()
+			s := scheduler.New(posts, pub, scheduler.WithMaxAttempts(3), scheduler.WithBackoff(time.Minute))
+			<-posts.updated
+			require.NoError(t, s.Close(context.Background()))
+
+			require.Len(t, pub.PublishCalls(), 1)
+			require.Len(t, posts.outcomes, 1)
+			got := posts.outcomes[0]
+			assert.Equal(t, scheduler.DefaultBatchSize, posts.ClaimDueCalls()[0].Limit)
+			assert.Equal(t, got.ScheduledBy, pub.PublishCalls()[0].UserID, "Published to the feed of whoever scheduled it")
+			assert.Equal(t, tt.status, got.ScheduleStatus)
+			if tt.err != nil && tt.status != model.ScheduleStatusPublished {
+				assert.Equal(t, tt.err.Error(), got.ScheduleError)
+			}
+			if tt.uncounted {
+				assert.Equal(t, tt.attempts, got.ScheduleAttempts)
+			}
+			if tt.retry {
+				assert.WithinDuration(t, start.Add(time.Minute), got.NextAttemptAt, 5*time.Second)
+			} else {
+				assert.True(t, got.NextAttemptAt.IsZero())
+			}
+		})
+	}
+}
+
+func TestScheduler_BackoffDoubles(t *testing.T) {
+	posts := newDuePosts(scheduledPost(2))
+	start := time.Now()
+	s := scheduler.New(posts, publisher(errors.New("timeout")), scheduler.WithBackoff(time.Minute))
+	<-posts.updated
+	require.NoError(t, s.Close(context.Background()))
+
+	assert.WithinDuration(t, start.Add(4*time.Minute), posts.outcomes[0].NextAttemptAt, 5*time.Second, "The third attempt waits 4 times the backoff")
+}
+
+func TestScheduler_AbandonedClaims(t *testing.T) {
+	posts := newDuePosts(scheduledPost(scheduler.DefaultMaxAttempts))
+	pub := publisher(nil)
+	s := scheduler.New(posts, pub)
+	<-posts.updated
+	require.NoError(t, s.Close(context.Background()))
+
+	assert.Empty(t, pub.PublishCalls(), "A post whose claims kept expiring is not tried again")
+	assert.Equal(t, model.ScheduleStatusFailed, posts.outcomes[0].ScheduleStatus)
+}
+
+func TestScheduler_CloseRequeuesInterruptedPublish(t *testing.T) {
+	posts := newDuePosts(scheduledPost(0))
+	publishing := make(chan struct{})
+	pub := &service.LinkedInServiceInteractorMock{
+		PublishFunc: func(ctx context.Context, userID, postID uuid.UUID) (*model.LinkedInPost, error) {
+			close(publishing)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	s := scheduler.New(posts, pub)
+	<-publishing
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.Close(ctx), context.DeadlineExceeded)
+
+	require.Len(t, posts.outcomes, 1)
+	got := posts.outcomes[0]
+	assert.Equal(t, model.ScheduleStatusScheduled, got.ScheduleStatus)
+	assert.Zero(t, got.ScheduleAttempts, "The interrupted attempt is not counted")
+	assert.Empty(t, got.ScheduleError)
+}
+
+func TestScheduler_CloseWaitsForPublish(t *testing.T) {
+	posts := newDuePosts(scheduledPost(0))
+	publishing, release := make(chan struct{}), make(chan struct{})
+	pub := &service.LinkedInServiceInteractorMock{
+		PublishFunc: func(ctx context.Context, userID, postID uuid.UUID) (*model.LinkedInPost, error) {
+			close(publishing)
+			<-release
+			return &model.LinkedInPost{ID: postID}, nil
+		},
+	}
+	s := scheduler.New(posts, pub)
+	<-publishing
+
+	closed := make(chan error)
+	go func() { closed <- s.Close(context.Background()) }()
+	select {
+	case <-closed:
+		t.Fatal("Close returned while a post was being published")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	require.NoError(t, <-closed)
+	assert.Equal(t, model.ScheduleStatusPublished, posts.outcomes[0].ScheduleStatus)
+}
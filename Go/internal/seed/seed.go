@@ -0,0 +1,175 @@
+// internal/seed/seed.go
+
+// Package seed fills a development database with users and posts to try the
+// API with.
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// Password is the password of every seeded user.
+const Password = "Linkedinify-dev-1"
+
+// Emails of the seeded users: a regular user with sample posts and an admin.
+const (
+	UserEmail  = "ada@example.com"
+	AdminEmail = "admin@example.com"
+)
+
+// namespace derives the IDs of seeded rows, so a second run finds them.
+var namespace = uuid.MustParse("6f1c7a52-0d8e-4b7e-9a35-2f7d4c1e8b90")
+
+type account struct {
+	email, role              string
+	name, headline, industry string
+	posts                    []samplePost
+}
+
+type samplePost struct {
+	template, tone, length, status string
+	input, output                  string
+}
+
+var accounts = []account{
+	{
+		email: UserEmail, role: model.RoleUser,
+		name: "Ada Lovelace", headline: "Analytical engine programmer", industry: "Software",
+		posts: []samplePost{
+			{
+				template: "default", tone: "professional", length: "short", status: model.PostStatusFinal,
+				input:  "fixed a bug today",
+				output: "Today I was reminded that every bug is a lesson in disguise. 🐛\n\nAfter hours of digging, the fix was a single line. The real win? A deeper understanding of the system — and a test that makes sure it never comes back.\n\nWhat's the smallest fix that taught you the most?",
+			},
+			{
+				template: "announcement", tone: "inspirational", length: "medium", status: model.PostStatusFinal,
+				input:  "our team shipped the new billing page",
+				output: "I'm thrilled to share that our team just shipped the new billing page! 🚀\n\nIt took weeks of collaboration between design, engineering and support, and every one of those conversations made it better. Clearer invoices, fewer clicks, happier customers.\n\nProud of what we build when we build it together.",
+			},
+			{
+				template: "thought-leadership", tone: "casual", length: "short", status: model.PostStatusDraft,
+				input:  "meetings could be emails",
+				output: "Hot take: half of my meetings this week could have been a well-written email. ✉️\n\nAsync isn't about avoiding people. It's about respecting their focus.\n\nWhat would you cancel first?",
+			},
+			{
+				template: "job-update", tone: "humorous", length: "short", status: model.PostStatusDraft,
+				input:  "started a new job",
+				output: "New job, new badge photo that I will regret for years. 📸\n\nExcited to start this chapter, learn from brilliant people and find out where the good coffee is.\n\nHere's to the next adventure!",
+			},
+		},
+	},
+	{
+		email: AdminEmail, role: model.RoleAdmin,
+		name: "Grace Hopper", headline: "Compiler pioneer", industry: "Software",
+	},
+}
+
+// Result counts the rows a Run created.
+type Result struct {
+	Users int
+	Posts int
+}
+
+// Run creates the seeded users and their posts, skipping any that exist
+// already, so it can be run repeatedly. A seeded post that was deleted is
+// restored. Passwords are hashed like at signup, so the users can log in
+// with Password.
+func Run(ctx context.Context, users repository.UserRepository, posts repository.PostRepository) (Result, error) {
+	var res Result
+	for _, a := range accounts {
+		u, created, err := ensureUser(ctx, users, a)
+		if err != nil {
+			return res, fmt.Errorf("seeding %s: %w", a.email, err)
+		}
+		if created {
+			res.Users++
+		}
+		for i, p := range a.posts {
+			created, err := ensurePost(ctx, posts, u.ID, a.email, i, len(a.posts), p)
+			if err != nil {
+				return res, fmt.Errorf("seeding post %d of %s: %w", i+1, a.email, err)
+			}
+			if created {
+				res.Posts++
+			}
+		}
+	}
+	return res, nil
+}
+
+func ensureUser(ctx context.Context, users repository.UserRepository, a account) (*model.User, bool, error) {
+	u, err := users.FindByEmail(ctx, a.email)
+	if err == nil {
+		return u, false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, err
+	}
+
+	hash, err := service.HashPassword(Password)
+	if err != nil {
+		return nil, false, err
+	}
+	u = &model.User{
+		ID:           uuid.NewSHA1(namespace, []byte(a.email)),
+		Email:        a.email,
+		PasswordHash: hash,
+		APIToken:     uuid.NewString(),
+		Role:         a.role,
+		Plan:         model.PlanFree,
+		Name:         a.name,
+		Headline:     a.headline,
+		Industry:     a.industry,
+	}
+	if err := users.Create(ctx, u); err != nil {
+		return nil, false, err
+	}
+	return u, true, nil
+}
+
+// ensurePost saves the i-th of n sample posts unless it exists. Posts are a
+// day apart, the last one created now, so history lists them in order.
+func ensurePost(ctx context.Context, posts repository.PostRepository, userID uuid.UUID, email string, i, n int, p samplePost) (bool, error) {
+	id := uuid.NewSHA1(namespace, fmt.Appendf(nil, "%s/post/%d", email, i))
+	_, err := posts.Get(ctx, userID, id)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	// Get skips deleted posts, which Restore brings back.
+	err = posts.Restore(ctx, userID, id)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+
+	err = posts.Save(ctx, &model.LinkedInPost{
+		ID:         id,
+		UserID:     userID,
+		InputText:  p.input,
+		OutputText: p.output,
+		CreatedAt:  time.Now().Add(-time.Duration(n-1-i) * 24 * time.Hour),
+		Status:     p.status,
+		Source:     model.PostSourceAI,
+		Model:      "gpt-4o-mini",
+		Template:   p.template,
+		Tone:       p.tone,
+		Length:     p.length,
+		Language:   "en",
+	})
+	return err == nil, err
+}
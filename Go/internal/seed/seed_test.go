@@ -0,0 +1,85 @@
+// internal/seed/seed_test.go
+package seed_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/seed"
+)
+
+// memoryRepos are in-memory user and post repositories. Posts in deleted are
+// soft-deleted.
+func memoryRepos() (*repository.UserRepositoryMock, *repository.PostRepositoryMock, map[uuid.UUID]*model.LinkedInPost, map[uuid.UUID]bool) {
+	users := map[string]*model.User{}
+	posts := map[uuid.UUID]*model.LinkedInPost{}
+	deleted := map[uuid.UUID]bool{}
+	userRepo := &repository.UserRepositoryMock{
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			if u, ok := users[email]; ok {
+				return u, nil
+			}
+			return nil, sql.ErrNoRows
+		},
+		CreateFunc: func(ctx context.Context, u *model.User) error {
+			users[u.Email] = u
+			return nil
+		},
+	}
+	postRepo := &repository.PostRepositoryMock{
+		GetFunc: func(ctx context.Context, userID, id uuid.UUID) (*model.LinkedInPost, error) {
+			if p, ok := posts[id]; ok && p.UserID == userID && !deleted[id] {
+				return p, nil
+			}
+			return nil, sql.ErrNoRows
+		},
+		RestoreFunc: func(ctx context.Context, userID, id uuid.UUID) error {
+			if !deleted[id] {
+				return sql.ErrNoRows
+			}
+			delete(deleted, id)
+			return nil
+		},
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+			posts[p.ID] = p
+			return nil
+		},
+	}
+	return userRepo, postRepo, posts, deleted
+}
+
+func TestRun_IsIdempotent(t *testing.T) {
+	users, posts, saved, deleted := memoryRepos()
+	ctx := context.Background()
+
+	res, err := seed.Run(ctx, users, posts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Users)
+	assert.Positive(t, res.Posts)
+	assert.Len(t, saved, res.Posts)
+
+	ada, err := users.FindByEmail(ctx, seed.UserEmail)
+	require.NoError(t, err)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(ada.PasswordHash), []byte(seed.Password)), "Seeded users can log in")
+	admin, err := users.FindByEmail(ctx, seed.AdminEmail)
+	require.NoError(t, err)
+	assert.Equal(t, model.RoleAdmin, admin.Role)
+
+	for id := range saved {
+		deleted[id] = true
+		break
+	}
+	res, err = seed.Run(ctx, users, posts)
+	require.NoError(t, err)
+	assert.Equal(t, seed.Result{}, res, "A second run creates nothing")
+	assert.Len(t, users.CreateCalls(), 2)
+	assert.Empty(t, deleted, "Deleted sample posts are restored")
+}
@@ -0,0 +1,173 @@
+// internal/server/server.go
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/you/linkedinify/internal/analytics"
+	"github.com/you/linkedinify/internal/config"
+	"github.com/you/linkedinify/internal/db"
+	"github.com/you/linkedinify/internal/migrate"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/router"
+	"github.com/you/linkedinify/internal/webhook"
+	"github.com/you/linkedinify/migrations"
+)
+
+// Run serves the API until SIGINT or SIGTERM, then stops accepting new
+// connections and gives in-flight requests and scheduled posts being
+// published up to cfg.ShutdownTimeout to finish, and buffered webhooks and
+// analytics events whatever time is left, before closing the database pool.
+// A second signal during shutdown terminates the process immediately.
+//
+// Run returns an error if the server could not start or if requests were
+// still running when the timeout expired.
+func Run(cfg config.Config) error {
+	database := db.New(cfg)
+	defer func() {
+		if err := database.Close(); err != nil {
+			slog.Warn("closing database failed", "error", err)
+		}
+	}()
+
+	if cfg.MigrateOnStart {
+		if err := applyMigrations(database); err != nil {
+			return err
+		}
+	}
+
+	webhooks := newWebhooks(cfg)
+	events := newAnalytics(cfg, database)
+
+	handler, jobs, posts := router.New(cfg, database, webhooks, events)
+	var inFlight atomic.Int64
+	srv := &http.Server{
+		Addr:    cfg.HTTPAddr,
+		Handler: countInFlight(handler, &inFlight),
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("server starting", "addr", cfg.HTTPAddr)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server failed to start: %w", err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	pending := inFlight.Load()
+	slog.Info("shutting down", "timeout", cfg.ShutdownTimeout, "in_flight", pending)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	// Scheduled posts being published finish alongside the requests, or
+	// are put back for another instance when time runs out.
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		if jobs == nil {
+			return
+		}
+		if err := jobs.Close(shutdownCtx); err != nil {
+			slog.Warn("stopping scheduler failed", "error", err)
+		}
+	}()
+	defer func() { <-schedulerDone }()
+	// Drafts of the streams cut off by the shutdown are saved before the
+	// database is closed, and before webhooks announcing them are flushed.
+	closePosts := func() {
+		if err := posts.Close(shutdownCtx); err != nil {
+			slog.Warn("saving drafts failed", "error", err)
+		}
+	}
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		remaining := inFlight.Load()
+		_ = srv.Close()
+		closePosts()
+		slog.Warn("in-flight requests did not finish in time", "drained", max(pending-remaining, 0), "remaining", remaining)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("shutdown timed out after %s", cfg.ShutdownTimeout)
+		}
+		return err
+	}
+	slog.Info("drained in-flight requests", "drained", pending)
+	closePosts()
+
+	// Webhooks for the drained requests get whatever time is left.
+	if webhooks != nil {
+		if err := webhooks.Close(shutdownCtx); err != nil {
+			slog.Warn("closing webhooks failed", "error", err)
+		}
+	}
+	if events != nil {
+		if err := events.Close(shutdownCtx); err != nil {
+			slog.Warn("flushing analytics events failed", "error", err)
+		}
+	}
+	return nil
+}
+
+// applyMigrations brings the schema up to date before serving. Instances
+// starting together take turns, so only the first one applies anything.
+func applyMigrations(database *db.DB) error {
+	all, err := migrate.Load(migrations.FS)
+	if err != nil {
+		return err
+	}
+	applied, err := migrate.New(database.DB, all).Up(context.Background())
+	for _, m := range applied {
+		slog.Info("applied migration", "migration", m.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("migrating database: %w", err)
+	}
+	return nil
+}
+
+// newWebhooks starts the webhook dispatcher, or returns nil when WEBHOOK_URL
+// is not set.
+func newWebhooks(cfg config.Config) *webhook.Dispatcher {
+	if cfg.WebhookURL == "" {
+		return nil
+	}
+	slog.Info("sending post webhooks", "url", cfg.WebhookURL)
+	return webhook.New(cfg.WebhookURL, cfg.WebhookSecret,
+		webhook.WithQueueSize(cfg.WebhookQueueSize),
+		webhook.WithMaxAttempts(cfg.WebhookMaxAttempts),
+	)
+}
+
+// newAnalytics starts the writer storing generation analytics, or returns nil
+// when ANALYTICS_ENABLED is false.
+func newAnalytics(cfg config.Config, database *db.DB) *analytics.Writer {
+	if !cfg.AnalyticsEnabled {
+		slog.Info("generation analytics disabled")
+		return nil
+	}
+	return analytics.New(repository.NewAnalyticsRepo(database),
+		analytics.WithFlushInterval(cfg.AnalyticsFlushInterval),
+	)
+}
+
+// countInFlight tracks how many requests are currently being served.
+func countInFlight(next http.Handler, n *atomic.Int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n.Add(1)
+		defer n.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
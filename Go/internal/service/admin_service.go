@@ -0,0 +1,74 @@
+// internal/service/admin_service.go
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/audit"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+// ErrAuditLogUnsupported is returned by AuditLog when the service was built
+// without an audit log store.
+var ErrAuditLogUnsupported = errors.New("the audit log is not enabled")
+
+// AuditFilter narrows AuditLog; see repository.AuditFilter.
+type AuditFilter = repository.AuditFilter
+
+// AdminServiceInteractor defines the operations available to administrators.
+type AdminServiceInteractor interface {
+	ListUsers(ctx context.Context) ([]model.User, error)
+	PurgePost(ctx context.Context, postID uuid.UUID) error
+	FeedbackStats(ctx context.Context) ([]FeedbackStat, error)
+	AuditLog(ctx context.Context, f AuditFilter, limit, offset int) ([]model.AuditLog, int, error)
+}
+
+type AdminService struct {
+	users repository.UserRepository
+	posts repository.PostRepository
+	audit repository.AuditLogRepository // nil when the audit log is disabled
+}
+
+// AdminOption configures optional AdminService dependencies.
+type AdminOption func(*AdminService)
+
+// WithAuditLog enables AuditLog, reading the entries audit.Record saved to
+// repo.
+func WithAuditLog(repo repository.AuditLogRepository) AdminOption {
+	return func(a *AdminService) { a.audit = repo }
+}
+
+// NewAdmin creates a new AdminService instance.
+func NewAdmin(users repository.UserRepository, posts repository.PostRepository, opts ...AdminOption) AdminServiceInteractor {
+	a := &AdminService{users: users, posts: posts}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func (a *AdminService) ListUsers(ctx context.Context) ([]model.User, error) {
+	return a.users.List(ctx)
+}
+
+// PurgePost permanently deletes any post, including soft-deleted ones.
+func (a *AdminService) PurgePost(ctx context.Context, postID uuid.UUID) error {
+	if err := a.posts.HardDelete(ctx, postID); err != nil {
+		return notFound(err)
+	}
+	audit.Record(ctx, audit.ActionPostPurge, postID, nil)
+	return nil
+}
+
+// AuditLog returns one page of the audit entries matching f, newest first,
+// together with the total number of matches.
+func (a *AdminService) AuditLog(ctx context.Context, f AuditFilter, limit, offset int) ([]model.AuditLog, int, error) {
+	if a.audit == nil {
+		return nil, 0, ErrAuditLogUnsupported
+	}
+	return a.audit.List(ctx, f, limit, offset)
+}
@@ -0,0 +1,233 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that AdminServiceInteractorMock does implement AdminServiceInteractor.
+// If this is not the case, regenerate this file with moq.
+var _ AdminServiceInteractor = &AdminServiceInteractorMock{}
+
+// AdminServiceInteractorMock is a mock implementation of AdminServiceInteractor.
+//
+//	func TestSomethingThatUsesAdminServiceInteractor(t *testing.T) {
+//
+//		// make and configure a mocked AdminServiceInteractor
+//		mockedAdminServiceInteractor := &AdminServiceInteractorMock{
+//			AuditLogFunc: func(ctx context.Context, f AuditFilter, limit int, offset int) ([]model.AuditLog, int, error) {
+//				panic("mock out the AuditLog method")
+//			},
+//			FeedbackStatsFunc: func(ctx context.Context) ([]FeedbackStat, error) {
+//				panic("mock out the FeedbackStats method")
+//			},
+//			ListUsersFunc: func(ctx context.Context) ([]model.User, error) {
+//				panic("mock out the ListUsers method")
+//			},
+//			PurgePostFunc: func(ctx context.Context, postID uuid.UUID) error {
+//				panic("mock out the PurgePost method")
+//			},
+//		}
+//
+//		// use mockedAdminServiceInteractor in code that requires AdminServiceInteractor
+//		// and then make assertions.
+//
+//	}
+type AdminServiceInteractorMock struct {
+	// AuditLogFunc mocks the AuditLog method.
+	AuditLogFunc func(ctx context.Context, f AuditFilter, limit int, offset int) ([]model.AuditLog, int, error)
+
+	// FeedbackStatsFunc mocks the FeedbackStats method.
+	FeedbackStatsFunc func(ctx context.Context) ([]FeedbackStat, error)
+
+	// ListUsersFunc mocks the ListUsers method.
+	ListUsersFunc func(ctx context.Context) ([]model.User, error)
+
+	// PurgePostFunc mocks the PurgePost method.
+	PurgePostFunc func(ctx context.Context, postID uuid.UUID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AuditLog holds details about calls to the AuditLog method.
+		AuditLog []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// F is the f argument value.
+			F AuditFilter
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
+		// FeedbackStats holds details about calls to the FeedbackStats method.
+		FeedbackStats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListUsers holds details about calls to the ListUsers method.
+		ListUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PurgePost holds details about calls to the PurgePost method.
+		PurgePost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
+	}
+	lockAuditLog      sync.RWMutex
+	lockFeedbackStats sync.RWMutex
+	lockListUsers     sync.RWMutex
+	lockPurgePost     sync.RWMutex
+}
+
+// AuditLog calls AuditLogFunc.
+func (mock *AdminServiceInteractorMock) AuditLog(ctx context.Context, f AuditFilter, limit int, offset int) ([]model.AuditLog, int, error) {
+	if mock.AuditLogFunc == nil {
+		panic("AdminServiceInteractorMock.AuditLogFunc: method is nil but AdminServiceInteractor.AuditLog was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		F      AuditFilter
+		Limit  int
+		Offset int
+	}{
+		Ctx:    ctx,
+		F:      f,
+		Limit:  limit,
+		Offset: offset,
+	}
+	mock.lockAuditLog.Lock()
+	mock.calls.AuditLog = append(mock.calls.AuditLog, callInfo)
+	mock.lockAuditLog.Unlock()
+	return mock.AuditLogFunc(ctx, f, limit, offset)
+}
+
+// AuditLogCalls gets all the calls that were made to AuditLog.
+// Check the length with:
+//
+//	len(mockedAdminServiceInteractor.AuditLogCalls())
+func (mock *AdminServiceInteractorMock) AuditLogCalls() []struct {
+	Ctx    context.Context
+	F      AuditFilter
+	Limit  int
+	Offset int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		F      AuditFilter
+		Limit  int
+		Offset int
+	}
+	mock.lockAuditLog.RLock()
+	calls = mock.calls.AuditLog
+	mock.lockAuditLog.RUnlock()
+	return calls
+}
+
+// FeedbackStats calls FeedbackStatsFunc.
+func (mock *AdminServiceInteractorMock) FeedbackStats(ctx context.Context) ([]FeedbackStat, error) {
+	if mock.FeedbackStatsFunc == nil {
+		panic("AdminServiceInteractorMock.FeedbackStatsFunc: method is nil but AdminServiceInteractor.FeedbackStats was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockFeedbackStats.Lock()
+	mock.calls.FeedbackStats = append(mock.calls.FeedbackStats, callInfo)
+	mock.lockFeedbackStats.Unlock()
+	return mock.FeedbackStatsFunc(ctx)
+}
+
+// FeedbackStatsCalls gets all the calls that were made to FeedbackStats.
+// Check the length with:
+//
+//	len(mockedAdminServiceInteractor.FeedbackStatsCalls())
+func (mock *AdminServiceInteractorMock) FeedbackStatsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockFeedbackStats.RLock()
+	calls = mock.calls.FeedbackStats
+	mock.lockFeedbackStats.RUnlock()
+	return calls
+}
+
+// ListUsers calls ListUsersFunc.
+func (mock *AdminServiceInteractorMock) ListUsers(ctx context.Context) ([]model.User, error) {
+	if mock.ListUsersFunc == nil {
+		panic("AdminServiceInteractorMock.ListUsersFunc: method is nil but AdminServiceInteractor.ListUsers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListUsers.Lock()
+	mock.calls.ListUsers = append(mock.calls.ListUsers, callInfo)
+	mock.lockListUsers.Unlock()
+	return mock.ListUsersFunc(ctx)
+}
+
+// ListUsersCalls gets all the calls that were made to ListUsers.
+// Check the length with:
+//
+//	len(mockedAdminServiceInteractor.ListUsersCalls())
+func (mock *AdminServiceInteractorMock) ListUsersCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListUsers.RLock()
+	calls = mock.calls.ListUsers
+	mock.lockListUsers.RUnlock()
+	return calls
+}
+
+// PurgePost calls PurgePostFunc.
+func (mock *AdminServiceInteractorMock) PurgePost(ctx context.Context, postID uuid.UUID) error {
+	if mock.PurgePostFunc == nil {
+		panic("AdminServiceInteractorMock.PurgePostFunc: method is nil but AdminServiceInteractor.PurgePost was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		PostID: postID,
+	}
+	mock.lockPurgePost.Lock()
+	mock.calls.PurgePost = append(mock.calls.PurgePost, callInfo)
+	mock.lockPurgePost.Unlock()
+	return mock.PurgePostFunc(ctx, postID)
+}
+
+// PurgePostCalls gets all the calls that were made to PurgePost.
+// Check the length with:
+//
+//	len(mockedAdminServiceInteractor.PurgePostCalls())
+func (mock *AdminServiceInteractorMock) PurgePostCalls() []struct {
+	Ctx    context.Context
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		PostID uuid.UUID
+	}
+	mock.lockPurgePost.RLock()
+	calls = mock.calls.PurgePost
+	mock.lockPurgePost.RUnlock()
+	return calls
+}
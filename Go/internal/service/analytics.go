@@ -0,0 +1,121 @@
+// internal/service/analytics.go
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+// ErrStatsUnsupported is returned by Stats when the service was built
+// without WithStats.
+var ErrStatsUnsupported = errors.New("generation stats are not enabled")
+
+// Bounds of the period Stats covers, in days.
+const (
+	DefaultStatsDays = 30
+	MaxStatsDays     = 365
+)
+
+// topStatsLimit is how many tones and templates Stats ranks.
+const topStatsLimit = 5
+
+// AnalyticsRecorder is told about every generated post, to be stored for
+// GenerationStats. Record must not block the request: events are written
+// in the background.
+type AnalyticsRecorder interface {
+	Record(e model.AnalyticsEvent)
+}
+
+// WithAnalytics records an event with r for every post generated, streamed
+// or regenerated.
+func WithAnalytics(r AnalyticsRecorder) LinkedInOption {
+	return func(l *LinkedInService) { l.analytics = r }
+}
+
+// record reports that userID generated post when an AnalyticsRecorder is
+// configured. userID is not always the author: colleagues can regenerate
+// each other's posts.
+func (l *LinkedInService) record(userID uuid.UUID, post *model.LinkedInPost, cached bool) {
+	if l.analytics == nil {
+		return
+	}
+	l.analytics.Record(model.AnalyticsEvent{
+		UserID:           userID,
+		PostID:           post.ID,
+		Model:            post.Model,
+		Tone:             post.Tone,
+		Template:         post.Template,
+		PromptTokens:     post.PromptTokens,
+		CompletionTokens: post.CompletionTokens,
+		TotalTokens:      post.TotalTokens,
+		Cached:           cached,
+		CreatedAt:        time.Now(),
+	})
+}
+
+// GenerationStats summarizes a user's generations over the last Days days.
+type GenerationStats struct {
+	Days        int
+	Since       time.Time
+	Generations int
+	TotalTokens int
+	Cached      int
+	// Daily has a count for each UTC day of the period, oldest first,
+	// including the days without generations.
+	Daily []repository.DailyGenerations
+	// Tones and Templates are the most used ones, most used first.
+	Tones     []repository.UsageCount
+	Templates []repository.UsageCount
+}
+
+// WithStats lets users see the trends of their generations, read from repo.
+func WithStats(repo repository.AnalyticsRepository) UserOption {
+	return func(s *UserService) { s.analytics = repo }
+}
+
+// Stats summarizes the user's generations of the last days days, today
+// included. Generations from before the analytics were enabled, and the last
+// few seconds' whose events are still buffered, are not counted.
+func (s *UserService) Stats(ctx context.Context, userID uuid.UUID, days int) (*GenerationStats, error) {
+	if s.analytics == nil {
+		return nil, ErrStatsUnsupported
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, 1-days)
+
+	daily, err := s.analytics.Daily(ctx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	tones, err := s.analytics.TopTones(ctx, userID, since, topStatsLimit)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := s.analytics.TopTemplates(ctx, userID, since, topStatsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &GenerationStats{Days: days, Since: since, Daily: make([]repository.DailyGenerations, days), Tones: tones, Templates: templates}
+	for i := range stats.Daily {
+		stats.Daily[i].Day = since.AddDate(0, 0, i)
+	}
+	for _, d := range daily {
+		i := int(d.Day.UTC().Sub(since) / (24 * time.Hour))
+		if i < 0 || i >= days {
+			continue
+		}
+		d.Day = stats.Daily[i].Day
+		stats.Daily[i] = d
+		stats.Generations += d.Generations
+		stats.TotalTokens += d.TotalTokens
+		stats.Cached += d.Cached
+	}
+	return stats, nil
+}
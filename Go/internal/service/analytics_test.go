@@ -0,0 +1,92 @@
+// internal/service/analytics_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// recorder collects the analytics events of a service.
+type recorder []model.AnalyticsEvent
+
+func (r *recorder) Record(e model.AnalyticsEvent) { *r = append(*r, e) }
+
+func TestLinkedInService_RecordsGenerations(t *testing.T) {
+	aiClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "Post", Model: "gpt-4o-mini", Usage: ai.Usage{PromptTokens: 10, CompletionTokens: 20, TotalTokens: 30}}, nil
+		},
+	}
+	repo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	events := &recorder{}
+	liSvc := service.NewLinkedIn(aiClient, repo, service.WithAnalytics(events))
+	userID := uuid.New()
+
+	for range 2 {
+		_, err := liSvc.Transform(context.Background(), userID, "text", service.TransformOptions{Tone: "witty"})
+		require.NoError(t, err)
+	}
+
+	require.Len(t, *events, 2)
+	first, second := (*events)[0], (*events)[1]
+	assert.Equal(t, userID, first.UserID)
+	assert.Equal(t, repo.SaveCalls()[0].P.ID, first.PostID)
+	assert.Equal(t, "witty", first.Tone)
+	assert.Equal(t, "gpt-4o-mini", first.Model)
+	assert.Equal(t, 30, first.TotalTokens)
+	assert.False(t, first.Cached)
+	assert.True(t, second.Cached, "The second generation is served from the cache")
+}
+
+func TestUserService_Stats(t *testing.T) {
+	userID := uuid.New()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	repo := &repository.AnalyticsRepositoryMock{
+		DailyFunc: func(ctx context.Context, userID uuid.UUID, since time.Time) ([]repository.DailyGenerations, error) {
+			return []repository.DailyGenerations{
+				{Day: today.AddDate(0, 0, -2), Generations: 3, TotalTokens: 300, Cached: 1},
+				{Day: today, Generations: 1, TotalTokens: 50},
+			}, nil
+		},
+		TopTonesFunc: func(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]repository.UsageCount, error) {
+			return []repository.UsageCount{{Name: "witty", Count: 3}, {Name: "formal", Count: 1}}, nil
+		},
+		TopTemplatesFunc: func(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]repository.UsageCount, error) {
+			return []repository.UsageCount{{Name: "announcement", Count: 4}}, nil
+		},
+	}
+	svc := service.NewUser(&repository.UserRepositoryMock{}, service.WithStats(repo))
+
+	stats, err := svc.Stats(context.Background(), userID, 7)
+	require.NoError(t, err)
+	assert.Equal(t, today.AddDate(0, 0, -6), stats.Since, "The period ends today")
+	assert.Equal(t, stats.Since, repo.DailyCalls()[0].Since)
+	assert.Equal(t, userID, repo.TopTonesCalls()[0].UserID)
+	assert.Equal(t, 4, stats.Generations)
+	assert.Equal(t, 350, stats.TotalTokens)
+	assert.Equal(t, 1, stats.Cached)
+
+	require.Len(t, stats.Daily, 7, "Days without generations are included")
+	assert.Equal(t, stats.Since, stats.Daily[0].Day)
+	assert.Zero(t, stats.Daily[0].Generations)
+	assert.Equal(t, 3, stats.Daily[4].Generations)
+	assert.Equal(t, today, stats.Daily[6].Day)
+	assert.Equal(t, 1, stats.Daily[6].Generations)
+	assert.Equal(t, "witty", stats.Tones[0].Name)
+	assert.Equal(t, "announcement", stats.Templates[0].Name)
+
+	_, err = service.NewUser(&repository.UserRepositoryMock{}).Stats(context.Background(), userID, 7)
+	assert.ErrorIs(t, err, service.ErrStatsUnsupported)
+}
@@ -0,0 +1,132 @@
+// internal/service/api_keys.go
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/audit"
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+var (
+	// ErrAPIKeyNotFound is returned when revoking a key the user does not
+	// have.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrInvalidAPIKey is returned by VerifyAPIKey for unknown or revoked
+	// keys.
+	ErrInvalidAPIKey = middleware.ErrInvalidAPIKey
+	// ErrTooManyAPIKeys is returned when a user already has MaxAPIKeys keys.
+	ErrTooManyAPIKeys = errors.New("too many api keys")
+	// ErrAPIKeysUnsupported is returned when the service was built without
+	// an API key store.
+	ErrAPIKeysUnsupported = errors.New("api keys are not enabled")
+)
+
+const (
+	// APIKeyPrefix starts every API key, so leaked keys are easy to spot.
+	APIKeyPrefix = "lk_"
+	// MaxAPIKeys is how many active keys a user may have.
+	MaxAPIKeys = 25
+	// MaxAPIKeyNameLength is the longest key name, in characters.
+	MaxAPIKeyNameLength = 100
+	// apiKeyShownPrefix is how much of a key is kept in clear for listing.
+	apiKeyShownPrefix = len(APIKeyPrefix) + 6
+)
+
+// NewAPIKey is a freshly created key. Key is the only time the secret is
+// available; just its hash is stored.
+type NewAPIKey struct {
+	model.APIKey
+	Key string
+}
+
+// WithAPIKeys enables API keys, stored in repo.
+func WithAPIKeys(repo repository.APIKeyRepository) UserOption {
+	return func(s *UserService) { s.apiKeys = repo }
+}
+
+// CreateAPIKey issues a new API key for the user under an optional name.
+func (s *UserService) CreateAPIKey(ctx context.Context, userID uuid.UUID, name string) (*NewAPIKey, error) {
+	if s.apiKeys == nil {
+		return nil, ErrAPIKeysUnsupported
+	}
+	existing, err := s.apiKeys.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxAPIKeys {
+		return nil, ErrTooManyAPIKeys
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	raw := APIKeyPrefix + secret
+	k := model.APIKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		Prefix:    raw[:apiKeyShownPrefix],
+		KeyHash:   hashToken(raw),
+		CreatedAt: time.Now(),
+	}
+	if err := s.apiKeys.Create(ctx, &k); err != nil {
+		return nil, err
+	}
+	audit.Record(ctx, audit.ActionAPIKeyCreate, k.ID, map[string]any{"name": k.Name, "prefix": k.Prefix})
+	return &NewAPIKey{APIKey: k, Key: raw}, nil
+}
+
+// ListAPIKeys returns the user's active keys, newest first, without their
+// secrets.
+func (s *UserService) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error) {
+	if s.apiKeys == nil {
+		return nil, ErrAPIKeysUnsupported
+	}
+	return s.apiKeys.ListByUser(ctx, userID)
+}
+
+// RevokeAPIKey stops a key of the user from authenticating. Revoking an
+// unknown or already revoked key returns ErrAPIKeyNotFound.
+func (s *UserService) RevokeAPIKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	if s.apiKeys == nil {
+		return ErrAPIKeysUnsupported
+	}
+	ok, err := s.apiKeys.Revoke(ctx, userID, keyID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAPIKeyNotFound
+	}
+	audit.Record(ctx, audit.ActionAPIKeyRevoke, keyID, nil)
+	return nil
+}
+
+// VerifyAPIKey returns the user an API key belongs to. It implements
+// middleware.APIKeyVerifier.
+func (s *UserService) VerifyAPIKey(ctx context.Context, key string) (uuid.UUID, error) {
+	if s.apiKeys == nil {
+		return uuid.Nil, ErrAPIKeysUnsupported
+	}
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return uuid.Nil, ErrInvalidAPIKey
+	}
+	k, err := s.apiKeys.FindByHash(ctx, hashToken(key))
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return k.UserID, nil
+}
@@ -5,6 +5,7 @@ package service
 
 import (
 	"sync"
+	"time"
 )
 
 // Ensure, that AuthConfigProviderMock does implement AuthConfigProvider.
@@ -17,9 +18,21 @@ var _ AuthConfigProvider = &AuthConfigProviderMock{}
 //
 //		// make and configure a mocked AuthConfigProvider
 //		mockedAuthConfigProvider := &AuthConfigProviderMock{
+//			GetJWTExpiryFunc: func() time.Duration {
+//				panic("mock out the GetJWTExpiry method")
+//			},
+//			GetJWTIssuerFunc: func() string {
+//				panic("mock out the GetJWTIssuer method")
+//			},
 //			GetJWTSecretFunc: func() []byte {
 //				panic("mock out the GetJWTSecret method")
 //			},
+//			GetMinPasswordLengthFunc: func() int {
+//				panic("mock out the GetMinPasswordLength method")
+//			},
+//			GetPasswordResetURLFunc: func() string {
+//				panic("mock out the GetPasswordResetURL method")
+//			},
 //		}
 //
 //		// use mockedAuthConfigProvider in code that requires AuthConfigProvider
@@ -27,16 +40,98 @@ var _ AuthConfigProvider = &AuthConfigProviderMock{}
 //
 //	}
 type AuthConfigProviderMock struct {
+	// GetJWTExpiryFunc mocks the GetJWTExpiry method.
+	GetJWTExpiryFunc func() time.Duration
+
+	// GetJWTIssuerFunc mocks the GetJWTIssuer method.
+	GetJWTIssuerFunc func() string
+
 	// GetJWTSecretFunc mocks the GetJWTSecret method.
 	GetJWTSecretFunc func() []byte
 
+	// GetMinPasswordLengthFunc mocks the GetMinPasswordLength method.
+	GetMinPasswordLengthFunc func() int
+
+	// GetPasswordResetURLFunc mocks the GetPasswordResetURL method.
+	GetPasswordResetURLFunc func() string
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// GetJWTExpiry holds details about calls to the GetJWTExpiry method.
+		GetJWTExpiry []struct {
+		}
+		// GetJWTIssuer holds details about calls to the GetJWTIssuer method.
+		GetJWTIssuer []struct {
+		}
 		// GetJWTSecret holds details about calls to the GetJWTSecret method.
 		GetJWTSecret []struct {
 		}
+		// GetMinPasswordLength holds details about calls to the GetMinPasswordLength method.
+		GetMinPasswordLength []struct {
+		}
+		// GetPasswordResetURL holds details about calls to the GetPasswordResetURL method.
+		GetPasswordResetURL []struct {
+		}
+	}
+	lockGetJWTExpiry         sync.RWMutex
+	lockGetJWTIssuer         sync.RWMutex
+	lockGetJWTSecret         sync.RWMutex
+	lockGetMinPasswordLength sync.RWMutex
+	lockGetPasswordResetURL  sync.RWMutex
+}
+
+// GetJWTExpiry calls GetJWTExpiryFunc.
+func (mock *AuthConfigProviderMock) GetJWTExpiry() time.Duration {
+	if mock.GetJWTExpiryFunc == nil {
+		panic("AuthConfigProviderMock.GetJWTExpiryFunc: method is nil but AuthConfigProvider.GetJWTExpiry was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetJWTExpiry.Lock()
+	mock.calls.GetJWTExpiry = append(mock.calls.GetJWTExpiry, callInfo)
+	mock.lockGetJWTExpiry.Unlock()
+	return mock.GetJWTExpiryFunc()
+}
+
+// GetJWTExpiryCalls gets all the calls that were made to GetJWTExpiry.
+// Check the length with:
+//
+//	len(mockedAuthConfigProvider.GetJWTExpiryCalls())
+func (mock *AuthConfigProviderMock) GetJWTExpiryCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetJWTExpiry.RLock()
+	calls = mock.calls.GetJWTExpiry
+	mock.lockGetJWTExpiry.RUnlock()
+	return calls
+}
+
+// GetJWTIssuer calls GetJWTIssuerFunc.
+func (mock *AuthConfigProviderMock) GetJWTIssuer() string {
+	if mock.GetJWTIssuerFunc == nil {
+		panic("AuthConfigProviderMock.GetJWTIssuerFunc: method is nil but AuthConfigProvider.GetJWTIssuer was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetJWTIssuer.Lock()
+	mock.calls.GetJWTIssuer = append(mock.calls.GetJWTIssuer, callInfo)
+	mock.lockGetJWTIssuer.Unlock()
+	return mock.GetJWTIssuerFunc()
+}
+
+// GetJWTIssuerCalls gets all the calls that were made to GetJWTIssuer.
+// Check the length with:
+//
+//	len(mockedAuthConfigProvider.GetJWTIssuerCalls())
+func (mock *AuthConfigProviderMock) GetJWTIssuerCalls() []struct {
+} {
+	var calls []struct {
 	}
-	lockGetJWTSecret sync.RWMutex
+	mock.lockGetJWTIssuer.RLock()
+	calls = mock.calls.GetJWTIssuer
+	mock.lockGetJWTIssuer.RUnlock()
+	return calls
 }
 
 // GetJWTSecret calls GetJWTSecretFunc.
@@ -65,3 +160,57 @@ func (mock *AuthConfigProviderMock) GetJWTSecretCalls() []struct {
 	mock.lockGetJWTSecret.RUnlock()
 	return calls
 }
+
+// GetMinPasswordLength calls GetMinPasswordLengthFunc.
+func (mock *AuthConfigProviderMock) GetMinPasswordLength() int {
+	if mock.GetMinPasswordLengthFunc == nil {
+		panic("AuthConfigProviderMock.GetMinPasswordLengthFunc: method is nil but AuthConfigProvider.GetMinPasswordLength was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetMinPasswordLength.Lock()
+	mock.calls.GetMinPasswordLength = append(mock.calls.GetMinPasswordLength, callInfo)
+	mock.lockGetMinPasswordLength.Unlock()
+	return mock.GetMinPasswordLengthFunc()
+}
+
+// GetMinPasswordLengthCalls gets all the calls that were made to GetMinPasswordLength.
+// Check the length with:
+//
+//	len(mockedAuthConfigProvider.GetMinPasswordLengthCalls())
+func (mock *AuthConfigProviderMock) GetMinPasswordLengthCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetMinPasswordLength.RLock()
+	calls = mock.calls.GetMinPasswordLength
+	mock.lockGetMinPasswordLength.RUnlock()
+	return calls
+}
+
+// GetPasswordResetURL calls GetPasswordResetURLFunc.
+func (mock *AuthConfigProviderMock) GetPasswordResetURL() string {
+	if mock.GetPasswordResetURLFunc == nil {
+		panic("AuthConfigProviderMock.GetPasswordResetURLFunc: method is nil but AuthConfigProvider.GetPasswordResetURL was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetPasswordResetURL.Lock()
+	mock.calls.GetPasswordResetURL = append(mock.calls.GetPasswordResetURL, callInfo)
+	mock.lockGetPasswordResetURL.Unlock()
+	return mock.GetPasswordResetURLFunc()
+}
+
+// GetPasswordResetURLCalls gets all the calls that were made to GetPasswordResetURL.
+// Check the length with:
+//
+//	len(mockedAuthConfigProvider.GetPasswordResetURLCalls())
+func (mock *AuthConfigProviderMock) GetPasswordResetURLCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetPasswordResetURL.RLock()
+	calls = mock.calls.GetPasswordResetURL
+	mock.lockGetPasswordResetURL.RUnlock()
+	return calls
+}
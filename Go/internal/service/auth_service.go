@@ -3,72 +3,438 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/you/linkedinify/internal/audit"
 	"github.com/you/linkedinify/internal/model"
 	"github.com/you/linkedinify/internal/repository"
 )
 
+const (
+	refreshTokenTTL = 30 * 24 * time.Hour
+	resetTokenTTL   = time.Hour
+)
+
+var (
+	// ErrInvalidRefreshToken is returned for unknown, expired or revoked
+	// refresh tokens.
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned when an already rotated refresh token
+	// is presented again. The whole token family is revoked in response.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+	// ErrRefreshUnsupported is returned by Refresh when the service was built
+	// without a refresh token store.
+	ErrRefreshUnsupported = errors.New("refresh tokens are not enabled")
+	// ErrInvalidResetToken is returned for unknown, expired or already used
+	// password reset tokens.
+	ErrInvalidResetToken = errors.New("invalid or expired reset token")
+	// ErrPasswordResetUnsupported is returned when the service was built
+	// without a password reset store.
+	ErrPasswordResetUnsupported = errors.New("password reset is not enabled")
+	// ErrInvalidAccessToken is returned by Logout for tokens that were not
+	// issued by this service.
+	ErrInvalidAccessToken = errors.New("invalid access token")
+	// ErrLogoutUnsupported is returned when the service was built without a
+	// revocation store.
+	ErrLogoutUnsupported = errors.New("logout is not enabled")
+)
+
 // AuthConfigProvider provides the JWT secret for AuthService.
 type AuthConfigProvider interface {
 	GetJWTSecret() []byte
+	// GetJWTExpiry is the lifetime of access tokens. Keep it short: access
+	// tokens cannot be revoked without logging out, and clients renew them
+	// with a refresh token.
+	GetJWTExpiry() time.Duration
+	// GetJWTIssuer is the iss claim of access tokens; empty leaves it out.
+	GetJWTIssuer() string
+	// GetPasswordResetURL is the page users land on from a reset email; the
+	// token is appended as a "token" query parameter.
+	GetPasswordResetURL() string
+	// GetMinPasswordLength is the fewest characters a new password may
+	// have; see ValidatePassword for the rest of the policy.
+	GetMinPasswordLength() int
+}
+
+// EmailSender delivers transactional email such as password reset links.
+type EmailSender interface {
+	Send(ctx context.Context, to, subject, body string) error
 }
 
 // AuthServiceInteractor defines the operations for authentication services.
 type AuthServiceInteractor interface {
-	Register(ctx context.Context, email, password string) (string, error)
-	Login(ctx context.Context, email, password string) (string, error)
+	Register(ctx context.Context, email, password string) (*Tokens, error)
+	Login(ctx context.Context, email, password, ip string) (*Tokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*Tokens, error)
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	Logout(ctx context.Context, accessToken string) error
+}
+
+// Tokens is the credential pair handed to a client after authenticating.
+// RefreshToken is empty when refresh tokens are not enabled.
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
 }
 
 type AuthService struct {
-	repo repository.UserRepository
-	cfg  AuthConfigProvider // Uses the interface
+	repo         repository.UserRepository
+	cfg          AuthConfigProvider // Uses the interface
+	refresh      repository.RefreshTokenRepository
+	resets       repository.PasswordResetRepository
+	mailer       EmailSender
+	revoked      repository.RevokedTokenRepository
+	lockout      LoginAttemptStore // nil when failed logins are not limited
+	emailLockout LoginAttemptStore // nil when failed logins are not limited per email
+}
+
+// AuthOption configures optional AuthService dependencies.
+type AuthOption func(*AuthService)
+
+// WithRefreshTokens enables refresh tokens backed by the given store.
+func WithRefreshTokens(repo repository.RefreshTokenRepository) AuthOption {
+	return func(a *AuthService) { a.refresh = repo }
+}
+
+// WithPasswordResets enables the forgot/reset password flow, storing reset
+// tokens in repo and delivering them with sender.
+func WithPasswordResets(repo repository.PasswordResetRepository, sender EmailSender) AuthOption {
+	return func(a *AuthService) {
+		a.resets = repo
+		a.mailer = sender
+	}
+}
+
+// WithLogout enables Logout, recording revoked access tokens in repo.
+func WithLogout(repo repository.RevokedTokenRepository) AuthOption {
+	return func(a *AuthService) { a.revoked = repo }
 }
 
 // NewAuth creates a new AuthService instance.
 // It now accepts AuthConfigProvider and returns AuthServiceInteractor.
-func NewAuth(repo repository.UserRepository, cfg AuthConfigProvider) AuthServiceInteractor {
-	return &AuthService{repo: repo, cfg: cfg}
+func NewAuth(repo repository.UserRepository, cfg AuthConfigProvider, opts ...AuthOption) AuthServiceInteractor {
+	a := &AuthService{repo: repo, cfg: cfg}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
-func (a *AuthService) Register(ctx context.Context, email, password string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// Register creates a user account. The password must satisfy
+// ValidatePassword, or the error wraps ErrWeakPassword.
+func (a *AuthService) Register(ctx context.Context, email, password string) (*Tokens, error) {
+	if err := ValidatePassword(password, a.cfg.GetMinPasswordLength()); err != nil {
+		return nil, err
+	}
+	email = normalizeEmail(email)
+	hash, err := HashPassword(password)
 	if err != nil {
-		return "", err // Handle bcrypt errors
+		return nil, err
 	}
 	user := &model.User{
 		ID:           uuid.New(),
 		Email:        email,
-		PasswordHash: string(hash),
+		PasswordHash: hash,
 		APIToken:     uuid.NewString(),
+		Role:         model.RoleUser,
+		Plan:         model.PlanFree,
 	}
 	if err := a.repo.Create(ctx, user); err != nil {
-		return "", err
+		return nil, err
 	}
-	return a.generateJWT(user.ID)
+	return a.issueTokens(ctx, user, uuid.New())
 }
 
-func (a *AuthService) Login(ctx context.Context, email, password string) (string, error) {
+// Login exchanges an email and password for a token pair. ip is where the
+// login comes from; with WithLoginLockout, repeated failures for the email
+// from it return a *LoginLockedError until the lock expires, and with
+// WithEmailLockout so do repeated failures for the email from anywhere.
+func (a *AuthService) Login(ctx context.Context, email, password, ip string) (*Tokens, error) {
+	email = normalizeEmail(email)
+	// Every attempt counts as failed until the password turns out right;
+	// unknown emails count too, or the lockout would tell them apart.
+	lockouts := a.lockouts(email, ip)
+	if err := a.reserveLogin(ctx, lockouts); err != nil {
+		return nil, err
+	}
 	u, err := a.repo.FindByEmail(ctx, email)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	err = bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password))
 	if err != nil {
-		return "", jwt.ErrTokenInvalidAudience
+		audit.Record(ctx, audit.ActionLoginFailed, u.ID, nil)
+		return nil, jwt.ErrTokenInvalidAudience
+	}
+	audit.Record(ctx, audit.ActionLogin, u.ID, nil)
+	a.loginSucceeded(ctx, lockouts)
+	return a.issueTokens(ctx, u, uuid.New())
+}
+
+// Refresh exchanges a refresh token for a new token pair. The presented token
+// is rotated: it can be used once, and presenting it again revokes every
+// token descended from the same login.
+func (a *AuthService) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	if a.refresh == nil {
+		return nil, ErrRefreshUnsupported
+	}
+	stored, err := a.refresh.FindByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if stored.RevokedAt != nil {
+		if err := a.refresh.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Revoke reports false when a concurrent request rotated the token first,
+	// which is indistinguishable from a replay.
+	ok, err := a.refresh.Revoke(ctx, stored.ID)
+	if err != nil {
+		return nil, err
 	}
-	return a.generateJWT(u.ID)
+	if !ok {
+		if err := a.refresh.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+	// The user is loaded again so role and organization changes apply from
+	// the next refresh.
+	u, err := a.repo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	return a.issueTokens(ctx, u, stored.FamilyID)
 }
 
-func (a *AuthService) generateJWT(userID uuid.UUID) (string, error) {
+// ForgotPassword emails a reset link if email belongs to a user. It returns
+// nil for unknown emails so callers cannot tell whether an account exists;
+// only unexpected failures are reported.
+func (a *AuthService) ForgotPassword(ctx context.Context, email string) error {
+	if a.resets == nil {
+		return ErrPasswordResetUnsupported
+	}
+	u, err := a.repo.FindByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	raw, err := randomToken()
+	if err != nil {
+		return err
+	}
+	err = a.resets.Create(ctx, &model.PasswordReset{
+		ID:        uuid.New(),
+		UserID:    u.ID,
+		TokenHash: hashToken(raw),
+		ExpiresAt: time.Now().Add(resetTokenTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := a.cfg.GetPasswordResetURL() + "?token=" + url.QueryEscape(raw)
+	body := fmt.Sprintf("Someone asked to reset the password for your LinkedInify account.\n\n"+
+		"Use the link below within %d minutes to choose a new password:\n%s\n\n"+
+		"If this wasn't you, you can ignore this email.", int(resetTokenTTL.Minutes()), link)
+	return a.mailer.Send(ctx, u.Email, "Reset your LinkedInify password", body)
+}
+
+// ResetPassword sets a new password using a token from ForgotPassword. The
+// token is single use, and every refresh token of the user is revoked so
+// other sessions have to log in again. A new password that breaks the
+// password policy fails with ErrWeakPassword and leaves the token unused.
+func (a *AuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if a.resets == nil {
+		return ErrPasswordResetUnsupported
+	}
+	if err := ValidatePassword(newPassword, a.cfg.GetMinPasswordLength()); err != nil {
+		return err
+	}
+	reset, err := a.resets.FindByHash(ctx, hashToken(token))
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+	if reset.UsedAt != nil || time.Now().After(reset.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+	ok, err := a.resets.MarkUsed(ctx, reset.ID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidResetToken
+	}
+
+	hash, err := HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := a.repo.UpdatePassword(ctx, reset.UserID, hash); err != nil {
+		return err
+	}
+	audit.Record(ctx, audit.ActionPasswordReset, reset.UserID, nil)
+	if a.refresh != nil {
+		return a.refresh.RevokeAllForUser(ctx, reset.UserID)
+	}
+	return nil
+}
+
+// Logout revokes an access token so the auth middleware rejects it from now
+// on, together with every refresh token of the login it came from, so the
+// session cannot be refreshed back to life. Logging out an already revoked
+// or expired token succeeds; an expired one still ends its login's refresh
+// tokens.
+func (a *AuthService) Logout(ctx context.Context, accessToken string) error {
+	if a.revoked == nil {
+		return ErrLogoutUnsupported
+	}
+	parserOpts := []jwt.ParserOption{jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name})}
+	if iss := a.cfg.GetJWTIssuer(); iss != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(iss))
+	}
+	token, err := jwt.Parse(accessToken, func(t *jwt.Token) (interface{}, error) {
+		return a.cfg.GetJWTSecret(), nil
+	}, parserOpts...)
+	expired := errors.Is(err, jwt.ErrTokenExpired)
+	if !expired && (err != nil || !token.Valid) {
+		return ErrInvalidAccessToken
+	}
+	claims, _ := token.Claims.(jwt.MapClaims)
+	if err := a.revokeFamily(ctx, claims); err != nil {
+		return err
+	}
+	if expired {
+		return nil
+	}
+	jti, _ := claims["jti"].(string)
+	exp, err := claims.GetExpirationTime()
+	if jti == "" || err != nil || exp == nil {
+		return ErrInvalidAccessToken
+	}
+	return a.revoked.Revoke(ctx, jti, exp.Time)
+}
+
+// revokeFamily revokes the refresh tokens of the login an access token was
+// issued for. Tokens minted before they carried a "fam" claim are skipped.
+func (a *AuthService) revokeFamily(ctx context.Context, claims jwt.MapClaims) error {
+	if a.refresh == nil {
+		return nil
+	}
+	fam, _ := claims["fam"].(string)
+	familyID, err := uuid.Parse(fam)
+	if err != nil {
+		return nil
+	}
+	return a.refresh.RevokeFamily(ctx, familyID)
+}
+
+// HashPassword returns the hash stored as model.User.PasswordHash and checked
+// on login.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// normalizeEmail is the form emails are stored and looked up in, so that
+// casing does not create a second account.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// issueTokens mints an access token and, when enabled, a refresh token in the
+// given family.
+func (a *AuthService) issueTokens(ctx context.Context, u *model.User, familyID uuid.UUID) (*Tokens, error) {
+	access, err := a.generateJWT(u, familyID)
+	if err != nil {
+		return nil, err
+	}
+	tokens := &Tokens{AccessToken: access, ExpiresIn: a.cfg.GetJWTExpiry()}
+	if a.refresh == nil {
+		return tokens, nil
+	}
+
+	raw, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	err = a.refresh.Create(ctx, &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    u.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(raw),
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	tokens.RefreshToken = raw
+	return tokens, nil
+}
+
+// generateJWT mints an access token for u. Its "fam" claim names the refresh
+// token family of the login, which Logout revokes.
+func (a *AuthService) generateJWT(u *model.User, familyID uuid.UUID) (string, error) {
+	role := u.Role
+	if role == "" {
+		role = model.RoleUser
+	}
+	now := time.Now()
 	claims := jwt.MapClaims{
-		"sub": userID.String(),
-		"exp": time.Now().Add(24 * time.Hour).Unix(),
+		"sub":  u.ID.String(),
+		"role": role,
+		"iat":  now.Unix(),
+		"nbf":  now.Unix(),
+		"exp":  now.Add(a.cfg.GetJWTExpiry()).Unix(),
+		"jti":  uuid.NewString(),
+		"fam":  familyID.String(),
+	}
+	if iss := a.cfg.GetJWTIssuer(); iss != "" {
+		claims["iss"] = iss
+	}
+	if u.OrgID != uuid.Nil {
+		claims["org"] = u.OrgID.String()
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(a.cfg.GetJWTSecret())
 }
+
+// randomToken returns a URL-safe random string with 256 bits of entropy.
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the value stored in place of a random token. Tokens are
+// high-entropy, so a fast unsalted hash is sufficient.
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -18,12 +18,24 @@ var _ AuthServiceInteractor = &AuthServiceInteractorMock{}
 //
 //		// make and configure a mocked AuthServiceInteractor
 //		mockedAuthServiceInteractor := &AuthServiceInteractorMock{
-//			LoginFunc: func(ctx context.Context, email string, password string) (string, error) {
+//			ForgotPasswordFunc: func(ctx context.Context, email string) error {
+//				panic("mock out the ForgotPassword method")
+//			},
+//			LoginFunc: func(ctx context.Context, email string, password string, ip string) (*Tokens, error) {
 //				panic("mock out the Login method")
 //			},
-//			RegisterFunc: func(ctx context.Context, email string, password string) (string, error) {
+//			LogoutFunc: func(ctx context.Context, accessToken string) error {
+//				panic("mock out the Logout method")
+//			},
+//			RefreshFunc: func(ctx context.Context, refreshToken string) (*Tokens, error) {
+//				panic("mock out the Refresh method")
+//			},
+//			RegisterFunc: func(ctx context.Context, email string, password string) (*Tokens, error) {
 //				panic("mock out the Register method")
 //			},
+//			ResetPasswordFunc: func(ctx context.Context, token string, newPassword string) error {
+//				panic("mock out the ResetPassword method")
+//			},
 //		}
 //
 //		// use mockedAuthServiceInteractor in code that requires AuthServiceInteractor
@@ -31,14 +43,33 @@ var _ AuthServiceInteractor = &AuthServiceInteractorMock{}
 //
 //	}
 type AuthServiceInteractorMock struct {
+	// ForgotPasswordFunc mocks the ForgotPassword method.
+	ForgotPasswordFunc func(ctx context.Context, email string) error
+
 	// LoginFunc mocks the Login method.
-	LoginFunc func(ctx context.Context, email string, password string) (string, error)
+	LoginFunc func(ctx context.Context, email string, password string, ip string) (*Tokens, error)
+
+	// LogoutFunc mocks the Logout method.
+	LogoutFunc func(ctx context.Context, accessToken string) error
+
+	// RefreshFunc mocks the Refresh method.
+	RefreshFunc func(ctx context.Context, refreshToken string) (*Tokens, error)
 
 	// RegisterFunc mocks the Register method.
-	RegisterFunc func(ctx context.Context, email string, password string) (string, error)
+	RegisterFunc func(ctx context.Context, email string, password string) (*Tokens, error)
+
+	// ResetPasswordFunc mocks the ResetPassword method.
+	ResetPasswordFunc func(ctx context.Context, token string, newPassword string) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ForgotPassword holds details about calls to the ForgotPassword method.
+		ForgotPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+		}
 		// Login holds details about calls to the Login method.
 		Login []struct {
 			// Ctx is the ctx argument value.
@@ -47,6 +78,22 @@ type AuthServiceInteractorMock struct {
 			Email string
 			// Password is the password argument value.
 			Password string
+			// IP is the ip argument value.
+			IP string
+		}
+		// Logout holds details about calls to the Logout method.
+		Logout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AccessToken is the accessToken argument value.
+			AccessToken string
+		}
+		// Refresh holds details about calls to the Refresh method.
+		Refresh []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RefreshToken is the refreshToken argument value.
+			RefreshToken string
 		}
 		// Register holds details about calls to the Register method.
 		Register []struct {
@@ -57,13 +104,62 @@ type AuthServiceInteractorMock struct {
 			// Password is the password argument value.
 			Password string
 		}
+		// ResetPassword holds details about calls to the ResetPassword method.
+		ResetPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+			// NewPassword is the newPassword argument value.
+			NewPassword string
+		}
+	}
+	lockForgotPassword sync.RWMutex
+	lockLogin          sync.RWMutex
+	lockLogout         sync.RWMutex
+	lockRefresh        sync.RWMutex
+	lockRegister       sync.RWMutex
+	lockResetPassword  sync.RWMutex
+}
+
+// ForgotPassword calls ForgotPasswordFunc.
+func (mock *AuthServiceInteractorMock) ForgotPassword(ctx context.Context, email string) error {
+	if mock.ForgotPasswordFunc == nil {
+		panic("AuthServiceInteractorMock.ForgotPasswordFunc: method is nil but AuthServiceInteractor.ForgotPassword was just called")
 	}
-	lockLogin    sync.RWMutex
-	lockRegister sync.RWMutex
+	callInfo := struct {
+		Ctx   context.Context
+		Email string
+	}{
+		Ctx:   ctx,
+		Email: email,
+	}
+	mock.lockForgotPassword.Lock()
+	mock.calls.ForgotPassword = append(mock.calls.ForgotPassword, callInfo)
+	mock.lockForgotPassword.Unlock()
+	return mock.ForgotPasswordFunc(ctx, email)
+}
+
+// ForgotPasswordCalls gets all the calls that were made to ForgotPassword.
+// Check the length with:
+//
+//	len(mockedAuthServiceInteractor.ForgotPasswordCalls())
+func (mock *AuthServiceInteractorMock) ForgotPasswordCalls() []struct {
+	Ctx   context.Context
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Email string
+	}
+	mock.lockForgotPassword.RLock()
+	calls = mock.calls.ForgotPassword
+	mock.lockForgotPassword.RUnlock()
+	return calls
 }
 
 // Login calls LoginFunc.
-func (mock *AuthServiceInteractorMock) Login(ctx context.Context, email string, password string) (string, error) {
+func (mock *AuthServiceInteractorMock) Login(ctx context.Context, email string, password string, ip string) (*Tokens, error) {
 	if mock.LoginFunc == nil {
 		panic("AuthServiceInteractorMock.LoginFunc: method is nil but AuthServiceInteractor.Login was just called")
 	}
@@ -71,15 +167,17 @@ func (mock *AuthServiceInteractorMock) Login(ctx context.Context, email string,
 		Ctx      context.Context
 		Email    string
 		Password string
+		IP       string
 	}{
 		Ctx:      ctx,
 		Email:    email,
 		Password: password,
+		IP:       ip,
 	}
 	mock.lockLogin.Lock()
 	mock.calls.Login = append(mock.calls.Login, callInfo)
 	mock.lockLogin.Unlock()
-	return mock.LoginFunc(ctx, email, password)
+	return mock.LoginFunc(ctx, email, password, ip)
 }
 
 // LoginCalls gets all the calls that were made to Login.
@@ -90,11 +188,13 @@ func (mock *AuthServiceInteractorMock) LoginCalls() []struct {
 	Ctx      context.Context
 	Email    string
 	Password string
+	IP       string
 } {
 	var calls []struct {
 		Ctx      context.Context
 		Email    string
 		Password string
+		IP       string
 	}
 	mock.lockLogin.RLock()
 	calls = mock.calls.Login
@@ -102,8 +202,80 @@ func (mock *AuthServiceInteractorMock) LoginCalls() []struct {
 	return calls
 }
 
+// Logout calls LogoutFunc.
+func (mock *AuthServiceInteractorMock) Logout(ctx context.Context, accessToken string) error {
+	if mock.LogoutFunc == nil {
+		panic("AuthServiceInteractorMock.LogoutFunc: method is nil but AuthServiceInteractor.Logout was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		AccessToken string
+	}{
+		Ctx:         ctx,
+		AccessToken: accessToken,
+	}
+	mock.lockLogout.Lock()
+	mock.calls.Logout = append(mock.calls.Logout, callInfo)
+	mock.lockLogout.Unlock()
+	return mock.LogoutFunc(ctx, accessToken)
+}
+
+// LogoutCalls gets all the calls that were made to Logout.
+// Check the length with:
+//
+//	len(mockedAuthServiceInteractor.LogoutCalls())
+func (mock *AuthServiceInteractorMock) LogoutCalls() []struct {
+	Ctx         context.Context
+	AccessToken string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		AccessToken string
+	}
+	mock.lockLogout.RLock()
+	calls = mock.calls.Logout
+	mock.lockLogout.RUnlock()
+	return calls
+}
+
+// Refresh calls RefreshFunc.
+func (mock *AuthServiceInteractorMock) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	if mock.RefreshFunc == nil {
+		panic("AuthServiceInteractorMock.RefreshFunc: method is nil but AuthServiceInteractor.Refresh was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		RefreshToken string
+	}{
+		Ctx:          ctx,
+		RefreshToken: refreshToken,
+	}
+	mock.lockRefresh.Lock()
+	mock.calls.Refresh = append(mock.calls.Refresh, callInfo)
+	mock.lockRefresh.Unlock()
+	return mock.RefreshFunc(ctx, refreshToken)
+}
+
+// RefreshCalls gets all the calls that were made to Refresh.
+// Check the length with:
+//
+//	len(mockedAuthServiceInteractor.RefreshCalls())
+func (mock *AuthServiceInteractorMock) RefreshCalls() []struct {
+	Ctx          context.Context
+	RefreshToken string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		RefreshToken string
+	}
+	mock.lockRefresh.RLock()
+	calls = mock.calls.Refresh
+	mock.lockRefresh.RUnlock()
+	return calls
+}
+
 // Register calls RegisterFunc.
-func (mock *AuthServiceInteractorMock) Register(ctx context.Context, email string, password string) (string, error) {
+func (mock *AuthServiceInteractorMock) Register(ctx context.Context, email string, password string) (*Tokens, error) {
 	if mock.RegisterFunc == nil {
 		panic("AuthServiceInteractorMock.RegisterFunc: method is nil but AuthServiceInteractor.Register was just called")
 	}
@@ -141,3 +313,43 @@ func (mock *AuthServiceInteractorMock) RegisterCalls() []struct {
 	mock.lockRegister.RUnlock()
 	return calls
 }
+
+// ResetPassword calls ResetPasswordFunc.
+func (mock *AuthServiceInteractorMock) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	if mock.ResetPasswordFunc == nil {
+		panic("AuthServiceInteractorMock.ResetPasswordFunc: method is nil but AuthServiceInteractor.ResetPassword was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Token       string
+		NewPassword string
+	}{
+		Ctx:         ctx,
+		Token:       token,
+		NewPassword: newPassword,
+	}
+	mock.lockResetPassword.Lock()
+	mock.calls.ResetPassword = append(mock.calls.ResetPassword, callInfo)
+	mock.lockResetPassword.Unlock()
+	return mock.ResetPasswordFunc(ctx, token, newPassword)
+}
+
+// ResetPasswordCalls gets all the calls that were made to ResetPassword.
+// Check the length with:
+//
+//	len(mockedAuthServiceInteractor.ResetPasswordCalls())
+func (mock *AuthServiceInteractorMock) ResetPasswordCalls() []struct {
+	Ctx         context.Context
+	Token       string
+	NewPassword string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Token       string
+		NewPassword string
+	}
+	mock.lockResetPassword.RLock()
+	calls = mock.calls.ResetPassword
+	mock.lockResetPassword.RUnlock()
+	return calls
+}
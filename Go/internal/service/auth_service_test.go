@@ -5,6 +5,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/you/linkedinify/internal/middleware"
 	"github.com/you/linkedinify/internal/model"
 	"github.com/you/linkedinify/internal/repository"
 	"github.com/you/linkedinify/internal/service"
@@ -44,15 +47,26 @@ func parseTestJWT(t *testing.T, tokenString string, secret []byte) (string, int6
 	return sub, int64(expFloat)
 }
 
+// parseTestRole returns the role claim of a token issued by the service.
+func parseTestRole(t *testing.T, tokenString string) string {
+	t.Helper()
+	claims := jwt.MapClaims{}
+	_, _, err := jwt.NewParser().ParseUnverified(tokenString, claims)
+	require.NoError(t, err)
+	role, _ := claims["role"].(string)
+	return role
+}
+
 func TestAuthService_Register_Success(t *testing.T) {
 	mockUserRepo := &repository.UserRepositoryMock{
 		CreateFunc: func(ctx context.Context, u *model.User) error {
 			assert.NotEmpty(t, u.ID)
 			assert.Equal(t, "test@example.com", u.Email)
 			assert.NotEmpty(t, u.PasswordHash)
-			err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte("password123"))
+			err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte("Correct-horse-7"))
 			assert.NoError(t, err, "Password was not hashed correctly")
 			assert.NotEmpty(t, u.APIToken)
+			assert.Equal(t, model.RoleUser, u.Role)
 			return nil
 		},
 	}
@@ -61,22 +75,26 @@ func TestAuthService_Register_Success(t *testing.T) {
 		GetJWTSecretFunc: func() []byte {
 			return []byte(testJWTSecret)
 		},
+		GetJWTExpiryFunc:         func() time.Duration { return 15 * time.Minute },
+		GetJWTIssuerFunc:         func() string { return "linkedinify" },
+		GetMinPasswordLengthFunc: func() int { return 8 },
 	}
 
 	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider)
 
 	email := "test@example.com"
-	password := "password123"
+	password := "Correct-horse-7"
 
-	tokenString, err := authSvc.Register(context.Background(), email, password)
+	tokens, err := authSvc.Register(context.Background(), email, password)
 	require.NoError(t, err)
-	require.NotEmpty(t, tokenString)
+	require.NotEmpty(t, tokens.AccessToken)
 
 	// Verify JWT
-	userIDStr, exp := parseTestJWT(t, tokenString, []byte(testJWTSecret))
+	userIDStr, exp := parseTestJWT(t, tokens.AccessToken, []byte(testJWTSecret))
 	_, parseErr := uuid.Parse(userIDStr)
 	require.NoError(t, parseErr, "Subject in JWT is not a valid UUID")
 	assert.True(t, exp > time.Now().Unix(), "Token should not be expired")
+	assert.Equal(t, model.RoleUser, parseTestRole(t, tokens.AccessToken))
 
 	assert.Len(t, mockUserRepo.CreateCalls(), 1, "Expected Create to be called once")
 	assert.Len(t, mockConfigProvider.GetJWTSecretCalls(), 1, "Expected GetJWTSecret to be called once")
@@ -89,10 +107,12 @@ func TestAuthService_Register_CreateUserError(t *testing.T) {
 			return dbError
 		},
 	}
-	mockConfigProvider := &service.AuthConfigProviderMock{}
+	mockConfigProvider := &service.AuthConfigProviderMock{
+		GetMinPasswordLengthFunc: func() int { return 8 },
+	}
 
 	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider)
-	_, err := authSvc.Register(context.Background(), "test@example.com", "password123")
+	_, err := authSvc.Register(context.Background(), "test@example.com", "Correct-horse-7")
 
 	require.Error(t, err)
 	assert.Equal(t, dbError, err)
@@ -100,6 +120,47 @@ func TestAuthService_Register_CreateUserError(t *testing.T) {
 	assert.Len(t, mockConfigProvider.GetJWTSecretCalls(), 0)
 }
 
+func TestAuthService_Register_WeakPassword(t *testing.T) {
+	mockUserRepo := &repository.UserRepositoryMock{}
+	authSvc := service.NewAuth(mockUserRepo, &service.AuthConfigProviderMock{
+		GetMinPasswordLengthFunc: func() int { return 10 },
+	})
+
+	_, err := authSvc.Register(context.Background(), "test@example.com", "Password1")
+	assert.ErrorIs(t, err, service.ErrWeakPassword)
+	assert.ErrorContains(t, err, "at least 10 characters", "The configured minimum applies")
+	assert.Empty(t, mockUserRepo.CreateCalls(), "No account is created for a weak password")
+}
+
+func TestAuthService_EmailIsCaseInsensitive(t *testing.T) {
+	var stored *model.User
+	mockUserRepo := &repository.UserRepositoryMock{
+		CreateFunc: func(ctx context.Context, u *model.User) error {
+			stored = u
+			return nil
+		},
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			if stored == nil || email != stored.Email {
+				return nil, sql.ErrNoRows
+			}
+			return stored, nil
+		},
+	}
+	authSvc := service.NewAuth(mockUserRepo, &service.AuthConfigProviderMock{
+		GetJWTSecretFunc:         func() []byte { return []byte(testJWTSecret) },
+		GetJWTExpiryFunc:         func() time.Duration { return 15 * time.Minute },
+		GetJWTIssuerFunc:         func() string { return "linkedinify" },
+		GetMinPasswordLengthFunc: func() int { return 8 },
+	})
+
+	_, err := authSvc.Register(context.Background(), " Ada.Lovelace@Example.COM ", "Correct-horse-7")
+	require.NoError(t, err)
+	assert.Equal(t, "ada.lovelace@example.com", stored.Email, "Emails are stored lower-cased")
+
+	_, err = authSvc.Login(context.Background(), "ADA.LOVELACE@example.com", "Correct-horse-7", "203.0.113.7")
+	assert.NoError(t, err, "Login matches regardless of casing")
+}
+
 func TestAuthService_Login_Success(t *testing.T) {
 	testUserID := uuid.New()
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
@@ -117,16 +178,18 @@ func TestAuthService_Login_Success(t *testing.T) {
 		GetJWTSecretFunc: func() []byte {
 			return []byte(testJWTSecret)
 		},
+		GetJWTExpiryFunc: func() time.Duration { return 15 * time.Minute },
+		GetJWTIssuerFunc: func() string { return "linkedinify" },
 	}
 
 	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider)
 
-	tokenString, err := authSvc.Login(context.Background(), "test@example.com", "password123")
+	tokens, err := authSvc.Login(context.Background(), "test@example.com", "password123", "203.0.113.7")
 	require.NoError(t, err)
-	require.NotEmpty(t, tokenString)
+	require.NotEmpty(t, tokens.AccessToken)
 
 	// Verify JWT
-	userIDStr, exp := parseTestJWT(t, tokenString, []byte(testJWTSecret))
+	userIDStr, exp := parseTestJWT(t, tokens.AccessToken, []byte(testJWTSecret))
 	assert.Equal(t, testUserID.String(), userIDStr, "UserID in JWT does not match")
 	assert.True(t, exp > time.Now().Unix(), "Token should not be expired")
 
@@ -143,7 +206,7 @@ func TestAuthService_Login_UserNotFound(t *testing.T) {
 	mockConfigProvider := &service.AuthConfigProviderMock{}
 
 	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider)
-	_, err := authSvc.Login(context.Background(), "unknown@example.com", "password123")
+	_, err := authSvc.Login(context.Background(), "unknown@example.com", "password123", "203.0.113.7")
 
 	require.Error(t, err)
 	assert.Equal(t, sql.ErrNoRows, err)
@@ -167,7 +230,7 @@ func TestAuthService_Login_IncorrectPassword(t *testing.T) {
 
 	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider)
 
-	_, err := authSvc.Login(context.Background(), "test@example.com", "wrongpassword")
+	_, err := authSvc.Login(context.Background(), "test@example.com", "wrongpassword", "203.0.113.7")
 	require.Error(t, err)
 	assert.Equal(t, jwt.ErrTokenInvalidAudience, err)
 	assert.Len(t, mockUserRepo.FindByEmailCalls(), 1)
@@ -184,10 +247,402 @@ func TestAuthService_Login_RepositoryError(t *testing.T) {
 	mockConfigProvider := &service.AuthConfigProviderMock{}
 
 	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider)
-	_, err := authSvc.Login(context.Background(), "test@example.com", "password")
+	_, err := authSvc.Login(context.Background(), "test@example.com", "password", "203.0.113.7")
 
 	require.Error(t, err)
 	assert.Equal(t, repoErr, err)
 	assert.Len(t, mockUserRepo.FindByEmailCalls(), 1)
 	assert.Len(t, mockConfigProvider.GetJWTSecretCalls(), 0)
 }
+
+func TestAuthService_Login_IssuesRefreshToken(t *testing.T) {
+	testUserID := uuid.New()
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			return &model.User{ID: testUserID, Email: email, PasswordHash: string(hashedPassword)}, nil
+		},
+	}
+	mockRefreshRepo := &repository.RefreshTokenRepositoryMock{
+		CreateFunc: func(ctx context.Context, rt *model.RefreshToken) error {
+			assert.Equal(t, testUserID, rt.UserID)
+			assert.NotEqual(t, uuid.Nil, rt.FamilyID)
+			assert.True(t, rt.ExpiresAt.After(time.Now()))
+			return nil
+		},
+	}
+	mockConfigProvider := &service.AuthConfigProviderMock{
+		GetJWTSecretFunc: func() []byte { return []byte(testJWTSecret) },
+		GetJWTExpiryFunc: func() time.Duration { return 15 * time.Minute },
+		GetJWTIssuerFunc: func() string { return "linkedinify" },
+	}
+
+	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider, service.WithRefreshTokens(mockRefreshRepo))
+	tokens, err := authSvc.Login(context.Background(), "test@example.com", "password123", "203.0.113.7")
+	require.NoError(t, err)
+	require.NotEmpty(t, tokens.RefreshToken)
+
+	require.Len(t, mockRefreshRepo.CreateCalls(), 1)
+	stored := mockRefreshRepo.CreateCalls()[0].T
+	assert.NotEqual(t, tokens.RefreshToken, stored.TokenHash, "The refresh token must be stored hashed")
+}
+
+func TestAuthService_Refresh_RotatesToken(t *testing.T) {
+	testUserID := uuid.New()
+	familyID := uuid.New()
+	stored := &model.RefreshToken{ID: uuid.New(), UserID: testUserID, FamilyID: familyID, ExpiresAt: time.Now().Add(time.Hour)}
+	mockRefreshRepo := &repository.RefreshTokenRepositoryMock{
+		FindByHashFunc: func(ctx context.Context, hash string) (*model.RefreshToken, error) {
+			return stored, nil
+		},
+		RevokeFunc: func(ctx context.Context, id uuid.UUID) (bool, error) {
+			assert.Equal(t, stored.ID, id)
+			return true, nil
+		},
+		CreateFunc: func(ctx context.Context, rt *model.RefreshToken) error {
+			assert.Equal(t, familyID, rt.FamilyID, "Rotated tokens should stay in the same family")
+			return nil
+		},
+	}
+	mockConfigProvider := &service.AuthConfigProviderMock{
+		GetJWTSecretFunc: func() []byte { return []byte(testJWTSecret) },
+		GetJWTExpiryFunc: func() time.Duration { return 15 * time.Minute },
+		GetJWTIssuerFunc: func() string { return "linkedinify" },
+	}
+
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			return &model.User{ID: id, Role: model.RoleAdmin}, nil
+		},
+	}
+
+	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider, service.WithRefreshTokens(mockRefreshRepo))
+	tokens, err := authSvc.Refresh(context.Background(), "presented-token")
+	require.NoError(t, err)
+
+	userIDStr, _ := parseTestJWT(t, tokens.AccessToken, []byte(testJWTSecret))
+	assert.Equal(t, testUserID.String(), userIDStr)
+	assert.Equal(t, model.RoleAdmin, parseTestRole(t, tokens.AccessToken), "The current role should be reloaded on refresh")
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.Len(t, mockRefreshRepo.RevokeCalls(), 1)
+	assert.Len(t, mockRefreshRepo.CreateCalls(), 1)
+}
+
+func TestAuthService_Refresh_ReusedTokenRevokesFamily(t *testing.T) {
+	familyID := uuid.New()
+	revokedAt := time.Now().Add(-time.Minute)
+	mockRefreshRepo := &repository.RefreshTokenRepositoryMock{
+		FindByHashFunc: func(ctx context.Context, hash string) (*model.RefreshToken, error) {
+			return &model.RefreshToken{ID: uuid.New(), FamilyID: familyID, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil
+		},
+		RevokeFamilyFunc: func(ctx context.Context, id uuid.UUID) error {
+			assert.Equal(t, familyID, id)
+			return nil
+		},
+	}
+
+	authSvc := service.NewAuth(&repository.UserRepositoryMock{}, &service.AuthConfigProviderMock{}, service.WithRefreshTokens(mockRefreshRepo))
+	_, err := authSvc.Refresh(context.Background(), "replayed-token")
+	assert.ErrorIs(t, err, service.ErrRefreshTokenReused)
+	assert.Len(t, mockRefreshRepo.RevokeFamilyCalls(), 1)
+}
+
+func TestAuthService_Refresh_ConcurrentRotationRevokesFamily(t *testing.T) {
+	mockRefreshRepo := &repository.RefreshTokenRepositoryMock{
+		FindByHashFunc: func(ctx context.Context, hash string) (*model.RefreshToken, error) {
+			return &model.RefreshToken{ID: uuid.New(), FamilyID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+		RevokeFunc: func(ctx context.Context, id uuid.UUID) (bool, error) {
+			return false, nil
+		},
+		RevokeFamilyFunc: func(ctx context.Context, id uuid.UUID) error {
+			return nil
+		},
+	}
+
+	authSvc := service.NewAuth(&repository.UserRepositoryMock{}, &service.AuthConfigProviderMock{}, service.WithRefreshTokens(mockRefreshRepo))
+	_, err := authSvc.Refresh(context.Background(), "raced-token")
+	assert.ErrorIs(t, err, service.ErrRefreshTokenReused)
+	assert.Len(t, mockRefreshRepo.RevokeFamilyCalls(), 1)
+}
+
+func TestAuthService_Refresh_ExpiredToken(t *testing.T) {
+	mockRefreshRepo := &repository.RefreshTokenRepositoryMock{
+		FindByHashFunc: func(ctx context.Context, hash string) (*model.RefreshToken, error) {
+			return &model.RefreshToken{ID: uuid.New(), ExpiresAt: time.Now().Add(-time.Hour)}, nil
+		},
+	}
+
+	authSvc := service.NewAuth(&repository.UserRepositoryMock{}, &service.AuthConfigProviderMock{}, service.WithRefreshTokens(mockRefreshRepo))
+	_, err := authSvc.Refresh(context.Background(), "expired-token")
+	assert.ErrorIs(t, err, service.ErrInvalidRefreshToken)
+}
+
+func TestAuthService_ForgotPassword_SendsResetLink(t *testing.T) {
+	testUserID := uuid.New()
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			return &model.User{ID: testUserID, Email: email}, nil
+		},
+	}
+	mockResetRepo := &repository.PasswordResetRepositoryMock{
+		CreateFunc: func(ctx context.Context, p *model.PasswordReset) error {
+			assert.Equal(t, testUserID, p.UserID)
+			assert.True(t, p.ExpiresAt.After(time.Now()))
+			return nil
+		},
+	}
+	mockSender := &service.EmailSenderMock{
+		SendFunc: func(ctx context.Context, to, subject, body string) error {
+			assert.Equal(t, "user@example.com", to)
+			assert.Contains(t, body, "https://app.example.com/reset?token=")
+			return nil
+		},
+	}
+	mockConfigProvider := &service.AuthConfigProviderMock{
+		GetPasswordResetURLFunc: func() string { return "https://app.example.com/reset" },
+	}
+
+	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider, service.WithPasswordResets(mockResetRepo, mockSender))
+	err := authSvc.ForgotPassword(context.Background(), "user@example.com")
+	require.NoError(t, err)
+	require.Len(t, mockResetRepo.CreateCalls(), 1)
+	require.Len(t, mockSender.SendCalls(), 1)
+	assert.NotContains(t, mockSender.SendCalls()[0].Body, mockResetRepo.CreateCalls()[0].P.TokenHash,
+		"The email must contain the raw token, not the stored hash")
+}
+
+func TestAuthService_ForgotPassword_UnknownEmail(t *testing.T) {
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			return nil, sql.ErrNoRows
+		},
+	}
+	mockResetRepo := &repository.PasswordResetRepositoryMock{}
+	mockSender := &service.EmailSenderMock{}
+
+	authSvc := service.NewAuth(mockUserRepo, &service.AuthConfigProviderMock{}, service.WithPasswordResets(mockResetRepo, mockSender))
+	err := authSvc.ForgotPassword(context.Background(), "nobody@example.com")
+	require.NoError(t, err, "Unknown emails must look like a success")
+	assert.Len(t, mockResetRepo.CreateCalls(), 0)
+	assert.Len(t, mockSender.SendCalls(), 0)
+}
+
+func TestAuthService_ResetPassword_Success(t *testing.T) {
+	testUserID := uuid.New()
+	resetID := uuid.New()
+	mockResetRepo := &repository.PasswordResetRepositoryMock{
+		FindByHashFunc: func(ctx context.Context, hash string) (*model.PasswordReset, error) {
+			return &model.PasswordReset{ID: resetID, UserID: testUserID, ExpiresAt: time.Now().Add(time.Minute)}, nil
+		},
+		MarkUsedFunc: func(ctx context.Context, id uuid.UUID) (bool, error) {
+			assert.Equal(t, resetID, id)
+			return true, nil
+		},
+	}
+	mockUserRepo := &repository.UserRepositoryMock{
+		UpdatePasswordFunc: func(ctx context.Context, id uuid.UUID, passwordHash string) error {
+			assert.Equal(t, testUserID, id)
+			assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte("New-password-1")))
+			return nil
+		},
+	}
+	mockRefreshRepo := &repository.RefreshTokenRepositoryMock{
+		RevokeAllForUserFunc: func(ctx context.Context, userID uuid.UUID) error {
+			assert.Equal(t, testUserID, userID)
+			return nil
+		},
+	}
+
+	authSvc := service.NewAuth(mockUserRepo, &service.AuthConfigProviderMock{GetMinPasswordLengthFunc: func() int { return 8 }},
+		service.WithRefreshTokens(mockRefreshRepo),
+		service.WithPasswordResets(mockResetRepo, &service.EmailSenderMock{}),
+	)
+	err := authSvc.ResetPassword(context.Background(), "reset-token", "New-password-1")
+	require.NoError(t, err)
+	assert.Len(t, mockUserRepo.UpdatePasswordCalls(), 1)
+	assert.Len(t, mockRefreshRepo.RevokeAllForUserCalls(), 1)
+}
+
+func TestAuthService_ResetPassword_WeakPassword(t *testing.T) {
+	mockResetRepo := &repository.PasswordResetRepositoryMock{}
+	authSvc := service.NewAuth(&repository.UserRepositoryMock{}, &service.AuthConfigProviderMock{GetMinPasswordLengthFunc: func() int { return 8 }},
+		service.WithPasswordResets(mockResetRepo, &service.EmailSenderMock{}))
+
+	err := authSvc.ResetPassword(context.Background(), "reset-token", "qwerty123")
+	assert.ErrorIs(t, err, service.ErrWeakPassword)
+	assert.Empty(t, mockResetRepo.MarkUsedCalls(), "The token stays usable for another try")
+}
+
+func TestAuthService_ResetPassword_ExpiredToken(t *testing.T) {
+	mockResetRepo := &repository.PasswordResetRepositoryMock{
+		FindByHashFunc: func(ctx context.Context, hash string) (*model.PasswordReset, error) {
+			return &model.PasswordReset{ID: uuid.New(), ExpiresAt: time.Now().Add(-time.Minute)}, nil
+		},
+	}
+	mockUserRepo := &repository.UserRepositoryMock{}
+
+	authSvc := service.NewAuth(mockUserRepo, &service.AuthConfigProviderMock{GetMinPasswordLengthFunc: func() int { return 8 }}, service.WithPasswordResets(mockResetRepo, &service.EmailSenderMock{}))
+	err := authSvc.ResetPassword(context.Background(), "reset-token", "New-password-1")
+	assert.ErrorIs(t, err, service.ErrInvalidResetToken)
+	assert.Len(t, mockUserRepo.UpdatePasswordCalls(), 0)
+}
+
+func TestAuthService_Logout_RevokesJTI(t *testing.T) {
+	mockConfigProvider := &service.AuthConfigProviderMock{
+		GetJWTSecretFunc:         func() []byte { return []byte(testJWTSecret) },
+		GetJWTExpiryFunc:         func() time.Duration { return 15 * time.Minute },
+		GetJWTIssuerFunc:         func() string { return "linkedinify" },
+		GetMinPasswordLengthFunc: func() int { return 8 },
+	}
+	mockUserRepo := &repository.UserRepositoryMock{
+		CreateFunc: func(ctx context.Context, u *model.User) error { return nil },
+	}
+	mockRevokedRepo := &repository.RevokedTokenRepositoryMock{
+		RevokeFunc: func(ctx context.Context, jti string, expiresAt time.Time) error { return nil },
+	}
+	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider, service.WithLogout(mockRevokedRepo))
+
+	tokens, err := authSvc.Register(context.Background(), "test@example.com", "Correct-horse-7")
+	require.NoError(t, err)
+	_, exp := parseTestJWT(t, tokens.AccessToken, []byte(testJWTSecret))
+
+	require.NoError(t, authSvc.Logout(context.Background(), tokens.AccessToken))
+	require.NoError(t, authSvc.Logout(context.Background(), tokens.AccessToken), "Logout must be idempotent")
+
+	calls := mockRevokedRepo.RevokeCalls()
+	require.Len(t, calls, 2)
+	assert.NotEmpty(t, calls[0].Jti)
+	assert.Equal(t, calls[0].Jti, calls[1].Jti)
+	assert.Equal(t, exp, calls[0].ExpiresAt.Unix())
+}
+
+func TestAuthService_Logout_RevokesRefreshFamily(t *testing.T) {
+	mockConfigProvider := &service.AuthConfigProviderMock{
+		GetJWTSecretFunc:         func() []byte { return []byte(testJWTSecret) },
+		GetJWTExpiryFunc:         func() time.Duration { return 15 * time.Minute },
+		GetJWTIssuerFunc:         func() string { return "linkedinify" },
+		GetMinPasswordLengthFunc: func() int { return 8 },
+	}
+	mockUserRepo := &repository.UserRepositoryMock{
+		CreateFunc: func(ctx context.Context, u *model.User) error { return nil },
+	}
+	var familyID uuid.UUID
+	mockRefreshRepo := &repository.RefreshTokenRepositoryMock{
+		CreateFunc: func(ctx context.Context, rt *model.RefreshToken) error {
+			familyID = rt.FamilyID
+			return nil
+		},
+		RevokeFamilyFunc: func(ctx context.Context, id uuid.UUID) error { return nil },
+	}
+	mockRevokedRepo := &repository.RevokedTokenRepositoryMock{
+		RevokeFunc: func(ctx context.Context, jti string, expiresAt time.Time) error { return nil },
+	}
+	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider,
+		service.WithRefreshTokens(mockRefreshRepo), service.WithLogout(mockRevokedRepo))
+
+	tokens, err := authSvc.Register(context.Background(), "test@example.com", "Correct-horse-7")
+	require.NoError(t, err)
+	require.NoError(t, authSvc.Logout(context.Background(), tokens.AccessToken))
+
+	calls := mockRefreshRepo.RevokeFamilyCalls()
+	require.Len(t, calls, 1, "Logging out ends the refresh tokens of the login")
+	assert.Equal(t, familyID, calls[0].FamilyID)
+	assert.Len(t, mockRevokedRepo.RevokeCalls(), 1)
+}
+
+func TestAuthService_Logout_InvalidToken(t *testing.T) {
+	mockConfigProvider := &service.AuthConfigProviderMock{
+		GetJWTSecretFunc: func() []byte { return []byte(testJWTSecret) },
+		GetJWTExpiryFunc: func() time.Duration { return 15 * time.Minute },
+		GetJWTIssuerFunc: func() string { return "linkedinify" },
+	}
+	mockRevokedRepo := &repository.RevokedTokenRepositoryMock{}
+	authSvc := service.NewAuth(&repository.UserRepositoryMock{}, mockConfigProvider, service.WithLogout(mockRevokedRepo))
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": uuid.NewString(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"jti": uuid.NewString(),
+	})
+	raw, err := forged.SignedString([]byte("some-other-secret"))
+	require.NoError(t, err)
+
+	err = authSvc.Logout(context.Background(), raw)
+	assert.ErrorIs(t, err, service.ErrInvalidAccessToken)
+	assert.Len(t, mockRevokedRepo.RevokeCalls(), 0)
+}
+
+func TestAuthService_Login_ConfiguredExpiryAndIssuer(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			return &model.User{ID: uuid.New(), Email: email, PasswordHash: string(hashedPassword)}, nil
+		},
+	}
+	expiry := 2 * time.Hour
+	mockConfigProvider := &service.AuthConfigProviderMock{
+		GetJWTSecretFunc: func() []byte { return []byte(testJWTSecret) },
+		GetJWTExpiryFunc: func() time.Duration { return expiry },
+		GetJWTIssuerFunc: func() string { return "linkedinify-test" },
+	}
+	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider)
+
+	// authenticate runs a token through the auth middleware.
+	authenticate := func(token, issuer string) int {
+		h := middleware.Auth([]byte(testJWTSecret), middleware.WithIssuer(issuer))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	before := time.Now().Unix()
+	tokens, err := authSvc.Login(context.Background(), "test@example.com", "password123", "203.0.113.7")
+	require.NoError(t, err)
+	assert.Equal(t, expiry, tokens.ExpiresIn)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(tokens.AccessToken, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(testJWTSecret), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "linkedinify-test", claims["iss"])
+	iat, nbf, exp := int64(claims["iat"].(float64)), int64(claims["nbf"].(float64)), int64(claims["exp"].(float64))
+	assert.GreaterOrEqual(t, iat, before)
+	assert.Equal(t, iat, nbf)
+	assert.Equal(t, iat+int64(expiry/time.Second), exp)
+
+	assert.Equal(t, http.StatusOK, authenticate(tokens.AccessToken, "linkedinify-test"))
+	assert.Equal(t, http.StatusUnauthorized, authenticate(tokens.AccessToken, "someone-else"), "Tokens from another issuer are rejected")
+
+	expiry = -time.Minute
+	tokens, err = authSvc.Login(context.Background(), "test@example.com", "password123", "203.0.113.7")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, authenticate(tokens.AccessToken, "linkedinify-test"), "Expired tokens are rejected")
+}
+
+func TestAuthService_Login_OrgClaim(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	orgID := uuid.New()
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			return &model.User{ID: uuid.New(), Email: email, PasswordHash: string(hashedPassword), OrgID: orgID}, nil
+		},
+	}
+	mockConfigProvider := &service.AuthConfigProviderMock{
+		GetJWTSecretFunc: func() []byte { return []byte(testJWTSecret) },
+		GetJWTExpiryFunc: func() time.Duration { return time.Hour },
+		GetJWTIssuerFunc: func() string { return "" },
+	}
+	authSvc := service.NewAuth(mockUserRepo, mockConfigProvider)
+
+	tokens, err := authSvc.Login(context.Background(), "test@example.com", "password123", "203.0.113.7")
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{}
+	_, _, err = jwt.NewParser().ParseUnverified(tokens.AccessToken, claims)
+	require.NoError(t, err)
+	assert.Equal(t, orgID.String(), claims["org"], "The org claim names the user's organization")
+}
@@ -0,0 +1,74 @@
+// internal/service/batch.go
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+// ErrBatchTooLarge is returned by TransformBatch for more than MaxBatchSize
+// items.
+var ErrBatchTooLarge = errors.New("batch too large")
+
+// MaxBatchSize is the most posts one TransformBatch call generates.
+const MaxBatchSize = 20
+
+// DefaultBatchConcurrency is how many posts of a batch are generated at once
+// unless WithBatchConcurrency says otherwise.
+const DefaultBatchConcurrency = 4
+
+// WithBatchConcurrency sets how many posts of a batch are generated at once.
+// Zero or less means DefaultBatchConcurrency.
+func WithBatchConcurrency(n int) LinkedInOption {
+	return func(l *LinkedInService) {
+		if n > 0 {
+			l.batchConcurrency = n
+		}
+	}
+}
+
+// WithBatchItemTimeout bounds the generation of each post of a batch, so one
+// slow item cannot hold up the rest. Zero leaves items bounded only by the
+// request.
+func WithBatchItemTimeout(d time.Duration) LinkedInOption {
+	return func(l *LinkedInService) { l.batchItemTimeout = d }
+}
+
+// BatchItem is one post to generate in a batch.
+type BatchItem struct {
+	Text    string
+	Options TransformOptions
+}
+
+// BatchResult is the outcome of one BatchItem: either Result or Err is set.
+// Err wraps ai.ErrAbandoned for an item the batch was cancelled before it
+// completed.
+type BatchResult struct {
+	Result *TransformResult
+	Err    error
+}
+
+// TransformBatch generates a post for each item, as Transform would, with at
+// most the configured number of generations running at once. A failed item
+// does not stop the others; results are in the order of items. When ctx is
+// cancelled the items done so far keep their results and the rest are
+// abandoned.
+func (l *LinkedInService) TransformBatch(ctx context.Context, userID uuid.UUID, items []BatchItem) ([]BatchResult, error) {
+	if len(items) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+	results := make([]BatchResult, len(items))
+	runner := ai.NewBatchRunner[*TransformResult](l.batchConcurrency, l.batchItemTimeout)
+	done := runner.Run(ctx, len(items), func(ctx context.Context, i int) (*TransformResult, error) {
+		return l.Transform(ctx, userID, items[i].Text, items[i].Options)
+	})
+	for res := range done {
+		results[res.Index] = BatchResult{Result: res.Value, Err: res.Err}
+	}
+	return results, nil
+}
@@ -0,0 +1,34 @@
+// internal/service/context_length.go
+package service
+
+import (
+	"cmp"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+// contextCheck is what checkContextLength needs to know about the client.
+type contextCheck struct {
+	model        string
+	systemPrompt string
+}
+
+// WithContextCheck rejects prompts estimated not to fit the context window
+// of their model before they are sent, with the *ai.Error of kind
+// ai.ErrorContextLength the provider would have answered after a round
+// trip. model is the client's default model and systemPrompt the system
+// message it sends, empty meaning ai.DefaultSystemPrompt.
+func WithContextCheck(model, systemPrompt string) LinkedInOption {
+	return func(l *LinkedInService) {
+		l.context = &contextCheck{model: model, systemPrompt: cmp.Or(systemPrompt, ai.DefaultSystemPrompt)}
+	}
+}
+
+// checkContextLength checks prompt against the context window of the model
+// opts select.
+func (l *LinkedInService) checkContextLength(prompt string, opts ai.Options) error {
+	if l.context == nil {
+		return nil
+	}
+	return ai.CheckContextLength(cmp.Or(opts.Model, l.context.model), l.context.systemPrompt, prompt, opts)
+}
@@ -0,0 +1,41 @@
+// internal/service/context_length_test.go
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestLinkedInService_ContextCheck(t *testing.T) {
+	unavailable := errors.New("provider unavailable")
+	aiClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{}, unavailable
+		},
+	}
+	liSvc := service.NewLinkedIn(aiClient, &repository.PostRepositoryMock{}, service.WithContextCheck("gpt-4", ""))
+	long := strings.Repeat("word ", 7000)
+
+	_, err := liSvc.Transform(context.Background(), uuid.New(), long, service.TransformOptions{})
+	var aiErr *ai.Error
+	require.ErrorAs(t, err, &aiErr)
+	assert.Equal(t, ai.ErrorContextLength, aiErr.Kind)
+	_, err = liSvc.TransformStream(context.Background(), uuid.New(), long, service.TransformOptions{})
+	require.ErrorAs(t, err, &aiErr)
+	assert.Empty(t, aiClient.TransformCalls(), "Nothing is sent to the provider")
+	assert.Empty(t, aiClient.StreamCalls())
+
+	_, err = liSvc.Transform(context.Background(), uuid.New(), long, service.TransformOptions{Model: "gpt-4o-mini"})
+	assert.ErrorIs(t, err, unavailable, "A model with a larger window is checked against its own")
+	assert.Len(t, aiClient.TransformCalls(), 1)
+}
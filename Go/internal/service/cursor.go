@@ -0,0 +1,146 @@
+// internal/service/cursor.go
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+// ErrInvalidCursor is returned for a history cursor that was not issued by
+// this service or has been altered.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PostPage is one page of a user's posts in cursor order. NextCursor fetches
+// the page after it and is empty on the last page.
+type PostPage struct {
+	Posts      []model.LinkedInPost
+	NextCursor string
+}
+
+// cursorMACSize is how much of the HMAC-SHA256 a cursor carries; 128 bits
+// is plenty to make cursors unforgeable.
+const cursorMACSize = 16
+
+// cursorPayloadSize is the sort, the post's sort key in microseconds and its
+// ID.
+const cursorPayloadSize = 1 + 8 + 16
+
+// WithCursorSecret signs history cursors with secret, so cursors stay valid
+// across restarts and instances sharing it. Without one a random secret is
+// used.
+func WithCursorSecret(secret []byte) LinkedInOption {
+	return func(l *LinkedInService) {
+		if len(secret) > 0 {
+			l.cursorSecret = secret
+		}
+	}
+}
+
+// randomCursorSecret is the cursor secret used unless WithCursorSecret is
+// given.
+func randomCursorSecret() []byte {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic("reading random cursor secret: " + err.Error())
+	}
+	return secret
+}
+
+// cursorMAC signs payload. The key is derived from the cursor secret so a
+// secret shared with other uses, such as signing JWTs, never signs cursors
+// directly.
+func (l *LinkedInService) cursorMAC(payload []byte) []byte {
+	key := hmac.New(sha256.New, l.cursorSecret)
+	key.Write([]byte("linkedinify post cursor"))
+	mac := hmac.New(sha256.New, key.Sum(nil))
+	mac.Write(payload)
+	return mac.Sum(nil)[:cursorMACSize]
+}
+
+// cursorSort is the index of sort in PostSorts, which a cursor carries so it
+// cannot be used to continue a listing in another order.
+func cursorSort(sort PostSort) byte {
+	for i, s := range PostSorts {
+		if s == sort {
+			return byte(i)
+		}
+	}
+	return 0
+}
+
+// sortKey is the value post is sorted by under sort, matching the
+// repository's sort expressions.
+func sortKey(post model.LinkedInPost, sort PostSort) time.Time {
+	if sort == SortUpdatedDesc && !post.UpdatedAt.IsZero() {
+		return post.UpdatedAt
+	}
+	return post.CreatedAt
+}
+
+// encodeCursor returns the opaque cursor of the page after post in the order
+// of sort.
+func (l *LinkedInService) encodeCursor(post model.LinkedInPost, sort PostSort) string {
+	b := []byte{cursorSort(sort)}
+	b = binary.BigEndian.AppendUint64(b, uint64(sortKey(post, sort).UnixMicro()))
+	b = append(b, post.ID[:]...)
+	b = append(b, l.cursorMAC(b)...)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (l *LinkedInService) decodeCursor(cursor string, sort PostSort) (*repository.PostCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(b) != cursorPayloadSize+cursorMACSize {
+		return nil, ErrInvalidCursor
+	}
+	payload, mac := b[:cursorPayloadSize], b[cursorPayloadSize:]
+	if !hmac.Equal(mac, l.cursorMAC(payload)) || payload[0] != cursorSort(sort) {
+		return nil, ErrInvalidCursor
+	}
+	id, err := uuid.FromBytes(bytes.Clone(payload[9:]))
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	key := time.UnixMicro(int64(binary.BigEndian.Uint64(payload[1:9])))
+	return &repository.PostCursor{Key: key, ID: id}, nil
+}
+
+// HistoryAfter lists up to limit of the user's posts matching f after the
+// cursor returned with the previous page, in the order of f.Sort. An empty
+// cursor starts at the first post. Unlike History's offsets, cursors neither
+// skip nor repeat posts when new ones are created between pages. A cursor
+// only continues the sort it was issued for.
+func (l *LinkedInService) HistoryAfter(ctx context.Context, userID uuid.UUID, f PostFilter, cursor string, limit int) (*PostPage, error) {
+	var after *repository.PostCursor
+	if cursor != "" {
+		var err error
+		if after, err = l.decodeCursor(cursor, f.Sort); err != nil {
+			return nil, err
+		}
+	}
+	if limit < 1 {
+		return &PostPage{NextCursor: cursor}, nil
+	}
+	// One post more than asked for tells whether there is a next page.
+	posts, err := l.posts.ListByUserAfter(ctx, userID, f, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &PostPage{Posts: posts}
+	if len(posts) > limit {
+		page.Posts = posts[:limit]
+		page.NextCursor = l.encodeCursor(posts[limit-1], f.Sort)
+	}
+	return page, nil
+}
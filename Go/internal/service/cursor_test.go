@@ -0,0 +1,132 @@
+// internal/service/cursor_test.go
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// cursorPosts is a PostRepository holding posts, newest first, that
+// implements ListByUserAfter.
+func cursorPosts(posts []model.LinkedInPost) *repository.PostRepositoryMock {
+	return &repository.PostRepositoryMock{
+		ListByUserAfterFunc: func(ctx context.Context, userID uuid.UUID, f repository.PostFilter, after *repository.PostCursor, limit int) ([]model.LinkedInPost, error) {
+			start := 0
+			if after != nil {
+				for start < len(posts) && !posts[start].CreatedAt.Before(after.Key) {
+					start++
+				}
+			}
+			return posts[start:min(start+limit, len(posts))], nil
+		},
+	}
+}
+
+func TestLinkedInService_HistoryAfter(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now().Truncate(time.Microsecond)
+	var posts []model.LinkedInPost
+	for i := range 5 {
+		posts = append(posts, model.LinkedInPost{ID: uuid.New(), UserID: userID, CreatedAt: now.Add(-time.Duration(i) * time.Minute)})
+	}
+	repo := cursorPosts(posts)
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, repo, service.WithCursorSecret(secret))
+	ctx := context.Background()
+
+	var seen []uuid.UUID
+	cursor := ""
+	for range 3 {
+		page, err := liSvc.HistoryAfter(ctx, userID, service.PostFilter{}, cursor, 2)
+		require.NoError(t, err)
+		for _, p := range page.Posts {
+			seen = append(seen, p.ID)
+		}
+		cursor = page.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+	require.Len(t, seen, 5)
+	for i, p := range posts {
+		assert.Equal(t, p.ID, seen[i], "Pages neither skip nor repeat posts")
+	}
+	assert.Empty(t, cursor, "The last page has no next cursor")
+
+	page, err := liSvc.HistoryAfter(ctx, userID, service.PostFilter{}, "", 2)
+	require.NoError(t, err)
+	after := repo.ListByUserAfterCalls()
+	assert.Equal(t, 3, after[len(after)-1].Limit, "One extra post is fetched to detect the last page")
+
+	again, err := service.NewLinkedIn(&ai.ClientMock{}, repo, service.WithCursorSecret(secret)).HistoryAfter(ctx, userID, service.PostFilter{}, page.NextCursor, 2)
+	require.NoError(t, err, "Cursors are valid on every instance sharing the secret")
+	assert.Equal(t, posts[2].ID, again.Posts[0].ID)
+
+	_, err = liSvc.HistoryAfter(ctx, userID, service.PostFilter{Sort: service.SortCreatedAsc}, page.NextCursor, 2)
+	assert.ErrorIs(t, err, service.ErrInvalidCursor, "A cursor only continues the sort it was issued for")
+
+	for name, cursor := range map[string]string{
+		"not base64":   "not a cursor!",
+		"truncated":    page.NextCursor[:10],
+		"tampered":     tamper(page.NextCursor),
+		"other secret": mustNextCursor(t, service.NewLinkedIn(&ai.ClientMock{}, repo), userID),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := liSvc.HistoryAfter(ctx, userID, service.PostFilter{}, cursor, 2)
+			assert.ErrorIs(t, err, service.ErrInvalidCursor)
+		})
+	}
+}
+
+func TestLinkedInService_HistoryAfter_UpdatedSort(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now().Truncate(time.Microsecond)
+	edited := model.LinkedInPost{ID: uuid.New(), UserID: userID, CreatedAt: now.Add(-time.Hour), UpdatedAt: now}
+	unedited := model.LinkedInPost{ID: uuid.New(), UserID: userID, CreatedAt: now.Add(-time.Minute)}
+	repo := &repository.PostRepositoryMock{
+		ListByUserAfterFunc: func(ctx context.Context, userID uuid.UUID, f repository.PostFilter, after *repository.PostCursor, limit int) ([]model.LinkedInPost, error) {
+			return []model.LinkedInPost{edited, unedited}, nil
+		},
+	}
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, repo)
+	ctx := context.Background()
+	f := service.PostFilter{Sort: service.SortUpdatedDesc}
+
+	page, err := liSvc.HistoryAfter(ctx, userID, f, "", 1)
+	require.NoError(t, err)
+	_, err = liSvc.HistoryAfter(ctx, userID, f, page.NextCursor, 1)
+	require.NoError(t, err)
+	after := repo.ListByUserAfterCalls()[1].After
+	require.NotNil(t, after)
+	assert.True(t, edited.UpdatedAt.Equal(after.Key), "The cursor is at the time the post was edited")
+	assert.Equal(t, edited.ID, after.ID)
+	assert.Equal(t, service.SortUpdatedDesc, repo.ListByUserAfterCalls()[1].F.Sort)
+}
+
+// tamper flips one character of a cursor.
+func tamper(cursor string) string {
+	c := "A"
+	if strings.HasPrefix(cursor, "A") {
+		c = "B"
+	}
+	return c + cursor[1:]
+}
+
+func mustNextCursor(t *testing.T, svc service.LinkedInServiceInteractor, userID uuid.UUID) string {
+	t.Helper()
+	page, err := svc.HistoryAfter(context.Background(), userID, service.PostFilter{}, "", 1)
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextCursor)
+	return page.NextCursor
+}
@@ -0,0 +1,121 @@
+// internal/service/duplicate.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrDuplicatePost is matched by a *DuplicateError.
+var ErrDuplicatePost = errors.New("near-duplicate of a recent post")
+
+// DuplicateError is returned by Transform when the generated post is nearly
+// identical to one the user generated recently. The new post is not saved;
+// set TransformOptions.Force to save it anyway.
+type DuplicateError struct {
+	// PostID is the recent post the new one duplicates.
+	PostID uuid.UUID
+	// Similarity, from 0 to 1, is how alike the posts are.
+	Similarity float64
+}
+
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("%s %s (%.0f%% similar)", ErrDuplicatePost, e.PostID, e.Similarity*100)
+}
+
+func (e *DuplicateError) Is(target error) bool { return target == ErrDuplicatePost }
+
+// Defaults for the duplicate check. It is off unless a threshold is
+// configured; DefaultDuplicateThreshold is a good one to turn it on with.
+const (
+	DefaultDuplicateThreshold = 0.9
+	DefaultDuplicateWindow    = 24 * time.Hour
+	// duplicateCheckLimit is how many recent posts a new one is compared
+	// with at most.
+	duplicateCheckLimit = 50
+	// shingleSize is how many consecutive words Similarity compares.
+	shingleSize = 3
+)
+
+// duplicateCheck is how alike a post must be to one generated in the last
+// window to be rejected.
+type duplicateCheck struct {
+	threshold float64
+	window    time.Duration
+}
+
+// WithDuplicateCheck rejects generated posts with a Similarity of at least
+// threshold to a post the user generated in the last window, so a post
+// generated twice by accident is not saved twice.
+func WithDuplicateCheck(threshold float64, window time.Duration) LinkedInOption {
+	return func(l *LinkedInService) { l.duplicates = &duplicateCheck{threshold: threshold, window: window} }
+}
+
+// checkDuplicate returns a *DuplicateError when text is too alike to one of
+// userID's recent posts. A failed lookup only costs the check, so it is
+// logged rather than failing the post.
+func (l *LinkedInService) checkDuplicate(ctx context.Context, userID uuid.UUID, text string) error {
+	if l.duplicates == nil {
+		return nil
+	}
+	recent, err := l.posts.Recent(ctx, userID, time.Now().Add(-l.duplicates.window), duplicateCheckLimit)
+	if err != nil {
+		slog.WarnContext(ctx, "loading recent posts failed, skipping the duplicate check", "error", err)
+		return nil
+	}
+	shingles := shingle(text)
+	var dup *DuplicateError
+	for _, p := range recent {
+		s := jaccard(shingles, shingle(p.OutputText))
+		if s >= l.duplicates.threshold && (dup == nil || s > dup.Similarity) {
+			dup = &DuplicateError{PostID: p.ID, Similarity: s}
+		}
+	}
+	if dup == nil {
+		return nil
+	}
+	return dup
+}
+
+// Similarity reports how alike two posts are, from 0 for nothing in common
+// to 1 for the same words in the same order. It is the Jaccard index of the
+// runs of three consecutive words of each, ignoring case and whitespace, so
+// rewording a sentence lowers it a little and reordering paragraphs hardly
+// at all.
+func Similarity(a, b string) float64 {
+	return jaccard(shingle(a), shingle(b))
+}
+
+// shingle returns the set of runs of shingleSize consecutive words of s,
+// lowercased. Shorter texts are one run of all their words.
+func shingle(s string) map[string]struct{} {
+	words := strings.Fields(strings.ToLower(s))
+	set := make(map[string]struct{})
+	if len(words) == 0 {
+		return set
+	}
+	n := max(len(words)-shingleSize+1, 1)
+	for i := range n {
+		set[strings.Join(words[i:min(i+shingleSize, len(words))], " ")] = struct{}{}
+	}
+	return set
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for s := range a {
+		if _, ok := b[s]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
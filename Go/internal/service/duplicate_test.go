@@ -0,0 +1,82 @@
+// internal/service/duplicate_test.go
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestSimilarity(t *testing.T) {
+	post := "Thrilled to announce that I have joined Acme as a senior engineer. Grateful for the journey so far!"
+	assert.Equal(t, 1.0, service.Similarity(post, "  THRILLED to announce that I have joined Acme\n\nas a senior engineer.   Grateful for the journey so far! "),
+		"Case and whitespace are ignored")
+	assert.Greater(t, service.Similarity(post, post+" #hiring"), 0.9, "Appending a hashtag")
+	assert.Less(t, service.Similarity(post, "Five lessons I learned shipping a product in six weeks."), 0.1)
+	assert.Equal(t, 1.0, service.Similarity("Hello world", "hello  WORLD"), "Posts shorter than a shingle")
+	assert.Zero(t, service.Similarity("Hello world", "Goodbye world"))
+}
+
+func TestLinkedInService_Transform_Duplicate(t *testing.T) {
+	userID, earlierID := uuid.New(), uuid.New()
+	aiClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "Excited to share that our team shipped the new onboarding flow today!"}, nil
+		},
+	}
+	repo := &repository.PostRepositoryMock{
+		RecentFunc: func(ctx context.Context, id uuid.UUID, since time.Time, limit int) ([]model.LinkedInPost, error) {
+			return []model.LinkedInPost{
+				{ID: uuid.New(), OutputText: "Something else entirely."},
+				{ID: earlierID, OutputText: "excited to share that our team  shipped the new onboarding flow today!"},
+			}, nil
+		},
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(aiClient, repo, service.WithCache(nil), service.WithDuplicateCheck(0.9, time.Hour))
+
+	start := time.Now()
+	_, err := liSvc.Transform(context.Background(), userID, "text", service.TransformOptions{})
+	var dup *service.DuplicateError
+	require.True(t, errors.As(err, &dup), err)
+	assert.ErrorIs(t, err, service.ErrDuplicatePost)
+	assert.Equal(t, earlierID, dup.PostID)
+	assert.Equal(t, 1.0, dup.Similarity)
+	assert.Empty(t, repo.SaveCalls(), "The duplicate is not saved")
+	assert.Equal(t, userID, repo.RecentCalls()[0].UserID)
+	assert.WithinDuration(t, start.Add(-time.Hour), repo.RecentCalls()[0].Since, 5*time.Second)
+
+	_, err = liSvc.Transform(context.Background(), userID, "text", service.TransformOptions{Force: true})
+	require.NoError(t, err)
+	assert.Len(t, repo.SaveCalls(), 1, "Forced duplicates are saved")
+	assert.Len(t, repo.RecentCalls(), 1, "Forced posts are not compared")
+}
+
+func TestLinkedInService_Transform_DuplicateLookupFails(t *testing.T) {
+	aiClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "Post"}, nil
+		},
+	}
+	repo := &repository.PostRepositoryMock{
+		RecentFunc: func(ctx context.Context, id uuid.UUID, since time.Time, limit int) ([]model.LinkedInPost, error) {
+			return nil, errors.New("connection refused")
+		},
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(aiClient, repo, service.WithDuplicateCheck(0.9, time.Hour))
+
+	_, err := liSvc.Transform(context.Background(), uuid.New(), "text", service.TransformOptions{})
+	require.NoError(t, err, "A failed lookup skips the check")
+	assert.Len(t, repo.SaveCalls(), 1)
+}
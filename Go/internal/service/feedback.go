@@ -0,0 +1,62 @@
+// internal/service/feedback.go
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+// ErrInvalidRating is returned for a rating other than model.FeedbackUp or
+// model.FeedbackDown.
+var ErrInvalidRating = errors.New("invalid rating")
+
+// MaxFeedbackCommentLength is the longest feedback comment, in characters.
+const MaxFeedbackCommentLength = 1000
+
+// ValidRating reports whether rating is one users may give.
+func ValidRating(rating string) bool {
+	return rating == model.FeedbackUp || rating == model.FeedbackDown
+}
+
+// FeedbackStat is the rating count of one template and model.
+type FeedbackStat = repository.FeedbackStat
+
+// RatePost records the user's rating of one of their posts together with the
+// model, tone and template it was generated with, replacing any earlier
+// rating of it. The comment is trimmed and may be empty; its length is
+// expected to have been checked by the caller.
+func (l *LinkedInService) RatePost(ctx context.Context, userID, postID uuid.UUID, rating, comment string) (*model.PostFeedback, error) {
+	if !ValidRating(rating) {
+		return nil, ErrInvalidRating
+	}
+	post, err := l.posts.GetOwned(ctx, userID, postID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	f := &model.PostFeedback{
+		ID:       uuid.New(),
+		PostID:   post.ID,
+		UserID:   userID,
+		Rating:   rating,
+		Comment:  strings.TrimSpace(comment),
+		Model:    post.Model,
+		Tone:     post.Tone,
+		Template: post.Template,
+	}
+	if err := l.posts.SaveFeedback(ctx, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// FeedbackStats reports how well each template and model combination is
+// rated.
+func (a *AdminService) FeedbackStats(ctx context.Context) ([]FeedbackStat, error) {
+	return a.posts.FeedbackStats(ctx)
+}
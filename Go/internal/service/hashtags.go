@@ -0,0 +1,114 @@
+// internal/service/hashtags.go
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+// ErrNoHashtags is returned when the AI reply contained no usable hashtags,
+// typically because the model answered in prose.
+var ErrNoHashtags = errors.New("AI returned no hashtags")
+
+// MaxHashtags is the most hashtags SuggestHashtags returns.
+const MaxHashtags = 10
+
+// hashtagMaxTokens caps the reply; ten hashtags fit comfortably.
+const hashtagMaxTokens = 100
+
+// hashtagInstruction precedes the post in the hashtag prompt.
+const hashtagInstruction = "Suggest between 5 and 10 relevant LinkedIn hashtags for the following post. " +
+	"Reply with only the hashtags, separated by spaces, each starting with #. " +
+	"Do not number them or add any other text.\n\nPost:\n"
+
+// SuggestHashtags asks the AI for hashtags that suit content. They are
+// lowercased and deduplicated, and anything in the reply that is not a
+// hashtag is dropped.
+func (l *LinkedInService) SuggestHashtags(ctx context.Context, content string) ([]string, error) {
+	tags, _, err := l.hashtags(ctx, content)
+	return tags, err
+}
+
+// PostHashtags suggests hashtags for one of the user's posts.
+func (l *LinkedInService) PostHashtags(ctx context.Context, userID, postID uuid.UUID) ([]string, error) {
+	post, err := l.posts.GetOwned(ctx, userID, postID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return l.SuggestHashtags(ctx, post.OutputText)
+}
+
+// hashtags is SuggestHashtags, also returning what the suggestion cost.
+func (l *LinkedInService) hashtags(ctx context.Context, content string) ([]string, ai.Usage, error) {
+	res, err := l.ai.Transform(ctx, hashtagInstruction+content, ai.Options{MaxTokens: hashtagMaxTokens})
+	if err != nil {
+		return nil, ai.Usage{}, err
+	}
+	tags := parseHashtags(res.Text)
+	if len(tags) == 0 {
+		return nil, res.Usage, ErrNoHashtags
+	}
+	return tags, res.Usage, nil
+}
+
+// hashtagLine suggests hashtags for post and returns them as a line to add
+// to its end. A failed suggestion only costs the hashtags, so it is logged
+// and the line is empty.
+func (l *LinkedInService) hashtagLine(ctx context.Context, post string) (string, ai.Usage) {
+	tags, usage, err := l.hashtags(ctx, post)
+	if err != nil {
+		slog.WarnContext(ctx, "suggesting hashtags failed, saving post without them", "error", err)
+		return "", usage
+	}
+	return "\n\n" + strings.Join(tags, " "), usage
+}
+
+// parseHashtags extracts the hashtags from an AI reply, in order, lowercased
+// and without duplicates. Words not starting with # are ignored, as are
+// hashtags with anything but letters, digits and underscores after trailing
+// punctuation is removed.
+func parseHashtags(reply string) []string {
+	fields := strings.FieldsFunc(reply, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+	seen := make(map[string]bool)
+	var tags []string
+	for _, f := range fields {
+		if !strings.HasPrefix(f, "#") {
+			continue
+		}
+		tag := strings.ToLower(strings.TrimRight(f, ".;:!?)\"'"))
+		if !validHashtag(tag) || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+		if len(tags) == MaxHashtags {
+			break
+		}
+	}
+	return tags
+}
+
+// validHashtag reports whether tag is # followed by letters, digits and
+// underscores, with at least one letter.
+func validHashtag(tag string) bool {
+	letter := false
+	for _, r := range tag[1:] {
+		switch {
+		case unicode.IsLetter(r):
+			letter = true
+		case unicode.IsDigit(r) || r == '_':
+		default:
+			return false
+		}
+	}
+	return letter
+}
@@ -0,0 +1,160 @@
+// internal/service/idempotency.go
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrIdempotencyKeyReused is returned by Transform when an idempotency key is
+// sent again with a different request.
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+
+// DefaultIdempotencyTTL is how long Transform remembers an idempotency key
+// unless WithIdempotencyTTL is given.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// WithIdempotencyTTL sets how long the result of a Transform with
+// TransformOptions.IdempotencyKey is replayed for repeats of the key. Keys are
+// kept in memory, so they are per instance and lost on restart. A ttl of zero
+// disables idempotency keys.
+func WithIdempotencyTTL(ttl time.Duration) LinkedInOption {
+	return func(l *LinkedInService) {
+		l.idempotency = nil
+		if ttl > 0 {
+			l.idempotency = newIdempotencyKeys(ttl)
+		}
+	}
+}
+
+// idempotencyKeys remembers successful Transform results by user and key.
+type idempotencyKeys struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]*idempotencyEntry
+	lastSweep time.Time
+}
+
+// idempotencyEntry is a key that is being, or has been, used. done is closed
+// once the first request with the key has finished; res is nil if it failed.
+type idempotencyEntry struct {
+	fingerprint [sha256.Size]byte
+	done        chan struct{}
+	res         *TransformResult
+	expires     time.Time
+}
+
+func newIdempotencyKeys(ttl time.Duration) *idempotencyKeys {
+	return &idempotencyKeys{ttl: ttl, now: time.Now, entries: make(map[string]*idempotencyEntry)}
+}
+
+// do runs fn for the first request with key and replays its result for
+// repeats, which must be for the same text and options. Repeats arriving
+// while fn is running wait for it, so only one generation fires. A failed
+// request is forgotten and the next repeat runs fn itself.
+func (k *idempotencyKeys) do(ctx context.Context, userID uuid.UUID, key, text string, opts TransformOptions, fn func() (*TransformResult, error)) (*TransformResult, error) {
+	id, fp := idempotencyID(userID, key, text, opts)
+	for {
+		k.mu.Lock()
+		k.sweep()
+		e := k.entries[id]
+		if e != nil && e.res != nil && k.now().After(e.expires) {
+			delete(k.entries, id)
+			e = nil
+		}
+		if e == nil {
+			e = &idempotencyEntry{fingerprint: fp, done: make(chan struct{})}
+			k.entries[id] = e
+			k.mu.Unlock()
+			return k.run(id, e, fn)
+		}
+		k.mu.Unlock()
+		if e.fingerprint != fp {
+			return nil, ErrIdempotencyKeyReused
+		}
+
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if e.res != nil {
+			replay := *e.res
+			replay.Replayed = true
+			return &replay, nil
+		}
+	}
+}
+
+// replay returns what do would replay for a repeat of key, waiting for a
+// request with the key still running, or nil when do would run fn instead.
+func (k *idempotencyKeys) replay(ctx context.Context, userID uuid.UUID, key, text string, opts TransformOptions) (*TransformResult, error) {
+	id, fp := idempotencyID(userID, key, text, opts)
+	k.mu.Lock()
+	e := k.entries[id]
+	k.mu.Unlock()
+	if e == nil {
+		return nil, nil
+	}
+	if e.fingerprint != fp {
+		return nil, ErrIdempotencyKeyReused
+	}
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if e.res == nil || k.now().After(e.expires) {
+		return nil, nil
+	}
+	replay := *e.res
+	replay.Replayed = true
+	return &replay, nil
+}
+
+// idempotencyID identifies key among the keys of all users, and fingerprints
+// the request it was sent with.
+func idempotencyID(userID uuid.UUID, key, text string, opts TransformOptions) (string, [sha256.Size]byte) {
+	sampling := opts.samplingKey()
+	opts.IdempotencyKey = ""
+	opts.Temperature, opts.TopP = nil, nil
+	return userID.String() + " " + key, sha256.Sum256(fmt.Appendf(nil, "%q %+v %s", text, opts, sampling))
+}
+
+func (k *idempotencyKeys) run(id string, e *idempotencyEntry, fn func() (*TransformResult, error)) (*TransformResult, error) {
+	res, err := fn()
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if err != nil {
+		delete(k.entries, id)
+	} else {
+		e.res = res
+		e.expires = k.now().Add(k.ttl)
+	}
+	close(e.done)
+	return res, err
+}
+
+// sweep drops expired keys, at most once a minute. k.mu must be held.
+func (k *idempotencyKeys) sweep() {
+	now := k.now()
+	if now.Sub(k.lastSweep) < time.Minute {
+		return
+	}
+	k.lastSweep = now
+	for id, e := range k.entries {
+		if e.res != nil && now.After(e.expires) {
+			delete(k.entries, id)
+		}
+	}
+}
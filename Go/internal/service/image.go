@@ -0,0 +1,98 @@
+// internal/service/image.go
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+)
+
+var (
+	// ErrImagesDisabled is returned by GenerateImage when no image generator
+	// is configured.
+	ErrImagesDisabled = errors.New("image generation is not configured")
+	// ErrInvalidImageSize is returned for a size not in ai.ImageSizes.
+	ErrInvalidImageSize = errors.New("invalid image size")
+)
+
+// DefaultImageTimeout bounds image generation unless WithImages says
+// otherwise. Images take far longer than text, often 10-30 seconds.
+const DefaultImageTimeout = 90 * time.Second
+
+// maxImagePromptPost is how much of a post goes into the image prompt. The
+// opening of a post sets its topic, and long prompts do not make better
+// images.
+const maxImagePromptPost = 1000
+
+// imageInstruction precedes the post in the image prompt.
+const imageInstruction = "Create a clean, professional illustration to accompany the following LinkedIn post. " +
+	"Do not include any text, letters or logos in the image.\n\n"
+
+// ImageOptions configures image generation. Size is used when a request
+// does not choose one and defaults to ai.DefaultImageSize; Timeout defaults
+// to DefaultImageTimeout.
+type ImageOptions struct {
+	Size    string
+	Timeout time.Duration
+}
+
+// WithImages enables GenerateImage using gen.
+func WithImages(gen ai.ImageGenerator, opts ImageOptions) LinkedInOption {
+	return func(l *LinkedInService) {
+		l.images = gen
+		l.imageOpts = ImageOptions{
+			Size:    cmp.Or(opts.Size, ai.DefaultImageSize),
+			Timeout: cmp.Or(opts.Timeout, DefaultImageTimeout),
+		}
+	}
+}
+
+// imagePrompt describes the image to generate for a post.
+func imagePrompt(post string) string {
+	if r := []rune(post); len(r) > maxImagePromptPost {
+		post = string(r[:maxImagePromptPost]) + "…"
+	}
+	return imageInstruction + post
+}
+
+// GenerateImage creates an image for one of the user's posts and stores it
+// on the post, replacing any earlier image. An empty size uses the
+// configured default.
+func (l *LinkedInService) GenerateImage(ctx context.Context, userID, postID uuid.UUID, size string) (*model.LinkedInPost, error) {
+	if l.images == nil {
+		return nil, ErrImagesDisabled
+	}
+	size = cmp.Or(size, l.imageOpts.Size)
+	if !ai.IsImageSize(size) {
+		return nil, ErrInvalidImageSize
+	}
+	post, err := l.posts.GetOwned(ctx, userID, postID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.imageOpts.Timeout)
+	defer cancel()
+	prompt := imagePrompt(post.OutputText)
+	img, err := l.images.GenerateImage(ctx, prompt, size)
+	if err != nil {
+		return nil, err
+	}
+
+	post.ImageURL = img.URL
+	post.ImageExpiresAt = img.ExpiresAt
+	post.ImagePrompt = cmp.Or(img.RevisedPrompt, prompt)
+	post.ImageSize = size
+	// The image is paid for by now, so store it even if the client has
+	// given up waiting.
+	if err := l.posts.UpdateImage(context.WithoutCancel(ctx), post); err != nil {
+		return nil, notFound(err)
+	}
+	return post, nil
+}
@@ -0,0 +1,196 @@
+// internal/service/input_filter.go
+package service
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+// ErrInputRejected matches an *InputRejectedError with errors.Is.
+var ErrInputRejected = errors.New("text was rejected by the input filter")
+
+// BlocklistCategory is the category of an InputRejectedError for text
+// matching the blocklist.
+const BlocklistCategory = "blocklist"
+
+// InputRejectedError is returned when the text of a post is rejected by the
+// input filter before anything is generated. Categories lists the
+// moderation categories it was flagged for, or BlocklistCategory.
+type InputRejectedError struct {
+	Categories []string
+}
+
+func (e *InputRejectedError) Error() string {
+	return ErrInputRejected.Error() + ": " + strings.Join(e.Categories, ", ")
+}
+
+func (e *InputRejectedError) Is(target error) bool { return target == ErrInputRejected }
+
+//go:embed input_blocklist.txt
+var defaultBlocklist string
+
+// Blocklist is a list of case-insensitive regular expressions text must not
+// match.
+type Blocklist struct {
+	patterns []*regexp.Regexp
+}
+
+// DefaultBlocklist returns the built-in blocklist of common prompt
+// injection and jailbreak phrases.
+func DefaultBlocklist() *Blocklist {
+	b, err := ParseBlocklist(defaultBlocklist)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// ParseBlocklist reads one pattern per line of src. Blank lines and lines
+// starting with # are ignored.
+func ParseBlocklist(src string) (*Blocklist, error) {
+	b := &Blocklist{}
+	for i, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		re, err := regexp.Compile("(?i)" + line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		b.patterns = append(b.patterns, re)
+	}
+	return b, nil
+}
+
+// Match returns the first pattern text matches.
+func (b *Blocklist) Match(text string) (pattern string, ok bool) {
+	for _, re := range b.patterns {
+		if re.MatchString(text) {
+			return strings.TrimPrefix(re.String(), "(?i)"), true
+		}
+	}
+	return "", false
+}
+
+// Len is the number of patterns.
+func (b *Blocklist) Len() int { return len(b.patterns) }
+
+// InputFilter screens the text of a post before its prompt is built,
+// complementing the moderation of generated posts: text matching the
+// blocklist, or flagged by the moderator, is rejected without spending any
+// tokens on it.
+type InputFilter struct {
+	moderator ai.Moderator // nil screens with the blocklist only
+
+	mu        sync.Mutex
+	blocklist *Blocklist
+	// file is reloaded when it changes, so the blocklist can be tuned
+	// without a restart; empty keeps the blocklist given to NewInputFilter.
+	file    string
+	modTime time.Time
+}
+
+// NewInputFilter screens text with blocklist and, when moderator is not nil,
+// with the moderation API.
+func NewInputFilter(blocklist *Blocklist, moderator ai.Moderator) *InputFilter {
+	return &InputFilter{blocklist: blocklist, moderator: moderator}
+}
+
+// NewFileInputFilter is NewInputFilter with the blocklist read from path.
+// The file is reloaded whenever its modification time changes; a file that
+// no longer loads is logged and the previous blocklist kept. Checking costs
+// a stat per post, nothing next to generating it.
+func NewFileInputFilter(path string, moderator ai.Moderator) (*InputFilter, error) {
+	f := &InputFilter{moderator: moderator, file: path}
+	if err := f.reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// current returns the blocklist, reloading its file first when it changed.
+func (f *InputFilter) current() *Blocklist {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file != "" {
+		if err := f.reload(); err != nil {
+			slog.Warn("reloading the input blocklist failed, keeping the previous one", "file", f.file, "error", err)
+		}
+	}
+	return f.blocklist
+}
+
+// reload reads the blocklist file if it changed since it was last read.
+// Callers other than NewFileInputFilter hold f.mu.
+func (f *InputFilter) reload() error {
+	info, err := os.Stat(f.file)
+	if err != nil {
+		return err
+	}
+	if info.ModTime().Equal(f.modTime) {
+		return nil
+	}
+	src, err := os.ReadFile(f.file)
+	if err != nil {
+		return err
+	}
+	b, err := ParseBlocklist(string(src))
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.file, err)
+	}
+	if f.blocklist != nil {
+		slog.Info("reloaded the input blocklist", "file", f.file, "patterns", b.Len())
+	}
+	f.blocklist, f.modTime = b, info.ModTime()
+	return nil
+}
+
+// Check returns an *InputRejectedError when text is rejected. Like the
+// moderation of generated posts it fails open: text is allowed when the
+// moderation API cannot be reached.
+func (f *InputFilter) Check(ctx context.Context, text string) error {
+	if pattern, ok := f.current().Match(text); ok {
+		slog.WarnContext(ctx, "input filter rejected a post's text", "pattern", pattern)
+		return &InputRejectedError{Categories: []string{BlocklistCategory}}
+	}
+	if f.moderator == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, moderationTimeout)
+	defer cancel()
+	m, err := f.moderator.Moderate(ctx, text)
+	if err != nil {
+		slog.WarnContext(ctx, "moderation unavailable, allowing the post's text", "error", err)
+		return nil
+	}
+	if m.Flagged {
+		slog.WarnContext(ctx, "moderation flagged a post's text", "categories", m.Categories)
+		return &InputRejectedError{Categories: m.Categories}
+	}
+	return nil
+}
+
+// WithInputFilter screens the text of every post generated or streamed with
+// f before its prompt is built.
+func WithInputFilter(f *InputFilter) LinkedInOption {
+	return func(l *LinkedInService) { l.inputFilter = f }
+}
+
+// screen checks text with the input filter, if there is one.
+func (l *LinkedInService) screen(ctx context.Context, text string) error {
+	if l.inputFilter == nil {
+		return nil
+	}
+	return l.inputFilter.Check(ctx, text)
+}
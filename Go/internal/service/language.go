@@ -0,0 +1,65 @@
+// internal/service/language.go
+package service
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultLanguage is used when a request does not choose a language.
+const DefaultLanguage = "en"
+
+// languages maps the supported ISO 639-1 codes to the English language names
+// used in prompts.
+var languages = map[string]string{
+	"ar": "Arabic",
+	"da": "Danish",
+	"de": "German",
+	"en": "English",
+	"es": "Spanish",
+	"fi": "Finnish",
+	"fr": "French",
+	"hi": "Hindi",
+	"it": "Italian",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"nl": "Dutch",
+	"no": "Norwegian",
+	"pl": "Polish",
+	"pt": "Portuguese",
+	"sv": "Swedish",
+	"tr": "Turkish",
+	"uk": "Ukrainian",
+	"zh": "Chinese",
+}
+
+// ValidLanguage reports whether code is a supported language; empty means
+// DefaultLanguage.
+func ValidLanguage(code string) bool {
+	_, ok := languages[code]
+	return ok || code == ""
+}
+
+// LanguageCodes lists the supported language codes, sorted.
+func LanguageCodes() []string {
+	codes := make([]string, 0, len(languages))
+	for c := range languages {
+		codes = append(codes, c)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// LanguageNames is the comma-separated supported codes, for error messages.
+var LanguageNames = strings.Join(LanguageCodes(), ", ")
+
+// languageInstruction asks for a post in the given language. English needs
+// no instruction, which keeps the original prompts unchanged.
+func languageInstruction(code string) string {
+	if code == "" || code == DefaultLanguage {
+		return ""
+	}
+	name := languages[code]
+	return "Write the post in " + name + ", including the hashtags, and use phrasing and humour that read naturally in " +
+		name + " rather than translating from English."
+}
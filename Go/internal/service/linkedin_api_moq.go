@@ -0,0 +1,287 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that LinkedInAPIMock does implement LinkedInAPI.
+// If this is not the case, regenerate this file with moq.
+var _ LinkedInAPI = &LinkedInAPIMock{}
+
+// LinkedInAPIMock is a mock implementation of LinkedInAPI.
+//
+//	func TestSomethingThatUsesLinkedInAPI(t *testing.T) {
+//
+//		// make and configure a mocked LinkedInAPI
+//		mockedLinkedInAPI := &LinkedInAPIMock{
+//			AuthCodeURLFunc: func(state string) string {
+//				panic("mock out the AuthCodeURL method")
+//			},
+//			ExchangeFunc: func(ctx context.Context, code string) (*LinkedInToken, error) {
+//				panic("mock out the Exchange method")
+//			},
+//			MemberURNFunc: func(ctx context.Context, accessToken string) (string, error) {
+//				panic("mock out the MemberURN method")
+//			},
+//			RefreshFunc: func(ctx context.Context, refreshToken string) (*LinkedInToken, error) {
+//				panic("mock out the Refresh method")
+//			},
+//			ShareFunc: func(ctx context.Context, accessToken string, author string, text string) (string, error) {
+//				panic("mock out the Share method")
+//			},
+//		}
+//
+//		// use mockedLinkedInAPI in code that requires LinkedInAPI
+//		// and then make assertions.
+//
+//	}
+type LinkedInAPIMock struct {
+	// AuthCodeURLFunc mocks the AuthCodeURL method.
+	AuthCodeURLFunc func(state string) string
+
+	// ExchangeFunc mocks the Exchange method.
+	ExchangeFunc func(ctx context.Context, code string) (*LinkedInToken, error)
+
+	// MemberURNFunc mocks the MemberURN method.
+	MemberURNFunc func(ctx context.Context, accessToken string) (string, error)
+
+	// RefreshFunc mocks the Refresh method.
+	RefreshFunc func(ctx context.Context, refreshToken string) (*LinkedInToken, error)
+
+	// ShareFunc mocks the Share method.
+	ShareFunc func(ctx context.Context, accessToken string, author string, text string) (string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AuthCodeURL holds details about calls to the AuthCodeURL method.
+		AuthCodeURL []struct {
+			// State is the state argument value.
+			State string
+		}
+		// Exchange holds details about calls to the Exchange method.
+		Exchange []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+		}
+		// MemberURN holds details about calls to the MemberURN method.
+		MemberURN []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AccessToken is the accessToken argument value.
+			AccessToken string
+		}
+		// Refresh holds details about calls to the Refresh method.
+		Refresh []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RefreshToken is the refreshToken argument value.
+			RefreshToken string
+		}
+		// Share holds details about calls to the Share method.
+		Share []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AccessToken is the accessToken argument value.
+			AccessToken string
+			// Author is the author argument value.
+			Author string
+			// Text is the text argument value.
+			Text string
+		}
+	}
+	lockAuthCodeURL sync.RWMutex
+	lockExchange    sync.RWMutex
+	lockMemberURN   sync.RWMutex
+	lockRefresh     sync.RWMutex
+	lockShare       sync.RWMutex
+}
+
+// AuthCodeURL calls AuthCodeURLFunc.
+func (mock *LinkedInAPIMock) AuthCodeURL(state string) string {
+	if mock.AuthCodeURLFunc == nil {
+		panic("LinkedInAPIMock.AuthCodeURLFunc: method is nil but LinkedInAPI.AuthCodeURL was just called")
+	}
+	callInfo := struct {
+		State string
+	}{
+		State: state,
+	}
+	mock.lockAuthCodeURL.Lock()
+	mock.calls.AuthCodeURL = append(mock.calls.AuthCodeURL, callInfo)
+	mock.lockAuthCodeURL.Unlock()
+	return mock.AuthCodeURLFunc(state)
+}
+
+// AuthCodeURLCalls gets all the calls that were made to AuthCodeURL.
+// Check the length with:
+//
+//	len(mockedLinkedInAPI.AuthCodeURLCalls())
+func (mock *LinkedInAPIMock) AuthCodeURLCalls() []struct {
+	State string
+} {
+	var calls []struct {
+		State string
+	}
+	mock.lockAuthCodeURL.RLock()
+	calls = mock.calls.AuthCodeURL
+	mock.lockAuthCodeURL.RUnlock()
+	return calls
+}
+
+// Exchange calls ExchangeFunc.
+func (mock *LinkedInAPIMock) Exchange(ctx context.Context, code string) (*LinkedInToken, error) {
+	if mock.ExchangeFunc == nil {
+		panic("LinkedInAPIMock.ExchangeFunc: method is nil but LinkedInAPI.Exchange was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Code string
+	}{
+		Ctx:  ctx,
+		Code: code,
+	}
+	mock.lockExchange.Lock()
+	mock.calls.Exchange = append(mock.calls.Exchange, callInfo)
+	mock.lockExchange.Unlock()
+	return mock.ExchangeFunc(ctx, code)
+}
+
+// ExchangeCalls gets all the calls that were made to Exchange.
+// Check the length with:
+//
+//	len(mockedLinkedInAPI.ExchangeCalls())
+func (mock *LinkedInAPIMock) ExchangeCalls() []struct {
+	Ctx  context.Context
+	Code string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Code string
+	}
+	mock.lockExchange.RLock()
+	calls = mock.calls.Exchange
+	mock.lockExchange.RUnlock()
+	return calls
+}
+
+// MemberURN calls MemberURNFunc.
+func (mock *LinkedInAPIMock) MemberURN(ctx context.Context, accessToken string) (string, error) {
+	if mock.MemberURNFunc == nil {
+		panic("LinkedInAPIMock.MemberURNFunc: method is nil but LinkedInAPI.MemberURN was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		AccessToken string
+	}{
+		Ctx:         ctx,
+		AccessToken: accessToken,
+	}
+	mock.lockMemberURN.Lock()
+	mock.calls.MemberURN = append(mock.calls.MemberURN, callInfo)
+	mock.lockMemberURN.Unlock()
+	return mock.MemberURNFunc(ctx, accessToken)
+}
+
+// MemberURNCalls gets all the calls that were made to MemberURN.
+// Check the length with:
+//
+//	len(mockedLinkedInAPI.MemberURNCalls())
+func (mock *LinkedInAPIMock) MemberURNCalls() []struct {
+	Ctx         context.Context
+	AccessToken string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		AccessToken string
+	}
+	mock.lockMemberURN.RLock()
+	calls = mock.calls.MemberURN
+	mock.lockMemberURN.RUnlock()
+	return calls
+}
+
+// Refresh calls RefreshFunc.
+func (mock *LinkedInAPIMock) Refresh(ctx context.Context, refreshToken string) (*LinkedInToken, error) {
+	if mock.RefreshFunc == nil {
+		panic("LinkedInAPIMock.RefreshFunc: method is nil but LinkedInAPI.Refresh was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		RefreshToken string
+	}{
+		Ctx:          ctx,
+		RefreshToken: refreshToken,
+	}
+	mock.lockRefresh.Lock()
+	mock.calls.Refresh = append(mock.calls.Refresh, callInfo)
+	mock.lockRefresh.Unlock()
+	return mock.RefreshFunc(ctx, refreshToken)
+}
+
+// RefreshCalls gets all the calls that were made to Refresh.
+// Check the length with:
+//
+//	len(mockedLinkedInAPI.RefreshCalls())
+func (mock *LinkedInAPIMock) RefreshCalls() []struct {
+	Ctx          context.Context
+	RefreshToken string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		RefreshToken string
+	}
+	mock.lockRefresh.RLock()
+	calls = mock.calls.Refresh
+	mock.lockRefresh.RUnlock()
+	return calls
+}
+
+// Share calls ShareFunc.
+func (mock *LinkedInAPIMock) Share(ctx context.Context, accessToken string, author string, text string) (string, error) {
+	if mock.ShareFunc == nil {
+		panic("LinkedInAPIMock.ShareFunc: method is nil but LinkedInAPI.Share was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		AccessToken string
+		Author      string
+		Text        string
+	}{
+		Ctx:         ctx,
+		AccessToken: accessToken,
+		Author:      author,
+		Text:        text,
+	}
+	mock.lockShare.Lock()
+	mock.calls.Share = append(mock.calls.Share, callInfo)
+	mock.lockShare.Unlock()
+	return mock.ShareFunc(ctx, accessToken, author, text)
+}
+
+// ShareCalls gets all the calls that were made to Share.
+// Check the length with:
+//
+//	len(mockedLinkedInAPI.ShareCalls())
+func (mock *LinkedInAPIMock) ShareCalls() []struct {
+	Ctx         context.Context
+	AccessToken string
+	Author      string
+	Text        string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		AccessToken string
+		Author      string
+		Text        string
+	}
+	mock.lockShare.RLock()
+	calls = mock.calls.Share
+	mock.lockShare.RUnlock()
+	return calls
+}
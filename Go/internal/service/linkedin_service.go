@@ -2,78 +2,708 @@
 package service
 
 import (
+	"cmp"
 	"context"
-	"sync" // Added for RWMutex
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 
 	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/audit"
 	"github.com/you/linkedinify/internal/model"
 	"github.com/you/linkedinify/internal/repository"
 )
 
 // LinkedInServiceInteractor defines the operations for LinkedIn related services.
 type LinkedInServiceInteractor interface {
-	Transform(ctx context.Context, userID uuid.UUID, text string) (string, error)
-	History(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error)
+	Transform(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*TransformResult, error)
+	Replay(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*TransformResult, error)
+	PreviewPrompt(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (string, error)
+	TransformStream(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (<-chan StreamChunk, error)
+	History(ctx context.Context, userID uuid.UUID, f PostFilter, limit, offset int) ([]model.LinkedInPost, int, error)
+	HistoryAfter(ctx context.Context, userID uuid.UUID, f PostFilter, cursor string, limit int) (*PostPage, error)
+	Search(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]model.LinkedInPost, int, error)
+	Get(ctx context.Context, userID, postID uuid.UUID) (*model.LinkedInPost, error)
+	Export(ctx context.Context, userID uuid.UUID, fn func(model.LinkedInPost) error) error
+	Delete(ctx context.Context, userID, postID uuid.UUID) error
+	Restore(ctx context.Context, userID, postID uuid.UUID) error
+	Favorite(ctx context.Context, userID, postID uuid.UUID) error
+	Unfavorite(ctx context.Context, userID, postID uuid.UUID) error
+	Update(ctx context.Context, userID, postID uuid.UUID, u PostUpdate) (*model.LinkedInPost, error)
+	Versions(ctx context.Context, userID, postID uuid.UUID) ([]model.PostVersion, error)
+	RestoreVersion(ctx context.Context, userID, postID, versionID uuid.UUID) (*model.LinkedInPost, error)
+	Regenerate(ctx context.Context, userID, postID uuid.UUID, overrides TransformOptions) (*TransformResult, error)
+	GenerateImage(ctx context.Context, userID, postID uuid.UUID, size string) (*model.LinkedInPost, error)
+	SuggestHashtags(ctx context.Context, content string) ([]string, error)
+	PostHashtags(ctx context.Context, userID, postID uuid.UUID) ([]string, error)
+	TransformBatch(ctx context.Context, userID uuid.UUID, items []BatchItem) ([]BatchResult, error)
+	RatePost(ctx context.Context, userID, postID uuid.UUID, rating, comment string) (*model.PostFeedback, error)
+	ConnectURL(ctx context.Context, userID uuid.UUID) (url, nonce string, err error)
+	Connect(ctx context.Context, state, nonce, code string) (*model.LinkedInConnection, error)
+	Publish(ctx context.Context, userID, postID uuid.UUID) (*model.LinkedInPost, error)
+	Schedule(ctx context.Context, userID, postID uuid.UUID, at time.Time) (*model.LinkedInPost, error)
+	Close(ctx context.Context) error
+}
+
+var (
+	// ErrPostNotFound is returned when a post does not exist or belongs to
+	// another user.
+	ErrPostNotFound = errors.New("post not found")
+	// ErrVersionNotFound is returned when a post has no version with the
+	// given ID.
+	ErrVersionNotFound = errors.New("version not found")
+	// ErrInvalidStatus is returned for a post status other than
+	// model.PostStatusDraft or model.PostStatusFinal.
+	ErrInvalidStatus = errors.New("invalid post status")
+	// ErrPreconditionFailed is returned by Update when the post no longer
+	// passes PostUpdate.Precondition.
+	ErrPreconditionFailed = errors.New("post was changed")
+)
+
+// ValidStatus reports whether status is a post status users may set.
+func ValidStatus(status string) bool {
+	return status == model.PostStatusDraft || status == model.PostStatusFinal
+}
+
+// PostUpdate describes an edit to a post. Nil or empty fields are left
+// unchanged.
+type PostUpdate struct {
+	Post   *string
+	Status string
+	// Precondition, when set, is checked against the stored post while it
+	// is locked for the update; if it returns false nothing is changed and
+	// Update returns ErrPreconditionFailed.
+	Precondition func(current *model.LinkedInPost) bool
+}
+
+// TransformOptions holds the optional per-request settings for a transform.
+type TransformOptions struct {
+	// Model overrides the deployment's default model when set.
+	Model string
+	// Template names the prompt template; empty means DefaultTemplate.
+	Template string
+	// Tone sets the voice of the post; empty leaves it to the template.
+	Tone Tone
+	// Length sets the target length; empty means DefaultLength.
+	Length Length
+	// Language is an ISO 639-1 code; empty means DefaultLanguage.
+	Language string
+	// IncludeHashtags appends suggested hashtags to the post.
+	IncludeHashtags bool
+	// WithoutProfile leaves the author's profile out of the prompt, for a
+	// generic post.
+	WithoutProfile bool
+	// Temperature and TopP tune how freely the model picks words; nil uses
+	// the deployment's defaults. Changing one of them is usually enough.
+	Temperature *float64
+	TopP        *float64
+	// IdempotencyKey makes Transform return the result of the earlier
+	// request with the same key, for the same user, instead of generating
+	// again.
+	IdempotencyKey string
+	// Force saves the post even when it duplicates a recent one.
+	Force bool
+}
+
+// TransformResult is the outcome of a successful Transform.
+type TransformResult struct {
+	PostID uuid.UUID
+	Post   string
+	// Model is the model that generated Post. When the post was served
+	// from the cache, Usage only covers suggesting its hashtags.
+	Model string
+	Usage ai.Usage
+	// Cached reports whether Post was served from the cache.
+	Cached bool
+	// Truncated reports whether Post was trimmed to the maximum post
+	// length.
+	Truncated bool
+	// Replayed reports whether this is the result of an earlier request
+	// with the same idempotency key.
+	Replayed bool
+}
+
+func (o TransformOptions) language() string {
+	return cmp.Or(o.Language, DefaultLanguage)
+}
+
+func (o TransformOptions) aiOptions() ai.Options {
+	return ai.Options{Model: o.Model, MaxTokens: o.Length.spec().maxTokens, Temperature: o.Temperature, TopP: o.TopP}
+}
+
+// cacheKey identifies a transform result; the same prompt generated with a
+// different model, style or sampling is a different result.
+func (o TransformOptions) cacheKey(prompt string) string {
+	return ai.CacheKey(o.Model, prompt, string(o.Tone), string(o.Length.orDefault()), o.samplingKey())
 }
 
 type LinkedInService struct {
-	ai    ai.Client
-	posts repository.PostRepository
-	cache map[string]string // Added for in-memory caching
-	mu    sync.RWMutex      // Added for cache synchronization
+	ai        ai.Client
+	posts     repository.PostRepository
+	templates *PromptTemplates
+	cache     ai.Cache     // nil when caching is disabled
+	moderator ai.Moderator // nil when moderation is disabled
+	// inputFilter screens the text of posts; nil when disabled.
+	inputFilter *InputFilter
+	// postProcessors clean up generated posts, in order.
+	postProcessors []PostProcessor
+	context        *contextCheck // nil leaves prompt lengths to the provider
+	events         PostEventSender
+	analytics      AnalyticsRecorder         // nil when analytics are disabled
+	users          repository.UserRepository // nil leaves the profile out of prompts
+	images         ai.ImageGenerator         // nil when image generation is disabled
+	imageOpts      ImageOptions
+	// maxPostLength is the longest post, in characters, Transform and
+	// Regenerate return.
+	maxPostLength int
+	// batchConcurrency is how many posts of a batch are generated at once,
+	// and batchItemTimeout how long each may take.
+	batchConcurrency int
+	batchItemTimeout time.Duration
+	idempotency      *idempotencyKeys // nil when idempotency keys are disabled
+	duplicates       *duplicateCheck  // nil when duplicates are allowed
+	quotas           *Quotas          // nil when generations are not counted
+	cursorSecret     []byte
+	linkedin         LinkedInAPI // nil when publishing is disabled
+	connections      repository.LinkedInConnectionRepository
+	stateSecret      []byte
+	// temperature and topP are the sampling parameters of generations that
+	// do not choose their own.
+	temperature float64
+	topP        float64
+	// drafts counts the drafts of cancelled streams still being saved.
+	drafts sync.WaitGroup
+}
+
+// LinkedInOption configures optional LinkedInService behaviour.
+type LinkedInOption func(*LinkedInService)
+
+// Defaults for the in-memory cache used unless WithCache is given.
+const (
+	defaultCacheSize = 1000
+	defaultCacheTTL  = time.Hour
+)
+
+// WithCache replaces the default in-memory cache of generated posts. A nil
+// cache disables caching, so every transform reaches the AI provider.
+func WithCache(c ai.Cache) LinkedInOption {
+	return func(l *LinkedInService) { l.cache = c }
+}
+
+// WithProfiles fills PromptData.Profile from the author's stored profile.
+func WithProfiles(users repository.UserRepository) LinkedInOption {
+	return func(l *LinkedInService) { l.users = users }
+}
+
+// WithPromptTemplates replaces the built-in prompt templates.
+func WithPromptTemplates(t *PromptTemplates) LinkedInOption {
+	return func(l *LinkedInService) { l.templates = t }
 }
 
 // NewLinkedIn creates a new LinkedInService instance.
 // It now returns the LinkedInServiceInteractor interface.
-func NewLinkedIn(ai ai.Client, pr repository.PostRepository) LinkedInServiceInteractor {
-	return &LinkedInService{
-		ai:    ai,
-		posts: pr,
-		cache: make(map[string]string), // Initialize cache
+func NewLinkedIn(client ai.Client, pr repository.PostRepository, opts ...LinkedInOption) LinkedInServiceInteractor {
+	l := &LinkedInService{
+		ai:        client,
+		posts:     pr,
+		templates: BuiltinPromptTemplates(),
+		cache:     ai.NewLRUCache(defaultCacheSize, defaultCacheTTL),
+
+		maxPostLength:    DefaultMaxPostLength,
+		batchConcurrency: DefaultBatchConcurrency,
+		idempotency:      newIdempotencyKeys(DefaultIdempotencyTTL),
+		cursorSecret:     randomCursorSecret(),
+		temperature:      DefaultTemperature,
+		topP:             DefaultTopP,
 	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
 }
 
-func (l *LinkedInService) Transform(ctx context.Context, userID uuid.UUID, text string) (string, error) {
-	// Check cache first (read lock)
-	l.mu.RLock()
-	cachedOutput, found := l.cache[text]
-	l.mu.RUnlock()
+// prompt renders the template selected in opts for text, written by userID.
+func (l *LinkedInService) prompt(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (string, error) {
+	var profile Profile
+	if !opts.WithoutProfile {
+		profile = l.profile(ctx, userID)
+	}
+	return l.templates.Render(opts.Template, PromptData{
+		Input:               text,
+		Profile:             profile,
+		ToneInstruction:     opts.Tone.instruction(),
+		LengthInstruction:   opts.Length.spec().instruction,
+		Language:            opts.language(),
+		LanguageInstruction: languageInstruction(opts.Language),
+	})
+}
 
-	var out string
-	var err error
+// PreviewPrompt renders the prompt Transform would send to the model for
+// text, without generating or saving anything, so it costs no tokens and
+// does not count towards the quota.
+func (l *LinkedInService) PreviewPrompt(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (string, error) {
+	return l.prompt(ctx, userID, text, opts)
+}
+
+// profile looks up the author's profile. A failed lookup only costs the
+// personalisation, so it is logged rather than failing the post.
+func (l *LinkedInService) profile(ctx context.Context, userID uuid.UUID) Profile {
+	if l.users == nil {
+		return Profile{}
+	}
+	u, err := findUser(ctx, l.users, userID)
+	if err != nil {
+		slog.WarnContext(ctx, "loading profile failed, generating without it", "error", err)
+		return Profile{}
+	}
+	return Profile{Name: u.Name, Headline: u.Headline, Industry: u.Industry}
+}
+
+func (l *LinkedInService) Transform(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*TransformResult, error) {
+	transform := func() (*TransformResult, error) {
+		return l.metered(ctx, userID, func() (*TransformResult, error) {
+			return l.transform(ctx, userID, text, opts)
+		})
+	}
+	// A replayed key returns the earlier post, so it is not counted again.
+	if opts.IdempotencyKey != "" && l.idempotency != nil {
+		return l.idempotency.do(ctx, userID, opts.IdempotencyKey, text, opts, transform)
+	}
+	return transform()
+}
+
+// Replay returns what Transform would replay for opts.IdempotencyKey, without
+// generating anything, or nil when Transform would generate a post. A
+// request with the key still running is waited for. Callers limiting
+// generations check it first, so that retries are not limited.
+func (l *LinkedInService) Replay(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*TransformResult, error) {
+	if opts.IdempotencyKey == "" || l.idempotency == nil {
+		return nil, nil
+	}
+	return l.idempotency.replay(ctx, userID, opts.IdempotencyKey, text, opts)
+}
+
+func (l *LinkedInService) transform(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*TransformResult, error) {
+	if err := l.screen(ctx, text); err != nil {
+		return nil, err
+	}
+	opts = l.withSampling(opts)
+	prompt, err := l.prompt(ctx, userID, text, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.checkContextLength(prompt, opts.aiOptions()); err != nil {
+		return nil, err
+	}
+	key := opts.cacheKey(prompt)
+
+	// Check cache first
+	var cached ai.Result
+	found := false
+	if l.cache != nil {
+		cached, found = l.cache.Get(ctx, key)
+	}
+
+	var res ai.Result
 
 	if found {
-		out = cachedOutput
+		// A cache hit consumes no tokens, so only the text and model carry over.
+		res = ai.Result{Text: cached.Text, Model: cached.Model}
 	} else {
-		// If not found, call AI, then write to cache (write lock)
-		out, err = l.ai.Transform(ctx, text)
+		// If not found, call AI, then write to cache
+		res, err = l.generate(ctx, prompt, opts.aiOptions())
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
-		l.mu.Lock()
-		l.cache[text] = out
-		l.mu.Unlock()
+		if l.cache != nil {
+			l.cache.Set(ctx, key, res)
+		}
+	}
+	// Hashtags are added after caching so the cached post can be reused
+	// with or without them. The post is trimmed to leave room for them.
+	var hashtags string
+	if opts.IncludeHashtags {
+		var usage ai.Usage
+		hashtags, usage = l.hashtagLine(ctx, res.Text)
+		res.Usage.PromptTokens += usage.PromptTokens
+		res.Usage.CompletionTokens += usage.CompletionTokens
+		res.Usage.TotalTokens += usage.TotalTokens
+	}
+	output, truncated := l.fitPost(res.Text, utf8.RuneCountInString(hashtags))
+	res.Text = output + hashtags
+	if !opts.Force {
+		if err := l.checkDuplicate(ctx, userID, res.Text); err != nil {
+			return nil, err
+		}
 	}
 
 	// Save the transformation to history regardless of cache hit/miss
 	post := &model.LinkedInPost{
-		ID:         uuid.New(),
-		UserID:     userID,
-		InputText:  text,
-		OutputText: out,
+		ID:               uuid.New(),
+		UserID:           userID,
+		InputText:        text,
+		OutputText:       res.Text,
+		Status:           model.PostStatusDraft,
+		Source:           model.PostSourceAI,
+		Model:            res.Model,
+		Template:         cmp.Or(opts.Template, DefaultTemplate),
+		Tone:             string(opts.Tone),
+		Length:           string(opts.Length.orDefault()),
+		Language:         opts.language(),
+		Temperature:      *opts.Temperature,
+		TopP:             opts.topP(),
+		PromptTokens:     res.Usage.PromptTokens,
+		CompletionTokens: res.Usage.CompletionTokens,
+		TotalTokens:      res.Usage.TotalTokens,
 	}
 	if err = l.posts.Save(ctx, post); err != nil {
 		// Note: If saving fails, we might have already transformed and cached.
 		// Depending on requirements, one might want to invalidate the cache entry here.
 		// For now, we'll return the error and keep the cache entry.
-		return "", err
+		return nil, err
+	}
+	l.notify(EventPostCreated, post)
+	l.record(userID, post, found)
+	return &TransformResult{
+		PostID:    post.ID,
+		Post:      res.Text,
+		Model:     res.Model,
+		Usage:     res.Usage,
+		Cached:    found,
+		Truncated: truncated,
+	}, nil
+}
+
+// TransformStream streams the AI output chunk by chunk. Once the stream
+// completes successfully the full post is saved to history and cached, just
+// like Transform, and the stream ends with a chunk carrying only its PostID;
+// a failed save is reported as a final error chunk instead. With
+// moderation enabled, a flagged post ends the stream with a
+// *ModerationError instead of being saved; it has already been sent by then,
+// so it cannot be regenerated. Requested hashtags are suggested once the post
+// is complete and sent as one last chunk. Streamed posts are not trimmed to
+// the maximum post length, as they have been sent by the time it is known;
+// post processors only clean up the saved post, which can therefore differ
+// slightly from the streamed text. When ctx is cancelled part way, what was
+// streamed so far is saved as a draft in the background; see
+// savePartialDraft. A stream counts towards the quota once it saves its post
+// or hands a draft to savePartialDraft; only one ending with nothing saved,
+// such as a failed or flagged one, is given back.
+func (l *LinkedInService) TransformStream(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (<-chan StreamChunk, error) {
+	if err := l.screen(ctx, text); err != nil {
+		return nil, err
+	}
+	opts = l.withSampling(opts)
+	prompt, err := l.prompt(ctx, userID, text, opts)
+	if err != nil {
+		return nil, err
 	}
+	if err := l.checkContextLength(prompt, opts.aiOptions()); err != nil {
+		return nil, err
+	}
+	release := func() {}
+	if l.quotas != nil {
+		if release, err = l.quotas.reserve(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	upstream, err := l.ai.Stream(ctx, prompt, opts.aiOptions())
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	out := make(chan StreamChunk)
+	go func() {
+		defer close(out)
+		saved := false
+		defer func() {
+			if !saved {
+				release()
+			}
+		}()
+		var sb strings.Builder
+		var gen ai.Result
+		for c := range upstream {
+			if c.Model != "" {
+				gen.Model = c.Model
+			}
+			if c.Usage != (ai.Usage{}) {
+				gen.Usage = c.Usage
+			}
+			if c.Text == "" && c.Err == nil {
+				continue
+			}
+			select {
+			case out <- StreamChunk{Text: c.Text, Err: c.Err}:
+			case <-ctx.Done():
+				saved = l.savePartialDraft(ctx, streamedPost(userID, text, sb.String(), opts, gen))
+				return
+			}
+			if c.Err != nil {
+				return
+			}
+			sb.WriteString(c.Text)
+		}
+		if ctx.Err() != nil {
+			saved = l.savePartialDraft(ctx, streamedPost(userID, text, sb.String(), opts, gen))
+			return
+		}
+		generated := l.postProcess(sb.String())
+		if l.moderator != nil {
+			if m := l.moderate(ctx, generated); m.Flagged {
+				select {
+				case out <- StreamChunk{Err: &ModerationError{Categories: m.Categories}}:
+				case <-ctx.Done():
+				}
+				return
+			}
+		}
+
+		output := generated
+		if opts.IncludeHashtags {
+			if line, _ := l.hashtagLine(ctx, generated); line != "" {
+				select {
+				case out <- StreamChunk{Text: line}:
+				case <-ctx.Done():
+					saved = l.savePartialDraft(ctx, streamedPost(userID, text, generated, opts, gen))
+					return
+				}
+				output += line
+			}
+		}
+
+		post := streamedPost(userID, text, output, opts, gen)
+		if err := l.posts.Save(ctx, post); err != nil {
+			select {
+			case out <- StreamChunk{Err: err}:
+			case <-ctx.Done():
+			}
+			return
+		}
+		saved = true
+		l.notify(EventPostCreated, post)
+		l.record(userID, post, false)
+
+		if l.cache != nil {
+			l.cache.Set(ctx, opts.cacheKey(prompt), ai.Result{Text: generated, Model: post.Model, Usage: gen.Usage})
+		}
+		select {
+		case out <- StreamChunk{PostID: post.ID}:
+		case <-ctx.Done():
+		}
+	}()
 	return out, nil
 }
 
-func (l *LinkedInService) History(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error) {
-	return l.posts.ListByUser(ctx, userID, page, pageSize)
+// Regenerate asks the AI for a new take on one of the user's posts, using
+// the input, template, model, style and language it was generated with. A tone or
+// length set in overrides replaces the stored one. The new text replaces the
+// current one, which is kept as a version, and the post becomes a draft
+// again.
+func (l *LinkedInService) Regenerate(ctx context.Context, userID, postID uuid.UUID, overrides TransformOptions) (*TransformResult, error) {
+	post, err := l.posts.GetOwned(ctx, userID, postID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return l.metered(ctx, userID, func() (*TransformResult, error) {
+		return l.regenerate(ctx, userID, post, overrides)
+	})
+}
+
+func (l *LinkedInService) regenerate(ctx context.Context, userID uuid.UUID, post *model.LinkedInPost, overrides TransformOptions) (*TransformResult, error) {
+	opts := TransformOptions{
+		Model:       post.Model,
+		Template:    post.Template,
+		Tone:        cmp.Or(overrides.Tone, Tone(post.Tone)),
+		Length:      cmp.Or(overrides.Length, Length(post.Length)),
+		Language:    post.Language,
+		Temperature: cmp.Or(overrides.Temperature, &post.Temperature),
+		TopP:        cmp.Or(overrides.TopP, storedTopP(post.TopP)),
+	}
+	prompt, err := l.prompt(ctx, userID, post.InputText, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.checkContextLength(prompt, opts.aiOptions()); err != nil {
+		return nil, err
+	}
+	// The cache is skipped on purpose: the point is to get a different post.
+	res, err := l.generate(ctx, prompt, opts.aiOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	text, truncated := l.fitPost(res.Text, 0)
+	post.OutputText = text
+	post.Source = model.PostSourceAI
+	post.Status = model.PostStatusDraft
+	post.Model = res.Model
+	post.Tone = string(opts.Tone)
+	post.Length = string(opts.Length.orDefault())
+	post.Temperature = *opts.Temperature
+	post.TopP = opts.topP()
+	post.PromptTokens = res.Usage.PromptTokens
+	post.CompletionTokens = res.Usage.CompletionTokens
+	post.TotalTokens = res.Usage.TotalTokens
+	if err := l.posts.Update(ctx, post); err != nil {
+		return nil, notFound(err)
+	}
+	l.record(userID, post, false)
+	return &TransformResult{
+		PostID:    post.ID,
+		Post:      text,
+		Model:     res.Model,
+		Usage:     res.Usage,
+		Truncated: truncated,
+	}, nil
+}
+
+// PostFilter selects the posts History lists and their order.
+type PostFilter = repository.PostFilter
+
+// PostSort is an order History lists posts in.
+type PostSort = repository.PostSort
+
+const (
+	SortCreatedDesc = repository.SortCreatedDesc
+	SortCreatedAsc  = repository.SortCreatedAsc
+	SortUpdatedDesc = repository.SortUpdatedDesc
+)
+
+// PostSorts lists the sorts a PostFilter may ask for, the default first.
+var PostSorts = []PostSort{SortCreatedDesc, SortCreatedAsc, SortUpdatedDesc}
+
+// History returns a page of the user's posts matching f and the number of
+// posts that match.
+func (l *LinkedInService) History(ctx context.Context, userID uuid.UUID, f PostFilter, limit, offset int) ([]model.LinkedInPost, int, error) {
+	return l.posts.ListByUser(ctx, userID, f, limit, offset)
+}
+
+// Search finds the user's posts matching query, most relevant first.
+func (l *LinkedInService) Search(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]model.LinkedInPost, int, error) {
+	return l.posts.Search(ctx, userID, query, limit, offset)
+}
+
+// Export calls fn with each of the user's posts, newest first, without
+// loading them all at once. It stops at the first error fn returns.
+func (l *LinkedInService) Export(ctx context.Context, userID uuid.UUID, fn func(model.LinkedInPost) error) error {
+	return l.posts.Each(ctx, userID, fn)
+}
+
+// Delete soft-deletes one of the user's posts; it can be brought back with
+// Restore.
+func (l *LinkedInService) Delete(ctx context.Context, userID, postID uuid.UUID) error {
+	if err := l.posts.Delete(ctx, userID, postID); err != nil {
+		return notFound(err)
+	}
+	audit.Record(ctx, audit.ActionPostDelete, postID, nil)
+	return nil
+}
+
+// Restore undoes Delete.
+func (l *LinkedInService) Restore(ctx context.Context, userID, postID uuid.UUID) error {
+	return notFound(l.posts.Restore(ctx, userID, postID))
+}
+
+// Favorite bookmarks one of the user's posts. Favoriting a favorite is a
+// no-op.
+func (l *LinkedInService) Favorite(ctx context.Context, userID, postID uuid.UUID) error {
+	return notFound(l.posts.SetFavorite(ctx, userID, postID, true))
+}
+
+// Unfavorite undoes Favorite.
+func (l *LinkedInService) Unfavorite(ctx context.Context, userID, postID uuid.UUID) error {
+	return notFound(l.posts.SetFavorite(ctx, userID, postID, false))
+}
+
+// Get returns one of the posts in the user's organization.
+func (l *LinkedInService) Get(ctx context.Context, userID, postID uuid.UUID) (*model.LinkedInPost, error) {
+	post, err := l.posts.Get(ctx, userID, postID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return post, nil
+}
+
+// Update edits the text or status of one of the user's posts and returns the
+// updated post. Moving a post to final sends an EventPostFinalized.
+func (l *LinkedInService) Update(ctx context.Context, userID, postID uuid.UUID, u PostUpdate) (*model.LinkedInPost, error) {
+	if u.Status != "" && !ValidStatus(u.Status) {
+		return nil, ErrInvalidStatus
+	}
+	post, err := l.posts.GetOwned(ctx, userID, postID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	wasFinal := post.Status == model.PostStatusFinal
+	if u.Post != nil && *u.Post != post.OutputText {
+		post.OutputText = *u.Post
+		post.Source = model.PostSourceManual
+	}
+	if u.Status != "" {
+		post.Status = u.Status
+	}
+	var check func(*model.LinkedInPost) error
+	if u.Precondition != nil {
+		check = func(current *model.LinkedInPost) error {
+			if !u.Precondition(current) {
+				return ErrPreconditionFailed
+			}
+			return nil
+		}
+	}
+	if err := l.posts.UpdateIf(ctx, post, check); err != nil {
+		return nil, notFound(err)
+	}
+	if !wasFinal && post.Status == model.PostStatusFinal {
+		l.notify(EventPostFinalized, post)
+	}
+	return post, nil
+}
+
+// Versions lists the earlier versions of one of the user's posts, newest
+// first.
+func (l *LinkedInService) Versions(ctx context.Context, userID, postID uuid.UUID) ([]model.PostVersion, error) {
+	if _, err := l.posts.Get(ctx, userID, postID); err != nil {
+		return nil, notFound(err)
+	}
+	return l.posts.ListVersions(ctx, postID)
+}
+
+// RestoreVersion rolls a post back to an earlier version. The text being
+// replaced becomes a version itself, so a restore can be undone.
+func (l *LinkedInService) RestoreVersion(ctx context.Context, userID, postID, versionID uuid.UUID) (*model.LinkedInPost, error) {
+	post, err := l.posts.GetOwned(ctx, userID, postID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	v, err := l.posts.GetVersion(ctx, postID, versionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVersionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	post.OutputText = v.OutputText
+	post.Source = v.Source
+	if err := l.posts.Update(ctx, post); err != nil {
+		return nil, notFound(err)
+	}
+	return post, nil
+}
+
+// notFound maps a missing row to ErrPostNotFound.
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrPostNotFound
+	}
+	return err
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/you/linkedinify/internal/model"
 	"sync"
+	"time"
 )
 
 // Ensure, that LinkedInServiceInteractorMock does implement LinkedInServiceInteractor.
@@ -20,12 +21,87 @@ var _ LinkedInServiceInteractor = &LinkedInServiceInteractorMock{}
 //
 //		// make and configure a mocked LinkedInServiceInteractor
 //		mockedLinkedInServiceInteractor := &LinkedInServiceInteractorMock{
-//			HistoryFunc: func(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]model.LinkedInPost, error) {
+//			CloseFunc: func(ctx context.Context) error {
+//				panic("mock out the Close method")
+//			},
+//			ConnectFunc: func(ctx context.Context, state string, nonce string, code string) (*model.LinkedInConnection, error) {
+//				panic("mock out the Connect method")
+//			},
+//			ConnectURLFunc: func(ctx context.Context, userID uuid.UUID) (string, string, error) {
+//				panic("mock out the ConnectURL method")
+//			},
+//			DeleteFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error {
+//				panic("mock out the Delete method")
+//			},
+//			ExportFunc: func(ctx context.Context, userID uuid.UUID, fn func(model.LinkedInPost) error) error {
+//				panic("mock out the Export method")
+//			},
+//			FavoriteFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error {
+//				panic("mock out the Favorite method")
+//			},
+//			GenerateImageFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, size string) (*model.LinkedInPost, error) {
+//				panic("mock out the GenerateImage method")
+//			},
+//			GetFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) (*model.LinkedInPost, error) {
+//				panic("mock out the Get method")
+//			},
+//			HistoryFunc: func(ctx context.Context, userID uuid.UUID, f PostFilter, limit int, offset int) ([]model.LinkedInPost, int, error) {
 //				panic("mock out the History method")
 //			},
-//			TransformFunc: func(ctx context.Context, userID uuid.UUID, text string) (string, error) {
+//			HistoryAfterFunc: func(ctx context.Context, userID uuid.UUID, f PostFilter, cursor string, limit int) (*PostPage, error) {
+//				panic("mock out the HistoryAfter method")
+//			},
+//			PostHashtagsFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]string, error) {
+//				panic("mock out the PostHashtags method")
+//			},
+//			PreviewPromptFunc: func(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (string, error) {
+//				panic("mock out the PreviewPrompt method")
+//			},
+//			PublishFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) (*model.LinkedInPost, error) {
+//				panic("mock out the Publish method")
+//			},
+//			RatePostFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, rating string, comment string) (*model.PostFeedback, error) {
+//				panic("mock out the RatePost method")
+//			},
+//			RegenerateFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, overrides TransformOptions) (*TransformResult, error) {
+//				panic("mock out the Regenerate method")
+//			},
+//			ReplayFunc: func(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*TransformResult, error) {
+//				panic("mock out the Replay method")
+//			},
+//			RestoreFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error {
+//				panic("mock out the Restore method")
+//			},
+//			RestoreVersionFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, versionID uuid.UUID) (*model.LinkedInPost, error) {
+//				panic("mock out the RestoreVersion method")
+//			},
+//			ScheduleFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, at time.Time) (*model.LinkedInPost, error) {
+//				panic("mock out the Schedule method")
+//			},
+//			SearchFunc: func(ctx context.Context, userID uuid.UUID, query string, limit int, offset int) ([]model.LinkedInPost, int, error) {
+//				panic("mock out the Search method")
+//			},
+//			SuggestHashtagsFunc: func(ctx context.Context, content string) ([]string, error) {
+//				panic("mock out the SuggestHashtags method")
+//			},
+//			TransformFunc: func(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*TransformResult, error) {
 //				panic("mock out the Transform method")
 //			},
+//			TransformBatchFunc: func(ctx context.Context, userID uuid.UUID, items []BatchItem) ([]BatchResult, error) {
+//				panic("mock out the TransformBatch method")
+//			},
+//			TransformStreamFunc: func(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (<-chan StreamChunk, error) {
+//				panic("mock out the TransformStream method")
+//			},
+//			UnfavoriteFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error {
+//				panic("mock out the Unfavorite method")
+//			},
+//			UpdateFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, u PostUpdate) (*model.LinkedInPost, error) {
+//				panic("mock out the Update method")
+//			},
+//			VersionsFunc: func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]model.PostVersion, error) {
+//				panic("mock out the Versions method")
+//			},
 //		}
 //
 //		// use mockedLinkedInServiceInteractor in code that requires LinkedInServiceInteractor
@@ -33,24 +109,299 @@ var _ LinkedInServiceInteractor = &LinkedInServiceInteractorMock{}
 //
 //	}
 type LinkedInServiceInteractorMock struct {
+	// CloseFunc mocks the Close method.
+	CloseFunc func(ctx context.Context) error
+
+	// ConnectFunc mocks the Connect method.
+	ConnectFunc func(ctx context.Context, state string, nonce string, code string) (*model.LinkedInConnection, error)
+
+	// ConnectURLFunc mocks the ConnectURL method.
+	ConnectURLFunc func(ctx context.Context, userID uuid.UUID) (string, string, error)
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error
+
+	// ExportFunc mocks the Export method.
+	ExportFunc func(ctx context.Context, userID uuid.UUID, fn func(model.LinkedInPost) error) error
+
+	// FavoriteFunc mocks the Favorite method.
+	FavoriteFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error
+
+	// GenerateImageFunc mocks the GenerateImage method.
+	GenerateImageFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, size string) (*model.LinkedInPost, error)
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) (*model.LinkedInPost, error)
+
 	// HistoryFunc mocks the History method.
-	HistoryFunc func(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]model.LinkedInPost, error)
+	HistoryFunc func(ctx context.Context, userID uuid.UUID, f PostFilter, limit int, offset int) ([]model.LinkedInPost, int, error)
+
+	// HistoryAfterFunc mocks the HistoryAfter method.
+	HistoryAfterFunc func(ctx context.Context, userID uuid.UUID, f PostFilter, cursor string, limit int) (*PostPage, error)
+
+	// PostHashtagsFunc mocks the PostHashtags method.
+	PostHashtagsFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]string, error)
+
+	// PreviewPromptFunc mocks the PreviewPrompt method.
+	PreviewPromptFunc func(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (string, error)
+
+	// PublishFunc mocks the Publish method.
+	PublishFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) (*model.LinkedInPost, error)
+
+	// RatePostFunc mocks the RatePost method.
+	RatePostFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, rating string, comment string) (*model.PostFeedback, error)
+
+	// RegenerateFunc mocks the Regenerate method.
+	RegenerateFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, overrides TransformOptions) (*TransformResult, error)
+
+	// ReplayFunc mocks the Replay method.
+	ReplayFunc func(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*TransformResult, error)
+
+	// RestoreFunc mocks the Restore method.
+	RestoreFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error
+
+	// RestoreVersionFunc mocks the RestoreVersion method.
+	RestoreVersionFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, versionID uuid.UUID) (*model.LinkedInPost, error)
+
+	// ScheduleFunc mocks the Schedule method.
+	ScheduleFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, at time.Time) (*model.LinkedInPost, error)
+
+	// SearchFunc mocks the Search method.
+	SearchFunc func(ctx context.Context, userID uuid.UUID, query string, limit int, offset int) ([]model.LinkedInPost, int, error)
+
+	// SuggestHashtagsFunc mocks the SuggestHashtags method.
+	SuggestHashtagsFunc func(ctx context.Context, content string) ([]string, error)
 
 	// TransformFunc mocks the Transform method.
-	TransformFunc func(ctx context.Context, userID uuid.UUID, text string) (string, error)
+	TransformFunc func(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*TransformResult, error)
+
+	// TransformBatchFunc mocks the TransformBatch method.
+	TransformBatchFunc func(ctx context.Context, userID uuid.UUID, items []BatchItem) ([]BatchResult, error)
+
+	// TransformStreamFunc mocks the TransformStream method.
+	TransformStreamFunc func(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (<-chan StreamChunk, error)
+
+	// UnfavoriteFunc mocks the Unfavorite method.
+	UnfavoriteFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID, u PostUpdate) (*model.LinkedInPost, error)
+
+	// VersionsFunc mocks the Versions method.
+	VersionsFunc func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]model.PostVersion, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Close holds details about calls to the Close method.
+		Close []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Connect holds details about calls to the Connect method.
+		Connect []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// State is the state argument value.
+			State string
+			// Nonce is the nonce argument value.
+			Nonce string
+			// Code is the code argument value.
+			Code string
+		}
+		// ConnectURL holds details about calls to the ConnectURL method.
+		ConnectURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
+		// Export holds details about calls to the Export method.
+		Export []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Fn is the fn argument value.
+			Fn func(model.LinkedInPost) error
+		}
+		// Favorite holds details about calls to the Favorite method.
+		Favorite []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
+		// GenerateImage holds details about calls to the GenerateImage method.
+		GenerateImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+			// Size is the size argument value.
+			Size string
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
 		// History holds details about calls to the History method.
 		History []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// UserID is the userID argument value.
 			UserID uuid.UUID
-			// Page is the page argument value.
-			Page int
-			// PageSize is the pageSize argument value.
-			PageSize int
+			// F is the f argument value.
+			F PostFilter
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
+		// HistoryAfter holds details about calls to the HistoryAfter method.
+		HistoryAfter []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// F is the f argument value.
+			F PostFilter
+			// Cursor is the cursor argument value.
+			Cursor string
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// PostHashtags holds details about calls to the PostHashtags method.
+		PostHashtags []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
+		// PreviewPrompt holds details about calls to the PreviewPrompt method.
+		PreviewPrompt []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Text is the text argument value.
+			Text string
+			// Opts is the opts argument value.
+			Opts TransformOptions
+		}
+		// Publish holds details about calls to the Publish method.
+		Publish []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
+		// RatePost holds details about calls to the RatePost method.
+		RatePost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+			// Rating is the rating argument value.
+			Rating string
+			// Comment is the comment argument value.
+			Comment string
+		}
+		// Regenerate holds details about calls to the Regenerate method.
+		Regenerate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+			// Overrides is the overrides argument value.
+			Overrides TransformOptions
+		}
+		// Replay holds details about calls to the Replay method.
+		Replay []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Text is the text argument value.
+			Text string
+			// Opts is the opts argument value.
+			Opts TransformOptions
+		}
+		// Restore holds details about calls to the Restore method.
+		Restore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
+		// RestoreVersion holds details about calls to the RestoreVersion method.
+		RestoreVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+			// VersionID is the versionID argument value.
+			VersionID uuid.UUID
+		}
+		// Schedule holds details about calls to the Schedule method.
+		Schedule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+			// At is the at argument value.
+			At time.Time
+		}
+		// Search holds details about calls to the Search method.
+		Search []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Query is the query argument value.
+			Query string
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
+		// SuggestHashtags holds details about calls to the SuggestHashtags method.
+		SuggestHashtags []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Content is the content argument value.
+			Content string
 		}
 		// Transform holds details about calls to the Transform method.
 		Transform []struct {
@@ -60,92 +411,1220 @@ type LinkedInServiceInteractorMock struct {
 			UserID uuid.UUID
 			// Text is the text argument value.
 			Text string
+			// Opts is the opts argument value.
+			Opts TransformOptions
+		}
+		// TransformBatch holds details about calls to the TransformBatch method.
+		TransformBatch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Items is the items argument value.
+			Items []BatchItem
+		}
+		// TransformStream holds details about calls to the TransformStream method.
+		TransformStream []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Text is the text argument value.
+			Text string
+			// Opts is the opts argument value.
+			Opts TransformOptions
+		}
+		// Unfavorite holds details about calls to the Unfavorite method.
+		Unfavorite []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
+			// U is the u argument value.
+			U PostUpdate
+		}
+		// Versions holds details about calls to the Versions method.
+		Versions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostID is the postID argument value.
+			PostID uuid.UUID
 		}
 	}
-	lockHistory   sync.RWMutex
-	lockTransform sync.RWMutex
+	lockClose           sync.RWMutex
+	lockConnect         sync.RWMutex
+	lockConnectURL      sync.RWMutex
+	lockDelete          sync.RWMutex
+	lockExport          sync.RWMutex
+	lockFavorite        sync.RWMutex
+	lockGenerateImage   sync.RWMutex
+	lockGet             sync.RWMutex
+	lockHistory         sync.RWMutex
+	lockHistoryAfter    sync.RWMutex
+	lockPostHashtags    sync.RWMutex
+	lockPreviewPrompt   sync.RWMutex
+	lockPublish         sync.RWMutex
+	lockRatePost        sync.RWMutex
+	lockRegenerate      sync.RWMutex
+	lockReplay          sync.RWMutex
+	lockRestore         sync.RWMutex
+	lockRestoreVersion  sync.RWMutex
+	lockSchedule        sync.RWMutex
+	lockSearch          sync.RWMutex
+	lockSuggestHashtags sync.RWMutex
+	lockTransform       sync.RWMutex
+	lockTransformBatch  sync.RWMutex
+	lockTransformStream sync.RWMutex
+	lockUnfavorite      sync.RWMutex
+	lockUpdate          sync.RWMutex
+	lockVersions        sync.RWMutex
 }
 
-// History calls HistoryFunc.
-func (mock *LinkedInServiceInteractorMock) History(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]model.LinkedInPost, error) {
-	if mock.HistoryFunc == nil {
-		panic("LinkedInServiceInteractorMock.HistoryFunc: method is nil but LinkedInServiceInteractor.History was just called")
+// Close calls CloseFunc.
+func (mock *LinkedInServiceInteractorMock) Close(ctx context.Context) error {
+	if mock.CloseFunc == nil {
+		panic("LinkedInServiceInteractorMock.CloseFunc: method is nil but LinkedInServiceInteractor.Close was just called")
 	}
 	callInfo := struct {
-		Ctx      context.Context
-		UserID   uuid.UUID
-		Page     int
-		PageSize int
+		Ctx context.Context
 	}{
-		Ctx:      ctx,
-		UserID:   userID,
-		Page:     page,
-		PageSize: pageSize,
+		Ctx: ctx,
 	}
-	mock.lockHistory.Lock()
-	mock.calls.History = append(mock.calls.History, callInfo)
-	mock.lockHistory.Unlock()
-	return mock.HistoryFunc(ctx, userID, page, pageSize)
+	mock.lockClose.Lock()
+	mock.calls.Close = append(mock.calls.Close, callInfo)
+	mock.lockClose.Unlock()
+	return mock.CloseFunc(ctx)
 }
 
-// HistoryCalls gets all the calls that were made to History.
+// CloseCalls gets all the calls that were made to Close.
 // Check the length with:
 //
-//	len(mockedLinkedInServiceInteractor.HistoryCalls())
-func (mock *LinkedInServiceInteractorMock) HistoryCalls() []struct {
-	Ctx      context.Context
-	UserID   uuid.UUID
-	Page     int
-	PageSize int
+//	len(mockedLinkedInServiceInteractor.CloseCalls())
+func (mock *LinkedInServiceInteractorMock) CloseCalls() []struct {
+	Ctx context.Context
 } {
 	var calls []struct {
-		Ctx      context.Context
-		UserID   uuid.UUID
-		Page     int
-		PageSize int
+		Ctx context.Context
 	}
-	mock.lockHistory.RLock()
-	calls = mock.calls.History
-	mock.lockHistory.RUnlock()
+	mock.lockClose.RLock()
+	calls = mock.calls.Close
+	mock.lockClose.RUnlock()
 	return calls
 }
 
-// Transform calls TransformFunc.
-func (mock *LinkedInServiceInteractorMock) Transform(ctx context.Context, userID uuid.UUID, text string) (string, error) {
-	if mock.TransformFunc == nil {
-		panic("LinkedInServiceInteractorMock.TransformFunc: method is nil but LinkedInServiceInteractor.Transform was just called")
+// Connect calls ConnectFunc.
+func (mock *LinkedInServiceInteractorMock) Connect(ctx context.Context, state string, nonce string, code string) (*model.LinkedInConnection, error) {
+	if mock.ConnectFunc == nil {
+		panic("LinkedInServiceInteractorMock.ConnectFunc: method is nil but LinkedInServiceInteractor.Connect was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		State string
+		Nonce string
+		Code  string
+	}{
+		Ctx:   ctx,
+		State: state,
+		Nonce: nonce,
+		Code:  code,
+	}
+	mock.lockConnect.Lock()
+	mock.calls.Connect = append(mock.calls.Connect, callInfo)
+	mock.lockConnect.Unlock()
+	return mock.ConnectFunc(ctx, state, nonce, code)
+}
+
+// ConnectCalls gets all the calls that were made to Connect.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.ConnectCalls())
+func (mock *LinkedInServiceInteractorMock) ConnectCalls() []struct {
+	Ctx   context.Context
+	State string
+	Nonce string
+	Code  string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		State string
+		Nonce string
+		Code  string
+	}
+	mock.lockConnect.RLock()
+	calls = mock.calls.Connect
+	mock.lockConnect.RUnlock()
+	return calls
+}
+
+// ConnectURL calls ConnectURLFunc.
+func (mock *LinkedInServiceInteractorMock) ConnectURL(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	if mock.ConnectURLFunc == nil {
+		panic("LinkedInServiceInteractorMock.ConnectURLFunc: method is nil but LinkedInServiceInteractor.ConnectURL was just called")
 	}
 	callInfo := struct {
 		Ctx    context.Context
 		UserID uuid.UUID
-		Text   string
 	}{
 		Ctx:    ctx,
 		UserID: userID,
-		Text:   text,
 	}
-	mock.lockTransform.Lock()
-	mock.calls.Transform = append(mock.calls.Transform, callInfo)
-	mock.lockTransform.Unlock()
-	return mock.TransformFunc(ctx, userID, text)
+	mock.lockConnectURL.Lock()
+	mock.calls.ConnectURL = append(mock.calls.ConnectURL, callInfo)
+	mock.lockConnectURL.Unlock()
+	return mock.ConnectURLFunc(ctx, userID)
 }
 
-// TransformCalls gets all the calls that were made to Transform.
+// ConnectURLCalls gets all the calls that were made to ConnectURL.
 // Check the length with:
 //
-//	len(mockedLinkedInServiceInteractor.TransformCalls())
-func (mock *LinkedInServiceInteractorMock) TransformCalls() []struct {
+//	len(mockedLinkedInServiceInteractor.ConnectURLCalls())
+func (mock *LinkedInServiceInteractorMock) ConnectURLCalls() []struct {
 	Ctx    context.Context
 	UserID uuid.UUID
-	Text   string
 } {
 	var calls []struct {
 		Ctx    context.Context
 		UserID uuid.UUID
-		Text   string
 	}
-	mock.lockTransform.RLock()
-	calls = mock.calls.Transform
-	mock.lockTransform.RUnlock()
+	mock.lockConnectURL.RLock()
+	calls = mock.calls.ConnectURL
+	mock.lockConnectURL.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *LinkedInServiceInteractorMock) Delete(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error {
+	if mock.DeleteFunc == nil {
+		panic("LinkedInServiceInteractorMock.DeleteFunc: method is nil but LinkedInServiceInteractor.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, userID, postID)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.DeleteCalls())
+func (mock *LinkedInServiceInteractorMock) DeleteCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// Export calls ExportFunc.
+func (mock *LinkedInServiceInteractorMock) Export(ctx context.Context, userID uuid.UUID, fn func(model.LinkedInPost) error) error {
+	if mock.ExportFunc == nil {
+		panic("LinkedInServiceInteractorMock.ExportFunc: method is nil but LinkedInServiceInteractor.Export was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Fn     func(model.LinkedInPost) error
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Fn:     fn,
+	}
+	mock.lockExport.Lock()
+	mock.calls.Export = append(mock.calls.Export, callInfo)
+	mock.lockExport.Unlock()
+	return mock.ExportFunc(ctx, userID, fn)
+}
+
+// ExportCalls gets all the calls that were made to Export.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.ExportCalls())
+func (mock *LinkedInServiceInteractorMock) ExportCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Fn     func(model.LinkedInPost) error
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Fn     func(model.LinkedInPost) error
+	}
+	mock.lockExport.RLock()
+	calls = mock.calls.Export
+	mock.lockExport.RUnlock()
+	return calls
+}
+
+// Favorite calls FavoriteFunc.
+func (mock *LinkedInServiceInteractorMock) Favorite(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error {
+	if mock.FavoriteFunc == nil {
+		panic("LinkedInServiceInteractorMock.FavoriteFunc: method is nil but LinkedInServiceInteractor.Favorite was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+	}
+	mock.lockFavorite.Lock()
+	mock.calls.Favorite = append(mock.calls.Favorite, callInfo)
+	mock.lockFavorite.Unlock()
+	return mock.FavoriteFunc(ctx, userID, postID)
+}
+
+// FavoriteCalls gets all the calls that were made to Favorite.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.FavoriteCalls())
+func (mock *LinkedInServiceInteractorMock) FavoriteCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}
+	mock.lockFavorite.RLock()
+	calls = mock.calls.Favorite
+	mock.lockFavorite.RUnlock()
+	return calls
+}
+
+// GenerateImage calls GenerateImageFunc.
+func (mock *LinkedInServiceInteractorMock) GenerateImage(ctx context.Context, userID uuid.UUID, postID uuid.UUID, size string) (*model.LinkedInPost, error) {
+	if mock.GenerateImageFunc == nil {
+		panic("LinkedInServiceInteractorMock.GenerateImageFunc: method is nil but LinkedInServiceInteractor.GenerateImage was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+		Size   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+		Size:   size,
+	}
+	mock.lockGenerateImage.Lock()
+	mock.calls.GenerateImage = append(mock.calls.GenerateImage, callInfo)
+	mock.lockGenerateImage.Unlock()
+	return mock.GenerateImageFunc(ctx, userID, postID, size)
+}
+
+// GenerateImageCalls gets all the calls that were made to GenerateImage.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.GenerateImageCalls())
+func (mock *LinkedInServiceInteractorMock) GenerateImageCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+	Size   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+		Size   string
+	}
+	mock.lockGenerateImage.RLock()
+	calls = mock.calls.GenerateImage
+	mock.lockGenerateImage.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *LinkedInServiceInteractorMock) Get(ctx context.Context, userID uuid.UUID, postID uuid.UUID) (*model.LinkedInPost, error) {
+	if mock.GetFunc == nil {
+		panic("LinkedInServiceInteractorMock.GetFunc: method is nil but LinkedInServiceInteractor.Get was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, userID, postID)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.GetCalls())
+func (mock *LinkedInServiceInteractorMock) GetCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// History calls HistoryFunc.
+func (mock *LinkedInServiceInteractorMock) History(ctx context.Context, userID uuid.UUID, f PostFilter, limit int, offset int) ([]model.LinkedInPost, int, error) {
+	if mock.HistoryFunc == nil {
+		panic("LinkedInServiceInteractorMock.HistoryFunc: method is nil but LinkedInServiceInteractor.History was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		F      PostFilter
+		Limit  int
+		Offset int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		F:      f,
+		Limit:  limit,
+		Offset: offset,
+	}
+	mock.lockHistory.Lock()
+	mock.calls.History = append(mock.calls.History, callInfo)
+	mock.lockHistory.Unlock()
+	return mock.HistoryFunc(ctx, userID, f, limit, offset)
+}
+
+// HistoryCalls gets all the calls that were made to History.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.HistoryCalls())
+func (mock *LinkedInServiceInteractorMock) HistoryCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	F      PostFilter
+	Limit  int
+	Offset int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		F      PostFilter
+		Limit  int
+		Offset int
+	}
+	mock.lockHistory.RLock()
+	calls = mock.calls.History
+	mock.lockHistory.RUnlock()
+	return calls
+}
+
+// HistoryAfter calls HistoryAfterFunc.
+func (mock *LinkedInServiceInteractorMock) HistoryAfter(ctx context.Context, userID uuid.UUID, f PostFilter, cursor string, limit int) (*PostPage, error) {
+	if mock.HistoryAfterFunc == nil {
+		panic("LinkedInServiceInteractorMock.HistoryAfterFunc: method is nil but LinkedInServiceInteractor.HistoryAfter was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		F      PostFilter
+		Cursor string
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		F:      f,
+		Cursor: cursor,
+		Limit:  limit,
+	}
+	mock.lockHistoryAfter.Lock()
+	mock.calls.HistoryAfter = append(mock.calls.HistoryAfter, callInfo)
+	mock.lockHistoryAfter.Unlock()
+	return mock.HistoryAfterFunc(ctx, userID, f, cursor, limit)
+}
+
+// HistoryAfterCalls gets all the calls that were made to HistoryAfter.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.HistoryAfterCalls())
+func (mock *LinkedInServiceInteractorMock) HistoryAfterCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	F      PostFilter
+	Cursor string
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		F      PostFilter
+		Cursor string
+		Limit  int
+	}
+	mock.lockHistoryAfter.RLock()
+	calls = mock.calls.HistoryAfter
+	mock.lockHistoryAfter.RUnlock()
+	return calls
+}
+
+// PostHashtags calls PostHashtagsFunc.
+func (mock *LinkedInServiceInteractorMock) PostHashtags(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]string, error) {
+	if mock.PostHashtagsFunc == nil {
+		panic("LinkedInServiceInteractorMock.PostHashtagsFunc: method is nil but LinkedInServiceInteractor.PostHashtags was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+	}
+	mock.lockPostHashtags.Lock()
+	mock.calls.PostHashtags = append(mock.calls.PostHashtags, callInfo)
+	mock.lockPostHashtags.Unlock()
+	return mock.PostHashtagsFunc(ctx, userID, postID)
+}
+
+// PostHashtagsCalls gets all the calls that were made to PostHashtags.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.PostHashtagsCalls())
+func (mock *LinkedInServiceInteractorMock) PostHashtagsCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}
+	mock.lockPostHashtags.RLock()
+	calls = mock.calls.PostHashtags
+	mock.lockPostHashtags.RUnlock()
+	return calls
+}
+
+// PreviewPrompt calls PreviewPromptFunc.
+func (mock *LinkedInServiceInteractorMock) PreviewPrompt(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (string, error) {
+	if mock.PreviewPromptFunc == nil {
+		panic("LinkedInServiceInteractorMock.PreviewPromptFunc: method is nil but LinkedInServiceInteractor.PreviewPrompt was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Text   string
+		Opts   TransformOptions
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Text:   text,
+		Opts:   opts,
+	}
+	mock.lockPreviewPrompt.Lock()
+	mock.calls.PreviewPrompt = append(mock.calls.PreviewPrompt, callInfo)
+	mock.lockPreviewPrompt.Unlock()
+	return mock.PreviewPromptFunc(ctx, userID, text, opts)
+}
+
+// PreviewPromptCalls gets all the calls that were made to PreviewPrompt.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.PreviewPromptCalls())
+func (mock *LinkedInServiceInteractorMock) PreviewPromptCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Text   string
+	Opts   TransformOptions
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Text   string
+		Opts   TransformOptions
+	}
+	mock.lockPreviewPrompt.RLock()
+	calls = mock.calls.PreviewPrompt
+	mock.lockPreviewPrompt.RUnlock()
+	return calls
+}
+
+// Publish calls PublishFunc.
+func (mock *LinkedInServiceInteractorMock) Publish(ctx context.Context, userID uuid.UUID, postID uuid.UUID) (*model.LinkedInPost, error) {
+	if mock.PublishFunc == nil {
+		panic("LinkedInServiceInteractorMock.PublishFunc: method is nil but LinkedInServiceInteractor.Publish was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+	}
+	mock.lockPublish.Lock()
+	mock.calls.Publish = append(mock.calls.Publish, callInfo)
+	mock.lockPublish.Unlock()
+	return mock.PublishFunc(ctx, userID, postID)
+}
+
+// PublishCalls gets all the calls that were made to Publish.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.PublishCalls())
+func (mock *LinkedInServiceInteractorMock) PublishCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}
+	mock.lockPublish.RLock()
+	calls = mock.calls.Publish
+	mock.lockPublish.RUnlock()
+	return calls
+}
+
+// RatePost calls RatePostFunc.
+func (mock *LinkedInServiceInteractorMock) RatePost(ctx context.Context, userID uuid.UUID, postID uuid.UUID, rating string, comment string) (*model.PostFeedback, error) {
+	if mock.RatePostFunc == nil {
+		panic("LinkedInServiceInteractorMock.RatePostFunc: method is nil but LinkedInServiceInteractor.RatePost was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		UserID  uuid.UUID
+		PostID  uuid.UUID
+		Rating  string
+		Comment string
+	}{
+		Ctx:     ctx,
+		UserID:  userID,
+		PostID:  postID,
+		Rating:  rating,
+		Comment: comment,
+	}
+	mock.lockRatePost.Lock()
+	mock.calls.RatePost = append(mock.calls.RatePost, callInfo)
+	mock.lockRatePost.Unlock()
+	return mock.RatePostFunc(ctx, userID, postID, rating, comment)
+}
+
+// RatePostCalls gets all the calls that were made to RatePost.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.RatePostCalls())
+func (mock *LinkedInServiceInteractorMock) RatePostCalls() []struct {
+	Ctx     context.Context
+	UserID  uuid.UUID
+	PostID  uuid.UUID
+	Rating  string
+	Comment string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		UserID  uuid.UUID
+		PostID  uuid.UUID
+		Rating  string
+		Comment string
+	}
+	mock.lockRatePost.RLock()
+	calls = mock.calls.RatePost
+	mock.lockRatePost.RUnlock()
+	return calls
+}
+
+// Regenerate calls RegenerateFunc.
+func (mock *LinkedInServiceInteractorMock) Regenerate(ctx context.Context, userID uuid.UUID, postID uuid.UUID, overrides TransformOptions) (*TransformResult, error) {
+	if mock.RegenerateFunc == nil {
+		panic("LinkedInServiceInteractorMock.RegenerateFunc: method is nil but LinkedInServiceInteractor.Regenerate was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		PostID    uuid.UUID
+		Overrides TransformOptions
+	}{
+		Ctx:       ctx,
+		UserID:    userID,
+		PostID:    postID,
+		Overrides: overrides,
+	}
+	mock.lockRegenerate.Lock()
+	mock.calls.Regenerate = append(mock.calls.Regenerate, callInfo)
+	mock.lockRegenerate.Unlock()
+	return mock.RegenerateFunc(ctx, userID, postID, overrides)
+}
+
+// RegenerateCalls gets all the calls that were made to Regenerate.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.RegenerateCalls())
+func (mock *LinkedInServiceInteractorMock) RegenerateCalls() []struct {
+	Ctx       context.Context
+	UserID    uuid.UUID
+	PostID    uuid.UUID
+	Overrides TransformOptions
+} {
+	var calls []struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		PostID    uuid.UUID
+		Overrides TransformOptions
+	}
+	mock.lockRegenerate.RLock()
+	calls = mock.calls.Regenerate
+	mock.lockRegenerate.RUnlock()
+	return calls
+}
+
+// Replay calls ReplayFunc.
+func (mock *LinkedInServiceInteractorMock) Replay(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*TransformResult, error) {
+	if mock.ReplayFunc == nil {
+		panic("LinkedInServiceInteractorMock.ReplayFunc: method is nil but LinkedInServiceInteractor.Replay was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Text   string
+		Opts   TransformOptions
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Text:   text,
+		Opts:   opts,
+	}
+	mock.lockReplay.Lock()
+	mock.calls.Replay = append(mock.calls.Replay, callInfo)
+	mock.lockReplay.Unlock()
+	return mock.ReplayFunc(ctx, userID, text, opts)
+}
+
+// ReplayCalls gets all the calls that were made to Replay.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.ReplayCalls())
+func (mock *LinkedInServiceInteractorMock) ReplayCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Text   string
+	Opts   TransformOptions
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Text   string
+		Opts   TransformOptions
+	}
+	mock.lockReplay.RLock()
+	calls = mock.calls.Replay
+	mock.lockReplay.RUnlock()
+	return calls
+}
+
+// Restore calls RestoreFunc.
+func (mock *LinkedInServiceInteractorMock) Restore(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error {
+	if mock.RestoreFunc == nil {
+		panic("LinkedInServiceInteractorMock.RestoreFunc: method is nil but LinkedInServiceInteractor.Restore was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+	}
+	mock.lockRestore.Lock()
+	mock.calls.Restore = append(mock.calls.Restore, callInfo)
+	mock.lockRestore.Unlock()
+	return mock.RestoreFunc(ctx, userID, postID)
+}
+
+// RestoreCalls gets all the calls that were made to Restore.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.RestoreCalls())
+func (mock *LinkedInServiceInteractorMock) RestoreCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}
+	mock.lockRestore.RLock()
+	calls = mock.calls.Restore
+	mock.lockRestore.RUnlock()
+	return calls
+}
+
+// RestoreVersion calls RestoreVersionFunc.
+func (mock *LinkedInServiceInteractorMock) RestoreVersion(ctx context.Context, userID uuid.UUID, postID uuid.UUID, versionID uuid.UUID) (*model.LinkedInPost, error) {
+	if mock.RestoreVersionFunc == nil {
+		panic("LinkedInServiceInteractorMock.RestoreVersionFunc: method is nil but LinkedInServiceInteractor.RestoreVersion was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		PostID    uuid.UUID
+		VersionID uuid.UUID
+	}{
+		Ctx:       ctx,
+		UserID:    userID,
+		PostID:    postID,
+		VersionID: versionID,
+	}
+	mock.lockRestoreVersion.Lock()
+	mock.calls.RestoreVersion = append(mock.calls.RestoreVersion, callInfo)
+	mock.lockRestoreVersion.Unlock()
+	return mock.RestoreVersionFunc(ctx, userID, postID, versionID)
+}
+
+// RestoreVersionCalls gets all the calls that were made to RestoreVersion.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.RestoreVersionCalls())
+func (mock *LinkedInServiceInteractorMock) RestoreVersionCalls() []struct {
+	Ctx       context.Context
+	UserID    uuid.UUID
+	PostID    uuid.UUID
+	VersionID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		PostID    uuid.UUID
+		VersionID uuid.UUID
+	}
+	mock.lockRestoreVersion.RLock()
+	calls = mock.calls.RestoreVersion
+	mock.lockRestoreVersion.RUnlock()
+	return calls
+}
+
+// Schedule calls ScheduleFunc.
+func (mock *LinkedInServiceInteractorMock) Schedule(ctx context.Context, userID uuid.UUID, postID uuid.UUID, at time.Time) (*model.LinkedInPost, error) {
+	if mock.ScheduleFunc == nil {
+		panic("LinkedInServiceInteractorMock.ScheduleFunc: method is nil but LinkedInServiceInteractor.Schedule was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+		At     time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+		At:     at,
+	}
+	mock.lockSchedule.Lock()
+	mock.calls.Schedule = append(mock.calls.Schedule, callInfo)
+	mock.lockSchedule.Unlock()
+	return mock.ScheduleFunc(ctx, userID, postID, at)
+}
+
+// ScheduleCalls gets all the calls that were made to Schedule.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.ScheduleCalls())
+func (mock *LinkedInServiceInteractorMock) ScheduleCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+	At     time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+		At     time.Time
+	}
+	mock.lockSchedule.RLock()
+	calls = mock.calls.Schedule
+	mock.lockSchedule.RUnlock()
+	return calls
+}
+
+// Search calls SearchFunc.
+func (mock *LinkedInServiceInteractorMock) Search(ctx context.Context, userID uuid.UUID, query string, limit int, offset int) ([]model.LinkedInPost, int, error) {
+	if mock.SearchFunc == nil {
+		panic("LinkedInServiceInteractorMock.SearchFunc: method is nil but LinkedInServiceInteractor.Search was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Query  string
+		Limit  int
+		Offset int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Query:  query,
+		Limit:  limit,
+		Offset: offset,
+	}
+	mock.lockSearch.Lock()
+	mock.calls.Search = append(mock.calls.Search, callInfo)
+	mock.lockSearch.Unlock()
+	return mock.SearchFunc(ctx, userID, query, limit, offset)
+}
+
+// SearchCalls gets all the calls that were made to Search.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.SearchCalls())
+func (mock *LinkedInServiceInteractorMock) SearchCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Query  string
+	Limit  int
+	Offset int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Query  string
+		Limit  int
+		Offset int
+	}
+	mock.lockSearch.RLock()
+	calls = mock.calls.Search
+	mock.lockSearch.RUnlock()
+	return calls
+}
+
+// SuggestHashtags calls SuggestHashtagsFunc.
+func (mock *LinkedInServiceInteractorMock) SuggestHashtags(ctx context.Context, content string) ([]string, error) {
+	if mock.SuggestHashtagsFunc == nil {
+		panic("LinkedInServiceInteractorMock.SuggestHashtagsFunc: method is nil but LinkedInServiceInteractor.SuggestHashtags was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Content string
+	}{
+		Ctx:     ctx,
+		Content: content,
+	}
+	mock.lockSuggestHashtags.Lock()
+	mock.calls.SuggestHashtags = append(mock.calls.SuggestHashtags, callInfo)
+	mock.lockSuggestHashtags.Unlock()
+	return mock.SuggestHashtagsFunc(ctx, content)
+}
+
+// SuggestHashtagsCalls gets all the calls that were made to SuggestHashtags.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.SuggestHashtagsCalls())
+func (mock *LinkedInServiceInteractorMock) SuggestHashtagsCalls() []struct {
+	Ctx     context.Context
+	Content string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Content string
+	}
+	mock.lockSuggestHashtags.RLock()
+	calls = mock.calls.SuggestHashtags
+	mock.lockSuggestHashtags.RUnlock()
+	return calls
+}
+
+// Transform calls TransformFunc.
+func (mock *LinkedInServiceInteractorMock) Transform(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (*TransformResult, error) {
+	if mock.TransformFunc == nil {
+		panic("LinkedInServiceInteractorMock.TransformFunc: method is nil but LinkedInServiceInteractor.Transform was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Text   string
+		Opts   TransformOptions
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Text:   text,
+		Opts:   opts,
+	}
+	mock.lockTransform.Lock()
+	mock.calls.Transform = append(mock.calls.Transform, callInfo)
+	mock.lockTransform.Unlock()
+	return mock.TransformFunc(ctx, userID, text, opts)
+}
+
+// TransformCalls gets all the calls that were made to Transform.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.TransformCalls())
+func (mock *LinkedInServiceInteractorMock) TransformCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Text   string
+	Opts   TransformOptions
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Text   string
+		Opts   TransformOptions
+	}
+	mock.lockTransform.RLock()
+	calls = mock.calls.Transform
+	mock.lockTransform.RUnlock()
+	return calls
+}
+
+// TransformBatch calls TransformBatchFunc.
+func (mock *LinkedInServiceInteractorMock) TransformBatch(ctx context.Context, userID uuid.UUID, items []BatchItem) ([]BatchResult, error) {
+	if mock.TransformBatchFunc == nil {
+		panic("LinkedInServiceInteractorMock.TransformBatchFunc: method is nil but LinkedInServiceInteractor.TransformBatch was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Items  []BatchItem
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Items:  items,
+	}
+	mock.lockTransformBatch.Lock()
+	mock.calls.TransformBatch = append(mock.calls.TransformBatch, callInfo)
+	mock.lockTransformBatch.Unlock()
+	return mock.TransformBatchFunc(ctx, userID, items)
+}
+
+// TransformBatchCalls gets all the calls that were made to TransformBatch.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.TransformBatchCalls())
+func (mock *LinkedInServiceInteractorMock) TransformBatchCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Items  []BatchItem
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Items  []BatchItem
+	}
+	mock.lockTransformBatch.RLock()
+	calls = mock.calls.TransformBatch
+	mock.lockTransformBatch.RUnlock()
+	return calls
+}
+
+// TransformStream calls TransformStreamFunc.
+func (mock *LinkedInServiceInteractorMock) TransformStream(ctx context.Context, userID uuid.UUID, text string, opts TransformOptions) (<-chan StreamChunk, error) {
+	if mock.TransformStreamFunc == nil {
+		panic("LinkedInServiceInteractorMock.TransformStreamFunc: method is nil but LinkedInServiceInteractor.TransformStream was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Text   string
+		Opts   TransformOptions
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Text:   text,
+		Opts:   opts,
+	}
+	mock.lockTransformStream.Lock()
+	mock.calls.TransformStream = append(mock.calls.TransformStream, callInfo)
+	mock.lockTransformStream.Unlock()
+	return mock.TransformStreamFunc(ctx, userID, text, opts)
+}
+
+// TransformStreamCalls gets all the calls that were made to TransformStream.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.TransformStreamCalls())
+func (mock *LinkedInServiceInteractorMock) TransformStreamCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Text   string
+	Opts   TransformOptions
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Text   string
+		Opts   TransformOptions
+	}
+	mock.lockTransformStream.RLock()
+	calls = mock.calls.TransformStream
+	mock.lockTransformStream.RUnlock()
+	return calls
+}
+
+// Unfavorite calls UnfavoriteFunc.
+func (mock *LinkedInServiceInteractorMock) Unfavorite(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error {
+	if mock.UnfavoriteFunc == nil {
+		panic("LinkedInServiceInteractorMock.UnfavoriteFunc: method is nil but LinkedInServiceInteractor.Unfavorite was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+	}
+	mock.lockUnfavorite.Lock()
+	mock.calls.Unfavorite = append(mock.calls.Unfavorite, callInfo)
+	mock.lockUnfavorite.Unlock()
+	return mock.UnfavoriteFunc(ctx, userID, postID)
+}
+
+// UnfavoriteCalls gets all the calls that were made to Unfavorite.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.UnfavoriteCalls())
+func (mock *LinkedInServiceInteractorMock) UnfavoriteCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}
+	mock.lockUnfavorite.RLock()
+	calls = mock.calls.Unfavorite
+	mock.lockUnfavorite.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *LinkedInServiceInteractorMock) Update(ctx context.Context, userID uuid.UUID, postID uuid.UUID, u PostUpdate) (*model.LinkedInPost, error) {
+	if mock.UpdateFunc == nil {
+		panic("LinkedInServiceInteractorMock.UpdateFunc: method is nil but LinkedInServiceInteractor.Update was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+		U      PostUpdate
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+		U:      u,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(ctx, userID, postID, u)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.UpdateCalls())
+func (mock *LinkedInServiceInteractorMock) UpdateCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+	U      PostUpdate
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+		U      PostUpdate
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Versions calls VersionsFunc.
+func (mock *LinkedInServiceInteractorMock) Versions(ctx context.Context, userID uuid.UUID, postID uuid.UUID) ([]model.PostVersion, error) {
+	if mock.VersionsFunc == nil {
+		panic("LinkedInServiceInteractorMock.VersionsFunc: method is nil but LinkedInServiceInteractor.Versions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		PostID: postID,
+	}
+	mock.lockVersions.Lock()
+	mock.calls.Versions = append(mock.calls.Versions, callInfo)
+	mock.lockVersions.Unlock()
+	return mock.VersionsFunc(ctx, userID, postID)
+}
+
+// VersionsCalls gets all the calls that were made to Versions.
+// Check the length with:
+//
+//	len(mockedLinkedInServiceInteractor.VersionsCalls())
+func (mock *LinkedInServiceInteractorMock) VersionsCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	PostID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		PostID uuid.UUID
+	}
+	mock.lockVersions.RLock()
+	calls = mock.calls.Versions
+	mock.lockVersions.RUnlock()
 	return calls
 }
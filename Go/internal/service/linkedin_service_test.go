@@ -3,9 +3,14 @@ package service_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -19,9 +24,13 @@ import (
 
 func TestLinkedInService_Transform_Success(t *testing.T) {
 	mockAIClient := &ai.ClientMock{
-		TransformFunc: func(ctx context.Context, text string) (string, error) {
-			assert.Equal(t, "original text", text)
-			return "ai transformed text", nil
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			assert.Contains(t, prompt, `"original text"`, "The prompt should embed the user's text")
+			return ai.Result{
+				Text:  "ai transformed text",
+				Model: "gpt-4o-mini",
+				Usage: ai.Usage{PromptTokens: 10, CompletionTokens: 20, TotalTokens: 30},
+			}, nil
 		},
 	}
 
@@ -32,6 +41,7 @@ func TestLinkedInService_Transform_Success(t *testing.T) {
 			assert.Equal(t, "11111111-1111-1111-1111-111111111111", p.UserID.String())
 			assert.Equal(t, "original text", p.InputText)
 			assert.Equal(t, "ai transformed text", p.OutputText)
+			assert.Equal(t, "gpt-4o-mini", p.Model)
 			return nil
 		},
 	}
@@ -41,17 +51,21 @@ func TestLinkedInService_Transform_Success(t *testing.T) {
 	userID, _ := uuid.Parse("11111111-1111-1111-1111-111111111111")
 	inputText := "original text"
 
-	transformedText, err := liSvc.Transform(context.Background(), userID, inputText)
+	transformed, err := liSvc.Transform(context.Background(), userID, inputText, service.TransformOptions{})
 	require.NoError(t, err)
-	assert.Equal(t, "ai transformed text", transformedText)
+	assert.Equal(t, "ai transformed text", transformed.Post)
+	assert.Equal(t, 30, transformed.Usage.TotalTokens)
+	assert.Equal(t, 30, mockPostRepo.SaveCalls()[0].P.TotalTokens)
 
 	assert.Len(t, mockAIClient.TransformCalls(), 1, "Expected AIClient.Transform to be called once on first call (cache miss)")
 	assert.Len(t, mockPostRepo.SaveCalls(), 1, "Expected PostRepository.Save to be called once on first call")
 
 	// Second call with the same input - should be a cache hit
-	transformedTextCached, errCached := liSvc.Transform(context.Background(), userID, inputText)
+	transformedCached, errCached := liSvc.Transform(context.Background(), userID, inputText, service.TransformOptions{})
 	require.NoError(t, errCached)
-	assert.Equal(t, "ai transformed text", transformedTextCached)
+	assert.Equal(t, "ai transformed text", transformedCached.Post)
+	assert.Zero(t, transformedCached.Usage, "A cache hit should not report token usage")
+	assert.True(t, transformedCached.Cached)
 
 	assert.Len(t, mockAIClient.TransformCalls(), 1, "Expected AIClient.Transform to still be called only once (cache hit)")
 	assert.Len(t, mockPostRepo.SaveCalls(), 2, "Expected PostRepository.Save to be called twice (once for cache miss, once for cache hit)")
@@ -60,8 +74,8 @@ func TestLinkedInService_Transform_Success(t *testing.T) {
 func TestLinkedInService_Transform_AIClientError(t *testing.T) {
 	aiError := errors.New("ai client failed")
 	mockAIClient := &ai.ClientMock{
-		TransformFunc: func(ctx context.Context, text string) (string, error) {
-			return "", aiError
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{}, aiError
 		},
 	}
 	mockPostRepo := &repository.PostRepositoryMock{}
@@ -69,7 +83,7 @@ func TestLinkedInService_Transform_AIClientError(t *testing.T) {
 	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
 	userID, _ := uuid.Parse("test-user-id")
 
-	_, err := liSvc.Transform(context.Background(), userID, "some text")
+	_, err := liSvc.Transform(context.Background(), userID, "some text", service.TransformOptions{})
 	require.Error(t, err)
 	assert.Equal(t, aiError, err)
 
@@ -80,8 +94,8 @@ func TestLinkedInService_Transform_AIClientError(t *testing.T) {
 func TestLinkedInService_Transform_RepositorySaveError(t *testing.T) {
 	repoSaveError := errors.New("failed to save post")
 	mockAIClient := &ai.ClientMock{
-		TransformFunc: func(ctx context.Context, text string) (string, error) {
-			return "transformed text", nil
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "transformed text"}, nil
 		},
 	}
 	mockPostRepo := &repository.PostRepositoryMock{
@@ -93,7 +107,7 @@ func TestLinkedInService_Transform_RepositorySaveError(t *testing.T) {
 	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
 	userID, _ := uuid.Parse("test-user-id")
 
-	_, err := liSvc.Transform(context.Background(), userID, "some text")
+	_, err := liSvc.Transform(context.Background(), userID, "some text", service.TransformOptions{})
 	require.Error(t, err)
 	assert.Equal(t, repoSaveError, err)
 
@@ -109,20 +123,21 @@ func TestLinkedInService_History_Success(t *testing.T) {
 	}
 
 	mockPostRepo := &repository.PostRepositoryMock{
-		ListByUserFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error) {
+		ListByUserFunc: func(ctx context.Context, userID uuid.UUID, f repository.PostFilter, limit, offset int) ([]model.LinkedInPost, int, error) {
 			assert.Equal(t, testUserID, userID)
-			assert.Equal(t, 1, page)
-			assert.Equal(t, 10, pageSize)
-			return expectedPosts, nil
+			assert.Equal(t, 10, limit)
+			assert.Equal(t, 20, offset)
+			return expectedPosts, 22, nil
 		},
 	}
 	mockAIClient := &ai.ClientMock{}
 
 	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
 
-	posts, err := liSvc.History(context.Background(), testUserID, 1, 10)
+	posts, total, err := liSvc.History(context.Background(), testUserID, service.PostFilter{}, 10, 20)
 	require.NoError(t, err)
 	assert.Equal(t, expectedPosts, posts)
+	assert.Equal(t, 22, total)
 	assert.Len(t, mockPostRepo.ListByUserCalls(), 1)
 }
 
@@ -131,16 +146,1153 @@ func TestLinkedInService_History_RepositoryError(t *testing.T) {
 	testUserID, _ := uuid.Parse("history-user-id-err")
 
 	mockPostRepo := &repository.PostRepositoryMock{
-		ListByUserFunc: func(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]model.LinkedInPost, error) {
-			return nil, repoListError
+		ListByUserFunc: func(ctx context.Context, userID uuid.UUID, f repository.PostFilter, limit, offset int) ([]model.LinkedInPost, int, error) {
+			return nil, 0, repoListError
 		},
 	}
 	mockAIClient := &ai.ClientMock{}
 
 	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
 
-	_, err := liSvc.History(context.Background(), testUserID, 1, 10)
+	_, _, err := liSvc.History(context.Background(), testUserID, service.PostFilter{}, 10, 0)
 	require.Error(t, err)
 	assert.Equal(t, repoListError, err)
 	assert.Len(t, mockPostRepo.ListByUserCalls(), 1)
 }
+
+// streamOf returns a closed channel pre-filled with the given chunks.
+func streamOf(chunks ...ai.Chunk) <-chan ai.Chunk {
+	ch := make(chan ai.Chunk, len(chunks))
+	for _, c := range chunks {
+		ch <- c
+	}
+	close(ch)
+	return ch
+}
+
+func TestLinkedInService_TransformStream_Success(t *testing.T) {
+	mockAIClient := &ai.ClientMock{
+		StreamFunc: func(ctx context.Context, prompt string, opts ai.Options) (<-chan ai.Chunk, error) {
+			assert.Contains(t, prompt, `"original text"`, "The prompt should embed the user's text")
+			return streamOf(
+				ai.Chunk{Text: "ai ", Model: "gpt-4o"},
+				ai.Chunk{Text: "streamed", Model: "gpt-4o"},
+				ai.Chunk{Model: "gpt-4o", Usage: ai.Usage{PromptTokens: 30, CompletionTokens: 2, TotalTokens: 32}},
+			), nil
+		},
+	}
+	var savedID uuid.UUID
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+			assert.Equal(t, "original text", p.InputText)
+			assert.Equal(t, "ai streamed", p.OutputText)
+			assert.Equal(t, "gpt-4o", p.Model, "The post records the model the stream was generated with")
+			assert.Equal(t, 32, p.TotalTokens)
+			savedID = p.ID
+			return nil
+		},
+	}
+
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
+	chunks, err := liSvc.TransformStream(context.Background(), uuid.New(), "original text", service.TransformOptions{})
+	require.NoError(t, err)
+
+	var got []string
+	var last service.StreamChunk
+	for c := range chunks {
+		require.NoError(t, c.Err)
+		if c.PostID == uuid.Nil {
+			got = append(got, c.Text)
+		}
+		last = c
+	}
+	assert.Equal(t, []string{"ai ", "streamed"}, got, "The usage chunk is not passed on")
+	assert.Len(t, mockPostRepo.SaveCalls(), 1)
+	assert.Equal(t, savedID, last.PostID, "The stream ends with the ID of the saved post")
+}
+
+func TestLinkedInService_TransformStream_MidStreamError(t *testing.T) {
+	streamErr := errors.New("stream broke")
+	mockAIClient := &ai.ClientMock{
+		StreamFunc: func(ctx context.Context, text string, opts ai.Options) (<-chan ai.Chunk, error) {
+			return streamOf(ai.Chunk{Text: "partial"}, ai.Chunk{Err: streamErr}), nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{}
+
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
+	chunks, err := liSvc.TransformStream(context.Background(), uuid.New(), "some text", service.TransformOptions{})
+	require.NoError(t, err)
+
+	var last service.StreamChunk
+	for c := range chunks {
+		last = c
+	}
+	assert.Equal(t, streamErr, last.Err)
+	assert.Len(t, mockPostRepo.SaveCalls(), 0)
+}
+
+func TestLinkedInService_TransformStream_StartError(t *testing.T) {
+	aiError := errors.New("ai client failed")
+	mockAIClient := &ai.ClientMock{
+		StreamFunc: func(ctx context.Context, text string, opts ai.Options) (<-chan ai.Chunk, error) {
+			return nil, aiError
+		},
+	}
+
+	liSvc := service.NewLinkedIn(mockAIClient, &repository.PostRepositoryMock{})
+	_, err := liSvc.TransformStream(context.Background(), uuid.New(), "some text", service.TransformOptions{})
+	assert.Equal(t, aiError, err)
+}
+
+func TestLinkedInService_Transform_ModelOverride(t *testing.T) {
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, text string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "output from " + opts.Model, Model: opts.Model}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
+	userID := uuid.New()
+
+	out, err := liSvc.Transform(context.Background(), userID, "same text", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "output from ", out.Post)
+
+	// The same text with a different model must not be served from the cache.
+	out, err = liSvc.Transform(context.Background(), userID, "same text", service.TransformOptions{Model: "gpt-4o"})
+	require.NoError(t, err)
+	assert.Equal(t, "output from gpt-4o", out.Post)
+	assert.Len(t, mockAIClient.TransformCalls(), 2)
+	assert.Equal(t, "gpt-4o", mockAIClient.TransformCalls()[1].Opts.Model)
+}
+
+func TestLinkedInService_Delete_NotFound(t *testing.T) {
+	mockPostRepo := &repository.PostRepositoryMock{
+		DeleteFunc: func(ctx context.Context, userID, id uuid.UUID) error {
+			return sql.ErrNoRows
+		},
+	}
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo)
+
+	err := liSvc.Delete(context.Background(), uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, service.ErrPostNotFound)
+}
+
+func TestLinkedInService_Transform_Template(t *testing.T) {
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "post"}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
+
+	_, err := liSvc.Transform(context.Background(), uuid.New(), "we raised a seed round", service.TransformOptions{Template: "announcement"})
+	require.NoError(t, err)
+	_, err = liSvc.Transform(context.Background(), uuid.New(), "we raised a seed round", service.TransformOptions{})
+	require.NoError(t, err)
+
+	calls := mockAIClient.TransformCalls()
+	require.Len(t, calls, 2, "Different templates must not share a cache entry")
+	assert.Contains(t, calls[0].Prompt, "announcement")
+	assert.NotEqual(t, calls[0].Prompt, calls[1].Prompt)
+
+	_, err = liSvc.Transform(context.Background(), uuid.New(), "text", service.TransformOptions{Template: "nope"})
+	assert.ErrorIs(t, err, service.ErrUnknownTemplate)
+}
+
+func TestLinkedInService_Transform_ToneAndLength(t *testing.T) {
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "post"}, nil
+		},
+	}
+	var saved []*model.LinkedInPost
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+			saved = append(saved, p)
+			return nil
+		},
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
+
+	_, err := liSvc.Transform(context.Background(), uuid.New(), "shipped v2", service.TransformOptions{})
+	require.NoError(t, err)
+	_, err = liSvc.Transform(context.Background(), uuid.New(), "shipped v2", service.TransformOptions{Tone: service.ToneHumorous, Length: service.LengthLong})
+	require.NoError(t, err)
+
+	calls := mockAIClient.TransformCalls()
+	require.Len(t, calls, 2, "Different styles must not share a cache entry")
+	assert.Contains(t, calls[0].Prompt, "under 240 characters")
+	assert.NotContains(t, calls[0].Prompt, "humorous")
+	assert.Contains(t, calls[1].Prompt, "humorous")
+	assert.NotContains(t, calls[1].Prompt, "under 240 characters")
+	assert.Less(t, calls[0].Opts.MaxTokens, calls[1].Opts.MaxTokens)
+
+	require.Len(t, saved, 2)
+	assert.Equal(t, "", saved[0].Tone)
+	assert.Equal(t, "short", saved[0].Length)
+	assert.Equal(t, "humorous", saved[1].Tone)
+	assert.Equal(t, "long", saved[1].Length)
+}
+
+func TestLinkedInService_Update(t *testing.T) {
+	testUserID := uuid.New()
+	stored := &model.LinkedInPost{ID: uuid.New(), UserID: testUserID, OutputText: "draft text", Status: model.PostStatusDraft}
+	mockPostRepo := &repository.PostRepositoryMock{
+		GetOwnedFunc: func(ctx context.Context, userID, id uuid.UUID) (*model.LinkedInPost, error) {
+			if userID != testUserID || id != stored.ID {
+				return nil, sql.ErrNoRows
+			}
+			p := *stored
+			return &p, nil
+		},
+		UpdateIfFunc: func(ctx context.Context, p *model.LinkedInPost, check func(*model.LinkedInPost) error) error {
+			if check != nil {
+				return check(stored)
+			}
+			return nil
+		},
+	}
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo)
+
+	post, err := liSvc.Update(context.Background(), testUserID, stored.ID, service.PostUpdate{Status: model.PostStatusFinal})
+	require.NoError(t, err)
+	assert.Equal(t, "draft text", post.OutputText, "Text is kept when only the status changes")
+	assert.Equal(t, model.PostStatusFinal, post.Status)
+	require.Len(t, mockPostRepo.UpdateIfCalls(), 1)
+
+	assert.Empty(t, post.Source, "Changing only the status is not an edit")
+
+	edited := "my own words"
+	post, err = liSvc.Update(context.Background(), testUserID, stored.ID, service.PostUpdate{Post: &edited})
+	require.NoError(t, err)
+	assert.Equal(t, model.PostSourceManual, post.Source)
+	require.Len(t, mockPostRepo.UpdateIfCalls(), 2)
+
+	_, err = liSvc.Update(context.Background(), uuid.New(), stored.ID, service.PostUpdate{Status: model.PostStatusFinal})
+	assert.ErrorIs(t, err, service.ErrPostNotFound, "Another user's post is not found")
+
+	_, err = liSvc.Update(context.Background(), testUserID, stored.ID, service.PostUpdate{Status: "published"})
+	assert.ErrorIs(t, err, service.ErrInvalidStatus)
+	assert.Len(t, mockPostRepo.UpdateIfCalls(), 2)
+
+	stale := func(current *model.LinkedInPost) bool { return current.Status == model.PostStatusFinal }
+	_, err = liSvc.Update(context.Background(), testUserID, stored.ID, service.PostUpdate{Status: model.PostStatusFinal, Precondition: stale})
+	assert.ErrorIs(t, err, service.ErrPreconditionFailed, "The precondition is checked against the stored post")
+}
+
+func TestLinkedInService_RestoreVersion(t *testing.T) {
+	testUserID := uuid.New()
+	postID := uuid.New()
+	version := &model.PostVersion{ID: uuid.New(), PostID: postID, OutputText: "first draft", Source: model.PostSourceAI}
+	mockPostRepo := &repository.PostRepositoryMock{
+		GetOwnedFunc: func(ctx context.Context, userID, id uuid.UUID) (*model.LinkedInPost, error) {
+			if userID != testUserID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.LinkedInPost{ID: id, UserID: userID, OutputText: "hand edit", Source: model.PostSourceManual}, nil
+		},
+		GetVersionFunc: func(ctx context.Context, pid, versionID uuid.UUID) (*model.PostVersion, error) {
+			if versionID != version.ID {
+				return nil, sql.ErrNoRows
+			}
+			return version, nil
+		},
+		UpdateFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo)
+
+	post, err := liSvc.RestoreVersion(context.Background(), testUserID, postID, version.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "first draft", post.OutputText)
+	assert.Equal(t, model.PostSourceAI, post.Source)
+	require.Len(t, mockPostRepo.UpdateCalls(), 1)
+
+	_, err = liSvc.RestoreVersion(context.Background(), testUserID, postID, uuid.New())
+	assert.ErrorIs(t, err, service.ErrVersionNotFound)
+	_, err = liSvc.RestoreVersion(context.Background(), uuid.New(), postID, version.ID)
+	assert.ErrorIs(t, err, service.ErrPostNotFound)
+	assert.Len(t, mockPostRepo.UpdateCalls(), 1)
+}
+
+func TestLinkedInService_Regenerate(t *testing.T) {
+	testUserID := uuid.New()
+	stored := &model.LinkedInPost{
+		ID: uuid.New(), UserID: testUserID, InputText: "we hired a designer", OutputText: "old post",
+		Status: model.PostStatusFinal, Template: "job-update", Tone: string(service.ToneCasual), Length: string(service.LengthShort),
+	}
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "new post", Model: "gpt-4o-mini", Usage: ai.Usage{TotalTokens: 42}}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		GetOwnedFunc: func(ctx context.Context, userID, id uuid.UUID) (*model.LinkedInPost, error) {
+			if userID != testUserID {
+				return nil, sql.ErrNoRows
+			}
+			p := *stored
+			return &p, nil
+		},
+		UpdateFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
+
+	for i := 0; i < 2; i++ {
+		res, err := liSvc.Regenerate(context.Background(), testUserID, stored.ID, service.TransformOptions{Length: service.LengthLong})
+		require.NoError(t, err)
+		assert.Equal(t, stored.ID, res.PostID, "Regenerating updates the existing post")
+		assert.Equal(t, "new post", res.Post)
+	}
+	calls := mockAIClient.TransformCalls()
+	require.Len(t, calls, 2, "Regenerating must bypass the cache")
+	assert.Contains(t, calls[0].Prompt, "we hired a designer")
+	assert.Contains(t, calls[0].Prompt, "conversational", "The stored tone is reused")
+	assert.NotContains(t, calls[0].Prompt, "under 240 characters", "The length override applies")
+
+	updated := mockPostRepo.UpdateCalls()[0].P
+	assert.Equal(t, "new post", updated.OutputText)
+	assert.Equal(t, model.PostStatusDraft, updated.Status)
+	assert.Equal(t, model.PostSourceAI, updated.Source)
+	assert.Equal(t, "long", updated.Length)
+	assert.Equal(t, 42, updated.TotalTokens)
+
+	_, err := liSvc.Regenerate(context.Background(), uuid.New(), stored.ID, service.TransformOptions{})
+	assert.ErrorIs(t, err, service.ErrPostNotFound)
+}
+
+func TestLinkedInService_Sampling(t *testing.T) {
+	userID := uuid.New()
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "post"}, nil
+		},
+	}
+	var saved *model.LinkedInPost
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { saved = p; return nil },
+		GetOwnedFunc: func(ctx context.Context, uid, id uuid.UUID) (*model.LinkedInPost, error) {
+			p := *saved
+			return &p, nil
+		},
+		UpdateFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithSampling(0.7, 1))
+	hot, narrow := 1.4, 0.3
+
+	res, err := liSvc.Transform(context.Background(), userID, "text", service.TransformOptions{})
+	require.NoError(t, err)
+	got := mockAIClient.TransformCalls()[0].Opts
+	assert.Equal(t, 0.7, *got.Temperature, "The deployment's defaults apply")
+	assert.Nil(t, got.TopP, "top_p is not sent unless it was changed")
+	assert.Equal(t, 0.7, saved.Temperature)
+	assert.Equal(t, 1.0, saved.TopP, "The provider's default is stored")
+
+	_, err = liSvc.Transform(context.Background(), userID, "text", service.TransformOptions{Temperature: &hot})
+	require.NoError(t, err)
+	require.Len(t, mockAIClient.TransformCalls(), 2, "Other sampling parameters are not served from the cache")
+	assert.Equal(t, 1.4, *mockAIClient.TransformCalls()[1].Opts.Temperature)
+	assert.Equal(t, 1.4, saved.Temperature, "The chosen values are stored with the post")
+	assert.Equal(t, 1.0, saved.TopP)
+
+	_, err = liSvc.Regenerate(context.Background(), userID, res.PostID, service.TransformOptions{TopP: &narrow})
+	require.NoError(t, err)
+	got = mockAIClient.TransformCalls()[2].Opts
+	assert.Equal(t, 1.4, *got.Temperature, "Regenerating reuses the stored values")
+	assert.Equal(t, 0.3, *got.TopP, "unless overridden")
+	updated := mockPostRepo.UpdateCalls()[0].P
+	assert.Equal(t, 1.4, updated.Temperature)
+	assert.Equal(t, 0.3, updated.TopP)
+
+	_, err = liSvc.Regenerate(context.Background(), userID, res.PostID, service.TransformOptions{Temperature: &hot})
+	require.NoError(t, err)
+	assert.Nil(t, mockAIClient.TransformCalls()[3].Opts.TopP, "A stored default top_p is not sent either")
+
+	narrowed := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithSampling(0.7, 0.5))
+	_, err = narrowed.Transform(context.Background(), userID, "text", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, *mockAIClient.TransformCalls()[4].Opts.TopP, "A top_p the deployment set is sent")
+	assert.Equal(t, 0.5, saved.TopP)
+}
+
+func TestLinkedInService_Transform_CacheDisabled(t *testing.T) {
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "post"}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithCache(nil))
+
+	for i := 0; i < 2; i++ {
+		res, err := liSvc.Transform(context.Background(), uuid.New(), "same text", service.TransformOptions{})
+		require.NoError(t, err)
+		assert.False(t, res.Cached)
+	}
+	assert.Len(t, mockAIClient.TransformCalls(), 2)
+}
+
+func TestLinkedInService_Transform_Moderation(t *testing.T) {
+	clean := func(ctx context.Context, text string) (ai.Moderation, error) {
+		if strings.Contains(text, "rude") {
+			return ai.Moderation{Flagged: true, Categories: []string{"hate"}}, nil
+		}
+		return ai.Moderation{}, nil
+	}
+	newService := func(outputs []string, mod ai.Moderator) (service.LinkedInServiceInteractor, *ai.ClientMock, *repository.PostRepositoryMock) {
+		mockAIClient := &ai.ClientMock{}
+		mockAIClient.TransformFunc = func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			n := len(mockAIClient.TransformCalls()) - 1
+			return ai.Result{Text: outputs[n], Usage: ai.Usage{TotalTokens: 10}}, nil
+		}
+		mockPostRepo := &repository.PostRepositoryMock{
+			SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+		}
+		return service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithModeration(mod)), mockAIClient, mockPostRepo
+	}
+
+	t.Run("flagged output is regenerated with a stricter prompt", func(t *testing.T) {
+		svc, client, _ := newService([]string{"a rude post", "a polite post"}, &ai.ModeratorMock{ModerateFunc: clean})
+		res, err := svc.Transform(context.Background(), uuid.New(), "text", service.TransformOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "a polite post", res.Post)
+		assert.Equal(t, 20, res.Usage.TotalTokens, "Both generations count towards usage")
+		calls := client.TransformCalls()
+		require.Len(t, calls, 2)
+		assert.Contains(t, calls[1].Prompt, "strictly professional")
+	})
+
+	t.Run("flagged twice is rejected", func(t *testing.T) {
+		svc, _, repo := newService([]string{"a rude post", "still rude"}, &ai.ModeratorMock{ModerateFunc: clean})
+		_, err := svc.Transform(context.Background(), uuid.New(), "text", service.TransformOptions{})
+		require.ErrorIs(t, err, service.ErrContentFlagged)
+		var flagged *service.ModerationError
+		require.ErrorAs(t, err, &flagged)
+		assert.Equal(t, []string{"hate"}, flagged.Categories)
+		assert.Equal(t, 20, flagged.Usage.TotalTokens, "Both flagged generations were paid for")
+		assert.Empty(t, repo.SaveCalls(), "A flagged post is not saved")
+	})
+
+	t.Run("moderation outage fails open", func(t *testing.T) {
+		down := &ai.ModeratorMock{ModerateFunc: func(ctx context.Context, text string) (ai.Moderation, error) {
+			return ai.Moderation{}, errors.New("moderation API unavailable")
+		}}
+		svc, client, _ := newService([]string{"a rude post"}, down)
+		res, err := svc.Transform(context.Background(), uuid.New(), "text", service.TransformOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "a rude post", res.Post)
+		assert.Len(t, client.TransformCalls(), 1)
+	})
+}
+
+func TestLinkedInService_Transform_Language(t *testing.T) {
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "post"}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
+
+	_, err := liSvc.Transform(context.Background(), uuid.New(), "we shipped", service.TransformOptions{})
+	require.NoError(t, err)
+	_, err = liSvc.Transform(context.Background(), uuid.New(), "we shipped", service.TransformOptions{Language: "es"})
+	require.NoError(t, err)
+
+	calls := mockAIClient.TransformCalls()
+	require.Len(t, calls, 2)
+	assert.NotContains(t, calls[0].Prompt, "Write the post in")
+	assert.Contains(t, calls[1].Prompt, "Write the post in Spanish")
+
+	saved := mockPostRepo.SaveCalls()
+	assert.Equal(t, "en", saved[0].P.Language)
+	assert.Equal(t, "es", saved[1].P.Language)
+}
+
+func TestLinkedInService_PostEvents(t *testing.T) {
+	userID := uuid.New()
+	var saved model.LinkedInPost
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "post"}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+			saved = *p
+			return nil
+		},
+		GetOwnedFunc: func(ctx context.Context, uid, id uuid.UUID) (*model.LinkedInPost, error) {
+			p := saved
+			return &p, nil
+		},
+		UpdateIfFunc: func(ctx context.Context, p *model.LinkedInPost, check func(*model.LinkedInPost) error) error {
+			saved = *p
+			return nil
+		},
+	}
+	events := &service.PostEventSenderMock{SendFunc: func(e service.PostEvent) {}}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithPostEvents(events))
+
+	res, err := liSvc.Transform(context.Background(), userID, "text", service.TransformOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.SendCalls(), 1)
+	created := events.SendCalls()[0].E
+	assert.Equal(t, service.EventPostCreated, created.Type)
+	assert.Equal(t, res.PostID, created.PostID)
+	assert.Equal(t, userID, created.UserID)
+	assert.Equal(t, "post", created.Content)
+	assert.Equal(t, model.PostStatusDraft, created.Status)
+
+	edited := "edited"
+	_, err = liSvc.Update(context.Background(), userID, res.PostID, service.PostUpdate{Post: &edited})
+	require.NoError(t, err)
+	assert.Len(t, events.SendCalls(), 1, "Editing a draft is not an event")
+
+	_, err = liSvc.Update(context.Background(), userID, res.PostID, service.PostUpdate{Status: model.PostStatusFinal})
+	require.NoError(t, err)
+	require.Len(t, events.SendCalls(), 2)
+	finalized := events.SendCalls()[1].E
+	assert.Equal(t, service.EventPostFinalized, finalized.Type)
+	assert.Equal(t, "edited", finalized.Content)
+
+	_, err = liSvc.Update(context.Background(), userID, res.PostID, service.PostUpdate{Status: model.PostStatusFinal})
+	require.NoError(t, err)
+	assert.Len(t, events.SendCalls(), 2, "A post that is already final is not finalized again")
+}
+
+func TestLinkedInService_Transform_Profile(t *testing.T) {
+	userID := uuid.New()
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			if id != userID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.User{ID: id, Headline: "staff engineer", Industry: "fintech"}, nil
+		},
+	}
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "post"}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithProfiles(mockUserRepo), service.WithCache(nil))
+
+	_, err := liSvc.Transform(context.Background(), userID, "text", service.TransformOptions{})
+	require.NoError(t, err)
+	prompt := mockAIClient.TransformCalls()[0].Prompt
+	assert.Contains(t, prompt, "The author is a staff engineer.")
+	assert.Contains(t, prompt, "The author works in fintech.")
+
+	_, err = liSvc.Transform(context.Background(), uuid.New(), "text", service.TransformOptions{})
+	require.NoError(t, err, "A missing profile does not fail the post")
+	assert.NotContains(t, mockAIClient.TransformCalls()[1].Prompt, "The author")
+
+	_, err = liSvc.Transform(context.Background(), userID, "text", service.TransformOptions{WithoutProfile: true})
+	require.NoError(t, err)
+	assert.NotContains(t, mockAIClient.TransformCalls()[2].Prompt, "The author", "Opting out gives a generic post")
+	assert.Len(t, mockUserRepo.FindByIDCalls(), 2, "Opting out skips the profile lookup")
+}
+
+func TestLinkedInService_PreviewPrompt(t *testing.T) {
+	userID := uuid.New()
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			return &model.User{ID: id, Headline: "staff engineer"}, nil
+		},
+	}
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "post"}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithProfiles(mockUserRepo), service.WithCache(nil))
+	opts := service.TransformOptions{Tone: service.ToneCasual, Length: service.LengthShort, Language: "de"}
+
+	prompt, err := liSvc.PreviewPrompt(context.Background(), userID, "we shipped v2", opts)
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "we shipped v2")
+	assert.Contains(t, prompt, "The author is a staff engineer.")
+	assert.Empty(t, mockAIClient.TransformCalls(), "A preview does not call the AI")
+	assert.Empty(t, mockPostRepo.SaveCalls(), "A preview saves nothing")
+
+	_, err = liSvc.Transform(context.Background(), userID, "we shipped v2", opts)
+	require.NoError(t, err)
+	assert.Equal(t, prompt, mockAIClient.TransformCalls()[0].Prompt, "The preview is the prompt Transform sends")
+
+	_, err = liSvc.PreviewPrompt(context.Background(), userID, "text", service.TransformOptions{Template: "nope"})
+	assert.ErrorIs(t, err, service.ErrUnknownTemplate)
+}
+
+func TestLinkedInService_GenerateImage(t *testing.T) {
+	userID := uuid.New()
+	postID := uuid.New()
+	mockPostRepo := &repository.PostRepositoryMock{
+		GetOwnedFunc: func(ctx context.Context, uid, id uuid.UUID) (*model.LinkedInPost, error) {
+			if uid != userID || id != postID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.LinkedInPost{ID: id, UserID: uid, OutputText: strings.Repeat("Big news! ", 200)}, nil
+		},
+		UpdateImageFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	mockImages := &ai.ImageGeneratorMock{
+		GenerateImageFunc: func(ctx context.Context, prompt, size string) (ai.Image, error) {
+			_, hasDeadline := ctx.Deadline()
+			assert.True(t, hasDeadline, "Image generation is bounded by a timeout")
+			return ai.Image{URL: "https://images.example.com/1.png", ExpiresAt: time.Unix(1780275600, 0)}, nil
+		},
+	}
+
+	_, err := service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo).GenerateImage(context.Background(), userID, postID, "")
+	assert.ErrorIs(t, err, service.ErrImagesDisabled)
+
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo, service.WithImages(mockImages, service.ImageOptions{Size: "1024x1792"}))
+	post, err := liSvc.GenerateImage(context.Background(), userID, postID, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://images.example.com/1.png", post.ImageURL)
+	assert.True(t, time.Unix(1780275600, 0).Equal(post.ImageExpiresAt), "The expiry of the URL is stored")
+	assert.Equal(t, "1024x1792", post.ImageSize, "The configured size is the default")
+	require.Len(t, mockImages.GenerateImageCalls(), 1)
+	prompt := mockImages.GenerateImageCalls()[0].Prompt
+	assert.Contains(t, prompt, "Big news!")
+	assert.Less(t, len(prompt), 1500, "Long posts are truncated in the image prompt")
+	assert.Equal(t, prompt, post.ImagePrompt)
+	require.Len(t, mockPostRepo.UpdateImageCalls(), 1)
+
+	_, err = liSvc.GenerateImage(context.Background(), userID, postID, "640x480")
+	assert.ErrorIs(t, err, service.ErrInvalidImageSize)
+	_, err = liSvc.GenerateImage(context.Background(), uuid.New(), postID, "")
+	assert.ErrorIs(t, err, service.ErrPostNotFound)
+	assert.Len(t, mockImages.GenerateImageCalls(), 1)
+}
+
+func TestLinkedInService_Connect(t *testing.T) {
+	userID := uuid.New()
+	mockAPI := &service.LinkedInAPIMock{
+		AuthCodeURLFunc: func(state string) string { return "https://linkedin.example.com/auth?state=" + state },
+		ExchangeFunc: func(ctx context.Context, code string) (*service.LinkedInToken, error) {
+			if code != "good-code" {
+				return nil, fmt.Errorf("%w: invalid_grant", service.ErrLinkedInReconnect)
+			}
+			return &service.LinkedInToken{AccessToken: "access", ExpiresAt: time.Now().Add(time.Hour), RefreshToken: "refresh"}, nil
+		},
+		MemberURNFunc: func(ctx context.Context, accessToken string) (string, error) { return "urn:li:person:abc", nil },
+	}
+	used := map[string]bool{}
+	mockConns := &repository.LinkedInConnectionRepositoryMock{
+		SaveFunc: func(ctx context.Context, c *model.LinkedInConnection) error { return nil },
+		UseStateFunc: func(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+			first := !used[id]
+			used[id] = true
+			return first, nil
+		},
+	}
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, &repository.PostRepositoryMock{}, service.WithPublishing(mockAPI, mockConns, []byte("state-secret")))
+	ctx := context.Background()
+
+	_, _, err := service.NewLinkedIn(&ai.ClientMock{}, &repository.PostRepositoryMock{}).ConnectURL(ctx, userID)
+	assert.ErrorIs(t, err, service.ErrPublishingDisabled)
+
+	connect := func() (state, nonce string) {
+		link, nonce, err := liSvc.ConnectURL(ctx, userID)
+		require.NoError(t, err)
+		require.NotEmpty(t, nonce)
+		return strings.TrimPrefix(link, "https://linkedin.example.com/auth?state="), nonce
+	}
+	state, nonce := connect()
+	assert.NotContains(t, state, nonce, "The state does not give its nonce away")
+
+	_, err = liSvc.Connect(ctx, state+"x", nonce, "good-code")
+	assert.ErrorIs(t, err, service.ErrInvalidOAuthState, "Altered states are rejected")
+	other := service.NewLinkedIn(&ai.ClientMock{}, &repository.PostRepositoryMock{}, service.WithPublishing(mockAPI, mockConns, []byte("other-secret")))
+	_, err = other.Connect(ctx, state, nonce, "good-code")
+	assert.ErrorIs(t, err, service.ErrInvalidOAuthState, "States are signed")
+	_, otherNonce := connect()
+	for _, n := range []string{"", otherNonce} {
+		_, err = liSvc.Connect(ctx, state, n, "good-code")
+		assert.ErrorIs(t, err, service.ErrInvalidOAuthState, "A state only works in the browser it was issued to")
+	}
+	assert.Empty(t, mockConns.UseStateCalls())
+
+	_, err = liSvc.Connect(ctx, state, nonce, "bad-code")
+	assert.ErrorIs(t, err, service.ErrInvalidOAuthState, "A code LinkedIn rejects needs a new authorization")
+	_, err = liSvc.Connect(ctx, state, nonce, "good-code")
+	assert.ErrorIs(t, err, service.ErrInvalidOAuthState, "States are single-use")
+	assert.Empty(t, mockConns.SaveCalls())
+
+	state, nonce = connect()
+	c, err := liSvc.Connect(ctx, state, nonce, "good-code")
+	require.NoError(t, err)
+	assert.Equal(t, userID, c.UserID, "The state says whose account it is")
+	assert.Equal(t, "urn:li:person:abc", c.MemberURN)
+	assert.Equal(t, "refresh", c.RefreshToken)
+	require.Len(t, mockConns.SaveCalls(), 1)
+}
+
+func TestLinkedInService_Publish(t *testing.T) {
+	userID := uuid.New()
+	post := &model.LinkedInPost{ID: uuid.New(), UserID: userID, OutputText: "Big news!"}
+	conn := &model.LinkedInConnection{UserID: userID, MemberURN: "urn:li:person:abc", AccessToken: "stale", ExpiresAt: time.Now().Add(-time.Hour), RefreshToken: "refresh"}
+	claimed := false
+	mockPostRepo := &repository.PostRepositoryMock{
+		ClaimPublishFunc: func(ctx context.Context, uid, id uuid.UUID, now time.Time, lease time.Duration) (*model.LinkedInPost, error) {
+			if id != post.ID || post.LinkedInURN != "" || claimed {
+				return nil, sql.ErrNoRows
+			}
+			claimed = true
+			return post, nil
+		},
+		ReleasePublishFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+			claimed = false
+			return nil
+		},
+		GetOwnedFunc: func(ctx context.Context, uid, id uuid.UUID) (*model.LinkedInPost, error) {
+			if id != post.ID {
+				return nil, sql.ErrNoRows
+			}
+			return post, nil
+		},
+		UpdatePublishedFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+			claimed = false
+			return nil
+		},
+	}
+	mockConns := &repository.LinkedInConnectionRepositoryMock{
+		GetFunc: func(ctx context.Context, uid uuid.UUID) (*model.LinkedInConnection, error) {
+			if uid != userID {
+				return nil, sql.ErrNoRows
+			}
+			return conn, nil
+		},
+		SaveFunc: func(ctx context.Context, c *model.LinkedInConnection) error { return nil },
+	}
+	mockAPI := &service.LinkedInAPIMock{
+		RefreshFunc: func(ctx context.Context, refreshToken string) (*service.LinkedInToken, error) {
+			return &service.LinkedInToken{AccessToken: "fresh", ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+		ShareFunc: func(ctx context.Context, accessToken, author, text string) (string, error) {
+			assert.Equal(t, "fresh", accessToken, "Expired access tokens are refreshed first")
+			assert.Equal(t, "urn:li:person:abc", author)
+			assert.Equal(t, "Big news!", text)
+			return "urn:li:share:1", nil
+		},
+	}
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo, service.WithPublishing(mockAPI, mockConns, []byte("state-secret")))
+	ctx := context.Background()
+
+	_, err := liSvc.Publish(ctx, uuid.New(), post.ID)
+	assert.ErrorIs(t, err, service.ErrLinkedInNotConnected)
+	assert.Len(t, mockPostRepo.ReleasePublishCalls(), 1, "A post that was not shared is released")
+
+	_, err = liSvc.Publish(ctx, userID, uuid.New())
+	assert.ErrorIs(t, err, service.ErrPostNotFound)
+
+	claimed = true
+	_, err = liSvc.Publish(ctx, userID, post.ID)
+	assert.ErrorIs(t, err, service.ErrPublishInProgress, "A post someone else is sharing is not shared again")
+	assert.Empty(t, mockAPI.ShareCalls())
+	claimed = false
+
+	published, err := liSvc.Publish(ctx, userID, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "urn:li:share:1", published.LinkedInURN)
+	assert.False(t, published.PublishedAt.IsZero())
+	require.Len(t, mockPostRepo.UpdatePublishedCalls(), 1)
+	require.Len(t, mockConns.SaveCalls(), 1)
+	assert.Equal(t, "refresh", mockConns.SaveCalls()[0].C.RefreshToken, "The refresh token is kept when LinkedIn does not issue a new one")
+
+	_, err = liSvc.Publish(ctx, userID, post.ID)
+	assert.ErrorIs(t, err, service.ErrAlreadyPublished)
+	assert.Len(t, mockAPI.ShareCalls(), 1)
+}
+
+func TestLinkedInService_Publish_Reconnect(t *testing.T) {
+	userID := uuid.New()
+	mockPostRepo := &repository.PostRepositoryMock{
+		ClaimPublishFunc: func(ctx context.Context, uid, id uuid.UUID, now time.Time, lease time.Duration) (*model.LinkedInPost, error) {
+			return &model.LinkedInPost{ID: id, UserID: uid, OutputText: "Big news!", PublishingUntil: now.Add(lease)}, nil
+		},
+		ReleasePublishFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	conn := &model.LinkedInConnection{UserID: userID, AccessToken: "stale", ExpiresAt: time.Now().Add(-time.Hour)}
+	mockConns := &repository.LinkedInConnectionRepositoryMock{
+		GetFunc: func(ctx context.Context, uid uuid.UUID) (*model.LinkedInConnection, error) { return conn, nil },
+	}
+	mockAPI := &service.LinkedInAPIMock{
+		RefreshFunc: func(ctx context.Context, refreshToken string) (*service.LinkedInToken, error) {
+			return nil, fmt.Errorf("%w: invalid_grant", service.ErrLinkedInReconnect)
+		},
+		ShareFunc: func(ctx context.Context, accessToken, author, text string) (string, error) {
+			return "", fmt.Errorf("%w: revoked", service.ErrLinkedInReconnect)
+		},
+	}
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo, service.WithPublishing(mockAPI, mockConns, nil))
+	ctx := context.Background()
+
+	_, err := liSvc.Publish(ctx, userID, uuid.New())
+	assert.ErrorIs(t, err, service.ErrLinkedInReconnect, "Without a refresh token the user has to reconnect")
+	assert.Empty(t, mockAPI.RefreshCalls())
+
+	conn.RefreshToken = "revoked"
+	_, err = liSvc.Publish(ctx, userID, uuid.New())
+	assert.ErrorIs(t, err, service.ErrLinkedInReconnect)
+	assert.Len(t, mockAPI.RefreshCalls(), 1)
+
+	conn.ExpiresAt = time.Now().Add(time.Hour)
+	_, err = liSvc.Publish(ctx, userID, uuid.New())
+	assert.ErrorIs(t, err, service.ErrLinkedInReconnect, "LinkedIn rejecting a valid-looking token")
+	assert.Empty(t, mockPostRepo.UpdatePublishedCalls())
+	assert.Len(t, mockPostRepo.ReleasePublishCalls(), 3)
+}
+
+func TestLinkedInService_Schedule(t *testing.T) {
+	userID := uuid.New()
+	post := &model.LinkedInPost{ID: uuid.New(), UserID: uuid.New(), OutputText: "Big news!", ScheduleAttempts: 3, ScheduleError: "linkedin: 503"}
+	mockPostRepo := &repository.PostRepositoryMock{
+		GetOwnedFunc: func(ctx context.Context, uid, id uuid.UUID) (*model.LinkedInPost, error) { return post, nil },
+		ScheduleFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	mockConns := &repository.LinkedInConnectionRepositoryMock{
+		GetFunc: func(ctx context.Context, uid uuid.UUID) (*model.LinkedInConnection, error) {
+			if uid != userID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.LinkedInConnection{UserID: userID}, nil
+		},
+	}
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo, service.WithPublishing(&service.LinkedInAPIMock{}, mockConns, nil))
+	ctx := context.Background()
+	at := time.Now().Add(time.Hour)
+
+	for _, bad := range []time.Time{time.Now().Add(-time.Minute), time.Now().Add(service.MaxScheduleAhead + time.Hour)} {
+		_, err := liSvc.Schedule(ctx, userID, post.ID, bad)
+		assert.ErrorIs(t, err, service.ErrInvalidScheduleTime, bad)
+	}
+	_, err := liSvc.Schedule(ctx, uuid.New(), post.ID, at)
+	assert.ErrorIs(t, err, service.ErrLinkedInNotConnected, "A colleague without a connection cannot schedule it for their feed")
+
+	scheduled, err := liSvc.Schedule(ctx, userID, post.ID, at)
+	require.NoError(t, err)
+	assert.Equal(t, model.ScheduleStatusScheduled, scheduled.ScheduleStatus)
+	assert.Equal(t, userID, scheduled.ScheduledBy)
+	assert.Equal(t, at, scheduled.NextAttemptAt)
+	assert.Zero(t, scheduled.ScheduleAttempts, "Rescheduling starts the attempts over")
+	assert.Empty(t, scheduled.ScheduleError)
+	require.Len(t, mockPostRepo.ScheduleCalls(), 1)
+
+	post.ScheduleStatus = model.ScheduleStatusPublishing
+	_, err = liSvc.Schedule(ctx, userID, post.ID, at)
+	assert.ErrorIs(t, err, service.ErrPublishInProgress)
+
+	post.LinkedInURN = "urn:li:share:1"
+	_, err = liSvc.Schedule(ctx, userID, post.ID, at)
+	assert.ErrorIs(t, err, service.ErrAlreadyPublished)
+
+	_, err = service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo).Schedule(ctx, userID, post.ID, at)
+	assert.ErrorIs(t, err, service.ErrPublishingDisabled)
+}
+
+func TestLinkedInService_SuggestHashtags(t *testing.T) {
+	reply := "Here are some hashtags: #AI, #MachineLearning #ai #Startups. #100 # #hiring! 1. #Future-Of-Work"
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: reply}, nil
+		},
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, &repository.PostRepositoryMock{})
+
+	tags, err := liSvc.SuggestHashtags(context.Background(), "We are hiring ML engineers")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"#ai", "#machinelearning", "#startups", "#hiring"}, tags,
+		"Prose, duplicates and malformed hashtags are dropped")
+	assert.Contains(t, mockAIClient.TransformCalls()[0].Prompt, "We are hiring ML engineers")
+
+	reply = "I think this post would do well with tags about technology and careers."
+	_, err = liSvc.SuggestHashtags(context.Background(), "text")
+	assert.ErrorIs(t, err, service.ErrNoHashtags)
+}
+
+func TestLinkedInService_Transform_IncludeHashtags(t *testing.T) {
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			if strings.HasPrefix(prompt, "Suggest") {
+				return ai.Result{Text: "#launch #product", Usage: ai.Usage{TotalTokens: 5}}, nil
+			}
+			return ai.Result{Text: "We launched!", Usage: ai.Usage{TotalTokens: 50}}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo)
+
+	res, err := liSvc.Transform(context.Background(), uuid.New(), "launch", service.TransformOptions{IncludeHashtags: true})
+	require.NoError(t, err)
+	assert.Equal(t, "We launched!\n\n#launch #product", res.Post)
+	assert.Equal(t, 55, res.Usage.TotalTokens, "Usage includes the hashtag suggestion")
+	assert.Equal(t, res.Post, mockPostRepo.SaveCalls()[0].P.OutputText)
+
+	res, err = liSvc.Transform(context.Background(), uuid.New(), "launch", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.True(t, res.Cached)
+	assert.Equal(t, "We launched!", res.Post, "Hashtags are not cached with the post")
+}
+
+func TestLinkedInService_Transform_MaxPostLength(t *testing.T) {
+	sentence := "We shipped a feature our customers asked for. " // 46 characters
+	var reply string
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			if strings.HasPrefix(prompt, "Suggest") {
+				return ai.Result{Text: "#shipping #product"}, nil
+			}
+			return ai.Result{Text: reply}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithCache(nil), service.WithMaxPostLength(100))
+
+	reply = strings.Repeat(sentence, 3)
+	res, err := liSvc.Transform(context.Background(), uuid.New(), "text", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.True(t, res.Truncated)
+	assert.Equal(t, strings.TrimSpace(strings.Repeat(sentence, 2)), res.Post, "Posts are cut after the last sentence that fits")
+	assert.Equal(t, res.Post, mockPostRepo.SaveCalls()[0].P.OutputText)
+
+	res, err = liSvc.Transform(context.Background(), uuid.New(), "text", service.TransformOptions{IncludeHashtags: true})
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(sentence)+"\n\n#shipping #product", res.Post, "Room is left for hashtags")
+	assert.LessOrEqual(t, utf8.RuneCountInString(res.Post), 100)
+
+	reply = strings.Repeat("word ", 30)
+	res, err = liSvc.Transform(context.Background(), uuid.New(), "text", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.True(t, res.Truncated)
+	assert.True(t, strings.HasSuffix(res.Post, "word…"), "Without a sentence that fits, whole words are kept")
+	assert.LessOrEqual(t, utf8.RuneCountInString(res.Post), 100)
+
+	reply = strings.Repeat(sentence, 2)
+	res, err = liSvc.Transform(context.Background(), uuid.New(), "text", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.False(t, res.Truncated)
+	assert.Equal(t, reply, res.Post, "Posts within the limit are untouched")
+}
+
+func TestLinkedInService_TransformBatch(t *testing.T) {
+	var running, peak int32
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			if strings.Contains(prompt, "topic 3") {
+				return ai.Result{}, errors.New("openai unavailable")
+			}
+			return ai.Result{Text: "a post"}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithBatchConcurrency(2))
+
+	items := make([]service.BatchItem, 6)
+	for i := range items {
+		items[i] = service.BatchItem{Text: fmt.Sprintf("topic %d", i)}
+	}
+	results, err := liSvc.TransformBatch(context.Background(), uuid.New(), items)
+	require.NoError(t, err)
+	require.Len(t, results, 6)
+	for i, res := range results {
+		if i == 3 {
+			assert.Error(t, res.Err, "The failed item is reported in place")
+			continue
+		}
+		require.NoError(t, res.Err)
+		assert.Equal(t, "a post", res.Result.Post)
+	}
+	assert.Len(t, mockPostRepo.SaveCalls(), 5)
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2), "No more than the configured number of generations run at once")
+
+	_, err = liSvc.TransformBatch(context.Background(), uuid.New(), make([]service.BatchItem, service.MaxBatchSize+1))
+	assert.ErrorIs(t, err, service.ErrBatchTooLarge)
+}
+
+func TestLinkedInService_Transform_IdempotencyKey(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			if calls.Add(1) == 1 {
+				<-release
+				return ai.Result{}, errors.New("openai unavailable")
+			}
+			return ai.Result{Text: "a post", Usage: ai.Usage{TotalTokens: 30}}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	// The cache would hide repeated generations.
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithCache(nil))
+	userID := uuid.New()
+	opts := service.TransformOptions{IdempotencyKey: "key-1"}
+
+	// Concurrent requests with the key wait for the first one, and retry
+	// themselves when it fails.
+	type outcome struct {
+		res *service.TransformResult
+		err error
+	}
+	outcomes := make(chan outcome, 3)
+	for range 3 {
+		go func() {
+			res, err := liSvc.Transform(context.Background(), userID, "some text", opts)
+			outcomes <- outcome{res, err}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	var failed, replayed int
+	var postID uuid.UUID
+	for range 3 {
+		o := <-outcomes
+		switch {
+		case o.err != nil:
+			failed++
+		case o.res.Replayed:
+			replayed++
+		default:
+			postID = o.res.PostID
+		}
+	}
+	assert.Equal(t, 1, failed, "Only the first request sees its failure")
+	assert.Equal(t, 1, replayed, "Only one of the waiting requests should generate")
+	assert.Equal(t, int32(2), calls.Load(), "The failed generation and a single retry")
+	assert.Len(t, mockPostRepo.SaveCalls(), 1)
+
+	again, err := liSvc.Transform(context.Background(), userID, "some text", opts)
+	require.NoError(t, err)
+	assert.True(t, again.Replayed)
+	assert.Equal(t, postID, again.PostID)
+	assert.Equal(t, 30, again.Usage.TotalTokens, "A replay returns the original response")
+
+	_, err = liSvc.Transform(context.Background(), userID, "other text", opts)
+	assert.ErrorIs(t, err, service.ErrIdempotencyKeyReused)
+
+	other, err := liSvc.Transform(context.Background(), uuid.New(), "some text", opts)
+	require.NoError(t, err)
+	assert.False(t, other.Replayed, "Keys are scoped per user")
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestLinkedInService_Replay(t *testing.T) {
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "a post"}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithCache(nil))
+	userID := uuid.New()
+	opts := service.TransformOptions{IdempotencyKey: "key-1"}
+
+	res, err := liSvc.Replay(context.Background(), userID, "some text", opts)
+	require.NoError(t, err)
+	assert.Nil(t, res, "Nothing to replay before the key is used")
+
+	first, err := liSvc.Transform(context.Background(), userID, "some text", opts)
+	require.NoError(t, err)
+	res, err = liSvc.Replay(context.Background(), userID, "some text", opts)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.True(t, res.Replayed)
+	assert.Equal(t, first.PostID, res.PostID)
+	assert.Len(t, mockAIClient.TransformCalls(), 1, "Replaying generates nothing")
+
+	_, err = liSvc.Replay(context.Background(), userID, "other text", opts)
+	assert.ErrorIs(t, err, service.ErrIdempotencyKeyReused)
+	res, err = liSvc.Replay(context.Background(), userID, "some text", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, res, "Requests without a key are never replayed")
+}
+
+func TestLinkedInService_Transform_IdempotencyDisabled(t *testing.T) {
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "a post"}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithCache(nil), service.WithIdempotencyTTL(0))
+	opts := service.TransformOptions{IdempotencyKey: "key-1"}
+
+	for range 2 {
+		res, err := liSvc.Transform(context.Background(), uuid.New(), "some text", opts)
+		require.NoError(t, err)
+		assert.False(t, res.Replayed)
+	}
+	assert.Len(t, mockAIClient.TransformCalls(), 2)
+}
+
+func TestLinkedInService_RatePost(t *testing.T) {
+	userID, postID := uuid.New(), uuid.New()
+	mockPostRepo := &repository.PostRepositoryMock{
+		GetOwnedFunc: func(ctx context.Context, uid, id uuid.UUID) (*model.LinkedInPost, error) {
+			if uid != userID || id != postID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.LinkedInPost{ID: id, UserID: uid, Model: "gpt-4o-mini", Tone: "casual", Template: "announcement"}, nil
+		},
+		SaveFeedbackFunc: func(ctx context.Context, f *model.PostFeedback) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(&ai.ClientMock{}, mockPostRepo)
+	ctx := context.Background()
+
+	f, err := liSvc.RatePost(ctx, userID, postID, model.FeedbackUp, "  loved the hook ")
+	require.NoError(t, err)
+	assert.Equal(t, "loved the hook", f.Comment)
+	require.Len(t, mockPostRepo.SaveFeedbackCalls(), 1)
+	saved := mockPostRepo.SaveFeedbackCalls()[0].F
+	assert.Equal(t, postID, saved.PostID)
+	assert.Equal(t, userID, saved.UserID)
+	assert.Equal(t, "gpt-4o-mini", saved.Model, "The generation parameters are stored with the rating")
+	assert.Equal(t, "casual", saved.Tone)
+	assert.Equal(t, "announcement", saved.Template)
+
+	_, err = liSvc.RatePost(ctx, uuid.New(), postID, model.FeedbackDown, "")
+	assert.ErrorIs(t, err, service.ErrPostNotFound, "Only the owner can rate a post")
+	_, err = liSvc.RatePost(ctx, userID, postID, "meh", "")
+	assert.ErrorIs(t, err, service.ErrInvalidRating)
+	assert.Len(t, mockPostRepo.SaveFeedbackCalls(), 1)
+}
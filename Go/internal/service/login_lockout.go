@@ -0,0 +1,185 @@
+// internal/service/login_lockout.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrLoginLocked is matched by a *LoginLockedError.
+var ErrLoginLocked = errors.New("too many failed logins")
+
+// LoginLockedError is returned by Login while logins for an email, from an
+// IP or from anywhere, are locked after too many failures in a row. It is
+// returned whether or not the account exists, without checking the
+// password.
+type LoginLockedError struct {
+	// RetryAfter is how long until logins are accepted again.
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", ErrLoginLocked, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginLockedError) Is(target error) bool { return target == ErrLoginLocked }
+
+// LoginAttemptStore counts failed logins per key. Implementations must be
+// safe for concurrent use; a shared store lets the lockout apply across
+// instances and survive restarts.
+type LoginAttemptStore interface {
+	// Attempt returns how long key stays locked, or zero after counting a
+	// login attempt for key as failed, in the same step, locking key once
+	// it has failed too often in a row. Login checks the password only
+	// after that, so concurrent attempts cannot all get past the limit, and
+	// calls Reset when the password was right.
+	Attempt(ctx context.Context, key string) (time.Duration, error)
+	// Reset forgets the failures of key, after a successful login.
+	Reset(ctx context.Context, key string) error
+}
+
+// WithLoginLockout locks logins for an email from an IP after the failures
+// counted in store; see NewMemoryLoginAttempts.
+func WithLoginLockout(store LoginAttemptStore) AuthOption {
+	return func(a *AuthService) { a.lockout = store }
+}
+
+// WithEmailLockout locks logins for an email from every IP after the
+// failures counted in store, which stops a brute force spread over many
+// addresses. Since anyone can lock an email this way, store should allow
+// far more failures than the one of WithLoginLockout.
+func WithEmailLockout(store LoginAttemptStore) AuthOption {
+	return func(a *AuthService) { a.emailLockout = store }
+}
+
+// loginKey identifies the logins counted together: an email from an IP, so
+// a brute force is stopped without letting anyone lock the owner of the
+// email out from everywhere.
+func loginKey(email, ip string) string {
+	return ip + "|" + email
+}
+
+// lockouts pairs each configured lockout with the key it counts a login
+// for email from ip under.
+func (a *AuthService) lockouts(email, ip string) []lockout {
+	var ls []lockout
+	if a.lockout != nil {
+		ls = append(ls, lockout{a.lockout, loginKey(email, ip)})
+	}
+	if a.emailLockout != nil {
+		ls = append(ls, lockout{a.emailLockout, email})
+	}
+	return ls
+}
+
+type lockout struct {
+	store LoginAttemptStore
+	key   string
+}
+
+// reserveLogin counts a login attempt against every lockout before the
+// password is checked, and returns a *LoginLockedError while one of them is
+// locked. A failing store only costs its lockout, so it is logged rather
+// than failing logins.
+func (a *AuthService) reserveLogin(ctx context.Context, ls []lockout) error {
+	for _, l := range ls {
+		wait, err := l.store.Attempt(ctx, l.key)
+		if err != nil {
+			slog.ErrorContext(ctx, "login lockout check failed, allowing login", "error", err)
+			continue
+		}
+		if wait > 0 {
+			return &LoginLockedError{RetryAfter: wait}
+		}
+	}
+	return nil
+}
+
+// loginSucceeded takes back the attempts reserveLogin counted, and any
+// earlier failures.
+func (a *AuthService) loginSucceeded(ctx context.Context, ls []lockout) {
+	for _, l := range ls {
+		if err := l.store.Reset(ctx, l.key); err != nil {
+			slog.ErrorContext(ctx, "resetting failed logins failed", "error", err)
+		}
+	}
+}
+
+type loginAttempts struct {
+	failures    int
+	last        time.Time
+	lockedUntil time.Time
+}
+
+// MemoryLoginAttempts is an in-process LoginAttemptStore. Its counts are
+// lost on restart and not shared between instances.
+type MemoryLoginAttempts struct {
+	mu          sync.Mutex
+	keys        map[string]*loginAttempts
+	maxAttempts int
+	cooldown    time.Duration
+	lastSweep   time.Time
+}
+
+// NewMemoryLoginAttempts locks a key for cooldown after maxAttempts failed
+// logins in a row. Failures older than cooldown are forgotten, so only
+// failures in quick succession count.
+func NewMemoryLoginAttempts(maxAttempts int, cooldown time.Duration) *MemoryLoginAttempts {
+	return &MemoryLoginAttempts{
+		keys:        make(map[string]*loginAttempts),
+		maxAttempts: maxAttempts,
+		cooldown:    cooldown,
+	}
+}
+
+func (s *MemoryLoginAttempts) Attempt(_ context.Context, key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.sweep(now)
+	a, ok := s.keys[key]
+	if ok && now.Before(a.lockedUntil) {
+		return a.lockedUntil.Sub(now), nil
+	}
+	if !ok || s.expired(a, now) {
+		a = &loginAttempts{}
+		s.keys[key] = a
+	}
+	a.failures++
+	a.last = now
+	if a.failures >= s.maxAttempts {
+		a.failures = 0
+		a.lockedUntil = now.Add(s.cooldown)
+	}
+	return 0, nil
+}
+
+func (s *MemoryLoginAttempts) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+	return nil
+}
+
+// expired reports whether a has been quiet for long enough to start afresh.
+func (s *MemoryLoginAttempts) expired(a *loginAttempts, now time.Time) bool {
+	return now.Sub(a.last) >= s.cooldown && !now.Before(a.lockedUntil)
+}
+
+// sweep drops keys that have expired, since they are indistinguishable from
+// new ones. Callers must hold s.mu.
+func (s *MemoryLoginAttempts) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for k, a := range s.keys {
+		if s.expired(a, now) {
+			delete(s.keys, k)
+		}
+	}
+}
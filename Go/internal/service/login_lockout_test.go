@@ -0,0 +1,164 @@
+// internal/service/login_lockout_test.go
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestAuthService_LoginLockout(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("Correct-horse-7"), bcrypt.MinCost)
+	require.NoError(t, err)
+	users := &repository.UserRepositoryMock{
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			if email != "ada@example.com" {
+				return nil, sql.ErrNoRows
+			}
+			return &model.User{ID: uuid.New(), Email: email, PasswordHash: string(hash)}, nil
+		},
+	}
+	cfg := &service.AuthConfigProviderMock{
+		GetJWTSecretFunc: func() []byte { return []byte("test-secret") },
+		GetJWTExpiryFunc: func() time.Duration { return time.Hour },
+		GetJWTIssuerFunc: func() string { return "" },
+	}
+	authSvc := service.NewAuth(users, cfg, service.WithLoginLockout(service.NewMemoryLoginAttempts(3, time.Minute)))
+	ctx := context.Background()
+	const ip = "203.0.113.7"
+
+	for range 3 {
+		_, err := authSvc.Login(ctx, "ada@example.com", "wrong", ip)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, service.ErrLoginLocked)
+	}
+	lookups := len(users.FindByEmailCalls())
+	_, err = authSvc.Login(ctx, "ADA@example.com", "Correct-horse-7", ip)
+	var locked *service.LoginLockedError
+	require.ErrorAs(t, err, &locked, "Even the right password is refused while locked")
+	assert.InDelta(t, time.Minute, locked.RetryAfter, float64(time.Second))
+	assert.Len(t, users.FindByEmailCalls(), lookups, "A locked login does not reach the database")
+
+	_, err = authSvc.Login(ctx, "ada@example.com", "Correct-horse-7", "198.51.100.1")
+	assert.NoError(t, err, "The lock only applies to the IP that failed")
+
+	for range 3 {
+		_, err := authSvc.Login(ctx, "nobody@example.com", "wrong", ip)
+		assert.NotErrorIs(t, err, service.ErrLoginLocked)
+	}
+	_, err = authSvc.Login(ctx, "nobody@example.com", "wrong", ip)
+	assert.ErrorIs(t, err, service.ErrLoginLocked, "Unknown emails are locked out the same way")
+}
+
+func TestAuthService_LoginLockout_SuccessResets(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("Correct-horse-7"), bcrypt.MinCost)
+	require.NoError(t, err)
+	users := &repository.UserRepositoryMock{
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			return &model.User{ID: uuid.New(), Email: email, PasswordHash: string(hash)}, nil
+		},
+	}
+	cfg := &service.AuthConfigProviderMock{
+		GetJWTSecretFunc: func() []byte { return []byte("test-secret") },
+		GetJWTExpiryFunc: func() time.Duration { return time.Hour },
+		GetJWTIssuerFunc: func() string { return "" },
+	}
+	authSvc := service.NewAuth(users, cfg, service.WithLoginLockout(service.NewMemoryLoginAttempts(3, time.Minute)))
+	ctx := context.Background()
+
+	for range 5 {
+		_, err := authSvc.Login(ctx, "ada@example.com", "wrong", "203.0.113.7")
+		require.Error(t, err)
+		_, err = authSvc.Login(ctx, "ada@example.com", "wrong", "203.0.113.7")
+		require.Error(t, err)
+		_, err = authSvc.Login(ctx, "ada@example.com", "Correct-horse-7", "203.0.113.7")
+		require.NoError(t, err, "Failures only count in a row")
+	}
+}
+
+func TestAuthService_LoginLockout_Concurrent(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("Correct-horse-7"), bcrypt.MinCost)
+	require.NoError(t, err)
+	users := &repository.UserRepositoryMock{
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			return &model.User{ID: uuid.New(), Email: email, PasswordHash: string(hash)}, nil
+		},
+	}
+	cfg := &service.AuthConfigProviderMock{
+		GetJWTSecretFunc: func() []byte { return []byte("test-secret") },
+		GetJWTExpiryFunc: func() time.Duration { return time.Hour },
+		GetJWTIssuerFunc: func() string { return "" },
+	}
+	authSvc := service.NewAuth(users, cfg, service.WithLoginLockout(service.NewMemoryLoginAttempts(3, time.Minute)))
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = authSvc.Login(context.Background(), "ada@example.com", "wrong", "203.0.113.7")
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, users.FindByEmailCalls(), 3, "Concurrent guesses cannot get past the limit")
+}
+
+func TestAuthService_EmailLockout(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("Correct-horse-7"), bcrypt.MinCost)
+	require.NoError(t, err)
+	users := &repository.UserRepositoryMock{
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			return &model.User{ID: uuid.New(), Email: email, PasswordHash: string(hash)}, nil
+		},
+	}
+	cfg := &service.AuthConfigProviderMock{
+		GetJWTSecretFunc: func() []byte { return []byte("test-secret") },
+		GetJWTExpiryFunc: func() time.Duration { return time.Hour },
+		GetJWTIssuerFunc: func() string { return "" },
+	}
+	authSvc := service.NewAuth(users, cfg,
+		service.WithLoginLockout(service.NewMemoryLoginAttempts(3, time.Minute)),
+		service.WithEmailLockout(service.NewMemoryLoginAttempts(5, time.Minute)))
+	ctx := context.Background()
+
+	for i := range 5 {
+		_, err := authSvc.Login(ctx, "ada@example.com", "wrong", fmt.Sprintf("203.0.113.%d", i))
+		assert.NotErrorIs(t, err, service.ErrLoginLocked)
+	}
+	_, err = authSvc.Login(ctx, "ada@example.com", "Correct-horse-7", "198.51.100.1")
+	assert.ErrorIs(t, err, service.ErrLoginLocked, "Failures from many IPs lock the email everywhere")
+	_, err = authSvc.Login(ctx, "grace@example.com", "Correct-horse-7", "198.51.100.1")
+	assert.NoError(t, err, "Other emails are not affected")
+}
+
+func TestMemoryLoginAttempts_Cooldown(t *testing.T) {
+	store := service.NewMemoryLoginAttempts(2, 50*time.Millisecond)
+	ctx := context.Background()
+	wait, _ := store.Attempt(ctx, "key")
+	assert.Zero(t, wait)
+	wait, _ = store.Attempt(ctx, "key")
+	assert.Zero(t, wait, "The attempt reaching the limit is still let through")
+
+	wait, _ = store.Attempt(ctx, "key")
+	assert.Positive(t, wait)
+
+	time.Sleep(60 * time.Millisecond)
+	wait, _ = store.Attempt(ctx, "key")
+	assert.Zero(t, wait, "The lock expires after the cooldown")
+
+	require.NoError(t, store.Reset(ctx, "key"))
+	wait, _ = store.Attempt(ctx, "key")
+	assert.Zero(t, wait, "Reset forgets the attempts")
+}
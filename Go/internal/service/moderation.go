@@ -0,0 +1,91 @@
+// internal/service/moderation.go
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/you/linkedinify/internal/ai"
+)
+
+// ErrContentFlagged matches a *ModerationError with errors.Is.
+var ErrContentFlagged = errors.New("generated post was flagged by moderation")
+
+// ModerationError is returned when a generated post failed moderation even
+// after being regenerated with a stricter prompt.
+type ModerationError struct {
+	Categories []string
+	// Model and Usage describe the generations that were flagged, which
+	// were paid for all the same.
+	Model string
+	Usage ai.Usage
+}
+
+func (e *ModerationError) Error() string {
+	return ErrContentFlagged.Error() + ": " + strings.Join(e.Categories, ", ")
+}
+
+func (e *ModerationError) Is(target error) bool { return target == ErrContentFlagged }
+
+// moderationTimeout bounds a moderation call so a slow moderation API delays
+// posts by at most this much.
+const moderationTimeout = 5 * time.Second
+
+// strictInstruction is appended to the prompt when retrying a flagged post.
+const strictInstruction = "Keep the post strictly professional and suitable for a workplace audience, " +
+	"with no offensive, hateful, sexual, violent or self-harm related content."
+
+// WithModeration checks every generated post with m before it is returned.
+func WithModeration(m ai.Moderator) LinkedInOption {
+	return func(l *LinkedInService) { l.moderator = m }
+}
+
+// generate runs the AI on prompt, post-processes the result and moderates it
+// when moderation is enabled. A flagged post is regenerated once with a stricter prompt; if that
+// is flagged too, a *ModerationError carrying the usage of both is returned.
+func (l *LinkedInService) generate(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+	res, err := l.ai.Transform(ctx, prompt, opts)
+	if err != nil {
+		return res, err
+	}
+	res.Text = l.postProcess(res.Text)
+	if l.moderator == nil {
+		return res, nil
+	}
+	if !l.moderate(ctx, res.Text).Flagged {
+		return res, nil
+	}
+
+	retry, err := l.ai.Transform(ctx, prompt+"\n\n"+strictInstruction, opts)
+	if err != nil {
+		return ai.Result{}, err
+	}
+	retry.Text = l.postProcess(retry.Text)
+	// Both generations were paid for.
+	retry.Usage.PromptTokens += res.Usage.PromptTokens
+	retry.Usage.CompletionTokens += res.Usage.CompletionTokens
+	retry.Usage.TotalTokens += res.Usage.TotalTokens
+	if m := l.moderate(ctx, retry.Text); m.Flagged {
+		return ai.Result{}, &ModerationError{Categories: m.Categories, Model: retry.Model, Usage: retry.Usage}
+	}
+	return retry, nil
+}
+
+// moderate checks text and logs the verdict. It fails open: when the
+// moderation API cannot be reached the text is treated as clean.
+func (l *LinkedInService) moderate(ctx context.Context, text string) ai.Moderation {
+	ctx, cancel := context.WithTimeout(ctx, moderationTimeout)
+	defer cancel()
+	m, err := l.moderator.Moderate(ctx, text)
+	if err != nil {
+		slog.WarnContext(ctx, "moderation unavailable, allowing post", "error", err)
+		return ai.Moderation{}
+	}
+	if m.Flagged {
+		slog.WarnContext(ctx, "moderation flagged a generated post", "categories", m.Categories)
+	}
+	return m
+}
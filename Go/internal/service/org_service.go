@@ -0,0 +1,241 @@
+// internal/service/org_service.go
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+var (
+	// ErrNotOrgOwner is returned when a member tries something only owners
+	// of the organization may do.
+	ErrNotOrgOwner = errors.New("not an organization owner")
+	// ErrPersonalOrg is returned when inviting into a personal organization,
+	// which only ever has its own user in it.
+	ErrPersonalOrg = errors.New("personal organizations cannot have members")
+	// ErrAlreadyMember is returned when inviting or joining someone already
+	// in the organization.
+	ErrAlreadyMember = errors.New("already a member of the organization")
+	// ErrSoleOwner is returned when the only owner of a team that has other
+	// members tries to leave it, which would leave the team without one.
+	ErrSoleOwner = errors.New("the only owner cannot leave the organization")
+	// ErrInvalidInvitation is returned for unknown, expired or already
+	// accepted invitations, and for invitations sent to another email.
+	ErrInvalidInvitation = errors.New("invalid or expired invitation")
+	// ErrInvitationsUnsupported is returned by Invite when the service was
+	// built without a way to deliver invitations.
+	ErrInvitationsUnsupported = errors.New("invitations are not enabled")
+)
+
+const (
+	// MaxOrgNameLength is the longest organization name, in characters.
+	MaxOrgNameLength = 100
+	invitationTTL    = 7 * 24 * time.Hour
+)
+
+// Org is the organization a user is in, as they see it: Role is their role
+// there and Members everyone in it, the user included.
+type Org struct {
+	model.Organization
+	Role    string
+	Members []model.User
+}
+
+// OrgServiceInteractor defines the operations users can perform on their
+// organization. Every user is in exactly one; creating or joining another
+// moves them out of the current one.
+type OrgServiceInteractor interface {
+	Create(ctx context.Context, userID uuid.UUID, name string) (*Org, error)
+	Current(ctx context.Context, userID uuid.UUID) (*Org, error)
+	Invite(ctx context.Context, userID uuid.UUID, email string) (*model.OrgInvitation, error)
+	AcceptInvitation(ctx context.Context, userID uuid.UUID, token string) (*Org, error)
+}
+
+type OrgService struct {
+	orgs      repository.OrgRepository
+	users     repository.UserRepository
+	mailer    EmailSender // nil when invitations are disabled
+	acceptURL string
+}
+
+// OrgOption configures optional OrgService dependencies.
+type OrgOption func(*OrgService)
+
+// WithInvitations enables Invite, delivering invitations with sender. They
+// link to acceptURL with the token appended as a "token" query parameter.
+func WithInvitations(sender EmailSender, acceptURL string) OrgOption {
+	return func(s *OrgService) {
+		s.mailer = sender
+		s.acceptURL = acceptURL
+	}
+}
+
+// NewOrg creates a new OrgService instance.
+func NewOrg(orgs repository.OrgRepository, users repository.UserRepository, opts ...OrgOption) OrgServiceInteractor {
+	s := &OrgService{orgs: orgs, users: users}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Create starts a team organization named name, which is expected to have
+// been checked by the caller, and moves the user into it as its owner.
+func (s *OrgService) Create(ctx context.Context, userID uuid.UUID, name string) (*Org, error) {
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, userNotFound(err)
+	}
+	if err := s.checkCanLeave(ctx, u); err != nil {
+		return nil, err
+	}
+	org := &model.Organization{ID: uuid.New(), Name: strings.TrimSpace(name), CreatedBy: userID, CreatedAt: time.Now()}
+	if err := s.orgs.Create(ctx, org, userID); err != nil {
+		return nil, userNotFound(err)
+	}
+	return s.Current(ctx, userID)
+}
+
+// Current returns the organization the user is in.
+func (s *OrgService) Current(ctx context.Context, userID uuid.UUID) (*Org, error) {
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, userNotFound(err)
+	}
+	org, err := s.orgs.FindByID(ctx, u.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.orgs.Members(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &Org{Organization: *org, Role: u.OrgRole, Members: members}, nil
+}
+
+// Invite emails email an invitation to join the user's organization. Only
+// owners of a team can invite. The invitation is returned without its token,
+// which only the recipient gets.
+func (s *OrgService) Invite(ctx context.Context, userID uuid.UUID, email string) (*model.OrgInvitation, error) {
+	if s.mailer == nil {
+		return nil, ErrInvitationsUnsupported
+	}
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, userNotFound(err)
+	}
+	if u.OrgRole != model.OrgRoleOwner {
+		return nil, ErrNotOrgOwner
+	}
+	org, err := s.orgs.FindByID(ctx, u.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	if org.Personal {
+		return nil, ErrPersonalOrg
+	}
+	email = normalizeEmail(email)
+	invitee, err := s.users.FindByEmail(ctx, email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil && invitee.OrgID == org.ID {
+		return nil, ErrAlreadyMember
+	}
+
+	raw, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	inv := &model.OrgInvitation{
+		ID:        uuid.New(),
+		OrgID:     org.ID,
+		Email:     email,
+		TokenHash: hashToken(raw),
+		InvitedBy: userID,
+		ExpiresAt: time.Now().Add(invitationTTL),
+		CreatedAt: time.Now(),
+	}
+	if err := s.orgs.CreateInvitation(ctx, inv); err != nil {
+		return nil, err
+	}
+
+	link := s.acceptURL + "?token=" + url.QueryEscape(raw)
+	body := fmt.Sprintf("%s invited you to join %s on LinkedInify, where you will share posts with the team.\n\n"+
+		"Log in or sign up with this email address and use the link below within %d days to accept:\n%s\n\n"+
+		"If you don't want to join, you can ignore this email.", u.Email, org.Name, int(invitationTTL.Hours()/24), link)
+	if err := s.mailer.Send(ctx, email, "Join "+org.Name+" on LinkedInify", body); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// AcceptInvitation moves the user into the organization they were invited
+// to, as a member. The invitation must have been sent to the user's email
+// and works once.
+func (s *OrgService) AcceptInvitation(ctx context.Context, userID uuid.UUID, token string) (*Org, error) {
+	inv, err := s.orgs.FindInvitationByHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, ErrInvalidInvitation
+	}
+	if inv.AcceptedAt != nil || time.Now().After(inv.ExpiresAt) {
+		return nil, ErrInvalidInvitation
+	}
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, userNotFound(err)
+	}
+	if normalizeEmail(u.Email) != inv.Email {
+		return nil, ErrInvalidInvitation
+	}
+	if u.OrgID == inv.OrgID {
+		return nil, ErrAlreadyMember
+	}
+	if err := s.checkCanLeave(ctx, u); err != nil {
+		return nil, err
+	}
+	ok, err := s.orgs.AcceptInvitation(ctx, inv, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidInvitation
+	}
+	return s.Current(ctx, userID)
+}
+
+// checkCanLeave returns ErrSoleOwner when u is the only owner of a team
+// that has other members.
+func (s *OrgService) checkCanLeave(ctx context.Context, u *model.User) error {
+	if u.OrgRole != model.OrgRoleOwner {
+		return nil
+	}
+	members, err := s.orgs.Members(ctx, u.OrgID)
+	if err != nil {
+		return err
+	}
+	others := false
+	for _, m := range members {
+		if m.ID == u.ID {
+			continue
+		}
+		if m.OrgRole == model.OrgRoleOwner {
+			return nil
+		}
+		others = true
+	}
+	if others {
+		return ErrSoleOwner
+	}
+	return nil
+}
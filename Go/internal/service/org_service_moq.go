@@ -0,0 +1,251 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that OrgServiceInteractorMock does implement OrgServiceInteractor.
+// If this is not the case, regenerate this file with moq.
+var _ OrgServiceInteractor = &OrgServiceInteractorMock{}
+
+// OrgServiceInteractorMock is a mock implementation of OrgServiceInteractor.
+//
+//	func TestSomethingThatUsesOrgServiceInteractor(t *testing.T) {
+//
+//		// make and configure a mocked OrgServiceInteractor
+//		mockedOrgServiceInteractor := &OrgServiceInteractorMock{
+//			AcceptInvitationFunc: func(ctx context.Context, userID uuid.UUID, token string) (*Org, error) {
+//				panic("mock out the AcceptInvitation method")
+//			},
+//			CreateFunc: func(ctx context.Context, userID uuid.UUID, name string) (*Org, error) {
+//				panic("mock out the Create method")
+//			},
+//			CurrentFunc: func(ctx context.Context, userID uuid.UUID) (*Org, error) {
+//				panic("mock out the Current method")
+//			},
+//			InviteFunc: func(ctx context.Context, userID uuid.UUID, email string) (*model.OrgInvitation, error) {
+//				panic("mock out the Invite method")
+//			},
+//		}
+//
+//		// use mockedOrgServiceInteractor in code that requires OrgServiceInteractor
+//		// and then make assertions.
+//
+//	}
+type OrgServiceInteractorMock struct {
+	// AcceptInvitationFunc mocks the AcceptInvitation method.
+	AcceptInvitationFunc func(ctx context.Context, userID uuid.UUID, token string) (*Org, error)
+
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, userID uuid.UUID, name string) (*Org, error)
+
+	// CurrentFunc mocks the Current method.
+	CurrentFunc func(ctx context.Context, userID uuid.UUID) (*Org, error)
+
+	// InviteFunc mocks the Invite method.
+	InviteFunc func(ctx context.Context, userID uuid.UUID, email string) (*model.OrgInvitation, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AcceptInvitation holds details about calls to the AcceptInvitation method.
+		AcceptInvitation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Token is the token argument value.
+			Token string
+		}
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Name is the name argument value.
+			Name string
+		}
+		// Current holds details about calls to the Current method.
+		Current []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// Invite holds details about calls to the Invite method.
+		Invite []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Email is the email argument value.
+			Email string
+		}
+	}
+	lockAcceptInvitation sync.RWMutex
+	lockCreate           sync.RWMutex
+	lockCurrent          sync.RWMutex
+	lockInvite           sync.RWMutex
+}
+
+// AcceptInvitation calls AcceptInvitationFunc.
+func (mock *OrgServiceInteractorMock) AcceptInvitation(ctx context.Context, userID uuid.UUID, token string) (*Org, error) {
+	if mock.AcceptInvitationFunc == nil {
+		panic("OrgServiceInteractorMock.AcceptInvitationFunc: method is nil but OrgServiceInteractor.AcceptInvitation was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Token  string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Token:  token,
+	}
+	mock.lockAcceptInvitation.Lock()
+	mock.calls.AcceptInvitation = append(mock.calls.AcceptInvitation, callInfo)
+	mock.lockAcceptInvitation.Unlock()
+	return mock.AcceptInvitationFunc(ctx, userID, token)
+}
+
+// AcceptInvitationCalls gets all the calls that were made to AcceptInvitation.
+// Check the length with:
+//
+//	len(mockedOrgServiceInteractor.AcceptInvitationCalls())
+func (mock *OrgServiceInteractorMock) AcceptInvitationCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Token  string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Token  string
+	}
+	mock.lockAcceptInvitation.RLock()
+	calls = mock.calls.AcceptInvitation
+	mock.lockAcceptInvitation.RUnlock()
+	return calls
+}
+
+// Create calls CreateFunc.
+func (mock *OrgServiceInteractorMock) Create(ctx context.Context, userID uuid.UUID, name string) (*Org, error) {
+	if mock.CreateFunc == nil {
+		panic("OrgServiceInteractorMock.CreateFunc: method is nil but OrgServiceInteractor.Create was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Name   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Name:   name,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, userID, name)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedOrgServiceInteractor.CreateCalls())
+func (mock *OrgServiceInteractorMock) CreateCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Name   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Name   string
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Current calls CurrentFunc.
+func (mock *OrgServiceInteractorMock) Current(ctx context.Context, userID uuid.UUID) (*Org, error) {
+	if mock.CurrentFunc == nil {
+		panic("OrgServiceInteractorMock.CurrentFunc: method is nil but OrgServiceInteractor.Current was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCurrent.Lock()
+	mock.calls.Current = append(mock.calls.Current, callInfo)
+	mock.lockCurrent.Unlock()
+	return mock.CurrentFunc(ctx, userID)
+}
+
+// CurrentCalls gets all the calls that were made to Current.
+// Check the length with:
+//
+//	len(mockedOrgServiceInteractor.CurrentCalls())
+func (mock *OrgServiceInteractorMock) CurrentCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockCurrent.RLock()
+	calls = mock.calls.Current
+	mock.lockCurrent.RUnlock()
+	return calls
+}
+
+// Invite calls InviteFunc.
+func (mock *OrgServiceInteractorMock) Invite(ctx context.Context, userID uuid.UUID, email string) (*model.OrgInvitation, error) {
+	if mock.InviteFunc == nil {
+		panic("OrgServiceInteractorMock.InviteFunc: method is nil but OrgServiceInteractor.Invite was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Email  string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Email:  email,
+	}
+	mock.lockInvite.Lock()
+	mock.calls.Invite = append(mock.calls.Invite, callInfo)
+	mock.lockInvite.Unlock()
+	return mock.InviteFunc(ctx, userID, email)
+}
+
+// InviteCalls gets all the calls that were made to Invite.
+// Check the length with:
+//
+//	len(mockedOrgServiceInteractor.InviteCalls())
+func (mock *OrgServiceInteractorMock) InviteCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Email  string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Email  string
+	}
+	mock.lockInvite.RLock()
+	calls = mock.calls.Invite
+	mock.lockInvite.RUnlock()
+	return calls
+}
@@ -0,0 +1,207 @@
+// internal/service/org_service_test.go
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// orgFixture is a team with an owner and a member, and a newcomer in a
+// personal organization of their own.
+type orgFixture struct {
+	team, personal       *model.Organization
+	owner, member, guest *model.User
+	orgs                 *repository.OrgRepositoryMock
+	users                *repository.UserRepositoryMock
+	sender               *service.EmailSenderMock
+}
+
+func newOrgFixture() *orgFixture {
+	f := &orgFixture{
+		team:     &model.Organization{ID: uuid.New(), Name: "Acme"},
+		personal: &model.Organization{ID: uuid.New(), Name: model.PersonalOrgName, Personal: true},
+	}
+	f.owner = &model.User{ID: uuid.New(), Email: "owner@example.com", OrgID: f.team.ID, OrgRole: model.OrgRoleOwner}
+	f.member = &model.User{ID: uuid.New(), Email: "member@example.com", OrgID: f.team.ID, OrgRole: model.OrgRoleMember}
+	f.guest = &model.User{ID: uuid.New(), Email: "guest@example.com", OrgID: f.personal.ID, OrgRole: model.OrgRoleOwner}
+	all := []*model.User{f.owner, f.member, f.guest}
+
+	var invitations []*model.OrgInvitation
+	f.users = &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			for _, u := range all {
+				if u.ID == id {
+					return u, nil
+				}
+			}
+			return nil, sql.ErrNoRows
+		},
+		FindByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+			for _, u := range all {
+				if u.Email == email {
+					return u, nil
+				}
+			}
+			return nil, sql.ErrNoRows
+		},
+	}
+	f.orgs = &repository.OrgRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
+			for _, o := range []*model.Organization{f.team, f.personal} {
+				if o.ID == id {
+					return o, nil
+				}
+			}
+			return nil, sql.ErrNoRows
+		},
+		MembersFunc: func(ctx context.Context, orgID uuid.UUID) ([]model.User, error) {
+			var members []model.User
+			for _, u := range all {
+				if u.OrgID == orgID {
+					members = append(members, *u)
+				}
+			}
+			return members, nil
+		},
+		CreateInvitationFunc: func(ctx context.Context, inv *model.OrgInvitation) error {
+			invitations = append(invitations, inv)
+			return nil
+		},
+		FindInvitationByHashFunc: func(ctx context.Context, hash string) (*model.OrgInvitation, error) {
+			for _, inv := range invitations {
+				if inv.TokenHash == hash {
+					return inv, nil
+				}
+			}
+			return nil, sql.ErrNoRows
+		},
+		AcceptInvitationFunc: func(ctx context.Context, inv *model.OrgInvitation, userID uuid.UUID) (bool, error) {
+			if inv.AcceptedAt != nil {
+				return false, nil
+			}
+			now := time.Now()
+			inv.AcceptedAt = &now
+			u, _ := f.users.FindByIDFunc(ctx, userID)
+			u.OrgID, u.OrgRole = inv.OrgID, model.OrgRoleMember
+			return true, nil
+		},
+	}
+	f.sender = &service.EmailSenderMock{
+		SendFunc: func(ctx context.Context, to, subject, body string) error { return nil },
+	}
+	return f
+}
+
+func (f *orgFixture) service() service.OrgServiceInteractor {
+	return service.NewOrg(f.orgs, f.users, service.WithInvitations(f.sender, "https://app.example.com/join"))
+}
+
+// sentToken returns the token of the last invitation emailed.
+func (f *orgFixture) sentToken(t *testing.T) string {
+	t.Helper()
+	calls := f.sender.SendCalls()
+	require.NotEmpty(t, calls)
+	link := regexp.MustCompile(`https://app\.example\.com/join\?token=\S+`).FindString(calls[len(calls)-1].Body)
+	require.NotEmpty(t, link, "The email links to the invitation page")
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	return u.Query().Get("token")
+}
+
+func TestOrgService_Invite(t *testing.T) {
+	f := newOrgFixture()
+	svc := f.service()
+	ctx := context.Background()
+
+	inv, err := svc.Invite(ctx, f.owner.ID, " Guest@Example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, "guest@example.com", inv.Email, "Emails are normalised")
+	assert.Equal(t, f.team.ID, inv.OrgID)
+	assert.True(t, inv.ExpiresAt.After(time.Now().Add(6*24*time.Hour)))
+	require.Len(t, f.sender.SendCalls(), 1)
+	assert.Equal(t, "guest@example.com", f.sender.SendCalls()[0].To)
+	assert.NotContains(t, f.sender.SendCalls()[0].Body, inv.TokenHash, "The email must contain the raw token, not the stored hash")
+
+	_, err = svc.Invite(ctx, f.member.ID, "someone@example.com")
+	assert.ErrorIs(t, err, service.ErrNotOrgOwner, "Members cannot invite")
+	_, err = svc.Invite(ctx, f.guest.ID, "someone@example.com")
+	assert.ErrorIs(t, err, service.ErrPersonalOrg)
+	_, err = svc.Invite(ctx, f.owner.ID, "member@example.com")
+	assert.ErrorIs(t, err, service.ErrAlreadyMember)
+
+	_, err = service.NewOrg(f.orgs, f.users).Invite(ctx, f.owner.ID, "someone@example.com")
+	assert.ErrorIs(t, err, service.ErrInvitationsUnsupported)
+	assert.Len(t, f.sender.SendCalls(), 1, "Failed invitations send nothing")
+}
+
+func TestOrgService_AcceptInvitation(t *testing.T) {
+	f := newOrgFixture()
+	svc := f.service()
+	ctx := context.Background()
+
+	_, err := svc.Invite(ctx, f.owner.ID, "guest@example.com")
+	require.NoError(t, err)
+	token := f.sentToken(t)
+
+	_, err = svc.AcceptInvitation(ctx, f.member.ID, token)
+	assert.ErrorIs(t, err, service.ErrInvalidInvitation, "Invitations only work for the email they were sent to")
+	_, err = svc.AcceptInvitation(ctx, f.guest.ID, "made-up")
+	assert.ErrorIs(t, err, service.ErrInvalidInvitation)
+
+	org, err := svc.AcceptInvitation(ctx, f.guest.ID, token)
+	require.NoError(t, err)
+	assert.Equal(t, f.team.ID, org.ID)
+	assert.Equal(t, model.OrgRoleMember, org.Role)
+	assert.Len(t, org.Members, 3)
+
+	_, err = svc.AcceptInvitation(ctx, f.guest.ID, token)
+	assert.ErrorIs(t, err, service.ErrInvalidInvitation, "Invitations work once")
+	assert.Len(t, f.orgs.AcceptInvitationCalls(), 1)
+}
+
+func TestOrgService_AcceptInvitation_Expired(t *testing.T) {
+	f := newOrgFixture()
+	svc := f.service()
+	ctx := context.Background()
+
+	inv, err := svc.Invite(ctx, f.owner.ID, "guest@example.com")
+	require.NoError(t, err)
+	inv.ExpiresAt = time.Now().Add(-time.Minute)
+
+	_, err = svc.AcceptInvitation(ctx, f.guest.ID, f.sentToken(t))
+	assert.ErrorIs(t, err, service.ErrInvalidInvitation)
+	assert.Empty(t, f.orgs.AcceptInvitationCalls())
+}
+
+func TestOrgService_Create_SoleOwner(t *testing.T) {
+	f := newOrgFixture()
+	f.orgs.CreateFunc = func(ctx context.Context, org *model.Organization, owner uuid.UUID) error { return nil }
+	svc := f.service()
+	ctx := context.Background()
+
+	_, err := svc.Create(ctx, f.owner.ID, "Side project")
+	assert.ErrorIs(t, err, service.ErrSoleOwner, "Leaving would leave the team without an owner")
+
+	_, err = svc.Create(ctx, f.member.ID, "  Side project ")
+	require.NoError(t, err, "Members can leave")
+	_, err = svc.Create(ctx, f.guest.ID, "Guest Co")
+	require.NoError(t, err, "Personal organizations have no one else to leave behind")
+
+	require.Len(t, f.orgs.CreateCalls(), 2)
+	created := f.orgs.CreateCalls()[0]
+	assert.Equal(t, "Side project", created.Org.Name)
+	assert.False(t, created.Org.Personal)
+	assert.Equal(t, f.member.ID, created.Owner)
+}
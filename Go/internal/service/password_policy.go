@@ -0,0 +1,85 @@
+// internal/service/password_policy.go
+package service
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrWeakPassword is wrapped by the errors of Register and ResetPassword for
+// passwords that break the password policy. The wrapping error's message says
+// which rule failed and is meant to be shown to the user.
+var ErrWeakPassword = errors.New("weak password")
+
+const (
+	// DefaultMinPasswordLength is the minimum password length, in
+	// characters, unless configured otherwise.
+	DefaultMinPasswordLength = 8
+	// MaxPasswordLength is the longest password accepted, in bytes: bcrypt
+	// only hashes the first 72.
+	MaxPasswordLength = 72
+	// minPasswordClasses is how many of lower case letters, upper case
+	// letters, digits and symbols a password must mix.
+	minPasswordClasses = 3
+)
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+// commonPasswords is the set of lower-cased passwords that are rejected
+// however they are capitalised.
+var commonPasswords = parseCommonPasswords(commonPasswordList)
+
+func parseCommonPasswords(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			set[line] = true
+		}
+	}
+	return set
+}
+
+// ValidatePassword checks password against the password policy: at least
+// minLength characters and at most MaxPasswordLength bytes, a mix of at least
+// three character classes, and not one of the most common passwords. The
+// error wraps ErrWeakPassword and names the first rule that failed.
+func ValidatePassword(password string, minLength int) error {
+	if utf8.RuneCountInString(password) < minLength {
+		return fmt.Errorf("%w: password must be at least %d characters", ErrWeakPassword, minLength)
+	}
+	if len(password) > MaxPasswordLength {
+		return fmt.Errorf("%w: password must be at most %d bytes", ErrWeakPassword, MaxPasswordLength)
+	}
+	if commonPasswords[strings.ToLower(password)] {
+		return fmt.Errorf("%w: password is too common", ErrWeakPassword)
+	}
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	classes := 0
+	for _, has := range []bool{lower, upper, digit, symbol} {
+		if has {
+			classes++
+		}
+	}
+	if classes < minPasswordClasses {
+		return fmt.Errorf("%w: password must mix at least %d of lower case letters, upper case letters, digits and symbols", ErrWeakPassword, minPasswordClasses)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// internal/service/password_policy_test.go
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestValidatePassword(t *testing.T) {
+	for name, tc := range map[string]struct {
+		password string
+		rule     string // empty when the password is accepted
+	}{
+		"exactly minimum length": {"Abcdef1!", ""},
+		"one below minimum":      {"Abcde1!", "at least 8 characters"},
+		"minimum counts runes":   {"Äbcdéf1!", ""},
+		"empty":                  {"", "at least 8 characters"},
+		"at bcrypt limit":        {"Aa1" + strings.Repeat("x", service.MaxPasswordLength-3), ""},
+		"over bcrypt limit":      {"Aa1" + strings.Repeat("x", service.MaxPasswordLength-2), "at most 72 bytes"},
+		"common password":        {"password123", "too common"},
+		"common, recapitalised":  {"PassWord123", "too common"},
+		"common, with classes":   {"P@ssw0rd", "too common"},
+		"lower case only":        {"correcthorsebattery", "must mix at least 3"},
+		"two classes":            {"correcthorse42", "must mix at least 3"},
+		"three classes":          {"Correcthorse42", ""},
+		"symbols count":          {"correct horse 42", ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := service.ValidatePassword(tc.password, 8)
+			if tc.rule == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, service.ErrWeakPassword)
+			assert.ErrorContains(t, err, tc.rule)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"sync"
+)
+
+// Ensure, that PostEventSenderMock does implement PostEventSender.
+// If this is not the case, regenerate this file with moq.
+var _ PostEventSender = &PostEventSenderMock{}
+
+// PostEventSenderMock is a mock implementation of PostEventSender.
+//
+//	func TestSomethingThatUsesPostEventSender(t *testing.T) {
+//
+//		// make and configure a mocked PostEventSender
+//		mockedPostEventSender := &PostEventSenderMock{
+//			SendFunc: func(e PostEvent)  {
+//				panic("mock out the Send method")
+//			},
+//		}
+//
+//		// use mockedPostEventSender in code that requires PostEventSender
+//		// and then make assertions.
+//
+//	}
+type PostEventSenderMock struct {
+	// SendFunc mocks the Send method.
+	SendFunc func(e PostEvent)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Send holds details about calls to the Send method.
+		Send []struct {
+			// E is the e argument value.
+			E PostEvent
+		}
+	}
+	lockSend sync.RWMutex
+}
+
+// Send calls SendFunc.
+func (mock *PostEventSenderMock) Send(e PostEvent) {
+	if mock.SendFunc == nil {
+		panic("PostEventSenderMock.SendFunc: method is nil but PostEventSender.Send was just called")
+	}
+	callInfo := struct {
+		E PostEvent
+	}{
+		E: e,
+	}
+	mock.lockSend.Lock()
+	mock.calls.Send = append(mock.calls.Send, callInfo)
+	mock.lockSend.Unlock()
+	mock.SendFunc(e)
+}
+
+// SendCalls gets all the calls that were made to Send.
+// Check the length with:
+//
+//	len(mockedPostEventSender.SendCalls())
+func (mock *PostEventSenderMock) SendCalls() []struct {
+	E PostEvent
+} {
+	var calls []struct {
+		E PostEvent
+	}
+	mock.lockSend.RLock()
+	calls = mock.calls.Send
+	mock.lockSend.RUnlock()
+	return calls
+}
@@ -0,0 +1,53 @@
+// internal/service/post_events.go
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+// Post event types, sent as PostEvent.Type.
+const (
+	EventPostCreated   = "post.created"
+	EventPostFinalized = "post.finalized"
+)
+
+// PostEvent describes a post being created or finalized. It is the JSON body
+// of outgoing webhooks.
+type PostEvent struct {
+	Type       string    `json:"event"`
+	PostID     uuid.UUID `json:"post_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Content    string    `json:"content"`
+	Status     string    `json:"status"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// PostEventSender is told about post events, typically to fire webhooks.
+// Send must not block the request: delivery happens in the background.
+type PostEventSender interface {
+	Send(e PostEvent)
+}
+
+// WithPostEvents reports created and finalized posts to s.
+func WithPostEvents(s PostEventSender) LinkedInOption {
+	return func(l *LinkedInService) { l.events = s }
+}
+
+// notify reports an event about post when a PostEventSender is configured.
+func (l *LinkedInService) notify(eventType string, post *model.LinkedInPost) {
+	if l.events == nil {
+		return
+	}
+	l.events.Send(PostEvent{
+		Type:       eventType,
+		PostID:     post.ID,
+		UserID:     post.UserID,
+		Content:    post.OutputText,
+		Status:     post.Status,
+		OccurredAt: time.Now().UTC(),
+	})
+}
@@ -0,0 +1,63 @@
+// internal/service/post_length.go
+package service
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultMaxPostLength is LinkedIn's limit; longer posts are cut off with a
+// "see more" link that readers rarely click.
+const DefaultMaxPostLength = 3000
+
+// WithMaxPostLength trims generated posts to at most n characters. Zero or
+// less means DefaultMaxPostLength.
+func WithMaxPostLength(n int) LinkedInOption {
+	return func(l *LinkedInService) {
+		if n > 0 {
+			l.maxPostLength = n
+		}
+	}
+}
+
+// fitPost trims post to the configured limit, leaving room for reserve
+// characters to be added afterwards, and reports whether it was trimmed.
+func (l *LinkedInService) fitPost(post string, reserve int) (string, bool) {
+	return trimPost(post, max(l.maxPostLength-reserve, 1))
+}
+
+// trimPost cuts text to at most limit characters. It cuts after the last
+// complete sentence or paragraph that fits, falling back to the last whole
+// word followed by an ellipsis when the first sentence alone is too long.
+func trimPost(text string, limit int) (string, bool) {
+	if utf8.RuneCountInString(text) <= limit {
+		return text, false
+	}
+	runes := []rune(text)
+	// A boundary is only one if the rune after it is whitespace, so look one
+	// rune past the limit.
+	cut := -1
+	for i := range limit {
+		switch runes[i] {
+		case '\n':
+			cut = i
+		case '.', '!', '?', '…':
+			if unicode.IsSpace(runes[i+1]) {
+				cut = i + 1
+			}
+		}
+	}
+	if cut > 0 {
+		if s := strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace); s != "" {
+			return s, true
+		}
+	}
+
+	// No sentence fits; keep whole words and mark the cut.
+	head := string(runes[:limit-1])
+	if i := strings.LastIndexFunc(head, unicode.IsSpace); i > 0 {
+		head = head[:i]
+	}
+	return strings.TrimRightFunc(head, unicode.IsSpace) + "…", true
+}
@@ -0,0 +1,141 @@
+// internal/service/post_process.go
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// PostProcessor cleans up the text of a generated post.
+type PostProcessor func(text string) string
+
+// Names of the built-in post processors, as accepted by PostProcessorsByName.
+const (
+	PostProcessorStripMarkdown       = "strip_markdown"
+	PostProcessorStripPreamble       = "strip_preamble"
+	PostProcessorNormalizeWhitespace = "normalize_whitespace"
+)
+
+var (
+	postProcessorsMu sync.RWMutex
+	postProcessors   = map[string]PostProcessor{
+		PostProcessorStripMarkdown:       StripMarkdown,
+		PostProcessorStripPreamble:       StripPreamble,
+		PostProcessorNormalizeWhitespace: NormalizeWhitespace,
+	}
+)
+
+// RegisterPostProcessor makes p available to PostProcessorsByName, and so to
+// POST_PROCESSORS, under name. Register custom processors before the
+// configuration is loaded, typically from an init function. It panics when
+// name is empty or already taken.
+func RegisterPostProcessor(name string, p PostProcessor) {
+	postProcessorsMu.Lock()
+	defer postProcessorsMu.Unlock()
+	if name == "" || p == nil {
+		panic("service: RegisterPostProcessor needs a name and a processor")
+	}
+	if _, ok := postProcessors[name]; ok {
+		panic("service: post processor " + name + " registered twice")
+	}
+	postProcessors[name] = p
+}
+
+// PostProcessorNames lists the registered post processors, sorted.
+func PostProcessorNames() []string {
+	postProcessorsMu.RLock()
+	defer postProcessorsMu.RUnlock()
+	names := make([]string, 0, len(postProcessors))
+	for name := range postProcessors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PostProcessorsByName returns the registered post processors with the given
+// names, in the same order.
+func PostProcessorsByName(names []string) ([]PostProcessor, error) {
+	postProcessorsMu.RLock()
+	defer postProcessorsMu.RUnlock()
+	ps := make([]PostProcessor, 0, len(names))
+	for _, name := range names {
+		p, ok := postProcessors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown post processor %q", name)
+		}
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+// WithPostProcessors runs every generated post through ps, in order, before
+// it is moderated, trimmed to length, cached and saved.
+func WithPostProcessors(ps ...PostProcessor) LinkedInOption {
+	return func(l *LinkedInService) { l.postProcessors = ps }
+}
+
+// postProcess applies the configured post processors to text.
+func (l *LinkedInService) postProcess(text string) string {
+	for _, p := range l.postProcessors {
+		text = p(text)
+	}
+	return text
+}
+
+var (
+	mdFence    = regexp.MustCompile("(?m)^[ \t]*```[^\n]*\n?")
+	mdHeading  = regexp.MustCompile(`(?m)^[ \t]*#{1,6}[ \t]+`)
+	mdBullet   = regexp.MustCompile(`(?m)^([ \t]*)[-*+][ \t]+`)
+	mdRule     = regexp.MustCompile(`(?m)^[ \t]*(?:[-*_][ \t]*){3,}$`)
+	mdLink     = regexp.MustCompile(`\[([^\]\n]+)\]\((\S+?)\)`)
+	mdStrong   = regexp.MustCompile(`(\*\*|__)(\S(?:[^\n]*?\S)?)(\*\*|__)`)
+	mdEmphasis = regexp.MustCompile(`(^|[\s(])\*(\S(?:[^*\n]*?\S)?)\*`)
+	mdCode     = regexp.MustCompile("`([^`\n]+)`")
+)
+
+// StripMarkdown removes Markdown formatting, which LinkedIn shows verbatim:
+// headings, bold, italics, code, rules and fences lose their markers, links
+// become "text (url)" and list items start with "•". Hashtags are kept,
+// since a heading needs a space after its "#".
+func StripMarkdown(text string) string {
+	text = mdFence.ReplaceAllString(text, "")
+	text = mdRule.ReplaceAllString(text, "")
+	text = mdHeading.ReplaceAllString(text, "")
+	text = mdBullet.ReplaceAllString(text, "$1• ")
+	text = mdLink.ReplaceAllString(text, "$1 ($2)")
+	text = mdStrong.ReplaceAllString(text, "$2")
+	text = mdEmphasis.ReplaceAllString(text, "$1$2")
+	return mdCode.ReplaceAllString(text, "$1")
+}
+
+// preamble matches a first line addressing the user rather than the reader,
+// such as "Sure, here's your LinkedIn post:".
+var preamble = regexp.MustCompile(`(?i)^\s*(?:sure|certainly|of course|absolutely|okay|ok|great|here(?:'s|’s| is| are))\b[^\n]*:[ \t]*(?:\n+|$)`)
+
+// StripPreamble removes a first line in which the model answers the user
+// before the post starts, such as "Sure, here's your post:". Only lines
+// ending with a colon are removed, so a post opening with "Sure" is kept.
+func StripPreamble(text string) string {
+	return preamble.ReplaceAllString(text, "")
+}
+
+var (
+	spaceRun     = regexp.MustCompile(`[ \t\x{00A0}]+`)
+	blankLineRun = regexp.MustCompile(`\n{3,}`)
+)
+
+// NormalizeWhitespace collapses runs of spaces within lines, strips spaces
+// around lines, keeps at most one blank line between paragraphs and trims
+// the post.
+func NormalizeWhitespace(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaceRun.ReplaceAllString(line, " "))
+	}
+	text = strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLineRun.ReplaceAllString(text, "\n\n"))
+}
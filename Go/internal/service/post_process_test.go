@@ -0,0 +1,84 @@
+// internal/service/post_process_test.go
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestStripMarkdown(t *testing.T) {
+	in := "## Big news\n\nWe **shipped** our *new* API, see [the docs](https://example.com/docs).\n\n" +
+		"- Faster\n* Cheaper\n\n---\n\nRun `make deploy`.\n#GoLang #snake_case_names"
+	want := "Big news\n\nWe shipped our new API, see the docs (https://example.com/docs).\n\n" +
+		"• Faster\n• Cheaper\n\n\n\nRun make deploy.\n#GoLang #snake_case_names"
+	assert.Equal(t, want, service.StripMarkdown(in))
+	assert.Equal(t, "2 * 3 = 6", service.StripMarkdown("2 * 3 = 6"), "A lone asterisk is not emphasis")
+}
+
+func TestStripPreamble(t *testing.T) {
+	tests := map[string]string{
+		"Sure, here's your LinkedIn post:\n\nWe shipped!": "We shipped!",
+		"Here is a post about our launch:\nWe shipped!":   "We shipped!",
+		"Certainly! Here’s a draft:\n\nWe shipped!":       "We shipped!",
+		"Sure enough, we shipped!":                        "Sure enough, we shipped!",
+		"We shipped!\nHere's why it matters:\nSpeed.":     "We shipped!\nHere's why it matters:\nSpeed.",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, service.StripPreamble(in), in)
+	}
+}
+
+func TestNormalizeWhitespace(t *testing.T) {
+	in := "  We  shipped!\t \r\n\r\n\r\n\nIt   is fast.  \n"
+	assert.Equal(t, "We shipped!\n\nIt is fast.", service.NormalizeWhitespace(in))
+}
+
+func TestLinkedInService_PostProcessors(t *testing.T) {
+	aiClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "Sure, here's your post:\n\n**We   shipped!**\n\n\n\nTry it."}, nil
+		},
+	}
+	moderator := &ai.ModeratorMock{
+		ModerateFunc: func(ctx context.Context, text string) (ai.Moderation, error) { return ai.Moderation{}, nil },
+	}
+	repo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	upper := func(s string) string { return strings.ToUpper(s) }
+	processors, err := service.PostProcessorsByName([]string{service.PostProcessorStripPreamble, service.PostProcessorStripMarkdown, service.PostProcessorNormalizeWhitespace})
+	require.NoError(t, err)
+	liSvc := service.NewLinkedIn(aiClient, repo,
+		service.WithCache(nil),
+		service.WithModeration(moderator),
+		service.WithPostProcessors(append(processors, upper)...),
+	)
+
+	res, err := liSvc.Transform(context.Background(), uuid.New(), "text", service.TransformOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "WE SHIPPED!\n\nTRY IT.", res.Post)
+	assert.Equal(t, res.Post, repo.SaveCalls()[0].P.OutputText)
+	assert.Equal(t, res.Post, moderator.ModerateCalls()[0].Text, "Posts are moderated once cleaned up")
+}
+
+func TestRegisterPostProcessor(t *testing.T) {
+	service.RegisterPostProcessor("test_upper", strings.ToUpper)
+	assert.Contains(t, service.PostProcessorNames(), "test_upper")
+	ps, err := service.PostProcessorsByName([]string{"test_upper"})
+	require.NoError(t, err)
+	assert.Equal(t, "HI", ps[0]("hi"))
+
+	assert.Panics(t, func() { service.RegisterPostProcessor(service.PostProcessorStripMarkdown, strings.ToUpper) })
+	_, err = service.PostProcessorsByName([]string{"missing"})
+	assert.Error(t, err)
+}
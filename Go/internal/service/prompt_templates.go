@@ -0,0 +1,124 @@
+// internal/service/prompt_templates.go
+package service
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// DefaultTemplate is used when a request does not name a template.
+const DefaultTemplate = "default"
+
+// ErrUnknownTemplate is returned when a request names a template that does
+// not exist.
+var ErrUnknownTemplate = errors.New("unknown template")
+
+//go:embed templates/*.tmpl
+var builtinTemplateFS embed.FS
+
+// Profile is the author context available to prompt templates. Empty fields
+// are left out of the prompt.
+type Profile struct {
+	Name     string
+	Headline string
+	Industry string
+}
+
+// PromptData is what a prompt template is executed with.
+type PromptData struct {
+	// Input is the user's text.
+	Input   string
+	Profile Profile
+	// LengthInstruction and ToneInstruction are sentences describing the
+	// requested length and tone. ToneInstruction is empty when no tone was
+	// chosen.
+	LengthInstruction string
+	ToneInstruction   string
+	// Language is the ISO 639-1 code of the requested language, for
+	// templates that need language-specific wording. LanguageInstruction
+	// asks for that language and is empty for English.
+	Language            string
+	LanguageInstruction string
+}
+
+// PromptTemplates is a set of named text/template prompts. Each NAME.tmpl
+// file is a template selectable as NAME; files starting with an underscore
+// only hold shared {{define}} blocks.
+type PromptTemplates struct {
+	set   *template.Template
+	names map[string]bool
+}
+
+// LoadPromptTemplates parses every *.tmpl file at the root of fsys. The set
+// must contain a default.tmpl.
+func LoadPromptTemplates(fsys fs.FS) (*PromptTemplates, error) {
+	set, err := template.New("").Option("missingkey=error").ParseFS(fsys, "*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("templates: %w", err)
+	}
+	names := make(map[string]bool)
+	for _, t := range set.Templates() {
+		file := t.Name()
+		if !strings.HasSuffix(file, ".tmpl") || strings.HasPrefix(file, "_") {
+			continue
+		}
+		names[strings.TrimSuffix(file, ".tmpl")] = true
+	}
+	if !names[DefaultTemplate] {
+		return nil, fmt.Errorf("templates: missing %s.tmpl", DefaultTemplate)
+	}
+	return &PromptTemplates{set: set, names: names}, nil
+}
+
+var builtinTemplates = sync.OnceValue(func() *PromptTemplates {
+	sub, err := fs.Sub(builtinTemplateFS, "templates")
+	if err != nil {
+		panic(err)
+	}
+	t, err := LoadPromptTemplates(sub)
+	if err != nil {
+		panic(err)
+	}
+	return t
+})
+
+// BuiltinPromptTemplates returns the templates shipped with the binary.
+func BuiltinPromptTemplates() *PromptTemplates {
+	return builtinTemplates()
+}
+
+// Has reports whether name is a selectable template; "" means the default.
+func (p *PromptTemplates) Has(name string) bool {
+	return name == "" || p.names[name]
+}
+
+// Names lists the selectable templates in alphabetical order.
+func (p *PromptTemplates) Names() []string {
+	out := make([]string, 0, len(p.names))
+	for n := range p.names {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Render executes the named template, or the default one when name is "".
+func (p *PromptTemplates) Render(name string, data PromptData) (string, error) {
+	if name == "" {
+		name = DefaultTemplate
+	}
+	if !p.names[name] {
+		return "", fmt.Errorf("%w: %s", ErrUnknownTemplate, name)
+	}
+	var sb strings.Builder
+	if err := p.set.ExecuteTemplate(&sb, name+".tmpl", data); err != nil {
+		return "", fmt.Errorf("templates: render %s: %w", name, err)
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
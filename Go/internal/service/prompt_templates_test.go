@@ -0,0 +1,50 @@
+// internal/service/prompt_templates_test.go
+package service_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestBuiltinPromptTemplates(t *testing.T) {
+	templates := service.BuiltinPromptTemplates()
+	assert.Equal(t, []string{"announcement", "default", "job-update", "thought-leadership"}, templates.Names())
+
+	for _, name := range templates.Names() {
+		prompt, err := templates.Render(name, service.PromptData{Input: "my topic"})
+		require.NoError(t, err, name)
+		assert.Contains(t, prompt, `"my topic"`, name)
+		assert.NotContains(t, prompt, "author", "Empty profile fields should be left out")
+	}
+}
+
+func TestPromptTemplates_Profile(t *testing.T) {
+	prompt, err := service.BuiltinPromptTemplates().Render("", service.PromptData{
+		Input:   "my topic",
+		Profile: service.Profile{Headline: "staff engineer", Industry: "fintech"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "The author is a staff engineer.")
+	assert.Contains(t, prompt, "The author works in fintech.")
+	assert.NotContains(t, prompt, "name")
+}
+
+func TestLoadPromptTemplates_RequiresDefault(t *testing.T) {
+	_, err := service.LoadPromptTemplates(fstest.MapFS{
+		"custom.tmpl": {Data: []byte("{{ .Input }}")},
+	})
+	assert.ErrorContains(t, err, "default.tmpl")
+
+	templates, err := service.LoadPromptTemplates(fstest.MapFS{
+		"default.tmpl": {Data: []byte("Say: {{ .Input }}")},
+	})
+	require.NoError(t, err)
+	prompt, err := templates.Render("", service.PromptData{Input: "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, "Say: hi", prompt)
+}
@@ -0,0 +1,312 @@
+// internal/service/publish.go
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+var (
+	// ErrPublishingDisabled is returned by ConnectURL, Connect and Publish
+	// when no LinkedIn app is configured.
+	ErrPublishingDisabled = errors.New("publishing to linkedin is not configured")
+	// ErrLinkedInNotConnected is returned by Publish for a user who has not
+	// connected their LinkedIn account.
+	ErrLinkedInNotConnected = errors.New("linkedin account not connected")
+	// ErrLinkedInReconnect is returned when LinkedIn rejects the user's
+	// tokens because they expired or were revoked; only connecting the
+	// account again helps. LinkedInAPI implementations wrap it for every
+	// token LinkedIn rejects.
+	ErrLinkedInReconnect = errors.New("linkedin connection expired or revoked")
+	// ErrInvalidOAuthState is returned by Connect for a state that was not
+	// issued by ConnectURL, has expired or been used, came without its
+	// nonce, or whose code LinkedIn rejected.
+	ErrInvalidOAuthState = errors.New("invalid oauth state")
+	// ErrAlreadyPublished is returned by Publish for a post already on
+	// LinkedIn.
+	ErrAlreadyPublished = errors.New("post already published")
+)
+
+// OAuthStateTTL is how long a user has to authorize the app on LinkedIn
+// after asking to connect.
+const OAuthStateTTL = 10 * time.Minute
+
+// publishLease is how long Publish holds a post it is sharing. It is far
+// longer than sharing takes, so it only runs out for an instance that died
+// while sharing the post.
+const publishLease = 5 * time.Minute
+
+// tokenExpiryMargin refreshes access tokens this long before they expire,
+// so one does not run out between the check and the call using it.
+const tokenExpiryMargin = time.Minute
+
+// LinkedInToken is the outcome of authorizing the app on LinkedIn or
+// refreshing its access. RefreshToken is empty when LinkedIn did not issue
+// one.
+type LinkedInToken struct {
+	AccessToken      string
+	ExpiresAt        time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// LinkedInAPI is LinkedIn's OAuth and share API.
+type LinkedInAPI interface {
+	// AuthCodeURL is the LinkedIn page asking the user to authorize the
+	// app; LinkedIn sends them back to the callback with a code and state.
+	AuthCodeURL(state string) string
+	// Exchange trades the code of an authorization for tokens.
+	Exchange(ctx context.Context, code string) (*LinkedInToken, error)
+	// Refresh trades a refresh token for new tokens.
+	Refresh(ctx context.Context, refreshToken string) (*LinkedInToken, error)
+	// MemberURN is the URN of the member accessToken acts for, such as
+	// "urn:li:person:abc123".
+	MemberURN(ctx context.Context, accessToken string) (string, error)
+	// Share posts text to the feed of author and returns the URN of the
+	// new post.
+	Share(ctx context.Context, accessToken, author, text string) (string, error)
+}
+
+// WithPublishing enables connecting LinkedIn accounts and publishing posts to
+// them through api, storing the connections in repo. OAuth states are signed
+// with a key derived from secret; without one a random secret is used, and
+// states only work on the instance that issued them.
+func WithPublishing(api LinkedInAPI, repo repository.LinkedInConnectionRepository, secret []byte) LinkedInOption {
+	return func(l *LinkedInService) {
+		l.linkedin = api
+		l.connections = repo
+		l.stateSecret = secret
+		if len(secret) == 0 {
+			l.stateSecret = randomCursorSecret()
+		}
+	}
+}
+
+// ConnectURL returns the LinkedIn page where userID authorizes publishing to
+// their feed, and a nonce for the browser asking for it to keep: Connect only
+// completes the authorization given the same nonce, so the page is of no use
+// to anyone the link is passed on to. It expires after OAuthStateTTL.
+func (l *LinkedInService) ConnectURL(ctx context.Context, userID uuid.UUID) (url, nonce string, err error) {
+	if l.linkedin == nil {
+		return "", "", ErrPublishingDisabled
+	}
+	nonce, err = randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	return l.linkedin.AuthCodeURL(l.oauthState(userID, nonce, time.Now().Add(OAuthStateTTL))), nonce, nil
+}
+
+// Connect completes the authorization started at ConnectURL, storing the
+// tokens LinkedIn issues for code. The user is the one state was issued to,
+// as long as nonce is the one issued with it, so the callback needs no other
+// authentication. A state completes one authorization only.
+func (l *LinkedInService) Connect(ctx context.Context, state, nonce, code string) (*model.LinkedInConnection, error) {
+	if l.linkedin == nil {
+		return nil, ErrPublishingDisabled
+	}
+	st, ok := l.parseOAuthState(state, nonce)
+	if !ok {
+		return nil, ErrInvalidOAuthState
+	}
+	first, err := l.connections.UseState(ctx, st.id, st.expires)
+	if err != nil {
+		return nil, err
+	}
+	if !first {
+		return nil, ErrInvalidOAuthState
+	}
+	token, err := l.linkedin.Exchange(ctx, code)
+	if errors.Is(err, ErrLinkedInReconnect) {
+		return nil, ErrInvalidOAuthState
+	}
+	if err != nil {
+		return nil, err
+	}
+	urn, err := l.linkedin.MemberURN(ctx, token.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	c := &model.LinkedInConnection{UserID: st.userID, MemberURN: urn, CreatedAt: time.Now()}
+	setToken(c, token)
+	if err := l.connections.Save(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Publish posts one of the user's posts to their LinkedIn feed and stores
+// the URN of the live post on it. Posts are published once; an expired
+// access token is refreshed first. The post is claimed while it is shared,
+// so a user publishing it and the scheduler publishing it at the same time
+// cannot both post it; the one that loses gets ErrPublishInProgress.
+func (l *LinkedInService) Publish(ctx context.Context, userID, postID uuid.UUID) (*model.LinkedInPost, error) {
+	if l.linkedin == nil {
+		return nil, ErrPublishingDisabled
+	}
+	post, err := l.claimPublish(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	urn, err := l.share(ctx, userID, post.OutputText)
+	if err != nil {
+		// Nothing was posted, so the post can be published again.
+		if err := l.posts.ReleasePublish(context.WithoutCancel(ctx), post); err != nil {
+			slog.WarnContext(ctx, "releasing publish claim failed", "post_id", post.ID, "error", err)
+		}
+		return nil, err
+	}
+	post.LinkedInURN = urn
+	post.PublishedAt = time.Now()
+	// The post is live by now, so remember it even if the client has given
+	// up waiting, or it would be published twice.
+	if err := l.posts.UpdatePublished(context.WithoutCancel(ctx), post); err != nil {
+		return nil, notFound(err)
+	}
+	return post, nil
+}
+
+// claimPublish claims one of the user's posts for Publish, telling why when
+// it cannot be claimed.
+func (l *LinkedInService) claimPublish(ctx context.Context, userID, postID uuid.UUID) (*model.LinkedInPost, error) {
+	post, err := l.posts.ClaimPublish(ctx, userID, postID, time.Now(), publishLease)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return post, err
+	}
+	post, err = l.posts.GetOwned(ctx, userID, postID)
+	switch {
+	case err != nil:
+		return nil, notFound(err)
+	case post.LinkedInURN != "":
+		return nil, ErrAlreadyPublished
+	default:
+		return nil, ErrPublishInProgress
+	}
+}
+
+// share posts text to the LinkedIn feed of userID and returns the URN of the
+// new post.
+func (l *LinkedInService) share(ctx context.Context, userID uuid.UUID, text string) (string, error) {
+	c, err := l.connections.Get(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrLinkedInNotConnected
+	}
+	if err != nil {
+		return "", err
+	}
+	accessToken, err := l.accessToken(ctx, c)
+	if err != nil {
+		return "", err
+	}
+	return l.linkedin.Share(ctx, accessToken, c.MemberURN, text)
+}
+
+// accessToken returns the connection's access token, refreshing it when it
+// has expired.
+func (l *LinkedInService) accessToken(ctx context.Context, c *model.LinkedInConnection) (string, error) {
+	now := time.Now()
+	if now.Add(tokenExpiryMargin).Before(c.ExpiresAt) {
+		return c.AccessToken, nil
+	}
+	if c.RefreshToken == "" || (!c.RefreshExpiresAt.IsZero() && !now.Before(c.RefreshExpiresAt)) {
+		return "", ErrLinkedInReconnect
+	}
+	token, err := l.linkedin.Refresh(ctx, c.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+	setToken(c, token)
+	if err := l.connections.Save(ctx, c); err != nil {
+		return "", err
+	}
+	return c.AccessToken, nil
+}
+
+// setToken stores token on c. LinkedIn does not always issue a new refresh
+// token on refresh, in which case the current one is kept.
+func setToken(c *model.LinkedInConnection, token *LinkedInToken) {
+	c.AccessToken = token.AccessToken
+	c.ExpiresAt = token.ExpiresAt
+	if token.RefreshToken != "" {
+		c.RefreshToken = token.RefreshToken
+		c.RefreshExpiresAt = token.RefreshExpiresAt
+	}
+}
+
+// oauthNonceHashSize is how much of the SHA-256 hash of its nonce a state
+// carries.
+const oauthNonceHashSize = 16
+
+// oauthStatePayloadSize is the user's ID, the state's expiry in Unix seconds
+// and the hash of its nonce.
+const oauthStatePayloadSize = 16 + 8 + oauthNonceHashSize
+
+// parsedOAuthState is what a valid state says.
+type parsedOAuthState struct {
+	userID  uuid.UUID
+	expires time.Time
+	// id tells the state apart from every other; it is the hash of the
+	// nonce.
+	id string
+}
+
+// oauthState is the state sent to LinkedIn when userID connects, so the
+// callback knows who authorized the app. It holds a hash of nonce rather
+// than nonce, which only the browser that asked to connect has.
+func (l *LinkedInService) oauthState(userID uuid.UUID, nonce string, expires time.Time) string {
+	payload := make([]byte, 0, oauthStatePayloadSize+cursorMACSize)
+	payload = append(payload, userID[:]...)
+	payload = binary.BigEndian.AppendUint64(payload, uint64(expires.Unix()))
+	payload = append(payload, oauthNonceHash(nonce)...)
+	return base64.RawURLEncoding.EncodeToString(append(payload, l.oauthStateMAC(payload)...))
+}
+
+// parseOAuthState returns what an unexpired state issued with nonce says.
+func (l *LinkedInService) parseOAuthState(state, nonce string) (parsedOAuthState, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(state)
+	if err != nil || len(raw) != oauthStatePayloadSize+cursorMACSize {
+		return parsedOAuthState{}, false
+	}
+	payload, mac := raw[:oauthStatePayloadSize], raw[oauthStatePayloadSize:]
+	if !hmac.Equal(mac, l.oauthStateMAC(payload)) {
+		return parsedOAuthState{}, false
+	}
+	nonceHash := payload[24:]
+	if !hmac.Equal(nonceHash, oauthNonceHash(nonce)) {
+		return parsedOAuthState{}, false
+	}
+	expires := time.Unix(int64(binary.BigEndian.Uint64(payload[16:24])), 0)
+	if !time.Now().Before(expires) {
+		return parsedOAuthState{}, false
+	}
+	userID, _ := uuid.FromBytes(payload[:16])
+	return parsedOAuthState{userID: userID, expires: expires, id: hex.EncodeToString(nonceHash)}, true
+}
+
+func oauthNonceHash(nonce string) []byte {
+	sum := sha256.Sum256([]byte(nonce))
+	return sum[:oauthNonceHashSize]
+}
+
+// oauthStateMAC signs payload with a key derived from the state secret, as
+// cursorMAC does for cursors.
+func (l *LinkedInService) oauthStateMAC(payload []byte) []byte {
+	key := hmac.New(sha256.New, l.stateSecret)
+	key.Write([]byte("linkedinify linkedin oauth state"))
+	mac := hmac.New(sha256.New, key.Sum(nil))
+	mac.Write(payload)
+	return mac.Sum(nil)[:cursorMACSize]
+}
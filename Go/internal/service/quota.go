@@ -0,0 +1,179 @@
+// internal/service/quota.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+var (
+	// ErrQuotaExceeded matches a *QuotaError with errors.Is.
+	ErrQuotaExceeded = errors.New("monthly generation quota exceeded")
+	// ErrUsageUnsupported is returned when the service was built without
+	// usage tracking.
+	ErrUsageUnsupported = errors.New("usage tracking is not enabled")
+)
+
+// Unlimited is the limit of a plan without a monthly quota.
+const Unlimited = -1
+
+// Usage is how many posts a user generated this month against the limit of
+// their plan.
+type Usage struct {
+	Plan string
+	// Limit is the plan's monthly generations, or Unlimited.
+	Limit int
+	Used  int
+	// ResetsAt is when the next month starts, in UTC, and Used goes back to
+	// zero.
+	ResetsAt time.Time
+}
+
+// Remaining is how many more posts may be generated this month, or
+// Unlimited.
+func (u Usage) Remaining() int {
+	if u.Limit == Unlimited {
+		return Unlimited
+	}
+	return max(u.Limit-u.Used, 0)
+}
+
+// QuotaError is returned when a user has generated as many posts this month
+// as their plan allows.
+type QuotaError struct {
+	Usage Usage
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s: %d of %d on the %s plan", ErrQuotaExceeded, e.Usage.Used, e.Usage.Limit, e.Usage.Plan)
+}
+
+func (e *QuotaError) Is(target error) bool { return target == ErrQuotaExceeded }
+
+// MonthStart returns the start of the quota month containing t. Months are
+// counted in UTC, so every user's quota resets at the same instant whatever
+// their timezone.
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Quotas counts the posts each user generates per calendar month and caps
+// them by plan.
+type Quotas struct {
+	users  repository.UserRepository
+	usage  repository.UsageRepository
+	limits map[string]int
+}
+
+// NewQuotas limits the monthly generations of each plan in limits, where
+// Unlimited means no cap. A plan missing from limits gets the limit of
+// model.PlanFree, or no cap when that is missing too, so with no limits at
+// all generations are only counted.
+func NewQuotas(users repository.UserRepository, usage repository.UsageRepository, limits map[string]int) *Quotas {
+	return &Quotas{users: users, usage: usage, limits: limits}
+}
+
+func (q *Quotas) limit(plan string) int {
+	if n, ok := q.limits[plan]; ok {
+		return n
+	}
+	if n, ok := q.limits[model.PlanFree]; ok {
+		return n
+	}
+	return Unlimited
+}
+
+func (q *Quotas) plan(ctx context.Context, userID uuid.UUID) (string, error) {
+	u, err := findUser(ctx, q.users, userID)
+	if err != nil {
+		return "", userNotFound(err)
+	}
+	return u.Plan, nil
+}
+
+// Usage reports the user's generations in the current month.
+func (q *Quotas) Usage(ctx context.Context, userID uuid.UUID) (*Usage, error) {
+	plan, err := q.plan(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	period := MonthStart(time.Now())
+	used, err := q.usage.Count(ctx, userID, period)
+	if err != nil {
+		return nil, err
+	}
+	return &Usage{Plan: plan, Limit: q.limit(plan), Used: used, ResetsAt: period.AddDate(0, 1, 0)}, nil
+}
+
+// reserve counts a generation against the user's quota, or returns a
+// *QuotaError when it is used up. The returned release gives the generation
+// back, for when no post came of it.
+func (q *Quotas) reserve(ctx context.Context, userID uuid.UUID) (release func(), err error) {
+	plan, err := q.plan(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	period := MonthStart(time.Now())
+	limit := q.limit(plan)
+	used, ok, err := q.usage.Increment(ctx, userID, period, limit)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &QuotaError{Usage: Usage{Plan: plan, Limit: limit, Used: used, ResetsAt: period.AddDate(0, 1, 0)}}
+	}
+	return func() {
+		// The generation may have failed because the request was cancelled.
+		if err := q.usage.Decrement(context.WithoutCancel(ctx), userID, period); err != nil {
+			slog.ErrorContext(ctx, "giving back a failed generation's quota failed", "error", err)
+		}
+	}, nil
+}
+
+// WithQuotas counts every generated post against the author's monthly quota
+// and refuses to generate more once it is used up.
+func WithQuotas(q *Quotas) LinkedInOption {
+	return func(l *LinkedInService) { l.quotas = q }
+}
+
+// metered runs fn as one generation of the user's quota, which is given
+// back if fn fails, unless it failed because the generated post was flagged
+// by moderation: that post was generated and paid for all the same.
+func (l *LinkedInService) metered(ctx context.Context, userID uuid.UUID, fn func() (*TransformResult, error)) (*TransformResult, error) {
+	if l.quotas == nil {
+		return fn()
+	}
+	release, err := l.quotas.reserve(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	res, err := fn()
+	var flagged *ModerationError
+	if err != nil && !errors.As(err, &flagged) {
+		release()
+	}
+	return res, err
+}
+
+// WithUsage lets users see their usage against their quota.
+func WithUsage(q *Quotas) UserOption {
+	return func(s *UserService) { s.quotas = q }
+}
+
+// Usage reports the user's generations this month against their plan's
+// limit.
+func (s *UserService) Usage(ctx context.Context, userID uuid.UUID) (*Usage, error) {
+	if s.quotas == nil {
+		return nil, ErrUsageUnsupported
+	}
+	return s.quotas.Usage(ctx, userID)
+}
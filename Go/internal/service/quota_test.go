@@ -0,0 +1,176 @@
+// internal/service/quota_test.go
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// memoryUsage is an in-memory UsageRepository.
+func memoryUsage() *repository.UsageRepositoryMock {
+	counts := make(map[string]int)
+	key := func(userID uuid.UUID, period time.Time) string {
+		return userID.String() + period.Format(time.DateOnly)
+	}
+	m := &repository.UsageRepositoryMock{}
+	m.IncrementFunc = func(ctx context.Context, userID uuid.UUID, period time.Time, limit int) (int, bool, error) {
+		k := key(userID, period)
+		if limit >= 0 && counts[k] >= limit {
+			return counts[k], false, nil
+		}
+		counts[k]++
+		return counts[k], true, nil
+	}
+	m.DecrementFunc = func(ctx context.Context, userID uuid.UUID, period time.Time) error {
+		counts[key(userID, period)]--
+		return nil
+	}
+	m.CountFunc = func(ctx context.Context, userID uuid.UUID, period time.Time) (int, error) {
+		return counts[key(userID, period)], nil
+	}
+	return m
+}
+
+func usersOnPlan(plans map[uuid.UUID]string) *repository.UserRepositoryMock {
+	return &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			plan, ok := plans[id]
+			if !ok {
+				return nil, sql.ErrNoRows
+			}
+			return &model.User{ID: id, Plan: plan}, nil
+		},
+	}
+}
+
+func TestMonthStart(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	losAngeles := time.FixedZone("PST", -8*60*60)
+	tests := []struct {
+		name string
+		at   time.Time
+		want time.Time
+	}{
+		{"mid month", time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC), time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{"already November in Tokyo", time.Date(2026, 11, 1, 8, 0, 0, 0, tokyo), time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{"still October in Los Angeles", time.Date(2026, 10, 31, 17, 0, 0, 0, losAngeles), time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"new year", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, service.MonthStart(tt.at))
+		})
+	}
+}
+
+func TestLinkedInService_Quota(t *testing.T) {
+	freeUser, proUser, otherUser := uuid.New(), uuid.New(), uuid.New()
+	users := usersOnPlan(map[uuid.UUID]string{freeUser: model.PlanFree, proUser: "pro", otherUser: "legacy"})
+	usage := memoryUsage()
+	quotas := service.NewQuotas(users, usage, map[string]int{model.PlanFree: 2, "pro": service.Unlimited})
+	failing := false
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			if failing {
+				return ai.Result{}, errors.New("ai client failed")
+			}
+			return ai.Result{Text: "post"}, nil
+		},
+	}
+	mockPostRepo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error { return nil },
+	}
+	liSvc := service.NewLinkedIn(mockAIClient, mockPostRepo, service.WithQuotas(quotas), service.WithCache(nil))
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := liSvc.Transform(ctx, freeUser, "text", service.TransformOptions{})
+		require.NoError(t, err)
+	}
+	_, err := liSvc.Transform(ctx, freeUser, "text", service.TransformOptions{})
+	var exceeded *service.QuotaError
+	require.ErrorAs(t, err, &exceeded)
+	assert.ErrorIs(t, err, service.ErrQuotaExceeded)
+	assert.Equal(t, service.Usage{Plan: model.PlanFree, Limit: 2, Used: 2, ResetsAt: service.MonthStart(time.Now()).AddDate(0, 1, 0)}, exceeded.Usage)
+	assert.Zero(t, exceeded.Usage.Remaining())
+	assert.Len(t, mockAIClient.TransformCalls(), 2, "An exhausted quota does not reach the AI")
+
+	for range 3 {
+		_, err := liSvc.Transform(ctx, proUser, "text", service.TransformOptions{})
+		require.NoError(t, err, "An unlimited plan is never refused")
+	}
+	_, err = liSvc.Transform(ctx, otherUser, "text", service.TransformOptions{})
+	require.NoError(t, err)
+
+	failing = true
+	_, err = liSvc.Transform(ctx, otherUser, "text", service.TransformOptions{})
+	require.Error(t, err)
+	assert.Len(t, usage.DecrementCalls(), 1, "A failed generation is given back")
+	failing = false
+
+	_, err = liSvc.Transform(ctx, otherUser, "text", service.TransformOptions{})
+	require.NoError(t, err, "Unlisted plans get the free limit")
+	_, err = liSvc.Transform(ctx, otherUser, "text", service.TransformOptions{})
+	assert.ErrorIs(t, err, service.ErrQuotaExceeded)
+}
+
+func TestLinkedInService_Quota_FlaggedPostCounts(t *testing.T) {
+	userID := uuid.New()
+	usage := memoryUsage()
+	quotas := service.NewQuotas(usersOnPlan(map[uuid.UUID]string{userID: model.PlanFree}), usage, map[string]int{model.PlanFree: 2})
+	mockAIClient := &ai.ClientMock{
+		TransformFunc: func(ctx context.Context, prompt string, opts ai.Options) (ai.Result, error) {
+			return ai.Result{Text: "a rude post", Usage: ai.Usage{TotalTokens: 10}}, nil
+		},
+	}
+	flagAll := &ai.ModeratorMock{ModerateFunc: func(ctx context.Context, text string) (ai.Moderation, error) {
+		return ai.Moderation{Flagged: true, Categories: []string{"hate"}}, nil
+	}}
+	liSvc := service.NewLinkedIn(mockAIClient, &repository.PostRepositoryMock{},
+		service.WithQuotas(quotas), service.WithModeration(flagAll), service.WithCache(nil))
+
+	_, err := liSvc.Transform(context.Background(), userID, "text", service.TransformOptions{})
+	require.ErrorIs(t, err, service.ErrContentFlagged)
+	assert.Empty(t, usage.DecrementCalls(), "Both flagged generations were paid for")
+	u, err := quotas.Usage(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, u.Used)
+}
+
+func TestUserService_Usage(t *testing.T) {
+	userID := uuid.New()
+	users := usersOnPlan(map[uuid.UUID]string{userID: model.PlanFree})
+	usage := memoryUsage()
+	quotas := service.NewQuotas(users, usage, map[string]int{model.PlanFree: 10})
+	svc := service.NewUser(users, service.WithUsage(quotas))
+	ctx := context.Background()
+
+	_, _, err := usage.Increment(ctx, userID, service.MonthStart(time.Now()), 10)
+	require.NoError(t, err)
+	u, err := svc.Usage(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, model.PlanFree, u.Plan)
+	assert.Equal(t, 1, u.Used)
+	assert.Equal(t, 9, u.Remaining())
+	assert.Equal(t, 1, u.ResetsAt.Day())
+	assert.Equal(t, time.UTC, u.ResetsAt.Location())
+	assert.True(t, u.ResetsAt.After(time.Now()))
+
+	_, err = svc.Usage(ctx, uuid.New())
+	assert.ErrorIs(t, err, service.ErrUserNotFound)
+
+	_, err = service.NewUser(users).Usage(ctx, userID)
+	assert.ErrorIs(t, err, service.ErrUsageUnsupported)
+}
@@ -0,0 +1,73 @@
+// internal/service/sampling.go
+package service
+
+import "strconv"
+
+// Sampling parameters of generations that do not choose their own, and the
+// highest values accepted, which are OpenAI's limits. The defaults are the
+// providers' own.
+const (
+	DefaultTemperature = 1.0
+	DefaultTopP        = 1.0
+	MaxTemperature     = 2.0
+	MaxTopP            = 1.0
+)
+
+// ValidTemperature reports whether t is a temperature generations accept.
+func ValidTemperature(t float64) bool { return t >= 0 && t <= MaxTemperature }
+
+// ValidTopP reports whether p is a top_p generations accept.
+func ValidTopP(p float64) bool { return p >= 0 && p <= MaxTopP }
+
+// WithSampling sets the temperature and top_p of generations that do not
+// choose their own, which are DefaultTemperature and DefaultTopP otherwise.
+// A top_p of DefaultTopP is not sent at all, since OpenAI advises against
+// tuning both.
+func WithSampling(temperature, topP float64) LinkedInOption {
+	return func(l *LinkedInService) { l.temperature, l.topP = temperature, topP }
+}
+
+// withSampling fills in the sampling parameters opts leaves to the
+// deployment, so the values a post is generated with can be stored with it.
+// top_p is only filled in when the deployment changed it from the
+// provider's default.
+func (l *LinkedInService) withSampling(opts TransformOptions) TransformOptions {
+	if opts.Temperature == nil {
+		opts.Temperature = &l.temperature
+	}
+	if opts.TopP == nil && l.topP != DefaultTopP {
+		opts.TopP = &l.topP
+	}
+	return opts
+}
+
+// topP is the top_p a generation with o uses: the one it sends, or the
+// provider's default when it sends none.
+func (o TransformOptions) topP() float64 {
+	if o.TopP == nil {
+		return DefaultTopP
+	}
+	return *o.TopP
+}
+
+// storedTopP is the top_p to send when generating again with the stored
+// value p, which is nil for the provider's default.
+func storedTopP(p float64) *float64 {
+	if p == DefaultTopP {
+		return nil
+	}
+	return &p
+}
+
+// samplingKey identifies the sampling parameters of opts in cache keys and
+// idempotency fingerprints, by value rather than by pointer.
+func (o TransformOptions) samplingKey() string {
+	return floatKey(o.Temperature) + " " + floatKey(o.TopP)
+}
+
+func floatKey(v *float64) string {
+	if v == nil {
+		return "default"
+	}
+	return strconv.FormatFloat(*v, 'g', -1, 64)
+}
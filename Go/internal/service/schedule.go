@@ -0,0 +1,72 @@
+// internal/service/schedule.go
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/model"
+)
+
+// MaxScheduleAhead is how far in the future a post can be scheduled.
+const MaxScheduleAhead = 365 * 24 * time.Hour
+
+var (
+	// ErrInvalidScheduleTime is returned by Schedule for a time that is not
+	// in the future or is more than MaxScheduleAhead away.
+	ErrInvalidScheduleTime = errors.New("invalid schedule time")
+	// ErrPublishInProgress is returned by Publish and Schedule for a post
+	// that the scheduler or its author is publishing right now.
+	ErrPublishInProgress = errors.New("post is being published")
+)
+
+// Schedule has one of the user's posts published to their LinkedIn feed at
+// at, replacing any earlier schedule of it. The post is published by the
+// scheduler of whichever instance gets to it first; failed attempts are
+// retried until the scheduler gives up and marks the post
+// model.ScheduleStatusFailed.
+func (l *LinkedInService) Schedule(ctx context.Context, userID, postID uuid.UUID, at time.Time) (*model.LinkedInPost, error) {
+	if l.linkedin == nil {
+		return nil, ErrPublishingDisabled
+	}
+	now := time.Now()
+	if !at.After(now) || at.Sub(now) > MaxScheduleAhead {
+		return nil, ErrInvalidScheduleTime
+	}
+	post, err := l.posts.GetOwned(ctx, userID, postID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if post.LinkedInURN != "" {
+		return nil, ErrAlreadyPublished
+	}
+	if post.ScheduleStatus == model.ScheduleStatusPublishing {
+		return nil, ErrPublishInProgress
+	}
+	// Checked now so a missing connection is reported to the user rather
+	// than found by the scheduler when it is too late to fix.
+	if _, err := l.connections.Get(ctx, userID); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLinkedInNotConnected
+	} else if err != nil {
+		return nil, err
+	}
+
+	post.ScheduledAt = at
+	post.ScheduledBy = userID
+	post.ScheduleStatus = model.ScheduleStatusScheduled
+	post.ScheduleAttempts = 0
+	post.ScheduleError = ""
+	post.NextAttemptAt = at
+	if err := l.posts.Schedule(ctx, post); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Published, or claimed by the scheduler, since it was read.
+			return nil, ErrPublishInProgress
+		}
+		return nil, err
+	}
+	return post, nil
+}
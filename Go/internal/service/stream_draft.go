@@ -0,0 +1,104 @@
+// internal/service/stream_draft.go
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+)
+
+// StreamChunk is a piece of a post streamed by TransformStream: text, or the
+// error ending the stream. The stream of a post that was saved ends with a
+// chunk holding only the PostID of the draft.
+type StreamChunk struct {
+	Text   string
+	Err    error
+	PostID uuid.UUID
+}
+
+// partialDraftTimeout bounds saving the draft of a cancelled stream, which
+// happens after the request is gone.
+const partialDraftTimeout = 10 * time.Second
+
+// streamedPost is the draft of a post streamed from input; output is the
+// text sent so far and gen the model and usage the stream reported, if any.
+func streamedPost(userID uuid.UUID, input, output string, opts TransformOptions, gen ai.Result) *model.LinkedInPost {
+	return &model.LinkedInPost{
+		ID:          uuid.New(),
+		UserID:      userID,
+		InputText:   input,
+		OutputText:  output,
+		Status:      model.PostStatusDraft,
+		Source:      model.PostSourceAI,
+		Model:       cmp.Or(gen.Model, opts.Model),
+		Template:    cmp.Or(opts.Template, DefaultTemplate),
+		Tone:        string(opts.Tone),
+		Length:      string(opts.Length.orDefault()),
+		Language:    opts.language(),
+		Temperature: *opts.Temperature,
+		TopP:        opts.topP(),
+
+		PromptTokens:     gen.Usage.PromptTokens,
+		CompletionTokens: gen.Usage.CompletionTokens,
+		TotalTokens:      gen.Usage.TotalTokens,
+	}
+}
+
+// savePartialDraft saves the text of a stream cancelled part way, usually by
+// the client going away, so what was generated is not lost. It returns at
+// once: the draft is cleaned up, moderated and saved in the background with
+// a context outliving ctx, and failures are only logged. Close waits for
+// the drafts still being saved. Nothing is saved when nothing but
+// whitespace was streamed; the result reports whether a draft was handed to
+// the background, in which case the stream counts towards the quota.
+func (l *LinkedInService) savePartialDraft(ctx context.Context, post *model.LinkedInPost) bool {
+	if strings.TrimSpace(post.OutputText) == "" {
+		return false
+	}
+	ctx = context.WithoutCancel(ctx)
+	l.drafts.Add(1)
+	go func() {
+		defer l.drafts.Done()
+		ctx, cancel := context.WithTimeout(ctx, partialDraftTimeout)
+		defer cancel()
+		post.OutputText = l.postProcess(post.OutputText)
+		if strings.TrimSpace(post.OutputText) == "" {
+			return
+		}
+		if l.moderator != nil {
+			if m := l.moderate(ctx, post.OutputText); m.Flagged {
+				return
+			}
+		}
+		if err := l.posts.Save(ctx, post); err != nil {
+			slog.ErrorContext(ctx, "saving the draft of a cancelled stream failed", "post_id", post.ID, "error", err)
+			return
+		}
+		l.notify(EventPostCreated, post)
+	}()
+	return true
+}
+
+// Close waits until the drafts of cancelled streams are saved, or ctx is
+// done. Call it on shutdown before closing the database.
+func (l *LinkedInService) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		l.drafts.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("service: drafts of cancelled streams abandoned: %w", ctx.Err())
+	}
+}
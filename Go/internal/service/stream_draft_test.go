@@ -0,0 +1,159 @@
+// internal/service/stream_draft_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/ai"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+// cancellableStream streams the chunks sent on the returned channel until
+// ctx is cancelled, as the AI clients do.
+func cancellableStream() (*ai.ClientMock, chan<- ai.Chunk) {
+	in := make(chan ai.Chunk)
+	return &ai.ClientMock{
+		StreamFunc: func(ctx context.Context, prompt string, opts ai.Options) (<-chan ai.Chunk, error) {
+			out := make(chan ai.Chunk)
+			go func() {
+				defer close(out)
+				for {
+					select {
+					case c := <-in:
+						select {
+						case out <- c:
+						case <-ctx.Done():
+							return
+						}
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+			return out, nil
+		},
+	}, in
+}
+
+func TestLinkedInService_TransformStream_SavesPartialDraft(t *testing.T) {
+	aiClient, upstream := cancellableStream()
+	saved := make(chan *model.LinkedInPost, 1)
+	repo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+			assert.NoError(t, ctx.Err(), "The draft is saved with a context outliving the request")
+			saved <- p
+			return nil
+		},
+	}
+	liSvc := service.NewLinkedIn(aiClient, repo)
+	ctx, cancel := context.WithCancel(context.Background())
+	userID := uuid.New()
+
+	chunks, err := liSvc.TransformStream(ctx, userID, "original text", service.TransformOptions{})
+	require.NoError(t, err)
+	upstream <- ai.Chunk{Text: "Half a "}
+	assert.Equal(t, "Half a ", (<-chunks).Text)
+	upstream <- ai.Chunk{Text: "post"}
+	assert.Equal(t, "post", (<-chunks).Text)
+	cancel()
+	for range chunks {
+	}
+
+	select {
+	case p := <-saved:
+		assert.Equal(t, "Half a post", p.OutputText)
+		assert.Equal(t, "original text", p.InputText)
+		assert.Equal(t, userID, p.UserID)
+		assert.Equal(t, model.PostStatusDraft, p.Status)
+	case <-time.After(time.Second):
+		t.Fatal("the partial draft was not saved")
+	}
+}
+
+func TestLinkedInService_TransformStream_PartialDraftKeepsQuota(t *testing.T) {
+	aiClient, upstream := cancellableStream()
+	saved := make(chan *model.LinkedInPost, 1)
+	repo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+			saved <- p
+			return nil
+		},
+	}
+	userID := uuid.New()
+	usage := memoryUsage()
+	quotas := service.NewQuotas(usersOnPlan(map[uuid.UUID]string{userID: model.PlanFree}), usage, map[string]int{model.PlanFree: 1})
+	liSvc := service.NewLinkedIn(aiClient, repo, service.WithQuotas(quotas))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	chunks, err := liSvc.TransformStream(ctx, userID, "original text", service.TransformOptions{})
+	require.NoError(t, err)
+	upstream <- ai.Chunk{Text: "A whole post"}
+	assert.Equal(t, "A whole post", (<-chunks).Text)
+	cancel()
+	for range chunks {
+	}
+
+	select {
+	case <-saved:
+	case <-time.After(time.Second):
+		t.Fatal("the partial draft was not saved")
+	}
+	assert.Empty(t, usage.DecrementCalls(), "A stream saved as a draft counts towards the quota")
+	u, err := quotas.Usage(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, u.Used)
+}
+
+func TestLinkedInService_TransformStream_SkipsEmptyDraft(t *testing.T) {
+	aiClient, upstream := cancellableStream()
+	repo := &repository.PostRepositoryMock{}
+	liSvc := service.NewLinkedIn(aiClient, repo)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	chunks, err := liSvc.TransformStream(ctx, uuid.New(), "original text", service.TransformOptions{})
+	require.NoError(t, err)
+	upstream <- ai.Chunk{Text: "  \n"}
+	<-chunks
+	cancel()
+	for range chunks {
+	}
+	assert.Empty(t, repo.SaveCalls(), "Nothing worth keeping was streamed")
+}
+
+func TestLinkedInService_Close_WaitsForDrafts(t *testing.T) {
+	aiClient, upstream := cancellableStream()
+	saving := make(chan struct{})
+	unblock := make(chan struct{})
+	repo := &repository.PostRepositoryMock{
+		SaveFunc: func(ctx context.Context, p *model.LinkedInPost) error {
+			close(saving)
+			<-unblock
+			return nil
+		},
+	}
+	liSvc := service.NewLinkedIn(aiClient, repo)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	chunks, err := liSvc.TransformStream(ctx, uuid.New(), "original text", service.TransformOptions{})
+	require.NoError(t, err)
+	upstream <- ai.Chunk{Text: "Half a post"}
+	<-chunks
+	cancel()
+	for range chunks {
+	}
+	<-saving
+
+	short, cancelShort := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelShort()
+	require.ErrorIs(t, liSvc.Close(short), context.DeadlineExceeded, "The draft is still being saved")
+	close(unblock)
+	require.NoError(t, liSvc.Close(context.Background()))
+}
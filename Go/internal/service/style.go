@@ -0,0 +1,92 @@
+// internal/service/style.go
+package service
+
+import "strings"
+
+// Tone selects the voice of a generated post.
+type Tone string
+
+const (
+	ToneProfessional  Tone = "professional"
+	ToneCasual        Tone = "casual"
+	ToneInspirational Tone = "inspirational"
+	ToneHumorous      Tone = "humorous"
+)
+
+var toneInstructions = map[Tone]string{
+	ToneProfessional:  "Use a polished, professional tone.",
+	ToneCasual:        "Use a relaxed, conversational tone, as if talking to a colleague.",
+	ToneInspirational: "Use an uplifting, inspirational tone.",
+	ToneHumorous:      "Use a light, humorous tone with a playful joke or two.",
+}
+
+// Tones lists the supported tones.
+var Tones = []Tone{ToneProfessional, ToneCasual, ToneInspirational, ToneHumorous}
+
+// Valid reports whether t is a supported tone. The empty tone is valid and
+// leaves the voice to the template.
+func (t Tone) Valid() bool {
+	_, ok := toneInstructions[t]
+	return ok || t == ""
+}
+
+func (t Tone) instruction() string { return toneInstructions[t] }
+
+// Length selects how long a generated post is.
+type Length string
+
+const (
+	LengthShort  Length = "short"
+	LengthMedium Length = "medium"
+	LengthLong   Length = "long"
+)
+
+// DefaultLength is used when a request does not choose a length.
+const DefaultLength = LengthShort
+
+type lengthSpec struct {
+	instruction string
+	// maxTokens leaves headroom over the instruction so posts are not cut
+	// off mid-sentence.
+	maxTokens int
+}
+
+var lengthSpecs = map[Length]lengthSpec{
+	LengthShort:  {instruction: "Keep it under 240 characters.", maxTokens: 120},
+	LengthMedium: {instruction: "Keep it between 500 and 1000 characters.", maxTokens: 400},
+	LengthLong:   {instruction: "Write 1500 to 2500 characters, split into short paragraphs.", maxTokens: 900},
+}
+
+// Lengths lists the supported lengths.
+var Lengths = []Length{LengthShort, LengthMedium, LengthLong}
+
+// Valid reports whether l is a supported length; empty means DefaultLength.
+func (l Length) Valid() bool {
+	_, ok := lengthSpecs[l]
+	return ok || l == ""
+}
+
+func (l Length) orDefault() Length {
+	if l == "" {
+		return DefaultLength
+	}
+	return l
+}
+
+func (l Length) spec() lengthSpec { return lengthSpecs[l.orDefault()] }
+
+// joinValues renders enum values for error messages.
+func joinValues[T ~string](values []T) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+	return strings.Join(s, ", ")
+}
+
+// ToneNames and LengthNames are the comma-separated supported values, for
+// error messages.
+var (
+	ToneNames   = joinValues(Tones)
+	LengthNames = joinValues(Lengths)
+)
@@ -0,0 +1,142 @@
+// internal/service/user_service.go
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/you/linkedinify/internal/audit"
+	"github.com/you/linkedinify/internal/middleware"
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+)
+
+var (
+	// ErrUserNotFound is returned when the authenticated user no longer exists.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrWrongPassword is returned by DeleteAccount when the password does not
+	// match the account's.
+	ErrWrongPassword = errors.New("wrong password")
+)
+
+// Longest accepted profile fields, in characters.
+const (
+	MaxNameLength     = 100
+	MaxHeadlineLength = 200
+	MaxIndustryLength = 100
+)
+
+// ProfileUpdate holds the profile fields to change; nil fields are kept.
+// Values are trimmed, and an empty value clears the field.
+type ProfileUpdate struct {
+	Name     *string
+	Headline *string
+	Industry *string
+}
+
+// UserServiceInteractor defines the operations users can perform on their
+// own account.
+type UserServiceInteractor interface {
+	Get(ctx context.Context, userID uuid.UUID) (*model.User, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, u ProfileUpdate) (*model.User, error)
+	CreateAPIKey(ctx context.Context, userID uuid.UUID, name string) (*NewAPIKey, error)
+	ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID uuid.UUID) error
+	VerifyAPIKey(ctx context.Context, key string) (uuid.UUID, error)
+	DeleteAccount(ctx context.Context, userID uuid.UUID, password string) error
+	Usage(ctx context.Context, userID uuid.UUID) (*Usage, error)
+	Stats(ctx context.Context, userID uuid.UUID, days int) (*GenerationStats, error)
+}
+
+type UserService struct {
+	users   repository.UserRepository
+	apiKeys repository.APIKeyRepository // nil when API keys are disabled
+	quotas  *Quotas                     // nil when usage is not tracked
+	// analytics is nil when generation stats are disabled.
+	analytics repository.AnalyticsRepository
+}
+
+// UserOption configures optional UserService dependencies.
+type UserOption func(*UserService)
+
+// NewUser creates a new UserService instance.
+func NewUser(users repository.UserRepository, opts ...UserOption) UserServiceInteractor {
+	s := &UserService{users: users}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *UserService) Get(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	u, err := findUser(ctx, s.users, userID)
+	if err != nil {
+		return nil, userNotFound(err)
+	}
+	return u, nil
+}
+
+// UpdateProfile changes the user's name, headline or industry and returns the
+// updated user. Lengths are expected to have been checked by the caller.
+func (s *UserService) UpdateProfile(ctx context.Context, userID uuid.UUID, p ProfileUpdate) (*model.User, error) {
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, userNotFound(err)
+	}
+	if p.Name != nil {
+		u.Name = strings.TrimSpace(*p.Name)
+	}
+	if p.Headline != nil {
+		u.Headline = strings.TrimSpace(*p.Headline)
+	}
+	if p.Industry != nil {
+		u.Industry = strings.TrimSpace(*p.Industry)
+	}
+	if err := s.users.UpdateProfile(ctx, u); err != nil {
+		return nil, userNotFound(err)
+	}
+	return u, nil
+}
+
+// DeleteAccount permanently erases the user with their posts, post versions,
+// feedback, generation analytics, refresh tokens, password resets, API keys,
+// invitations sent and personal organization, once password confirms it is
+// them. Soft-deleted posts are erased too. Access tokens already issued are
+// rejected from then on by the Auth middleware, which loads the user they
+// name.
+func (s *UserService) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) error {
+	u, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return userNotFound(err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) != nil {
+		return ErrWrongPassword
+	}
+	if err := s.users.Delete(ctx, userID); err != nil {
+		return userNotFound(err)
+	}
+	audit.Record(ctx, audit.ActionAccountDelete, userID, map[string]any{"email": u.Email})
+	return nil
+}
+
+func userNotFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrUserNotFound
+	}
+	return err
+}
+
+// findUser looks up userID, reusing the user the Auth middleware loaded for
+// the request when it is them. It is for reads only: the returned user may
+// be shared with the rest of the request.
+func findUser(ctx context.Context, users repository.UserRepository, userID uuid.UUID) (*model.User, error) {
+	if u, ok := middleware.User(ctx); ok && u.ID == userID {
+		return u, nil
+	}
+	return users.FindByID(ctx, userID)
+}
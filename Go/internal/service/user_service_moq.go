@@ -0,0 +1,513 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/you/linkedinify/internal/model"
+	"sync"
+)
+
+// Ensure, that UserServiceInteractorMock does implement UserServiceInteractor.
+// If this is not the case, regenerate this file with moq.
+var _ UserServiceInteractor = &UserServiceInteractorMock{}
+
+// UserServiceInteractorMock is a mock implementation of UserServiceInteractor.
+//
+//	func TestSomethingThatUsesUserServiceInteractor(t *testing.T) {
+//
+//		// make and configure a mocked UserServiceInteractor
+//		mockedUserServiceInteractor := &UserServiceInteractorMock{
+//			CreateAPIKeyFunc: func(ctx context.Context, userID uuid.UUID, name string) (*NewAPIKey, error) {
+//				panic("mock out the CreateAPIKey method")
+//			},
+//			DeleteAccountFunc: func(ctx context.Context, userID uuid.UUID, password string) error {
+//				panic("mock out the DeleteAccount method")
+//			},
+//			GetFunc: func(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+//				panic("mock out the Get method")
+//			},
+//			ListAPIKeysFunc: func(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error) {
+//				panic("mock out the ListAPIKeys method")
+//			},
+//			RevokeAPIKeyFunc: func(ctx context.Context, userID uuid.UUID, keyID uuid.UUID) error {
+//				panic("mock out the RevokeAPIKey method")
+//			},
+//			StatsFunc: func(ctx context.Context, userID uuid.UUID, days int) (*GenerationStats, error) {
+//				panic("mock out the Stats method")
+//			},
+//			UpdateProfileFunc: func(ctx context.Context, userID uuid.UUID, u ProfileUpdate) (*model.User, error) {
+//				panic("mock out the UpdateProfile method")
+//			},
+//			UsageFunc: func(ctx context.Context, userID uuid.UUID) (*Usage, error) {
+//				panic("mock out the Usage method")
+//			},
+//			VerifyAPIKeyFunc: func(ctx context.Context, key string) (uuid.UUID, error) {
+//				panic("mock out the VerifyAPIKey method")
+//			},
+//		}
+//
+//		// use mockedUserServiceInteractor in code that requires UserServiceInteractor
+//		// and then make assertions.
+//
+//	}
+type UserServiceInteractorMock struct {
+	// CreateAPIKeyFunc mocks the CreateAPIKey method.
+	CreateAPIKeyFunc func(ctx context.Context, userID uuid.UUID, name string) (*NewAPIKey, error)
+
+	// DeleteAccountFunc mocks the DeleteAccount method.
+	DeleteAccountFunc func(ctx context.Context, userID uuid.UUID, password string) error
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, userID uuid.UUID) (*model.User, error)
+
+	// ListAPIKeysFunc mocks the ListAPIKeys method.
+	ListAPIKeysFunc func(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error)
+
+	// RevokeAPIKeyFunc mocks the RevokeAPIKey method.
+	RevokeAPIKeyFunc func(ctx context.Context, userID uuid.UUID, keyID uuid.UUID) error
+
+	// StatsFunc mocks the Stats method.
+	StatsFunc func(ctx context.Context, userID uuid.UUID, days int) (*GenerationStats, error)
+
+	// UpdateProfileFunc mocks the UpdateProfile method.
+	UpdateProfileFunc func(ctx context.Context, userID uuid.UUID, u ProfileUpdate) (*model.User, error)
+
+	// UsageFunc mocks the Usage method.
+	UsageFunc func(ctx context.Context, userID uuid.UUID) (*Usage, error)
+
+	// VerifyAPIKeyFunc mocks the VerifyAPIKey method.
+	VerifyAPIKeyFunc func(ctx context.Context, key string) (uuid.UUID, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateAPIKey holds details about calls to the CreateAPIKey method.
+		CreateAPIKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Name is the name argument value.
+			Name string
+		}
+		// DeleteAccount holds details about calls to the DeleteAccount method.
+		DeleteAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Password is the password argument value.
+			Password string
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// ListAPIKeys holds details about calls to the ListAPIKeys method.
+		ListAPIKeys []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// RevokeAPIKey holds details about calls to the RevokeAPIKey method.
+		RevokeAPIKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// KeyID is the keyID argument value.
+			KeyID uuid.UUID
+		}
+		// Stats holds details about calls to the Stats method.
+		Stats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Days is the days argument value.
+			Days int
+		}
+		// UpdateProfile holds details about calls to the UpdateProfile method.
+		UpdateProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// U is the u argument value.
+			U ProfileUpdate
+		}
+		// Usage holds details about calls to the Usage method.
+		Usage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// VerifyAPIKey holds details about calls to the VerifyAPIKey method.
+		VerifyAPIKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+	}
+	lockCreateAPIKey  sync.RWMutex
+	lockDeleteAccount sync.RWMutex
+	lockGet           sync.RWMutex
+	lockListAPIKeys   sync.RWMutex
+	lockRevokeAPIKey  sync.RWMutex
+	lockStats         sync.RWMutex
+	lockUpdateProfile sync.RWMutex
+	lockUsage         sync.RWMutex
+	lockVerifyAPIKey  sync.RWMutex
+}
+
+// CreateAPIKey calls CreateAPIKeyFunc.
+func (mock *UserServiceInteractorMock) CreateAPIKey(ctx context.Context, userID uuid.UUID, name string) (*NewAPIKey, error) {
+	if mock.CreateAPIKeyFunc == nil {
+		panic("UserServiceInteractorMock.CreateAPIKeyFunc: method is nil but UserServiceInteractor.CreateAPIKey was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Name   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Name:   name,
+	}
+	mock.lockCreateAPIKey.Lock()
+	mock.calls.CreateAPIKey = append(mock.calls.CreateAPIKey, callInfo)
+	mock.lockCreateAPIKey.Unlock()
+	return mock.CreateAPIKeyFunc(ctx, userID, name)
+}
+
+// CreateAPIKeyCalls gets all the calls that were made to CreateAPIKey.
+// Check the length with:
+//
+//	len(mockedUserServiceInteractor.CreateAPIKeyCalls())
+func (mock *UserServiceInteractorMock) CreateAPIKeyCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Name   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Name   string
+	}
+	mock.lockCreateAPIKey.RLock()
+	calls = mock.calls.CreateAPIKey
+	mock.lockCreateAPIKey.RUnlock()
+	return calls
+}
+
+// DeleteAccount calls DeleteAccountFunc.
+func (mock *UserServiceInteractorMock) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) error {
+	if mock.DeleteAccountFunc == nil {
+		panic("UserServiceInteractorMock.DeleteAccountFunc: method is nil but UserServiceInteractor.DeleteAccount was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		Password string
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		Password: password,
+	}
+	mock.lockDeleteAccount.Lock()
+	mock.calls.DeleteAccount = append(mock.calls.DeleteAccount, callInfo)
+	mock.lockDeleteAccount.Unlock()
+	return mock.DeleteAccountFunc(ctx, userID, password)
+}
+
+// DeleteAccountCalls gets all the calls that were made to DeleteAccount.
+// Check the length with:
+//
+//	len(mockedUserServiceInteractor.DeleteAccountCalls())
+func (mock *UserServiceInteractorMock) DeleteAccountCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	Password string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		Password string
+	}
+	mock.lockDeleteAccount.RLock()
+	calls = mock.calls.DeleteAccount
+	mock.lockDeleteAccount.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *UserServiceInteractorMock) Get(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	if mock.GetFunc == nil {
+		panic("UserServiceInteractorMock.GetFunc: method is nil but UserServiceInteractor.Get was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(ctx, userID)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedUserServiceInteractor.GetCalls())
+func (mock *UserServiceInteractorMock) GetCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// ListAPIKeys calls ListAPIKeysFunc.
+func (mock *UserServiceInteractorMock) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error) {
+	if mock.ListAPIKeysFunc == nil {
+		panic("UserServiceInteractorMock.ListAPIKeysFunc: method is nil but UserServiceInteractor.ListAPIKeys was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListAPIKeys.Lock()
+	mock.calls.ListAPIKeys = append(mock.calls.ListAPIKeys, callInfo)
+	mock.lockListAPIKeys.Unlock()
+	return mock.ListAPIKeysFunc(ctx, userID)
+}
+
+// ListAPIKeysCalls gets all the calls that were made to ListAPIKeys.
+// Check the length with:
+//
+//	len(mockedUserServiceInteractor.ListAPIKeysCalls())
+func (mock *UserServiceInteractorMock) ListAPIKeysCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockListAPIKeys.RLock()
+	calls = mock.calls.ListAPIKeys
+	mock.lockListAPIKeys.RUnlock()
+	return calls
+}
+
+// RevokeAPIKey calls RevokeAPIKeyFunc.
+func (mock *UserServiceInteractorMock) RevokeAPIKey(ctx context.Context, userID uuid.UUID, keyID uuid.UUID) error {
+	if mock.RevokeAPIKeyFunc == nil {
+		panic("UserServiceInteractorMock.RevokeAPIKeyFunc: method is nil but UserServiceInteractor.RevokeAPIKey was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		KeyID  uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		KeyID:  keyID,
+	}
+	mock.lockRevokeAPIKey.Lock()
+	mock.calls.RevokeAPIKey = append(mock.calls.RevokeAPIKey, callInfo)
+	mock.lockRevokeAPIKey.Unlock()
+	return mock.RevokeAPIKeyFunc(ctx, userID, keyID)
+}
+
+// RevokeAPIKeyCalls gets all the calls that were made to RevokeAPIKey.
+// Check the length with:
+//
+//	len(mockedUserServiceInteractor.RevokeAPIKeyCalls())
+func (mock *UserServiceInteractorMock) RevokeAPIKeyCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	KeyID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		KeyID  uuid.UUID
+	}
+	mock.lockRevokeAPIKey.RLock()
+	calls = mock.calls.RevokeAPIKey
+	mock.lockRevokeAPIKey.RUnlock()
+	return calls
+}
+
+// Stats calls StatsFunc.
+func (mock *UserServiceInteractorMock) Stats(ctx context.Context, userID uuid.UUID, days int) (*GenerationStats, error) {
+	if mock.StatsFunc == nil {
+		panic("UserServiceInteractorMock.StatsFunc: method is nil but UserServiceInteractor.Stats was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Days   int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Days:   days,
+	}
+	mock.lockStats.Lock()
+	mock.calls.Stats = append(mock.calls.Stats, callInfo)
+	mock.lockStats.Unlock()
+	return mock.StatsFunc(ctx, userID, days)
+}
+
+// StatsCalls gets all the calls that were made to Stats.
+// Check the length with:
+//
+//	len(mockedUserServiceInteractor.StatsCalls())
+func (mock *UserServiceInteractorMock) StatsCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Days   int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Days   int
+	}
+	mock.lockStats.RLock()
+	calls = mock.calls.Stats
+	mock.lockStats.RUnlock()
+	return calls
+}
+
+// UpdateProfile calls UpdateProfileFunc.
+func (mock *UserServiceInteractorMock) UpdateProfile(ctx context.Context, userID uuid.UUID, u ProfileUpdate) (*model.User, error) {
+	if mock.UpdateProfileFunc == nil {
+		panic("UserServiceInteractorMock.UpdateProfileFunc: method is nil but UserServiceInteractor.UpdateProfile was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		U      ProfileUpdate
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		U:      u,
+	}
+	mock.lockUpdateProfile.Lock()
+	mock.calls.UpdateProfile = append(mock.calls.UpdateProfile, callInfo)
+	mock.lockUpdateProfile.Unlock()
+	return mock.UpdateProfileFunc(ctx, userID, u)
+}
+
+// UpdateProfileCalls gets all the calls that were made to UpdateProfile.
+// Check the length with:
+//
+//	len(mockedUserServiceInteractor.UpdateProfileCalls())
+func (mock *UserServiceInteractorMock) UpdateProfileCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	U      ProfileUpdate
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		U      ProfileUpdate
+	}
+	mock.lockUpdateProfile.RLock()
+	calls = mock.calls.UpdateProfile
+	mock.lockUpdateProfile.RUnlock()
+	return calls
+}
+
+// Usage calls UsageFunc.
+func (mock *UserServiceInteractorMock) Usage(ctx context.Context, userID uuid.UUID) (*Usage, error) {
+	if mock.UsageFunc == nil {
+		panic("UserServiceInteractorMock.UsageFunc: method is nil but UserServiceInteractor.Usage was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockUsage.Lock()
+	mock.calls.Usage = append(mock.calls.Usage, callInfo)
+	mock.lockUsage.Unlock()
+	return mock.UsageFunc(ctx, userID)
+}
+
+// UsageCalls gets all the calls that were made to Usage.
+// Check the length with:
+//
+//	len(mockedUserServiceInteractor.UsageCalls())
+func (mock *UserServiceInteractorMock) UsageCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockUsage.RLock()
+	calls = mock.calls.Usage
+	mock.lockUsage.RUnlock()
+	return calls
+}
+
+// VerifyAPIKey calls VerifyAPIKeyFunc.
+func (mock *UserServiceInteractorMock) VerifyAPIKey(ctx context.Context, key string) (uuid.UUID, error) {
+	if mock.VerifyAPIKeyFunc == nil {
+		panic("UserServiceInteractorMock.VerifyAPIKeyFunc: method is nil but UserServiceInteractor.VerifyAPIKey was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockVerifyAPIKey.Lock()
+	mock.calls.VerifyAPIKey = append(mock.calls.VerifyAPIKey, callInfo)
+	mock.lockVerifyAPIKey.Unlock()
+	return mock.VerifyAPIKeyFunc(ctx, key)
+}
+
+// VerifyAPIKeyCalls gets all the calls that were made to VerifyAPIKey.
+// Check the length with:
+//
+//	len(mockedUserServiceInteractor.VerifyAPIKeyCalls())
+func (mock *UserServiceInteractorMock) VerifyAPIKeyCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockVerifyAPIKey.RLock()
+	calls = mock.calls.VerifyAPIKey
+	mock.lockVerifyAPIKey.RUnlock()
+	return calls
+}
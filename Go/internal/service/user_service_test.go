@@ -0,0 +1,161 @@
+// internal/service/user_service_test.go
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/you/linkedinify/internal/model"
+	"github.com/you/linkedinify/internal/repository"
+	"github.com/you/linkedinify/internal/service"
+)
+
+func TestUserService_UpdateProfile(t *testing.T) {
+	userID := uuid.New()
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			if id != userID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.User{ID: id, Email: "user@example.com", Name: "Ada", Headline: "Engineer"}, nil
+		},
+		UpdateProfileFunc: func(ctx context.Context, u *model.User) error { return nil },
+	}
+	svc := service.NewUser(mockUserRepo)
+
+	industry, headline := "  fintech ", ""
+	u, err := svc.UpdateProfile(context.Background(), userID, service.ProfileUpdate{Industry: &industry, Headline: &headline})
+	require.NoError(t, err)
+	assert.Equal(t, "fintech", u.Industry, "Values are trimmed")
+	assert.Empty(t, u.Headline, "An empty value clears the field")
+	assert.Equal(t, "Ada", u.Name, "Fields that are not set are kept")
+	assert.Equal(t, "user@example.com", u.Email)
+
+	require.Len(t, mockUserRepo.UpdateProfileCalls(), 1)
+	assert.Equal(t, userID, mockUserRepo.UpdateProfileCalls()[0].U.ID)
+
+	_, err = svc.UpdateProfile(context.Background(), uuid.New(), service.ProfileUpdate{Industry: &industry})
+	assert.ErrorIs(t, err, service.ErrUserNotFound)
+}
+
+func TestUserService_DeleteAccount(t *testing.T) {
+	userID := uuid.New()
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	require.NoError(t, err)
+	deleted := false
+	mockUserRepo := &repository.UserRepositoryMock{
+		FindByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.User, error) {
+			if id != userID || deleted {
+				return nil, sql.ErrNoRows
+			}
+			return &model.User{ID: id, PasswordHash: string(hash)}, nil
+		},
+		DeleteFunc: func(ctx context.Context, id uuid.UUID) error {
+			deleted = true
+			return nil
+		},
+	}
+	svc := service.NewUser(mockUserRepo)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, svc.DeleteAccount(ctx, userID, "wrong"), service.ErrWrongPassword)
+	assert.Empty(t, mockUserRepo.DeleteCalls(), "A wrong password deletes nothing")
+
+	require.NoError(t, svc.DeleteAccount(ctx, userID, "correct horse"))
+	require.Len(t, mockUserRepo.DeleteCalls(), 1)
+	assert.Equal(t, userID, mockUserRepo.DeleteCalls()[0].ID)
+
+	assert.ErrorIs(t, svc.DeleteAccount(ctx, userID, "correct horse"), service.ErrUserNotFound)
+}
+
+// memoryAPIKeys is an in-memory APIKeyRepository.
+func memoryAPIKeys() *repository.APIKeyRepositoryMock {
+	var keys []model.APIKey
+	m := &repository.APIKeyRepositoryMock{}
+	m.CreateFunc = func(ctx context.Context, k *model.APIKey) error {
+		keys = append(keys, *k)
+		return nil
+	}
+	m.FindByHashFunc = func(ctx context.Context, hash string) (*model.APIKey, error) {
+		for _, k := range keys {
+			if k.KeyHash == hash && k.RevokedAt == nil {
+				return &k, nil
+			}
+		}
+		return nil, sql.ErrNoRows
+	}
+	m.ListByUserFunc = func(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error) {
+		var out []model.APIKey
+		for _, k := range keys {
+			if k.UserID == userID && k.RevokedAt == nil {
+				out = append(out, k)
+			}
+		}
+		return out, nil
+	}
+	m.RevokeFunc = func(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+		for i, k := range keys {
+			if k.ID == id && k.UserID == userID && k.RevokedAt == nil {
+				now := time.Now()
+				keys[i].RevokedAt = &now
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return m
+}
+
+func TestUserService_APIKeys(t *testing.T) {
+	userID := uuid.New()
+	repo := memoryAPIKeys()
+	svc := service.NewUser(&repository.UserRepositoryMock{}, service.WithAPIKeys(repo))
+	ctx := context.Background()
+
+	created, err := svc.CreateAPIKey(ctx, userID, "  CRM sync ")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, service.APIKeyPrefix))
+	assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+	assert.Equal(t, "CRM sync", created.Name)
+	stored := repo.CreateCalls()[0].K
+	assert.NotEqual(t, created.Key, stored.KeyHash, "Only a hash of the key is stored")
+	assert.Len(t, stored.KeyHash, 64)
+
+	got, err := svc.VerifyAPIKey(ctx, created.Key)
+	require.NoError(t, err)
+	assert.Equal(t, userID, got)
+	_, err = svc.VerifyAPIKey(ctx, created.Key+"x")
+	assert.ErrorIs(t, err, service.ErrInvalidAPIKey)
+	_, err = svc.VerifyAPIKey(ctx, "not-a-key")
+	assert.ErrorIs(t, err, service.ErrInvalidAPIKey)
+
+	keys, err := svc.ListAPIKeys(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, created.ID, keys[0].ID)
+
+	assert.ErrorIs(t, svc.RevokeAPIKey(ctx, uuid.New(), created.ID), service.ErrAPIKeyNotFound, "Users cannot revoke each other's keys")
+	require.NoError(t, svc.RevokeAPIKey(ctx, userID, created.ID))
+	_, err = svc.VerifyAPIKey(ctx, created.Key)
+	assert.ErrorIs(t, err, service.ErrInvalidAPIKey, "Revoked keys stop working")
+	assert.ErrorIs(t, svc.RevokeAPIKey(ctx, userID, created.ID), service.ErrAPIKeyNotFound)
+}
+
+func TestUserService_APIKeys_Limit(t *testing.T) {
+	userID := uuid.New()
+	svc := service.NewUser(&repository.UserRepositoryMock{}, service.WithAPIKeys(memoryAPIKeys()))
+	for range service.MaxAPIKeys {
+		_, err := svc.CreateAPIKey(context.Background(), userID, "")
+		require.NoError(t, err)
+	}
+	_, err := svc.CreateAPIKey(context.Background(), userID, "")
+	assert.ErrorIs(t, err, service.ErrTooManyAPIKeys)
+}
@@ -0,0 +1,43 @@
+// internal/webhook/signature_test.go
+package webhook_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/you/linkedinify/internal/webhook"
+)
+
+func TestVerify(t *testing.T) {
+	payload := []byte(`{"event":"delivery.succeeded"}`)
+	signature := webhook.Sign("s3cret", payload)
+	assert.True(t, strings.HasPrefix(signature, "t="), signature)
+	assert.True(t, webhook.Verify("s3cret", payload, signature))
+
+	assert.False(t, webhook.Verify("other", payload, signature), "Another secret")
+	assert.False(t, webhook.Verify("s3cret", []byte(`{"event":"delivery.failed"}`), signature), "Another payload")
+	assert.False(t, webhook.Verify("s3cret", payload, ""))
+	assert.False(t, webhook.Verify("s3cret", payload, "sha256=abc"), "No time")
+	assert.False(t, webhook.Verify("s3cret", payload, signature[:len(signature)-2]), "A truncated MAC")
+
+	_, sum, _ := strings.Cut(signature, ",")
+	newer := "t=" + strconv.FormatInt(time.Now().Unix()+1, 10) + "," + sum
+	assert.False(t, webhook.Verify("s3cret", payload, newer), "The time is signed with the payload")
+}
+
+func TestVerifyWithin(t *testing.T) {
+	payload := []byte("{}")
+	old := webhook.SignAt("s3cret", payload, time.Now().Add(-10*time.Minute))
+	assert.False(t, webhook.Verify("s3cret", payload, old), "Older than DefaultTolerance")
+	assert.True(t, webhook.VerifyWithin("s3cret", payload, old, 15*time.Minute))
+	assert.False(t, webhook.VerifyWithin("s3cret", payload, old, time.Minute))
+
+	future := webhook.SignAt("s3cret", payload, time.Now().Add(10*time.Minute))
+	assert.False(t, webhook.Verify("s3cret", payload, future), "Too far in the future")
+	skewed := webhook.SignAt("s3cret", payload, time.Now().Add(30*time.Second))
+	assert.True(t, webhook.Verify("s3cret", payload, skewed), "A little clock skew is tolerated")
+}
@@ -0,0 +1,202 @@
+// internal/webhook/webhook.go
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/you/linkedinify/internal/service"
+)
+
+// Headers set on every delivery.
+const (
+	// SignatureHeader carries the Sign signature of the request body, keyed
+	// with the shared secret; receivers check it with Verify.
+	SignatureHeader = "X-Signature"
+	// EventHeader repeats the event type so receivers can route without
+	// parsing the body.
+	EventHeader = "X-Webhook-Event"
+)
+
+// Defaults used unless overridden with an Option.
+const (
+	DefaultQueueSize   = 100
+	DefaultMaxAttempts = 5
+	DefaultBackoff     = time.Second
+	defaultTimeout     = 10 * time.Second
+)
+
+// Dispatcher delivers post events to a webhook URL in the background. Events
+// wait in a bounded queue and are posted one at a time, in order; failed
+// deliveries are retried with exponential backoff. When the queue is full
+// new events are dropped, so a slow receiver never holds up the API.
+type Dispatcher struct {
+	url, secret string
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan delivery
+
+	ctx    context.Context // cancelled when Close gives up waiting
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type delivery struct {
+	event service.PostEvent
+	body  []byte
+}
+
+// Option configures optional Dispatcher behaviour.
+type Option func(*Dispatcher)
+
+// WithQueueSize sets how many events may wait for delivery.
+func WithQueueSize(n int) Option {
+	return func(d *Dispatcher) { d.queue = make(chan delivery, n) }
+}
+
+// WithMaxAttempts sets how often a delivery is tried before it is dropped.
+func WithMaxAttempts(n int) Option {
+	return func(d *Dispatcher) { d.maxAttempts = n }
+}
+
+// WithBackoff sets the wait before the first retry; it doubles on each
+// further attempt.
+func WithBackoff(b time.Duration) Option {
+	return func(d *Dispatcher) { d.backoff = b }
+}
+
+// WithHTTPClient replaces the client used for deliveries, which defaults to
+// one with a 10 second timeout.
+func WithHTTPClient(c *http.Client) Option {
+	return func(d *Dispatcher) { d.client = c }
+}
+
+// New starts a Dispatcher posting to url and signing with secret. Call Close
+// to flush the queue on shutdown.
+func New(url, secret string, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		url:         url,
+		secret:      secret,
+		client:      &http.Client{Timeout: defaultTimeout},
+		maxAttempts: DefaultMaxAttempts,
+		backoff:     DefaultBackoff,
+		queue:       make(chan delivery, DefaultQueueSize),
+		done:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	go d.run()
+	return d
+}
+
+// Send queues e for delivery and returns immediately.
+func (d *Dispatcher) Send(e service.PostEvent) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		slog.Warn("webhook not sent", "event", e.Type, "post_id", e.PostID, "error", err)
+		return
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		slog.Warn("webhook dropped: shutting down", "event", e.Type, "post_id", e.PostID)
+		return
+	}
+	select {
+	case d.queue <- delivery{event: e, body: body}:
+	default:
+		slog.Warn("webhook dropped: queue is full", "event", e.Type, "post_id", e.PostID, "queue_size", cap(d.queue))
+	}
+}
+
+// Close stops accepting events and waits until the queued ones are
+// delivered or ctx is done, in which case pending deliveries are abandoned.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		pending := len(d.queue)
+		d.cancel()
+		<-d.done
+		return fmt.Errorf("webhook: %d queued deliveries abandoned: %w", pending, ctx.Err())
+	}
+}
+
+func (d *Dispatcher) run() {
+	defer close(d.done)
+	for job := range d.queue {
+		if d.ctx.Err() != nil {
+			continue
+		}
+		d.deliver(job)
+	}
+}
+
+// deliver posts job until it succeeds, fails permanently or runs out of
+// attempts.
+func (d *Dispatcher) deliver(job delivery) {
+	wait := d.backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := d.post(job)
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= d.maxAttempts {
+			slog.Warn("webhook delivery failed", "event", job.event.Type, "post_id", job.event.PostID, "attempts", attempt, "error", err)
+			return
+		}
+		select {
+		case <-time.After(wait):
+		case <-d.ctx.Done():
+			return
+		}
+		wait *= 2
+	}
+}
+
+// post makes one delivery attempt and reports whether a failure is worth
+// retrying.
+func (d *Dispatcher) post(job delivery) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, d.url, bytes.NewReader(job.body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Each attempt is signed afresh, so retries are not too old to verify.
+	req.Header.Set(SignatureHeader, Sign(d.secret, job.body))
+	req.Header.Set(EventHeader, job.event.Type)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusRequestTimeout, resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return true, fmt.Errorf("receiver responded %s", resp.Status)
+	default:
+		return false, fmt.Errorf("receiver responded %s", resp.Status)
+	}
+}
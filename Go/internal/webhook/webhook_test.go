@@ -0,0 +1,128 @@
+// internal/webhook/webhook_test.go
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/you/linkedinify/internal/service"
+	"github.com/you/linkedinify/internal/webhook"
+)
+
+func TestDispatcher_DeliversSignedEvent(t *testing.T) {
+	event := service.PostEvent{
+		Type:    service.EventPostCreated,
+		PostID:  uuid.New(),
+		UserID:  uuid.New(),
+		Content: "Thrilled to announce...",
+		Status:  "draft",
+	}
+	received := make(chan *http.Request, 1)
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		received <- r
+	}))
+	defer srv.Close()
+
+	d := webhook.New(srv.URL, "s3cret")
+	d.Send(event)
+	require.NoError(t, d.Close(context.Background()))
+
+	r := <-received
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+	assert.Equal(t, service.EventPostCreated, r.Header.Get(webhook.EventHeader))
+
+	signature := r.Header.Get(webhook.SignatureHeader)
+	ts, sum, ok := strings.Cut(strings.TrimPrefix(signature, "t="), ",sha256=")
+	require.True(t, ok, signature)
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), sum)
+	assert.True(t, webhook.Verify("s3cret", body, signature))
+
+	var got service.PostEvent
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, event.PostID, got.PostID)
+	assert.Equal(t, event.UserID, got.UserID)
+	assert.Equal(t, event.Content, got.Content)
+}
+
+func TestDispatcher_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	d := webhook.New(srv.URL, "s3cret", webhook.WithBackoff(time.Millisecond))
+	d.Send(service.PostEvent{Type: service.EventPostFinalized})
+	require.NoError(t, d.Close(context.Background()))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestDispatcher_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	d := webhook.New(srv.URL, "wrong", webhook.WithBackoff(time.Millisecond))
+	d.Send(service.PostEvent{Type: service.EventPostCreated})
+	require.NoError(t, d.Close(context.Background()))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestDispatcher_SendNeverBlocks(t *testing.T) {
+	release := make(chan struct{})
+	var once sync.Once
+	started := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() { close(started) })
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	d := webhook.New(srv.URL, "s3cret", webhook.WithQueueSize(1))
+	d.Send(service.PostEvent{Type: service.EventPostCreated})
+	<-started // the first event is in flight, so the queue is empty again
+
+	done := make(chan struct{})
+	go func() {
+		for range 5 {
+			d.Send(service.PostEvent{Type: service.EventPostCreated})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Send blocked on a slow receiver")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, d.Close(ctx), context.DeadlineExceeded, "Close gives up on a receiver that never answers")
+}
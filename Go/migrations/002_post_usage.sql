@@ -0,0 +1,6 @@
+-- migrations/002_post_usage.sql
+alter table linkedin_posts
+  add column model text not null default '',
+  add column prompt_tokens integer not null default 0,
+  add column completion_tokens integer not null default 0,
+  add column total_tokens integer not null default 0;
@@ -0,0 +1,12 @@
+-- migrations/003_refresh_tokens.sql
+create table refresh_tokens (
+  id uuid primary key default uuid_generate_v4(),
+  user_id uuid not null references users(id) on delete cascade,
+  family_id uuid not null,
+  token_hash text not null unique,
+  expires_at timestamptz not null,
+  revoked_at timestamptz,
+  created_at timestamptz default now()
+);
+
+create index refresh_tokens_family_id_idx on refresh_tokens (family_id);
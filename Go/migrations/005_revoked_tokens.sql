@@ -0,0 +1,7 @@
+-- migrations/005_revoked_tokens.sql
+create table revoked_tokens (
+  jti text primary key,
+  expires_at timestamptz not null
+);
+
+create index revoked_tokens_expires_at_idx on revoked_tokens (expires_at);
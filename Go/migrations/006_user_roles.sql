@@ -0,0 +1,2 @@
+-- migrations/006_user_roles.sql
+alter table users add column role text not null default 'user';
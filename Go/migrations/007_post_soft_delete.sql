@@ -0,0 +1,4 @@
+-- migrations/007_post_soft_delete.sql
+alter table linkedin_posts add column deleted_at timestamptz;
+
+create index linkedin_posts_user_id_live_idx on linkedin_posts (user_id, created_at desc) where deleted_at is null;
@@ -0,0 +1,6 @@
+-- migrations/008_post_search.sql
+alter table linkedin_posts
+  add column search_vector tsvector
+  generated always as (to_tsvector('english', input_text || ' ' || output_text)) stored;
+
+create index linkedin_posts_search_vector_idx on linkedin_posts using gin (search_vector);
@@ -0,0 +1,4 @@
+-- migrations/009_post_style.sql
+alter table linkedin_posts
+    add column tone text not null default '',
+    add column length text not null default 'short';
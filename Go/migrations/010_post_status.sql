@@ -0,0 +1,7 @@
+-- migrations/010_post_status.sql
+-- Posts created before drafts existed count as final.
+alter table linkedin_posts
+    add column status text not null default 'final',
+    add column updated_at timestamptz;
+
+alter table linkedin_posts alter column status set default 'draft';
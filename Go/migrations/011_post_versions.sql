@@ -0,0 +1,12 @@
+-- migrations/011_post_versions.sql
+alter table linkedin_posts add column source text not null default 'ai';
+
+create table if not exists post_versions (
+    id uuid primary key,
+    post_id uuid not null references linkedin_posts(id) on delete cascade,
+    output_text text not null,
+    source text not null,
+    created_at timestamptz not null default now()
+);
+
+create index post_versions_post_id_idx on post_versions (post_id, created_at desc);
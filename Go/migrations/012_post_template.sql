@@ -0,0 +1,3 @@
+-- migrations/012_post_template.sql
+-- Posts created before prompt templates existed used the default prompt.
+alter table linkedin_posts add column template text not null default 'default';
@@ -0,0 +1,2 @@
+-- migrations/013_post_language.sql
+alter table linkedin_posts add column language text not null default 'en';
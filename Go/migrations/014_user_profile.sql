@@ -0,0 +1,5 @@
+-- migrations/014_user_profile.sql
+alter table users
+    add column name text not null default '',
+    add column headline text not null default '',
+    add column industry text not null default '';
@@ -0,0 +1,5 @@
+-- migrations/015_post_image.sql
+alter table linkedin_posts
+    add column image_url text not null default '',
+    add column image_prompt text not null default '',
+    add column image_size text not null default '';
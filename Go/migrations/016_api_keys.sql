@@ -0,0 +1,12 @@
+-- migrations/016_api_keys.sql
+create table api_keys (
+  id uuid primary key default uuid_generate_v4(),
+  user_id uuid not null references users(id) on delete cascade,
+  name text not null default '',
+  prefix text not null,
+  key_hash text not null unique,
+  revoked_at timestamptz,
+  created_at timestamptz default now()
+);
+
+create index api_keys_user_id_idx on api_keys (user_id);
@@ -0,0 +1,21 @@
+-- migrations/017_user_email_ci.sql
+-- Emails are unique regardless of case from now on, and stored lower-cased.
+--
+-- Accounts that already share an email in different casing cannot all keep
+-- it. The oldest one does; the others are renamed to
+-- "<email>#duplicate-<id>", which keeps their data but stops them logging in
+-- or receiving reset links until they are merged or deleted by hand:
+--   select * from users where email like '%#duplicate-%';
+with ranked as (
+  select id, row_number() over (partition by lower(email) order by created_at nulls last, id) as n
+  from users
+)
+update users
+set email = lower(users.email) || '#duplicate-' || users.id
+from ranked
+where ranked.id = users.id and ranked.n > 1;
+
+update users set email = lower(email) where email <> lower(email);
+
+alter table users drop constraint if exists users_email_key;
+create unique index users_email_lower_key on users (lower(email));
@@ -0,0 +1,4 @@
+-- migrations/018_post_favorite.sql
+alter table linkedin_posts add column favorited boolean not null default false;
+
+create index linkedin_posts_user_id_favorited_idx on linkedin_posts (user_id, created_at desc) where favorited and deleted_at is null;
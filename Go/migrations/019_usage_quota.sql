@@ -0,0 +1,11 @@
+-- migrations/019_usage_quota.sql
+alter table users add column plan text not null default 'free';
+
+-- One row per user and calendar month, in UTC, in which they generated
+-- posts. period is the first day of the month.
+create table usage_counters (
+  user_id uuid not null references users(id) on delete cascade,
+  period date not null,
+  generations integer not null default 0,
+  primary key (user_id, period)
+);
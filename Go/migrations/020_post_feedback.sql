@@ -0,0 +1,18 @@
+-- migrations/020_post_feedback.sql
+-- One rating per user and post; rating again replaces it. The generation
+-- parameters are copied from the post so the ratings can be compared by
+-- model, tone and template even after the post is regenerated.
+create table post_feedback (
+  id uuid primary key,
+  post_id uuid not null references linkedin_posts(id) on delete cascade,
+  user_id uuid not null references users(id) on delete cascade,
+  rating text not null check (rating in ('up', 'down')),
+  comment text not null default '',
+  model text not null default '',
+  tone text not null default '',
+  template text not null default '',
+  created_at timestamptz not null default now(),
+  unique (post_id, user_id)
+);
+
+create index post_feedback_template_model_idx on post_feedback (template, model);
@@ -0,0 +1,6 @@
+-- migrations/021_post_cursor_index.sql
+-- Cursor pagination seeks to (created_at, id) within a user's live posts;
+-- id breaks ties between posts created in the same microsecond.
+create index linkedin_posts_user_id_cursor_idx on linkedin_posts (user_id, created_at desc, id desc) where deleted_at is null;
+
+drop index linkedin_posts_user_id_live_idx;
@@ -0,0 +1,4 @@
+-- migrations/022_post_updated_index.sql
+-- Sorting history by updated_desc orders by the time a post was last edited,
+-- or created when it never was.
+create index linkedin_posts_user_id_updated_idx on linkedin_posts (user_id, (coalesce(updated_at, created_at)) desc, id desc) where deleted_at is null;
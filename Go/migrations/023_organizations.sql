@@ -0,0 +1,43 @@
+-- migrations/023_organizations.sql
+-- Posts belong to an organization and are shared by its members. Every
+-- existing user gets a personal organization holding their posts, so nothing
+-- changes for them until they create or join a team.
+create table organizations (
+  id uuid primary key default uuid_generate_v4(),
+  name text not null,
+  personal boolean not null default false,
+  created_by uuid,
+  created_at timestamptz default now()
+);
+
+insert into organizations (name, personal, created_by, created_at)
+select 'Personal', true, id, created_at from users;
+
+alter table users add column org_id uuid references organizations(id);
+alter table users add column org_role text not null default 'owner';
+update users set org_id = o.id from organizations o where o.created_by = users.id and o.personal;
+alter table users alter column org_id set not null;
+create index users_org_id_idx on users (org_id);
+
+alter table linkedin_posts add column org_id uuid references organizations(id);
+update linkedin_posts set org_id = users.org_id from users where users.id = linkedin_posts.user_id;
+alter table linkedin_posts alter column org_id set not null;
+
+-- History is listed per organization now, so its indexes lead with org_id.
+create index linkedin_posts_org_id_cursor_idx on linkedin_posts (org_id, created_at desc, id desc) where deleted_at is null;
+create index linkedin_posts_org_id_updated_idx on linkedin_posts (org_id, (coalesce(updated_at, created_at)) desc, id desc) where deleted_at is null;
+drop index linkedin_posts_user_id_cursor_idx;
+drop index linkedin_posts_user_id_updated_idx;
+
+create table org_invitations (
+  id uuid primary key default uuid_generate_v4(),
+  org_id uuid not null references organizations(id) on delete cascade,
+  email text not null,
+  token_hash text not null unique,
+  invited_by uuid not null references users(id) on delete cascade,
+  expires_at timestamptz not null,
+  accepted_at timestamptz,
+  created_at timestamptz default now()
+);
+
+create index org_invitations_org_id_idx on org_invitations (org_id);
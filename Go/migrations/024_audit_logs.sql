@@ -0,0 +1,16 @@
+-- migrations/024_audit_logs.sql
+-- The trail of sensitive actions. Entries outlive the users and posts they
+-- name, so actor_id and subject_id reference nothing.
+create table audit_logs (
+  id uuid primary key default uuid_generate_v4(),
+  actor_id uuid,
+  subject_id uuid,
+  action text not null,
+  ip text not null default '',
+  metadata jsonb not null default '{}',
+  created_at timestamptz not null default now()
+);
+
+create index audit_logs_created_at_idx on audit_logs (created_at desc);
+create index audit_logs_actor_id_idx on audit_logs (actor_id, created_at desc);
+create index audit_logs_subject_id_idx on audit_logs (subject_id, created_at desc);
@@ -0,0 +1,17 @@
+-- migrations/025_linkedin_publishing.sql
+-- One row per user who connected their LinkedIn account. LinkedIn's tokens
+-- are needed in the clear to call its API, so they are not hashed.
+create table linkedin_connections (
+  user_id uuid primary key references users(id) on delete cascade,
+  member_urn text not null,
+  access_token text not null,
+  expires_at timestamptz not null,
+  refresh_token text not null default '',
+  refresh_expires_at timestamptz,
+  created_at timestamptz not null default now(),
+  updated_at timestamptz not null default now()
+);
+
+alter table linkedin_posts
+    add column linkedin_urn text not null default '',
+    add column published_at timestamptz;
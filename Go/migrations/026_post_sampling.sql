@@ -0,0 +1,7 @@
+-- migrations/026_post_sampling.sql
+-- The sampling parameters a post was generated with, for regenerating it
+-- alike. Older posts were generated with the providers' defaults, which are
+-- 1 for both.
+alter table linkedin_posts
+    add column temperature double precision not null default 1,
+    add column top_p double precision not null default 1;
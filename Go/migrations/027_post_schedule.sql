@@ -0,0 +1,15 @@
+-- migrations/027_post_schedule.sql
+-- Posts scheduled to be published to LinkedIn later. next_attempt_at is
+-- when the scheduler may pick a post up next: its scheduled time at first,
+-- then after the backoff of a failed attempt or the claim of an instance
+-- that died mid-publish.
+alter table linkedin_posts
+    add column scheduled_at timestamptz,
+    add column scheduled_by uuid references users(id) on delete set null,
+    add column schedule_status text not null default '',
+    add column schedule_attempts int not null default 0,
+    add column schedule_error text not null default '',
+    add column next_attempt_at timestamptz;
+
+create index linkedin_posts_schedule_due_idx on linkedin_posts (next_attempt_at)
+    where schedule_status in ('scheduled', 'publishing') and deleted_at is null;
@@ -0,0 +1,19 @@
+-- migrations/028_analytics_events.sql
+-- One row per generated post, for the generation trends of GET
+-- /users/me/stats. post_id has no foreign key: events outlive the posts
+-- they describe, which may be deleted for good.
+create table analytics_events (
+  id bigserial primary key,
+  user_id uuid not null references users(id) on delete cascade,
+  post_id uuid not null,
+  model text not null,
+  tone text not null default '',
+  template text not null default '',
+  prompt_tokens int not null default 0,
+  completion_tokens int not null default 0,
+  total_tokens int not null default 0,
+  cached boolean not null default false,
+  created_at timestamptz not null default now()
+);
+
+create index analytics_events_user_id_created_at_idx on analytics_events (user_id, created_at);
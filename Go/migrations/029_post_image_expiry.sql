@@ -0,0 +1,10 @@
+-- migrations/029_post_image_expiry.sql
+-- When the provider stops serving a post's image URL. Images generated
+-- before this was recorded are assumed to have lived an hour from the last
+-- change to their post, which has long passed for most of them.
+alter table linkedin_posts
+    add column image_expires_at timestamptz;
+
+update linkedin_posts
+set image_expires_at = coalesce(updated_at, created_at) + interval '1 hour'
+where image_url <> '';
@@ -0,0 +1,15 @@
+-- migrations/030_post_favorites.sql
+-- Favorites move to a table of their own, one row per user and post, now
+-- that posts are shared in an organization and a single column would be
+-- shared with it. Existing favorites were their author's.
+create table post_favorites (
+  user_id uuid not null references users(id) on delete cascade,
+  post_id uuid not null references linkedin_posts(id) on delete cascade,
+  created_at timestamptz default now(),
+  primary key (user_id, post_id)
+);
+
+insert into post_favorites (user_id, post_id)
+select user_id, id from linkedin_posts where favorited;
+
+alter table linkedin_posts drop column favorited;
@@ -0,0 +1,6 @@
+-- migrations/031_post_publish_claim.sql
+-- publishing_until is set while a user or the scheduler shares a post to
+-- LinkedIn, so no one else shares it meanwhile. It runs out in case the
+-- instance sharing the post dies before recording the outcome.
+alter table linkedin_posts
+    add column publishing_until timestamptz;
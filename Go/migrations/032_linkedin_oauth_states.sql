@@ -0,0 +1,7 @@
+-- migrations/032_linkedin_oauth_states.sql
+-- OAuth states that completed a LinkedIn authorization, so none completes
+-- two. Rows are pruned once their state has expired anyway.
+create table linkedin_oauth_states (
+  id text primary key,
+  expires_at timestamptz not null
+);
@@ -0,0 +1,3 @@
+-- migrations/down/001_init.sql
+drop table linkedin_posts;
+drop table users;
@@ -0,0 +1,6 @@
+-- migrations/down/002_post_usage.sql
+alter table linkedin_posts
+  drop column model,
+  drop column prompt_tokens,
+  drop column completion_tokens,
+  drop column total_tokens;
@@ -0,0 +1,2 @@
+-- migrations/down/003_refresh_tokens.sql
+drop table refresh_tokens;
@@ -0,0 +1,2 @@
+-- migrations/down/004_password_resets.sql
+drop table password_resets;
@@ -0,0 +1,2 @@
+-- migrations/down/005_revoked_tokens.sql
+drop table revoked_tokens;
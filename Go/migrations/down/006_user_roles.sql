@@ -0,0 +1,2 @@
+-- migrations/down/006_user_roles.sql
+alter table users drop column role;
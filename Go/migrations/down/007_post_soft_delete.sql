@@ -0,0 +1,5 @@
+-- migrations/down/007_post_soft_delete.sql
+-- Soft-deleted posts would reappear, so they are removed for good.
+delete from linkedin_posts where deleted_at is not null;
+
+alter table linkedin_posts drop column deleted_at;
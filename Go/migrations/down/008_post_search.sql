@@ -0,0 +1,2 @@
+-- migrations/down/008_post_search.sql
+alter table linkedin_posts drop column search_vector;
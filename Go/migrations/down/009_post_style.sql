@@ -0,0 +1,4 @@
+-- migrations/down/009_post_style.sql
+alter table linkedin_posts
+    drop column tone,
+    drop column length;
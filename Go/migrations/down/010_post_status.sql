@@ -0,0 +1,4 @@
+-- migrations/down/010_post_status.sql
+alter table linkedin_posts
+    drop column status,
+    drop column updated_at;
@@ -0,0 +1,4 @@
+-- migrations/down/011_post_versions.sql
+drop table post_versions;
+
+alter table linkedin_posts drop column source;
@@ -0,0 +1,2 @@
+-- migrations/down/012_post_template.sql
+alter table linkedin_posts drop column template;
@@ -0,0 +1,2 @@
+-- migrations/down/013_post_language.sql
+alter table linkedin_posts drop column language;
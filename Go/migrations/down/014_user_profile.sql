@@ -0,0 +1,5 @@
+-- migrations/down/014_user_profile.sql
+alter table users
+    drop column name,
+    drop column headline,
+    drop column industry;
@@ -0,0 +1,5 @@
+-- migrations/down/015_post_image.sql
+alter table linkedin_posts
+    drop column image_url,
+    drop column image_prompt,
+    drop column image_size;
@@ -0,0 +1,2 @@
+-- migrations/down/016_api_keys.sql
+drop table api_keys;
@@ -0,0 +1,6 @@
+-- migrations/down/017_user_email_ci.sql
+-- Emails stay lower-cased, and accounts renamed as duplicates keep their
+-- new email.
+drop index users_email_lower_key;
+
+alter table users add constraint users_email_key unique (email);
@@ -0,0 +1,2 @@
+-- migrations/down/018_post_favorite.sql
+alter table linkedin_posts drop column favorited;
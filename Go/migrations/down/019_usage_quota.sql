@@ -0,0 +1,4 @@
+-- migrations/down/019_usage_quota.sql
+drop table usage_counters;
+
+alter table users drop column plan;
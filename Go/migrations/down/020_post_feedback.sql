@@ -0,0 +1,2 @@
+-- migrations/down/020_post_feedback.sql
+drop table post_feedback;
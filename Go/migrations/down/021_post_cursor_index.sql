@@ -0,0 +1,4 @@
+-- migrations/down/021_post_cursor_index.sql
+create index linkedin_posts_user_id_live_idx on linkedin_posts (user_id, created_at desc) where deleted_at is null;
+
+drop index linkedin_posts_user_id_cursor_idx;
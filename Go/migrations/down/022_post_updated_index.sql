@@ -0,0 +1,2 @@
+-- migrations/down/022_post_updated_index.sql
+drop index linkedin_posts_user_id_updated_idx;
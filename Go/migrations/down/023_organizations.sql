@@ -0,0 +1,11 @@
+-- migrations/down/023_organizations.sql
+drop table org_invitations;
+
+create index linkedin_posts_user_id_cursor_idx on linkedin_posts (user_id, created_at desc, id desc) where deleted_at is null;
+create index linkedin_posts_user_id_updated_idx on linkedin_posts (user_id, (coalesce(updated_at, created_at)) desc, id desc) where deleted_at is null;
+
+alter table linkedin_posts drop column org_id;
+alter table users drop column org_role;
+alter table users drop column org_id;
+
+drop table organizations;
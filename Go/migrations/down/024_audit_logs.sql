@@ -0,0 +1,2 @@
+-- migrations/down/024_audit_logs.sql
+drop table audit_logs;
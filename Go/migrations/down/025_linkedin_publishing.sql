@@ -0,0 +1,6 @@
+-- migrations/down/025_linkedin_publishing.sql
+alter table linkedin_posts
+    drop column linkedin_urn,
+    drop column published_at;
+
+drop table linkedin_connections;
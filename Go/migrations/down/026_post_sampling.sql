@@ -0,0 +1,4 @@
+-- migrations/down/026_post_sampling.sql
+alter table linkedin_posts
+    drop column temperature,
+    drop column top_p;
@@ -0,0 +1,10 @@
+-- migrations/down/027_post_schedule.sql
+drop index linkedin_posts_schedule_due_idx;
+
+alter table linkedin_posts
+    drop column scheduled_at,
+    drop column scheduled_by,
+    drop column schedule_status,
+    drop column schedule_attempts,
+    drop column schedule_error,
+    drop column next_attempt_at;
@@ -0,0 +1,2 @@
+-- migrations/down/028_analytics_events.sql
+drop table analytics_events;
@@ -0,0 +1,3 @@
+-- migrations/down/029_post_image_expiry.sql
+alter table linkedin_posts
+    drop column image_expires_at;
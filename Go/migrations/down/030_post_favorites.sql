@@ -0,0 +1,9 @@
+-- migrations/down/030_post_favorites.sql
+alter table linkedin_posts add column favorited boolean not null default false;
+
+update linkedin_posts set favorited = true
+from post_favorites f where f.post_id = linkedin_posts.id and f.user_id = linkedin_posts.user_id;
+
+create index linkedin_posts_user_id_favorited_idx on linkedin_posts (user_id, created_at desc) where favorited and deleted_at is null;
+
+drop table post_favorites;
@@ -0,0 +1,3 @@
+-- migrations/down/031_post_publish_claim.sql
+alter table linkedin_posts
+    drop column publishing_until;
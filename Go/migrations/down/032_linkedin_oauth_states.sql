@@ -0,0 +1,2 @@
+-- migrations/down/032_linkedin_oauth_states.sql
+drop table linkedin_oauth_states;
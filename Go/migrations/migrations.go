@@ -0,0 +1,12 @@
+// migrations/migrations.go
+
+// Package migrations embeds the database schema. Each NNN_name.sql file is
+// applied in order by the migrate command, and down/NNN_name.sql reverts it.
+package migrations
+
+import "embed"
+
+// FS holds the migration scripts.
+//
+//go:embed *.sql down/*.sql
+var FS embed.FS